@@ -31,6 +31,18 @@ func SwapAdmin(method string, params []string) (result interface{}, err error) {
 	return result, err
 }
 
+// GetAdminProposals rpc call server `swap.GetAdminProposals` (keystore is not required)
+func GetAdminProposals(ctx *cli.Context) (result interface{}, err error) {
+	err = initSwapServer(ctx)
+	if err != nil {
+		return nil, err
+	}
+	timeout := 60
+	reqID := 1011
+	err = client.RPCPostWithTimeoutAndID(&result, timeout, reqID, swapServer, "swap.GetAdminProposals")
+	return result, err
+}
+
 func loadKeyStore(ctx *cli.Context) error {
 	keyfile := ctx.String(utils.KeystoreFileFlag.Name)
 	passfile := ctx.String(utils.PasswordFileFlag.Name)
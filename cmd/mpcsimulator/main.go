@@ -0,0 +1,74 @@
+// Command mpcsimulator is a local mpc network simulator serving the smpc rpc api for testing.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/BurntSushi/toml"
+	"github.com/anyswap/CrossChain-Router/v3/cmd/utils"
+	"github.com/anyswap/CrossChain-Router/v3/log"
+	"github.com/anyswap/CrossChain-Router/v3/mpc/simulator"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	clientIdentifier = "mpcsimulator"
+	// Git SHA1 commit hash of the release (set via linker flags)
+	gitCommit = ""
+	gitDate   = ""
+	// The app that holds all commands and flags.
+	app = utils.NewApp(clientIdentifier, gitCommit, gitDate, "the mpc simulator command line interface")
+)
+
+func initApp() {
+	// Initialize the CLI app and start action
+	app.Action = mpcsimulator
+	app.HideVersion = true // we have a command to print the version
+	app.Copyright = "Copyright 2017-2020 The CrossChain-Router Authors"
+	app.Commands = []*cli.Command{
+		utils.LicenseCommand,
+		utils.VersionCommand,
+	}
+	app.Flags = []cli.Flag{
+		utils.ConfigFileFlag,
+		utils.LogFileFlag,
+		utils.LogRotationFlag,
+		utils.LogMaxAgeFlag,
+		utils.VerbosityFlag,
+		utils.JSONFormatFlag,
+		utils.ColorFormatFlag,
+	}
+}
+
+func main() {
+	initApp()
+	if err := app.Run(os.Args); err != nil {
+		log.Println(err)
+		os.Exit(1)
+	}
+}
+
+func mpcsimulator(ctx *cli.Context) error {
+	utils.SetLogger(ctx)
+	if ctx.NArg() > 0 {
+		return fmt.Errorf("invalid command: %q", ctx.Args().Get(0))
+	}
+	configFile := utils.GetConfigFilePath(ctx)
+	if configFile == "" {
+		return fmt.Errorf("must specify config file")
+	}
+	var config simulator.Config
+	if _, err := toml.DecodeFile(configFile, &config); err != nil {
+		return fmt.Errorf("load config file %v failed, %w", configFile, err)
+	}
+	sim, err := simulator.NewSimulator(&config)
+	if err != nil {
+		return err
+	}
+	sim.Start()
+	utils.WaitAndCleanup(func() {
+		log.Info("mpc simulator exit")
+	})
+	return nil
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/anyswap/CrossChain-Router/v3/admin"
@@ -87,6 +88,40 @@ forbid specified swap
 				Flags:  swapKeyFlags,
 				Description: `
 pass forbidden swapout
+`,
+			},
+			{
+				Name:      "propose",
+				Usage:     "propose admin call which need multi-admin approval",
+				Action:    propose,
+				ArgsUsage: "<method> [params...]",
+				Description: `
+propose admin call which need multi-admin approval (see config 'Server.AdminApproval').
+the proposer is counted as the first approver, and the proposal id is returned.
+
+examples:
+
+propose passbigvalue <chainID> <txid> <logIndex>
+propose reswap <chainID> <txid> <logIndex>
+propose replaceswap <chainID> <txid> <logIndex> <gasPrice>
+propose passforbiddenswapout <chainID> <txid> <logIndex>
+`,
+			},
+			{
+				Name:      "approve",
+				Usage:     "approve admin proposal",
+				Action:    approve,
+				ArgsUsage: "<proposalID>",
+				Description: `
+approve admin proposal, the proposal is executed after enough admins approved.
+`,
+			},
+			{
+				Name:   "proposals",
+				Usage:  "list pending admin proposals",
+				Action: proposals,
+				Description: `
+list pending and unexpired admin proposals
 `,
 			},
 		},
@@ -259,3 +294,60 @@ func passforbiddenswapout(ctx *cli.Context) error {
 	log.Printf("result is '%v'", result)
 	return err
 }
+
+func propose(ctx *cli.Context) error {
+	utils.SetLogger(ctx)
+	if ctx.NArg() == 0 {
+		return fmt.Errorf("propose: no method is specified")
+	}
+	method := "propose"
+	err := admin.Prepare(ctx)
+	if err != nil {
+		return err
+	}
+
+	params := ctx.Args().Slice()
+
+	log.Printf("%v: %v", method, params)
+
+	result, err := admin.SwapAdmin(method, params)
+
+	log.Printf("result is '%v'", result)
+	return err
+}
+
+func approve(ctx *cli.Context) error {
+	utils.SetLogger(ctx)
+	if ctx.NArg() != 1 {
+		return fmt.Errorf("approve: must specify one proposal id")
+	}
+	method := "approve"
+	err := admin.Prepare(ctx)
+	if err != nil {
+		return err
+	}
+
+	proposalID := ctx.Args().Get(0)
+
+	log.Printf("%v: %v", method, proposalID)
+
+	params := []string{proposalID}
+	result, err := admin.SwapAdmin(method, params)
+
+	log.Printf("result is '%v'", result)
+	return err
+}
+
+func proposals(ctx *cli.Context) error {
+	utils.SetLogger(ctx)
+	result, err := admin.GetAdminProposals(ctx)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return err
+	}
+	log.Printf("pending proposals are %v", string(data))
+	return nil
+}
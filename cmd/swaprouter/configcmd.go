@@ -4,21 +4,66 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/big"
+	"strings"
 
 	"github.com/anyswap/CrossChain-Router/v3/cmd/utils"
 	"github.com/anyswap/CrossChain-Router/v3/common"
+	"github.com/anyswap/CrossChain-Router/v3/params"
 	"github.com/anyswap/CrossChain-Router/v3/router"
+	"github.com/anyswap/CrossChain-Router/v3/router/bridge"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
 	"github.com/urfave/cli/v2"
 )
 
 var (
 	configCommand = &cli.Command{
 		Name:  "config",
-		Usage: "query router config",
+		Usage: "query, validate and compare router config",
 		Description: `
-query router config
+query, validate and compare router config
 `,
 		Subcommands: []*cli.Command{
+			{
+				Name:   "validate",
+				Usage:  "validate config file and all onchain configs",
+				Action: validateConfig,
+				Flags: []cli.Flag{
+					utils.ConfigFileFlag,
+					utils.GatewayConfigFlag,
+					utils.RunServerFlag,
+				},
+				Description: `
+validate config file and all onchain configs, report all problems found.
+
+checks include config file sections, missing gateways, chain and token configs,
+token decimals with token contracts, router mpc and its public key,
+swap and fee configs, and fee receivers of charging fee on dest chain.
+
+example:
+
+swaprouter config validate --config config.toml --runserver
+`,
+			},
+			{
+				Name:   "diff",
+				Usage:  "compare onchain configs of two config contracts or two blocks",
+				Action: diffConfig,
+				Flags: []cli.Flag{
+					onchainContractFlag,
+					gatewaysFlag,
+					onchainContract2Flag,
+					blockNumberFlag,
+					blockNumber2Flag,
+				},
+				Description: `
+compare onchain configs of two config contracts or two blocks.
+
+examples:
+
+swaprouter config diff --gateway URL --contract 0xAAA --contract2 0xBBB
+swaprouter config diff --gateway URL --contract 0xAAA --block 100 --block2 200
+`,
+			},
 			{
 				Name:   "getAllChainIDs",
 				Usage:  "get all chainIDs",
@@ -198,8 +243,95 @@ query router config
 		Name:  "gateway",
 		Usage: "gateway URL to connect",
 	}
+
+	onchainContract2Flag = &cli.StringFlag{
+		Name:  "contract2",
+		Usage: "onchain contract address to compare with (default to 'contract')",
+	}
+
+	blockNumberFlag = &cli.StringFlag{
+		Name:  "block",
+		Usage: "block number to get onchain configs (default to latest)",
+	}
+
+	blockNumber2Flag = &cli.StringFlag{
+		Name:  "block2",
+		Usage: "block number to compare with (default to latest)",
+	}
 )
 
+func validateConfig(ctx *cli.Context) error {
+	utils.SetLogger(ctx)
+	isServer := ctx.Bool(utils.RunServerFlag.Name)
+	if ctx.IsSet(utils.GatewayConfigFlag.Name) {
+		params.GatewayConfigFile = ctx.String(utils.GatewayConfigFlag.Name)
+	}
+	config := params.LoadRouterConfig(utils.GetConfigFilePath(ctx), isServer, false)
+	tokens.InitRouterSwapType(config.SwapType)
+
+	problems := bridge.ValidateRouterConfig(isServer)
+	for _, problem := range problems {
+		fmt.Println(problem)
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("found %v config problems", len(problems))
+	}
+	fmt.Println("validate config success")
+	return nil
+}
+
+func getOnchainConfigSnapshot(contract string, gateways []string, blockNumberStr string) (*router.OnchainConfigSnapshot, error) {
+	var blockNumber *big.Int
+	if blockNumberStr != "" && blockNumberStr != "latest" {
+		var err error
+		blockNumber, err = common.GetBigIntFromStr(blockNumberStr)
+		if err != nil {
+			return nil, fmt.Errorf("wrong block number '%v'", blockNumberStr)
+		}
+	}
+	router.InitRouterConfigClientsWithArgs(contract, gateways)
+	router.SetOnchainConfigBlockNumber(blockNumber)
+	return router.GetOnchainConfigSnapshot()
+}
+
+func diffConfig(ctx *cli.Context) error {
+	utils.SetLogger(ctx)
+	contract := ctx.String(onchainContractFlag.Name)
+	contract2 := ctx.String(onchainContract2Flag.Name)
+	if contract2 == "" {
+		contract2 = contract
+	}
+	block := ctx.String(blockNumberFlag.Name)
+	block2 := ctx.String(blockNumber2Flag.Name)
+	if strings.EqualFold(contract, contract2) && block == block2 {
+		return fmt.Errorf("must specify different contracts or blocks to compare")
+	}
+	gateways := ctx.StringSlice(gatewaysFlag.Name)
+
+	oldSnapshot, err := getOnchainConfigSnapshot(contract, gateways, block)
+	if err != nil {
+		return err
+	}
+	newSnapshot, err := getOnchainConfigSnapshot(contract2, gateways, block2)
+	if err != nil {
+		return err
+	}
+	diffs := router.DiffOnchainConfigSnapshots(oldSnapshot, newSnapshot)
+	for _, diff := range diffs {
+		fmt.Println(diff)
+	}
+	fmt.Printf("found %v differences between (contract %v, block %v) and (contract %v, block %v)\n",
+		len(diffs), contract, getBlockNumberDesc(block), contract2, getBlockNumberDesc(block2))
+	return nil
+}
+
+func getBlockNumberDesc(blockNumber string) string {
+	if blockNumber == "" {
+		return "latest"
+	}
+	return blockNumber
+}
+
 func getAllChainConfig(ctx *cli.Context) error {
 	utils.SetLogger(ctx)
 	router.InitRouterConfigClientsWithArgs(
@@ -87,6 +87,9 @@ func swaprouter(ctx *cli.Context) error {
 			dbConfig.UserName,
 			dbConfig.Password,
 		)
+		if dbConfig.CreateSearchIndexes {
+			mongodb.InitSearchIndexes()
+		}
 		worker.StartRouterSwapWork(true)
 		time.Sleep(100 * time.Millisecond)
 		rpcserver.StartAPIServer()
@@ -12,6 +12,7 @@ import (
 	"github.com/anyswap/CrossChain-Router/v3/params"
 	"github.com/anyswap/CrossChain-Router/v3/router"
 	"github.com/anyswap/CrossChain-Router/v3/tokens"
+	"github.com/anyswap/CrossChain-Router/v3/tokens/gasoracle"
 	"github.com/anyswap/CrossChain-Router/v3/worker"
 	rpcjson "github.com/gorilla/rpc/v2/json2"
 )
@@ -40,13 +41,17 @@ func GetServerInfo() *ServerInfo {
 		CallByContractWhitelist:         extra.CallByContractWhitelist,
 		CallByContractCodeHashWhitelist: extra.CallByContractCodeHashWhitelist,
 	}
-	return &ServerInfo{
+	info := &ServerInfo{
 		Identifier:     params.GetIdentifier(),
 		Version:        params.VersionWithMeta,
 		ExtraConfig:    extraCfg,
 		AllChainIDs:    router.AllChainIDs,
 		PausedChainIDs: router.GetPausedChainIDs(),
 	}
+	if params.GetLeaderElectionConfig() != nil {
+		info.LeaderInfo = worker.GetLeaderInfo()
+	}
+	return info
 }
 
 // GetOracleInfo get oracle info
@@ -165,6 +170,8 @@ func RegisterRouterSwap(fromChainID, txid, logIndexStr string) (*MapIntResult, e
 			err = addMgoSwap(swapInfo, newStatus, memo)
 			if err != nil {
 				result[logIndex] = "db error"
+			} else if verifyErr == nil {
+				addNFTIDMappings(swapInfo)
 			}
 		case verifyErr == nil:
 			switch {
@@ -291,3 +298,103 @@ func GetAllMultichainTokens(tokenID string) map[string]string {
 	}
 	return m
 }
+
+// GetGasPriceEstimate get current gas price estimate of chain (refresh if expired)
+func GetGasPriceEstimate(chainID string) (*gasoracle.Estimate, error) {
+	oracle := gasoracle.GetOracle(chainID)
+	if oracle == nil {
+		return nil, newRPCError(-32099, "gas oracle of chain "+chainID+" not exist")
+	}
+	est, err := oracle.GetEstimate()
+	if err != nil {
+		return nil, newRPCInternalError(err)
+	}
+	return est, nil
+}
+
+// GetGasPriceEstimates get latest gas price estimates of all chains
+func GetGasPriceEstimates() map[string]*gasoracle.Estimate {
+	return gasoracle.GetEstimates()
+}
+
+// GetAccountBalance get native balance and fee resources (eg. tron energy and bandwidth) of router mpc,
+// default account is the router mpc of chain
+func GetAccountBalance(chainID, account string) (*AccountBalance, error) {
+	bridge := router.GetBridgeByChainID(chainID)
+	if bridge == nil {
+		return nil, newRPCError(-32099, "chainID "+chainID+" not exist")
+	}
+	if account == "" {
+		routerInfo := router.GetRouterInfo(bridge.GetChainConfig().RouterContract, chainID)
+		if routerInfo == nil {
+			return nil, newRPCError(-32099, "router mpc of chain "+chainID+" not exist")
+		}
+		account = routerInfo.RouterMPC
+	}
+	// forbid querying arbitrary accounts through the chain gateways
+	if !router.IsRouterMPC(chainID, account) {
+		return nil, newRPCError(-32099, "account "+account+" is not router mpc of chain "+chainID)
+	}
+	balance, err := bridge.GetBalance(account)
+	if err != nil {
+		return nil, newRPCInternalError(err)
+	}
+	result := &AccountBalance{
+		ChainID: chainID,
+		Account: account,
+		Balance: balance.String(),
+	}
+	if getter, ok := bridge.(tokens.AccountResourceGetter); ok {
+		result.Resources, err = getter.GetAccountResources(account)
+		if err != nil {
+			return nil, newRPCInternalError(err)
+		}
+	}
+	return result, nil
+}
+
+// GetMaintenanceState get effective maintenance state
+func GetMaintenanceState() *worker.MaintenanceState {
+	return worker.GetMaintenanceState()
+}
+
+// GetAdminProposals get pending admin proposals which need multi-admin approval
+func GetAdminProposals() ([]*mongodb.MgoAdminProposal, error) {
+	res, err := mongodb.FindPendingAdminProposals()
+	if err != nil {
+		return nil, newRPCInternalError(err)
+	}
+	return res, nil
+}
+
+// GetStuckSwaps get swaps staying in status longer than the SLA found by the latest check
+func GetStuckSwaps() *worker.StuckSwapReport {
+	return worker.GetStuckSwapReport()
+}
+
+// GetConfigAudits get latest audit records of reloading router config
+func GetConfigAudits(limit int) ([]*mongodb.MgoConfigAudit, error) {
+	switch {
+	case limit <= 0:
+		limit = 20 // default
+	case limit > 100:
+		limit = 100
+	}
+	res, err := mongodb.FindConfigAudits(int64(limit))
+	if err != nil {
+		return nil, newRPCInternalError(err)
+	}
+	return res, nil
+}
+
+// GetSignGroupHealth get health of mpc sign groups (key is `mpc` or `fastmpc`)
+func GetSignGroupHealth() map[string][]*mpc.SignGroupHealth {
+	result := make(map[string][]*mpc.SignGroupHealth, 2)
+	if health := mpc.GetMPCConfig(false).GetSignGroupHealth(); health != nil {
+		result["mpc"] = health
+	}
+	if health := mpc.GetMPCConfig(true).GetSignGroupHealth(); health != nil {
+		result["fastmpc"] = health
+	}
+	return result
+}
@@ -47,29 +47,34 @@ func ConvertMgoSwapResultToSwapInfo(mr *mongodb.MgoSwapResult) *SwapInfo {
 		}
 	}
 	return &SwapInfo{
-		SwapType:      mr.SwapType,
-		TxID:          mr.TxID,
-		TxTo:          mr.TxTo,
-		TxHeight:      mr.TxHeight,
-		From:          mr.From,
-		To:            mr.To,
-		Bind:          mr.Bind,
-		Value:         mr.Value,
-		LogIndex:      mr.LogIndex,
-		FromChainID:   mr.FromChainID,
-		ToChainID:     mr.ToChainID,
-		SwapInfo:      mr.SwapInfo,
-		SwapTx:        mr.SwapTx,
-		SwapHeight:    mr.SwapHeight,
-		SwapValue:     mr.SwapValue,
-		SwapNonce:     mr.SwapNonce,
-		Status:        mr.Status,
-		StatusMsg:     mr.Status.String(),
-		InitTime:      mr.InitTime,
-		Timestamp:     mr.Timestamp,
-		Memo:          mr.Memo,
-		ReplaceCount:  len(mr.OldSwapTxs),
-		Confirmations: confirmations,
+		SwapType:         mr.SwapType,
+		TxID:             mr.TxID,
+		TxTo:             mr.TxTo,
+		TxHeight:         mr.TxHeight,
+		From:             mr.From,
+		To:               mr.To,
+		Bind:             mr.Bind,
+		Value:            mr.Value,
+		LogIndex:         mr.LogIndex,
+		FromChainID:      mr.FromChainID,
+		ToChainID:        mr.ToChainID,
+		SwapInfo:         mr.SwapInfo,
+		SwapTx:           mr.SwapTx,
+		SwapHeight:       mr.SwapHeight,
+		SwapValue:        mr.SwapValue,
+		SwapNonce:        mr.SwapNonce,
+		Status:           mr.Status,
+		StatusMsg:        mr.Status.String(),
+		InitTime:         mr.InitTime,
+		Timestamp:        mr.Timestamp,
+		Memo:             mr.Memo,
+		ReplaceCount:     len(mr.OldSwapTxs),
+		Confirmations:    confirmations,
+		BatchSize:        mr.BatchSize,
+		BatchIndex:       mr.BatchIndex,
+		Payout:           mr.Payout,
+		PayoutUnderlying: mr.PayoutUnderlying,
+		Delivery:         mr.Delivery,
 	}
 }
 
@@ -0,0 +1,105 @@
+package swapapi
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/anyswap/CrossChain-Router/v3/common"
+	"github.com/anyswap/CrossChain-Router/v3/log"
+	"github.com/anyswap/CrossChain-Router/v3/mongodb"
+	"github.com/anyswap/CrossChain-Router/v3/router"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+)
+
+// NFTIDMappingArgs args of getting nft id mapping,
+// find by `nativeid` if it is not empty, otherwise find by `token` and `id`
+type NFTIDMappingArgs struct {
+	ChainID  string `json:"chainid"`
+	Token    string `json:"token"`
+	ID       string `json:"id"`
+	NativeID string `json:"nativeid"`
+}
+
+// GetNFTIDMapping get mapping between nft id and native nft item (eg. solana mint, aptos token object)
+func GetNFTIDMapping(args *NFTIDMappingArgs) (*mongodb.MgoNFTIDMapping, error) {
+	if args.NativeID != "" {
+		res, err := mongodb.FindNFTIDMappingByNativeID(args.ChainID, args.NativeID)
+		if err != nil {
+			return nil, newRPCInternalError(err)
+		}
+		return res, nil
+	}
+	res, err := mongodb.FindNFTIDMapping(args.ChainID, args.Token, args.ID)
+	if err == nil {
+		return res, nil
+	}
+	if !errors.Is(err, mongodb.ErrItemNotFound) {
+		return nil, newRPCInternalError(err)
+	}
+	// not swapped yet, calc it as the mapping is deterministic
+	id, err := common.GetBigIntFromStr(args.ID)
+	if err != nil {
+		return nil, newRPCError(-32099, "wrong nft id")
+	}
+	mapping, err := getNFTIDMapping(args.ChainID, args.Token, id)
+	if err != nil {
+		return nil, newRPCInternalError(err)
+	}
+	return mapping, nil
+}
+
+func getNFTIDMapping(chainID, token string, id *big.Int) (*mongodb.MgoNFTIDMapping, error) {
+	bridge := router.GetBridgeByChainID(chainID)
+	if bridge == nil {
+		return nil, tokens.ErrNoBridgeForChainID
+	}
+	mapper, ok := bridge.(tokens.NFTIDMapper)
+	if !ok {
+		return nil, tokens.ErrNotImplemented
+	}
+	tokenCfg := bridge.GetTokenConfig(token)
+	if tokenCfg == nil {
+		return nil, tokens.ErrMissTokenConfig
+	}
+	nativeID, err := mapper.GetNFTNativeID(token, id)
+	if err != nil {
+		return nil, err
+	}
+	return &mongodb.MgoNFTIDMapping{
+		ChainID:  chainID,
+		TokenID:  tokenCfg.TokenID,
+		Token:    token,
+		ID:       id.String(),
+		NativeID: nativeID,
+	}, nil
+}
+
+// addNFTIDMappings record native nft items of the swapped nft ids on source and dest chains
+func addNFTIDMappings(swapInfo *tokens.SwapTxInfo) {
+	nftSwapInfo := swapInfo.NFTSwapInfo
+	if nftSwapInfo == nil || nftSwapInfo.TokenID == "" {
+		return
+	}
+	fromChainID := swapInfo.FromChainID.String()
+	toChainID := swapInfo.ToChainID.String()
+	addNFTIDMapping(fromChainID, nftSwapInfo.Token, nftSwapInfo.IDs)
+	multichainToken := router.GetCachedMultichainToken(nftSwapInfo.TokenID, toChainID)
+	if multichainToken != "" {
+		addNFTIDMapping(toChainID, multichainToken, nftSwapInfo.IDs)
+	}
+}
+
+func addNFTIDMapping(chainID, token string, ids []*big.Int) {
+	bridge := router.GetBridgeByChainID(chainID)
+	if _, ok := bridge.(tokens.NFTIDMapper); !ok {
+		return
+	}
+	for _, id := range ids {
+		mapping, err := getNFTIDMapping(chainID, token, id)
+		if err != nil {
+			log.Warn("[api] get nft id mapping failed", "chainID", chainID, "token", token, "id", id, "err", err)
+			continue
+		}
+		_ = mongodb.AddNFTIDMapping(mapping)
+	}
+}
@@ -0,0 +1,73 @@
+package swapapi
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Router/v3/router"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+)
+
+type testBridge struct {
+	tokens.IBridge // not implemented methods panic
+}
+
+func (b *testBridge) GetTokenConfig(token string) *tokens.TokenConfig {
+	if token == "collection" {
+		return &tokens.TokenConfig{TokenID: "NFT"}
+	}
+	return nil
+}
+
+type testNFTBridge struct {
+	testBridge
+}
+
+func (b *testNFTBridge) GetNFTNativeID(token string, id *big.Int) (string, error) {
+	if id.Sign() < 0 {
+		return "", errors.New("invalid nft id")
+	}
+	return "native-" + id.String(), nil
+}
+
+func TestGetNFTIDMapping(t *testing.T) {
+	router.SetBridge("1", &testNFTBridge{})
+	router.SetBridge("2", &testBridge{})
+	defer func() {
+		router.SetBridge("1", nil)
+		router.SetBridge("2", nil)
+	}()
+
+	tests := []struct {
+		chainID  string
+		token    string
+		id       *big.Int
+		nativeID string
+		wantErr  error
+	}{
+		{"1", "collection", big.NewInt(7), "native-7", nil},
+		{"1", "collection", big.NewInt(0), "native-0", nil},
+		{"1", "collection", big.NewInt(-1), "", errors.New("invalid nft id")},
+		{"1", "unknown", big.NewInt(7), "", tokens.ErrMissTokenConfig},
+		{"2", "collection", big.NewInt(7), "", tokens.ErrNotImplemented},
+		{"3", "collection", big.NewInt(7), "", tokens.ErrNoBridgeForChainID},
+	}
+	for i, test := range tests {
+		mapping, err := getNFTIDMapping(test.chainID, test.token, test.id)
+		if test.wantErr != nil {
+			if err == nil || err.Error() != test.wantErr.Error() {
+				t.Errorf("test %v: want error %v, but got %v", i, test.wantErr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("test %v: get nft id mapping failed: %v", i, err)
+			continue
+		}
+		if mapping.ChainID != test.chainID || mapping.TokenID != "NFT" || mapping.Token != test.token ||
+			mapping.ID != test.id.String() || mapping.NativeID != test.nativeID {
+			t.Errorf("test %v: wrong mapping %+v", i, mapping)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package swapapi
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Router/v3/common"
+	"github.com/anyswap/CrossChain-Router/v3/mongodb"
+)
+
+// SwapSearchArgs args of searching swaps, empty field means no restriction
+type SwapSearchArgs struct {
+	FromChainID string `json:"fromchainid"`
+	ToChainID   string `json:"tochainid"`
+	TokenID     string `json:"tokenid"`
+	Bind        string `json:"bind"`
+	Status      string `json:"status"`    // comma separated statuses
+	MinValue    string `json:"minvalue"`  // inclusive
+	MaxValue    string `json:"maxvalue"`  // inclusive
+	StartTime   int64  `json:"starttime"` // unix seconds, inclusive
+	EndTime     int64  `json:"endtime"`   // unix seconds, exclusive
+	Order       string `json:"order"`     // asc or desc (default)
+	Cursor      string `json:"cursor"`    // next cursor of previous page
+	Limit       int    `json:"limit"`
+}
+
+// SwapSearchResult result of searching swaps
+type SwapSearchResult struct {
+	Swaps      []*SwapInfo `json:"swaps"`
+	NextCursor string      `json:"nextCursor,omitempty"` // empty means no more swaps
+}
+
+// SearchRouterSwaps search swaps with filters and cursor based pagination
+func SearchRouterSwaps(args *SwapSearchArgs) (*SwapSearchResult, error) {
+	filter, err := args.toFilter()
+	if err != nil {
+		return nil, newRPCError(-32099, err.Error())
+	}
+	swaps, err := mongodb.SearchRouterSwaps(filter)
+	if err != nil {
+		return nil, newRPCInternalError(err)
+	}
+	result := &SwapSearchResult{Swaps: ConvertMgoSwapResultsToSwapInfos(swaps)}
+	if int64(len(swaps)) == filter.Limit {
+		last := swaps[len(swaps)-1]
+		result.NextCursor = encodeSwapSearchCursor(&mongodb.SwapSearchCursor{InitTime: last.InitTime, Key: last.Key})
+	}
+	return result, nil
+}
+
+//nolint:gocyclo // allow many args
+func (args *SwapSearchArgs) toFilter() (*mongodb.SwapSearchFilter, error) {
+	filter := &mongodb.SwapSearchFilter{
+		FromChainID: args.FromChainID,
+		ToChainID:   args.ToChainID,
+		TokenID:     args.TokenID,
+		Bind:        args.Bind,
+		StartTime:   args.StartTime * 1000,
+		EndTime:     args.EndTime * 1000,
+	}
+
+	switch {
+	case args.Limit <= 0:
+		filter.Limit = 20 // default
+	case args.Limit > 100:
+		filter.Limit = 100
+	default:
+		filter.Limit = int64(args.Limit)
+	}
+
+	switch strings.ToLower(args.Order) {
+	case "", "desc":
+	case "asc":
+		filter.Ascending = true
+	default:
+		return nil, fmt.Errorf("wrong order '%v', should be asc or desc", args.Order)
+	}
+
+	for _, part := range strings.Split(args.Status, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		num, err := common.GetUint64FromStr(part)
+		if err != nil {
+			return nil, fmt.Errorf("wrong status '%v'", part)
+		}
+		filter.Statuses = append(filter.Statuses, mongodb.SwapStatus(num))
+	}
+
+	var err error
+	if filter.MinValue, err = parseSearchValue(args.MinValue); err != nil {
+		return nil, err
+	}
+	if filter.MaxValue, err = parseSearchValue(args.MaxValue); err != nil {
+		return nil, err
+	}
+
+	if args.Cursor != "" {
+		if filter.Cursor, err = decodeSwapSearchCursor(args.Cursor); err != nil {
+			return nil, err
+		}
+	}
+	return filter, nil
+}
+
+func parseSearchValue(value string) (*big.Int, error) {
+	if value == "" {
+		return nil, nil
+	}
+	bi, ok := new(big.Int).SetString(value, 10)
+	if !ok || bi.Sign() < 0 {
+		return nil, fmt.Errorf("wrong value '%v'", value)
+	}
+	return bi, nil
+}
+
+func encodeSwapSearchCursor(cursor *mongodb.SwapSearchCursor) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d:%s", cursor.InitTime, cursor.Key)))
+}
+
+func decodeSwapSearchCursor(cursor string) (*mongodb.SwapSearchCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("wrong cursor '%v'", cursor)
+	}
+	parts := strings.SplitN(string(data), ":", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("wrong cursor '%v'", cursor)
+	}
+	initTime, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("wrong cursor '%v'", cursor)
+	}
+	return &mongodb.SwapSearchCursor{InitTime: initTime, Key: parts[1]}, nil
+}
@@ -5,6 +5,8 @@ import (
 
 	"github.com/anyswap/CrossChain-Router/v3/mongodb"
 	"github.com/anyswap/CrossChain-Router/v3/params"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+	"github.com/anyswap/CrossChain-Router/v3/worker"
 )
 
 // MapIntResult type
@@ -16,7 +18,8 @@ type ServerInfo struct {
 	Version        string
 	ExtraConfig    *params.ExtraConfig `json:",omitempty"`
 	AllChainIDs    []*big.Int
-	PausedChainIDs []*big.Int `json:",omitempty"`
+	PausedChainIDs []*big.Int         `json:",omitempty"`
+	LeaderInfo     *worker.LeaderInfo `json:",omitempty"`
 }
 
 // OracleInfo oracle info
@@ -27,29 +30,34 @@ type OracleInfo struct {
 
 // SwapInfo swap info
 type SwapInfo struct {
-	SwapType      uint32             `json:"swaptype"`
-	TxID          string             `json:"txid"`
-	TxTo          string             `json:"txto,omitempty"`
-	TxHeight      uint64             `json:"txheight"`
-	From          string             `json:"from"`
-	To            string             `json:"to"`
-	Bind          string             `json:"bind"`
-	Value         string             `json:"value"`
-	LogIndex      int                `json:"logIndex,omitempty"`
-	FromChainID   string             `json:"fromChainID"`
-	ToChainID     string             `json:"toChainID"`
-	SwapInfo      mongodb.SwapInfo   `json:"swapinfo"`
-	SwapTx        string             `json:"swaptx"`
-	SwapHeight    uint64             `json:"swapheight"`
-	SwapValue     string             `json:"swapvalue"`
-	SwapNonce     uint64             `json:"swapnonce"`
-	Status        mongodb.SwapStatus `json:"status"`
-	StatusMsg     string             `json:"statusmsg"`
-	InitTime      int64              `json:"inittime"`
-	Timestamp     int64              `json:"timestamp"`
-	Memo          string             `json:"memo,omitempty"`
-	ReplaceCount  int                `json:"replaceCount,omitempty"`
-	Confirmations uint64             `json:"confirmations"`
+	SwapType         uint32             `json:"swaptype"`
+	TxID             string             `json:"txid"`
+	TxTo             string             `json:"txto,omitempty"`
+	TxHeight         uint64             `json:"txheight"`
+	From             string             `json:"from"`
+	To               string             `json:"to"`
+	Bind             string             `json:"bind"`
+	Value            string             `json:"value"`
+	LogIndex         int                `json:"logIndex,omitempty"`
+	FromChainID      string             `json:"fromChainID"`
+	ToChainID        string             `json:"toChainID"`
+	SwapInfo         mongodb.SwapInfo   `json:"swapinfo"`
+	SwapTx           string             `json:"swaptx"`
+	SwapHeight       uint64             `json:"swapheight"`
+	SwapValue        string             `json:"swapvalue"`
+	SwapNonce        uint64             `json:"swapnonce"`
+	Status           mongodb.SwapStatus `json:"status"`
+	StatusMsg        string             `json:"statusmsg"`
+	InitTime         int64              `json:"inittime"`
+	Timestamp        int64              `json:"timestamp"`
+	Memo             string             `json:"memo,omitempty"`
+	ReplaceCount     int                `json:"replaceCount,omitempty"`
+	Confirmations    uint64             `json:"confirmations"`
+	BatchSize        int                `json:"batchSize,omitempty"`
+	BatchIndex       int                `json:"batchIndex,omitempty"`
+	Payout           string             `json:"payout,omitempty"`
+	PayoutUnderlying string             `json:"payoutUnderlying,omitempty"`
+	Delivery         string             `json:"delivery,omitempty"`
 }
 
 // ChainConfig rpc type
@@ -86,3 +94,11 @@ type FeeConfig struct {
 	MaximumSwapFee        string
 	MinimumSwapFee        string
 }
+
+// AccountBalance rpc type
+type AccountBalance struct {
+	ChainID   string                   `json:"chainid"`
+	Account   string                   `json:"account"`
+	Balance   string                   `json:"balance"`
+	Resources *tokens.AccountResources `json:"resources,omitempty"`
+}
@@ -7,6 +7,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/anyswap/CrossChain-Router/v3/common"
@@ -33,6 +34,8 @@ var (
 	maxCountOfResults = int64(1000)
 
 	errInvalidSwap = errors.New("invalid swap fields")
+
+	fenceToken uint64 // fencing token of swap result writes (epoch of leader lease)
 )
 
 // GetRouterSwapKey get router swap key
@@ -47,6 +50,8 @@ func AddRouterSwap(ms *MgoSwap) error {
 	}
 	ms.Key = GetRouterSwapKey(ms.FromChainID, ms.TxID, ms.LogIndex)
 	ms.InitTime = common.NowMilli()
+	ms.StatusTime = ms.InitTime / 1000
+	ms.BindLower, ms.ValueNum = getSearchFields(ms.Bind, ms.Value)
 	_, err := collRouterSwap.InsertOne(clientCtx, ms)
 	switch {
 	case err == nil:
@@ -80,7 +85,7 @@ func PassRouterSwapVerify(fromChainID, txid string, logindex int, timestamp int6
 	}
 
 	key := GetRouterSwapKey(fromChainID, txid, logindex)
-	updates := bson.M{"status": TxNotSwapped, "timestamp": timestamp}
+	updates := bson.M{"status": TxNotSwapped, "timestamp": timestamp, "statustime": timestamp}
 	_, err = collRouterSwap.UpdateByID(clientCtx, key, bson.M{"$set": updates})
 	if err == nil {
 		log.Info("mongodb pass verify success", "chainid", fromChainID, "txid", txid, "logindex", logindex)
@@ -109,6 +114,7 @@ func UpdateRouterSwapStatus(fromChainID, txid string, logindex int, status SwapS
 		return errors.New("forbid update swap status to TxNotStable")
 	}
 	key := GetRouterSwapKey(fromChainID, txid, logindex)
+	setStatusTime(collRouterSwap, key, status, timestamp)
 	updates := bson.M{"status": status, "timestamp": timestamp}
 	if memo != "" {
 		updates["memo"] = memo
@@ -147,11 +153,12 @@ func UpdateRouterSwapInfoAndStatus(fromChainID, txid string, logindex int, swapI
 	}
 
 	updates := bson.M{
-		"swapinfo":  *swapInfo,
-		"status":    status,
-		"timestamp": timestamp,
-		"inittime":  timestamp * 1000,
-		"memo":      memo,
+		"swapinfo":   *swapInfo,
+		"status":     status,
+		"timestamp":  timestamp,
+		"inittime":   timestamp * 1000,
+		"statustime": timestamp,
+		"memo":       memo,
 	}
 
 	_, err = collRouterSwap.UpdateByID(clientCtx, key, bson.M{"$set": updates})
@@ -163,6 +170,36 @@ func UpdateRouterSwapInfoAndStatus(fromChainID, txid string, logindex int, swapI
 	return mgoError(err)
 }
 
+// setStatusTime set time of entering the status if the stored status is a different one
+func setStatusTime(coll *mongo.Collection, key string, status SwapStatus, timestamp int64) {
+	filter := bson.M{"_id": key, "status": bson.M{"$ne": status}}
+	_, err := coll.UpdateOne(clientCtx, filter, bson.M{"$set": bson.M{"statustime": timestamp}})
+	if err != nil {
+		log.Warn("mongodb set status time failed", "collection", coll.Name(), "key", key, "status", status, "err", err)
+	}
+}
+
+// IncreaseRouterSwapDisagreeCount increase times of mpc sign disagreed
+func IncreaseRouterSwapDisagreeCount(fromChainID, txid string, logindex int) error {
+	key := GetRouterSwapKey(fromChainID, txid, logindex)
+	_, err := collRouterSwap.UpdateByID(clientCtx, key, bson.M{"$inc": bson.M{"disagreecount": uint64(1)}})
+	if err != nil {
+		log.Warn("mongodb increase disagree count failed", "chainid", fromChainID, "txid", txid, "logindex", logindex, "err", err)
+	}
+	return mgoError(err)
+}
+
+// ResetRouterSwapDisagreeCount reset times of mpc sign disagreed
+func ResetRouterSwapDisagreeCount(fromChainID, txid string, logindex int) error {
+	key := GetRouterSwapKey(fromChainID, txid, logindex)
+	filter := bson.M{"_id": key, "disagreecount": bson.M{"$exists": true}}
+	_, err := collRouterSwap.UpdateOne(clientCtx, filter, bson.M{"$unset": bson.M{"disagreecount": ""}})
+	if err != nil {
+		log.Warn("mongodb reset disagree count failed", "chainid", fromChainID, "txid", txid, "logindex", logindex, "err", err)
+	}
+	return mgoError(err)
+}
+
 // FindRouterSwap find router swap
 func FindRouterSwap(fromChainID, txid string, logindex int) (*MgoSwap, error) {
 	key := GetRouterSwapKey(fromChainID, txid, logindex)
@@ -283,6 +320,8 @@ func FindRouterSwapsWithChainIDAndStatus(fromChainID string, status SwapStatus,
 func AddRouterSwapResult(mr *MgoSwapResult) error {
 	mr.Key = GetRouterSwapKey(mr.FromChainID, mr.TxID, mr.LogIndex)
 	mr.InitTime = common.NowMilli()
+	mr.StatusTime = mr.InitTime / 1000
+	mr.BindLower, mr.ValueNum = getSearchFields(mr.Bind, mr.Value)
 	_, err := collRouterSwapResult.InsertOne(clientCtx, mr)
 	if err == nil {
 		log.Info("mongodb add router swap result success", "chainid", mr.FromChainID, "txid", mr.TxID, "logindex", mr.LogIndex)
@@ -328,14 +367,19 @@ func AllocateRouterSwapNonce(args *tokens.BuildTxArgs, nonceptr *uint64, isRecyc
 	if args.SwapValue != nil {
 		resUpdates["swapvalue"] = args.SwapValue.String()
 	}
-	_, err = collRouterSwapResult.UpdateByID(clientCtx, key, bson.M{"$set": resUpdates})
+	if args.Extra != nil && args.Extra.KeyIndex != nil {
+		resUpdates["keyindex"] = *args.Extra.KeyIndex
+	}
+	setStatusTime(collRouterSwapResult, key, MatchTxNotStable, nowTime)
+	err = updateFencedSwapResult(key, resUpdates, nil)
 	if err != nil {
 		log.Warn("mongodb allocate swap nonce failed", "chainid", fromChainID, "txid", txid, "logindex", logindex, "swapnonce", swapnonce, "err", err)
-		return 0, mgoError(err)
+		return 0, err
 	}
 
 	log.Info("mongodb allocate swap nonce success", "chainid", fromChainID, "txid", txid, "logindex", logindex, "swapnonce", swapnonce)
 
+	setStatusTime(collRouterSwap, key, TxProcessed, nowTime)
 	statusUpdates := bson.M{"status": TxProcessed, "timestamp": nowTime}
 	_, errf := collRouterSwap.UpdateByID(clientCtx, key, bson.M{"$set": statusUpdates})
 	if errf != nil {
@@ -356,6 +400,7 @@ func UpdateRouterSwapResultStatus(fromChainID, txid string, logindex int, status
 	defer updateResultLock.Unlock()
 
 	key := GetRouterSwapKey(fromChainID, txid, logindex)
+	setStatusTime(collRouterSwapResult, key, status, timestamp)
 	updates := bson.M{"status": status, "timestamp": timestamp}
 	if memo != "" {
 		updates["memo"] = memo
@@ -367,14 +412,15 @@ func UpdateRouterSwapResultStatus(fromChainID, txid string, logindex int, status
 		updates["swapheight"] = 0
 		updates["swaptime"] = 0
 		updates["swapnonce"] = 0
+		updates["keyindex"] = nil
 	}
-	_, err := collRouterSwapResult.UpdateByID(clientCtx, key, bson.M{"$set": updates})
+	err := updateFencedSwapResult(key, updates, nil)
 	if err == nil {
 		log.Info("mongodb update swap result status success", "chainid", fromChainID, "txid", txid, "logindex", logindex, "status", status)
 	} else {
 		log.Error("mongodb update swap result status failed", "chainid", fromChainID, "txid", txid, "logindex", logindex, "status", status, "err", err)
 	}
-	return mgoError(err)
+	return err
 }
 
 // UpdateRouterOldSwapTxs update old swaptxs by appending `swapTx`
@@ -412,26 +458,22 @@ func UpdateRouterOldSwapTxs(fromChainID, txid string, logindex int, swapTx strin
 		log.Warn("UpdateRouterOldSwapTxs ignore update swap tx with stable status", "fromChainID", fromChainID, "txid", txid, "logindex", logindex, "ignored", swapTx, "swaptx", swapRes.SwapTx, "swapnonce", swapRes.SwapNonce)
 	}
 
-	var updates bson.M
+	var pushes bson.M
 
 	if len(swapRes.OldSwapTxs) == 0 {
 		updateSet["oldswaptxs"] = []string{swapRes.SwapTx, swapTx}
-		updates = bson.M{"$set": updateSet}
 	} else {
-		updates = bson.M{
-			"$set":  updateSet,
-			"$push": bson.M{"oldswaptxs": swapTx},
-		}
+		pushes = bson.M{"oldswaptxs": swapTx}
 	}
 
 	key := GetRouterSwapKey(fromChainID, txid, logindex)
-	_, err = collRouterSwapResult.UpdateByID(clientCtx, key, updates)
+	err = updateFencedSwapResult(key, updateSet, pushes)
 	if err == nil {
 		log.Info("UpdateRouterOldSwapTxs success", "fromChainID", fromChainID, "txid", txid, "logIndex", logindex, "swaptx", swapTx, "nonce", swapRes.SwapNonce)
 	} else {
 		log.Error("UpdateRouterOldSwapTxs failed", "fromChainID", fromChainID, "txid", txid, "logIndex", logindex, "swaptx", swapTx, "nonce", swapRes.SwapNonce, "err", err)
 	}
-	return mgoError(err)
+	return err
 }
 
 // FindRouterSwapResult find router swap result
@@ -543,6 +585,28 @@ func FindRouterSwapResultsWithChainIDAndStatus(fromChainID string, status SwapSt
 	return result, nil
 }
 
+// FindRouterSwapResultsOfBatch find router swap results in the batch swap tx with the swap nonce
+func FindRouterSwapResultsOfBatch(toChainID, mpc string, swapNonce uint64) ([]*MgoSwapResult, error) {
+	qchainid := bson.M{"toChainID": toChainID}
+	qmpc := bson.M{"mpc": mpc}
+	qnonce := bson.M{"swapnonce": swapNonce}
+	qbatch := bson.M{"batchsize": bson.M{"$gt": 0}}
+	queries := []bson.M{qchainid, qmpc, qnonce, qbatch}
+	opts := &options.FindOptions{
+		Sort: bson.D{{Key: "batchindex", Value: 1}},
+	}
+	cur, err := collRouterSwapResult.Find(clientCtx, bson.M{"$and": queries}, opts)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	result := make([]*MgoSwapResult, 0, 20)
+	err = cur.All(clientCtx, &result)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	return result, nil
+}
+
 // FindNextSwapNonce find next swap nonce
 func FindNextSwapNonce(chainID, mpc string) (uint64, error) {
 	qchainid := bson.M{"toChainID": chainID}
@@ -564,6 +628,48 @@ func FindNextSwapNonce(chainID, mpc string) (uint64, error) {
 	return result.SwapNonce + 1, nil
 }
 
+// FindNextKeySwapNonce find next swap nonce of the account key of mpc
+func FindNextKeySwapNonce(chainID, mpc string, keyIndex uint64) (uint64, error) {
+	opts := &options.FindOneOptions{
+		Sort: bson.D{{Key: "swapnonce", Value: -1}},
+	}
+	result := &MgoSwapResult{}
+	err := collRouterSwapResult.FindOne(clientCtx, getKeySwapNonceQuery(chainID, mpc, keyIndex), opts).Decode(result)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return 0, nil
+		}
+		log.Error("FindNextKeySwapNonce failed", "chainID", chainID, "mpc", mpc, "keyIndex", keyIndex, "err", err)
+		return 0, mgoError(err)
+	}
+	log.Info("FindNextKeySwapNonce success", "chainID", chainID, "mpc", mpc, "keyIndex", keyIndex, "nonce", result.SwapNonce)
+	return result.SwapNonce + 1, nil
+}
+
+// getKeySwapNonceQuery match mpc exactly (as stored or lowercased) to use `keyNonceIndexes`
+func getKeySwapNonceQuery(chainID, mpc string, keyIndex uint64) bson.M {
+	mpcs := []string{mpc}
+	if lower := strings.ToLower(mpc); lower != mpc {
+		mpcs = append(mpcs, lower)
+	}
+	qchainid := bson.M{"toChainID": chainID}
+	qmpc := bson.M{"mpc": bson.M{"$in": mpcs}}
+	qkey := bson.M{"keyindex": keyIndex}
+	return bson.M{"$and": []bson.M{qchainid, qmpc, qkey}}
+}
+
+// keyNonceIndexes indexes of swap result collection used by finding the next nonce of account key,
+// it is partial on results with key index (eg. flow proposal keys) and is cheap to build.
+var keyNonceIndexes = []mongo.IndexModel{
+	{
+		Keys: bson.D{
+			{Key: "toChainID", Value: 1}, {Key: "mpc", Value: 1},
+			{Key: "keyindex", Value: 1}, {Key: "swapnonce", Value: -1},
+		},
+		Options: options.Index().SetPartialFilterExpression(bson.M{"keyindex": bson.M{"$exists": true}}),
+	},
+}
+
 // FindRouterSwapResultsToStable find swap results to stable
 func FindRouterSwapResultsToStable(chainID string, septime int64) ([]*MgoSwapResult, error) {
 	qtime := bson.M{"inittime": bson.M{"$gte": septime}}
@@ -732,6 +838,9 @@ func UpdateRouterSwapResult(fromChainID, txid string, logindex int, items *SwapR
 	}
 	if items.Status != KeepStatus {
 		updates["status"] = items.Status
+		if items.Status != swapRes.Status {
+			updates["statustime"] = items.Timestamp
+		}
 	}
 	if items.MPC != "" {
 		updates["mpc"] = items.MPC
@@ -756,6 +865,22 @@ func UpdateRouterSwapResult(fromChainID, txid string, logindex int, items *SwapR
 	if items.TTL != 0 {
 		updates["ttl"] = items.TTL
 	}
+	if items.BatchSize != 0 {
+		updates["batchsize"] = items.BatchSize
+		updates["batchindex"] = items.BatchIndex
+	}
+	if items.Payout != "" {
+		updates["payout"] = items.Payout
+		updates["payoutunderlying"] = items.PayoutUnderlying
+	}
+	if items.Delivery != "" {
+		updates["delivery"] = items.Delivery
+	}
+	if items.DelayTime != 0 && swapRes.DelayTime == 0 {
+		updates["delaytime"] = items.DelayTime
+	} else if items.Status == MatchTxNotStable && swapRes.DelayTime != 0 {
+		updates["delaytime"] = int64(0)
+	}
 	if items.SwapNonce != 0 || items.Status == MatchTxNotStable {
 		err = checkRouterSwapResultUpdate(swapRes, items.SwapNonce)
 		if err != nil {
@@ -765,13 +890,13 @@ func UpdateRouterSwapResult(fromChainID, txid string, logindex int, items *SwapR
 			updates["swapnonce"] = items.SwapNonce
 		}
 	}
-	_, err = collRouterSwapResult.UpdateByID(clientCtx, key, bson.M{"$set": updates})
+	err = updateFencedSwapResult(key, updates, nil)
 	if err == nil {
 		log.Info("mongodb update router swap result success", "chainid", fromChainID, "txid", txid, "logindex", logindex, "updates", updates)
 	} else {
 		log.Error("mongodb update router swap result failed", "chainid", fromChainID, "txid", txid, "logindex", logindex, "updates", updates, "err", err)
 	}
-	return mgoError(err)
+	return err
 }
 
 func checkRouterSwapResultUpdate(swapRes *MgoSwapResult, swapnonce uint64) error {
@@ -787,6 +912,49 @@ func checkRouterSwapResultUpdate(swapRes *MgoSwapResult, swapnonce uint64) error
 	return nil
 }
 
+// SetFenceToken set the fencing token (epoch of leader lease) of swap result writes, 0 means no fencing
+func SetFenceToken(token uint64) {
+	atomic.StoreUint64(&fenceToken, token)
+}
+
+// GetFenceToken get the fencing token of swap result writes
+func GetFenceToken() uint64 {
+	return atomic.LoadUint64(&fenceToken)
+}
+
+// updateFencedSwapResult update swap result with fencing token,
+// reject the write of a stale leader if a newer leader has written this swap result.
+func updateFencedSwapResult(key string, sets, pushes bson.M) error {
+	filter, updates := getFencedSwapResultQuery(key, sets, pushes, GetFenceToken())
+	res, err := collRouterSwapResult.UpdateOne(clientCtx, filter, updates)
+	if err != nil {
+		return mgoError(err)
+	}
+	if res.MatchedCount == 0 {
+		if _, ok := filter["fencetoken"]; ok {
+			log.Warn("reject swap result update with stale fencing token", "key", key, "fenceToken", GetFenceToken())
+			return ErrStaleFenceToken
+		}
+		return ErrItemNotFound
+	}
+	return nil
+}
+
+// the write matches only if the swap result is not written by a newer leader (greater token),
+// and records the token. no fencing if token is 0.
+func getFencedSwapResultQuery(key string, sets, pushes bson.M, token uint64) (filter, updates bson.M) {
+	filter = bson.M{"_id": key}
+	if token != 0 {
+		filter["fencetoken"] = bson.M{"$not": bson.M{"$gt": token}}
+		sets["fencetoken"] = token
+	}
+	updates = bson.M{"$set": sets}
+	if len(pushes) != 0 {
+		updates["$push"] = pushes
+	}
+	return filter, updates
+}
+
 // AddUsedRValue add used r, if error mean already exist
 func AddUsedRValue(pubkey, r string) error {
 	key := strings.ToLower(r + ":" + pubkey)
@@ -817,6 +985,325 @@ func AddUsedRValue(pubkey, r string) error {
 	}
 }
 
+// ----------------------------- maintain functions -------------------------------------
+
+const maintainVersionCounter = "maintainversion"
+
+// GetMaintainItemKey get maintain item key
+func GetMaintainItemKey(category, itemType, scope, value string) string {
+	return strings.ToLower(fmt.Sprintf("%v:%v:%v:%v", category, itemType, scope, value))
+}
+
+// GetMaintainVersion get current maintain version (0 if not exist)
+func GetMaintainVersion() (uint64, error) {
+	result := &MgoCounter{}
+	err := collCounter.FindOne(clientCtx, bson.M{"_id": maintainVersionCounter}).Decode(result)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, mgoError(err)
+	}
+	return result.Value, nil
+}
+
+// UpdateMaintainItems add or update maintain items with a new version
+func UpdateMaintainItems(items []*MgoMaintainItem) (version uint64, err error) {
+	counter := &MgoCounter{}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	err = collCounter.FindOneAndUpdate(clientCtx,
+		bson.M{"_id": maintainVersionCounter},
+		bson.M{"$inc": bson.M{"value": uint64(1)}},
+		opts).Decode(counter)
+	if err != nil {
+		log.Error("mongodb increase maintain version failed", "err", err)
+		return 0, mgoError(err)
+	}
+	version = counter.Value
+	timestamp := common.NowMilli()
+	for _, item := range items {
+		item.Key = GetMaintainItemKey(item.Category, item.Type, item.Scope, item.Value)
+		item.Version = version
+		item.Timestamp = timestamp
+		_, err = collMaintainItem.ReplaceOne(clientCtx, bson.M{"_id": item.Key}, item, options.Replace().SetUpsert(true))
+		if err != nil {
+			log.Error("mongodb update maintain item failed", "key", item.Key, "version", version, "err", err)
+			return version, mgoError(err)
+		}
+	}
+	log.Info("mongodb update maintain items success", "count", len(items), "version", version)
+	return version, nil
+}
+
+// FindMaintainItems find all maintain items sorted by version
+func FindMaintainItems() ([]*MgoMaintainItem, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "version", Value: 1}})
+	cur, err := collMaintainItem.Find(clientCtx, bson.M{}, opts)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	result := make([]*MgoMaintainItem, 0)
+	err = cur.All(clientCtx, &result)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	return result, nil
+}
+
+// ----------------------------- admin proposal functions -------------------------------------
+
+// AddAdminProposal add admin proposal
+func AddAdminProposal(mp *MgoAdminProposal) error {
+	mp.Timestamp = time.Now().Unix()
+	_, err := collAdminProposal.InsertOne(clientCtx, mp)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrItemIsDup
+		}
+		log.Error("mongodb add admin proposal failed", "id", mp.Key, "method", mp.Method, "err", err)
+		return mgoError(err)
+	}
+	log.Info("mongodb add admin proposal success", "id", mp.Key, "method", mp.Method, "proposer", mp.Proposer)
+	return nil
+}
+
+// FindAdminProposal find admin proposal
+func FindAdminProposal(id string) (*MgoAdminProposal, error) {
+	result := &MgoAdminProposal{}
+	err := collAdminProposal.FindOne(clientCtx, bson.M{"_id": id}).Decode(result)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	return result, nil
+}
+
+// ApproveAdminProposal add approval to pending and unexpired proposal
+func ApproveAdminProposal(id, approver string) (*MgoAdminProposal, error) {
+	filter, update := getApproveAdminProposalQuery(id, approver, time.Now().Unix())
+	result := &MgoAdminProposal{}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	err := collAdminProposal.FindOneAndUpdate(clientCtx, filter, update, opts).Decode(result)
+	if err != nil {
+		log.Error("mongodb approve admin proposal failed", "id", id, "approver", approver, "err", err)
+		return nil, mgoError(err)
+	}
+	log.Info("mongodb approve admin proposal success", "id", id, "approver", approver, "approvals", len(result.Approvals))
+	return result, nil
+}
+
+// only pending and unexpired proposal can be approved,
+// approver is lowercased to keep one approval per admin
+func getApproveAdminProposalQuery(id, approver string, now int64) (filter, update bson.M) {
+	filter = bson.M{"_id": id, "status": ProposalPending, "expireTime": bson.M{"$gt": now}}
+	update = bson.M{
+		"$addToSet": bson.M{"approvals": strings.ToLower(approver)},
+		"$set":      bson.M{"timestamp": now},
+	}
+	return filter, update
+}
+
+// StartExecuteAdminProposal change proposal status from pending to executing,
+// returns error if the proposal is not pending (eg. executed by others).
+func StartExecuteAdminProposal(id string) error {
+	res, err := collAdminProposal.UpdateOne(clientCtx,
+		bson.M{"_id": id, "status": ProposalPending},
+		bson.M{"$set": bson.M{"status": ProposalExecuting, "timestamp": time.Now().Unix()}})
+	if err != nil {
+		return mgoError(err)
+	}
+	if res.MatchedCount == 0 {
+		return fmt.Errorf("admin proposal %v is not pending", id)
+	}
+	return nil
+}
+
+// FinishAdminProposal set proposal execution status and result
+func FinishAdminProposal(id, status, result string) error {
+	_, err := collAdminProposal.UpdateOne(clientCtx,
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{"status": status, "result": result, "timestamp": time.Now().Unix()}})
+	if err != nil {
+		log.Error("mongodb finish admin proposal failed", "id", id, "status", status, "err", err)
+		return mgoError(err)
+	}
+	log.Info("mongodb finish admin proposal success", "id", id, "status", status, "result", result)
+	return nil
+}
+
+// FindPendingAdminProposals find pending and unexpired admin proposals
+func FindPendingAdminProposals() ([]*MgoAdminProposal, error) {
+	qs := bson.M{"status": ProposalPending, "expireTime": bson.M{"$gt": time.Now().Unix()}}
+	opts := options.Find().SetSort(bson.D{{Key: "createTime", Value: 1}})
+	cur, err := collAdminProposal.Find(clientCtx, qs, opts)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	result := make([]*MgoAdminProposal, 0)
+	err = cur.All(clientCtx, &result)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	return result, nil
+}
+
+// ----------------------------- lease functions -------------------------------------
+
+// AcquireLease acquire or renew lease, succeed if the lease is held by `holder` or expired.
+// returns whether the lease is acquired, and the lease epoch (used as fencing token)
+// which is increased when the lease changes holder.
+func AcquireLease(key, holder string, ttl time.Duration) (epoch uint64, acquired bool, err error) {
+	filter, updates := getAcquireLeaseQuery(key, holder, common.NowMilli(), ttl)
+	lease := &MgoLease{}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	err = collLease.FindOneAndUpdate(clientCtx, filter, updates, opts).Decode(lease)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) { // held by others
+			return 0, false, nil
+		}
+		return 0, false, mgoError(err)
+	}
+	return lease.Epoch, true, nil
+}
+
+// the lease matches if it is held by `holder` or expired (taken over by `holder`),
+// otherwise the upsert fails with duplicate key error.
+// use pipeline update, as the epoch depends on the old holder.
+func getAcquireLeaseQuery(key, holder string, now int64, ttl time.Duration) (filter bson.M, updates mongo.Pipeline) {
+	filter = bson.M{
+		"_id": key,
+		"$or": []bson.M{
+			{"holder": holder},
+			{"expireTime": bson.M{"$lt": now}},
+		},
+	}
+	updates = mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"epoch": bson.M{"$cond": bson.A{
+				bson.M{"$eq": bson.A{"$holder", holder}},
+				"$epoch",
+				bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$epoch", int64(0)}}, int64(1)}},
+			}},
+			"holder":     holder,
+			"expireTime": now + ttl.Milliseconds(),
+			"timestamp":  now / 1000,
+		}}},
+	}
+	return filter, updates
+}
+
+// ReleaseLease release lease held by `holder`
+func ReleaseLease(key, holder string) error {
+	_, err := collLease.UpdateOne(clientCtx,
+		bson.M{"_id": key, "holder": holder},
+		bson.M{"$set": bson.M{"expireTime": int64(0), "timestamp": time.Now().Unix()}})
+	if err != nil {
+		return mgoError(err)
+	}
+	return nil
+}
+
+// FindLease find lease
+func FindLease(key string) (*MgoLease, error) {
+	result := &MgoLease{}
+	err := collLease.FindOne(clientCtx, bson.M{"_id": key}).Decode(result)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	return result, nil
+}
+
+// ----------------------------- config audit functions -------------------------------------
+
+const configVersionCounter = "configversion"
+
+// AddConfigAudit add config audit record with a new version
+func AddConfigAudit(audit *MgoConfigAudit) error {
+	counter := &MgoCounter{}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	err := collCounter.FindOneAndUpdate(clientCtx,
+		bson.M{"_id": configVersionCounter},
+		bson.M{"$inc": bson.M{"value": uint64(1)}},
+		opts).Decode(counter)
+	if err != nil {
+		log.Error("mongodb increase config version failed", "err", err)
+		return mgoError(err)
+	}
+	audit.Key = counter.Value
+	audit.Timestamp = common.NowMilli()
+	_, err = collConfigAudit.InsertOne(clientCtx, audit)
+	if err != nil {
+		log.Error("mongodb add config audit failed", "version", audit.Key, "err", err)
+		return mgoError(err)
+	}
+	log.Info("mongodb add config audit success", "version", audit.Key, "source", audit.Source, "status", audit.Status, "changes", len(audit.Changes))
+	return nil
+}
+
+// FindConfigAudits find latest config audit records sorted by version descending
+func FindConfigAudits(limit int64) ([]*MgoConfigAudit, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: -1}}).SetLimit(limit)
+	cur, err := collConfigAudit.Find(clientCtx, bson.M{}, opts)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	result := make([]*MgoConfigAudit, 0)
+	err = cur.All(clientCtx, &result)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	return result, nil
+}
+
+// ----------------------------- nft id mapping functions -------------------------------------
+
+// nftIDMappingIndexes indexes of nft id mapping collection used by finding with native id
+var nftIDMappingIndexes = []mongo.IndexModel{
+	{Keys: bson.D{{Key: "chainID", Value: 1}, {Key: "nativeID", Value: 1}}},
+}
+
+// GetNFTIDMappingKey get nft id mapping key (hex token address is case insensitive)
+func GetNFTIDMappingKey(chainID, token, id string) string {
+	if common.HasHexPrefix(token) {
+		token = strings.ToLower(token)
+	}
+	return fmt.Sprintf("%v:%v:%v", chainID, token, id)
+}
+
+// AddNFTIDMapping add or update nft id mapping
+func AddNFTIDMapping(mapping *MgoNFTIDMapping) error {
+	mapping.Key = GetNFTIDMappingKey(mapping.ChainID, mapping.Token, mapping.ID)
+	mapping.Timestamp = time.Now().Unix()
+	_, err := collNFTIDMapping.ReplaceOne(clientCtx,
+		bson.M{"_id": mapping.Key}, mapping,
+		options.Replace().SetUpsert(true))
+	if err != nil {
+		log.Error("mongodb add nft id mapping failed", "key", mapping.Key, "nativeID", mapping.NativeID, "err", err)
+		return mgoError(err)
+	}
+	log.Info("mongodb add nft id mapping success", "key", mapping.Key, "nativeID", mapping.NativeID)
+	return nil
+}
+
+// FindNFTIDMapping find nft id mapping by token and id
+func FindNFTIDMapping(chainID, token, id string) (*MgoNFTIDMapping, error) {
+	result := &MgoNFTIDMapping{}
+	err := collNFTIDMapping.FindOne(clientCtx, bson.M{"_id": GetNFTIDMappingKey(chainID, token, id)}).Decode(result)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	return result, nil
+}
+
+// FindNFTIDMappingByNativeID find nft id mapping by native id
+func FindNFTIDMappingByNativeID(chainID, nativeID string) (*MgoNFTIDMapping, error) {
+	result := &MgoNFTIDMapping{}
+	err := collNFTIDMapping.FindOne(clientCtx, bson.M{"chainID": chainID, "nativeID": nativeID}).Decode(result)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	return result, nil
+}
+
 // ----------------------------- admin functions -------------------------------------
 
 // RouterAdminPassBigValue pass big value
@@ -0,0 +1,104 @@
+package mongodb
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestGetKeySwapNonceQuery(t *testing.T) {
+	tests := []struct {
+		mpc  string
+		mpcs []string
+	}{
+		{"0xabcdef0123456789", []string{"0xabcdef0123456789"}},
+		{"0xABCDEF0123456789", []string{"0xABCDEF0123456789", "0xabcdef0123456789"}},
+	}
+	for i, test := range tests {
+		want := bson.M{"$and": []bson.M{
+			{"toChainID": "1"},
+			{"mpc": bson.M{"$in": test.mpcs}},
+			{"keyindex": uint64(3)},
+		}}
+		have := getKeySwapNonceQuery("1", test.mpc, 3)
+		if !reflect.DeepEqual(have, want) {
+			t.Errorf("test %v: want query %v, but got %v", i, want, have)
+		}
+	}
+}
+
+func TestGetApproveAdminProposalQuery(t *testing.T) {
+	filter, update := getApproveAdminProposalQuery("0x1", "0xABCDEF0123456789", 1000)
+	wantFilter := bson.M{"_id": "0x1", "status": ProposalPending, "expireTime": bson.M{"$gt": int64(1000)}}
+	if !reflect.DeepEqual(filter, wantFilter) {
+		t.Errorf("want filter %v, but got %v", wantFilter, filter)
+	}
+	wantUpdate := bson.M{
+		"$addToSet": bson.M{"approvals": "0xabcdef0123456789"},
+		"$set":      bson.M{"timestamp": int64(1000)},
+	}
+	if !reflect.DeepEqual(update, wantUpdate) {
+		t.Errorf("want update %v, but got %v", wantUpdate, update)
+	}
+}
+
+func TestGetAcquireLeaseQuery(t *testing.T) {
+	filter, updates := getAcquireLeaseQuery("leader:test", "holder1", 10000, 30*time.Second)
+	// held by the holder, or taken over after expired
+	wantFilter := bson.M{
+		"_id": "leader:test",
+		"$or": []bson.M{
+			{"holder": "holder1"},
+			{"expireTime": bson.M{"$lt": int64(10000)}},
+		},
+	}
+	if !reflect.DeepEqual(filter, wantFilter) {
+		t.Errorf("want filter %v, but got %v", wantFilter, filter)
+	}
+	if len(updates) != 1 || len(updates[0]) != 1 || updates[0][0].Key != "$set" {
+		t.Fatalf("want one $set stage, but got %v", updates)
+	}
+	sets := updates[0][0].Value.(bson.M)
+	// epoch is kept when renewing, and increased when the holder changes
+	wantEpoch := bson.M{"$cond": bson.A{
+		bson.M{"$eq": bson.A{"$holder", "holder1"}},
+		"$epoch",
+		bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$epoch", int64(0)}}, int64(1)}},
+	}}
+	if !reflect.DeepEqual(sets["epoch"], wantEpoch) {
+		t.Errorf("want epoch %v, but got %v", wantEpoch, sets["epoch"])
+	}
+	if sets["holder"] != "holder1" || sets["expireTime"] != int64(40000) {
+		t.Errorf("want holder %v expire time %v, but got %v %v", "holder1", 40000, sets["holder"], sets["expireTime"])
+	}
+}
+
+func TestGetFencedSwapResultQuery(t *testing.T) {
+	pushes := bson.M{"oldswaptxs": "0x2"}
+	tests := []struct {
+		token       uint64
+		pushes      bson.M
+		wantFilter  bson.M
+		wantUpdates bson.M
+	}{
+		// no fencing
+		{0, nil, bson.M{"_id": "key"}, bson.M{"$set": bson.M{"swaptx": "0x1"}}},
+		// reject the write if a newer leader has written it
+		{
+			3, pushes,
+			bson.M{"_id": "key", "fencetoken": bson.M{"$not": bson.M{"$gt": uint64(3)}}},
+			bson.M{"$set": bson.M{"swaptx": "0x1", "fencetoken": uint64(3)}, "$push": pushes},
+		},
+	}
+	for i, test := range tests {
+		filter, updates := getFencedSwapResultQuery("key", bson.M{"swaptx": "0x1"}, test.pushes, test.token)
+		if !reflect.DeepEqual(filter, test.wantFilter) {
+			t.Errorf("test %v: want filter %v, but got %v", i, test.wantFilter, filter)
+		}
+		if !reflect.DeepEqual(updates, test.wantUpdates) {
+			t.Errorf("test %v: want updates %v, but got %v", i, test.wantUpdates, updates)
+		}
+	}
+}
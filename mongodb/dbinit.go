@@ -82,5 +82,6 @@ func connect(opts *options.ClientOptions) (err error) {
 	}
 
 	initCollections()
+	initIndexes()
 	return nil
 }
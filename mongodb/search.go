@@ -0,0 +1,217 @@
+package mongodb
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var errMixedSearchStatuses = errors.New("can not search register statuses and result statuses together")
+
+// SwapSearchCursor position of the last swap in previous page
+type SwapSearchCursor struct {
+	InitTime int64
+	Key      string
+}
+
+// SwapSearchFilter filter of searching swaps, empty field means no restriction.
+// bind is matched exactly or case insensitively, value is matched by the numeric
+// value field, which swaps stored before it's introduced do not have.
+type SwapSearchFilter struct {
+	FromChainID string
+	ToChainID   string
+	TokenID     string
+	Bind        string
+	Statuses    []SwapStatus
+	MinValue    *big.Int // inclusive
+	MaxValue    *big.Int // inclusive
+	StartTime   int64    // unix milliseconds of inittime, inclusive
+	EndTime     int64    // unix milliseconds of inittime, exclusive
+	Ascending   bool     // sort by inittime ascending, default is descending
+	Cursor      *SwapSearchCursor
+	Limit       int64
+}
+
+// SearchRouterSwaps search swaps with filter, sorted by (inittime, _id).
+// if all statuses are register statuses then search registered swaps,
+// otherwise search swap results.
+func SearchRouterSwaps(filter *SwapSearchFilter) ([]*MgoSwapResult, error) {
+	isInResultColl, err := isSearchInResultColl(filter.Statuses)
+	if err != nil {
+		return nil, err
+	}
+	query, err := getSwapSearchQuery(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	sortOrder := -1
+	if filter.Ascending {
+		sortOrder = 1
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "inittime", Value: sortOrder}, {Key: "_id", Value: sortOrder}}).
+		SetLimit(filter.Limit)
+
+	if !isInResultColl {
+		cur, errf := collRouterSwap.Find(clientCtx, query, opts)
+		if errf != nil {
+			return nil, mgoError(errf)
+		}
+		swaps := make([]*MgoSwap, 0, filter.Limit)
+		if errf = cur.All(clientCtx, &swaps); errf != nil {
+			return nil, mgoError(errf)
+		}
+		result := make([]*MgoSwapResult, len(swaps))
+		for i, swap := range swaps {
+			result[i] = swap.ToSwapResult()
+		}
+		return result, nil
+	}
+
+	cur, err := collRouterSwapResult.Find(clientCtx, query, opts)
+	if err != nil {
+		return nil, mgoError(err)
+	}
+	result := make([]*MgoSwapResult, 0, filter.Limit)
+	if err = cur.All(clientCtx, &result); err != nil {
+		return nil, mgoError(err)
+	}
+	return result, nil
+}
+
+func isSearchInResultColl(statuses []SwapStatus) (bool, error) {
+	registerCount := 0
+	for _, status := range statuses {
+		if !status.IsResultStatus() {
+			registerCount++
+		}
+	}
+	switch registerCount {
+	case 0:
+		return true, nil
+	case len(statuses):
+		return false, nil
+	default:
+		return false, errMixedSearchStatuses
+	}
+}
+
+//nolint:gocyclo // allow many filters
+func getSwapSearchQuery(filter *SwapSearchFilter) (bson.M, error) {
+	queries := make([]bson.M, 0)
+
+	if filter.FromChainID != "" {
+		queries = append(queries, bson.M{"fromChainID": filter.FromChainID})
+	}
+	if filter.ToChainID != "" {
+		queries = append(queries, bson.M{"toChainID": filter.ToChainID})
+	}
+	if filter.TokenID != "" {
+		queries = append(queries, bson.M{"$or": []bson.M{
+			{"swapinfo.routerSwapInfo.tokenID": filter.TokenID},
+			{"swapinfo.nftSwapInfo.tokenID": filter.TokenID},
+		}})
+	}
+	if filter.Bind != "" {
+		queries = append(queries, bson.M{"$or": []bson.M{
+			{"bind": filter.Bind},
+			{"bindlower": strings.ToLower(filter.Bind)},
+		}})
+	}
+	switch len(filter.Statuses) {
+	case 0:
+	case 1:
+		queries = append(queries, bson.M{"status": filter.Statuses[0]})
+	default:
+		queries = append(queries, bson.M{"status": bson.M{"$in": filter.Statuses}})
+	}
+
+	qtime := bson.M{}
+	if filter.StartTime > 0 {
+		qtime["$gte"] = filter.StartTime
+	}
+	if filter.EndTime > 0 {
+		qtime["$lt"] = filter.EndTime
+	}
+	if len(qtime) > 0 {
+		queries = append(queries, bson.M{"inittime": qtime})
+	}
+
+	qvalue := bson.M{}
+	if filter.MinValue != nil {
+		minValue, err := toDecimal128(filter.MinValue)
+		if err != nil {
+			return nil, err
+		}
+		qvalue["$gte"] = minValue
+	}
+	if filter.MaxValue != nil {
+		maxValue, err := toDecimal128(filter.MaxValue)
+		if err != nil {
+			return nil, err
+		}
+		qvalue["$lte"] = maxValue
+	}
+	if len(qvalue) > 0 {
+		queries = append(queries, bson.M{"valuenum": qvalue})
+	}
+
+	if cursor := filter.Cursor; cursor != nil {
+		op := "$lt"
+		if filter.Ascending {
+			op = "$gt"
+		}
+		queries = append(queries, bson.M{"$or": []bson.M{
+			{"inittime": bson.M{op: cursor.InitTime}},
+			{"inittime": cursor.InitTime, "_id": bson.M{op: cursor.Key}},
+		}})
+	}
+
+	switch len(queries) {
+	case 0:
+		return bson.M{}, nil
+	case 1:
+		return queries[0], nil
+	default:
+		return bson.M{"$and": queries}, nil
+	}
+}
+
+func toDecimal128(value *big.Int) (primitive.Decimal128, error) {
+	d, err := primitive.ParseDecimal128(value.String())
+	if err != nil {
+		return d, fmt.Errorf("wrong value %v: %w", value, err)
+	}
+	return d, nil
+}
+
+// getSearchFields get the normalized bind and numeric value stored for swap search
+func getSearchFields(bind, value string) (bindLower string, valueNum *primitive.Decimal128) {
+	bindLower = strings.ToLower(bind)
+	if v, ok := new(big.Int).SetString(value, 10); ok {
+		if d, err := toDecimal128(v); err == nil {
+			valueNum = &d
+		}
+	}
+	return bindLower, valueNum
+}
+
+// swapSearchIndexes indexes of swap and swap result collections used by swap search,
+// they are created only if `CreateSearchIndexes` is configed (see `InitSearchIndexes`)
+var swapSearchIndexes = []mongo.IndexModel{
+	{Keys: bson.D{{Key: "inittime", Value: 1}, {Key: "_id", Value: 1}}},
+	{Keys: bson.D{{Key: "status", Value: 1}, {Key: "inittime", Value: 1}}},
+	{Keys: bson.D{{Key: "fromChainID", Value: 1}, {Key: "inittime", Value: 1}}},
+	{Keys: bson.D{{Key: "toChainID", Value: 1}, {Key: "status", Value: 1}, {Key: "inittime", Value: 1}}},
+	{Keys: bson.D{{Key: "swapinfo.routerSwapInfo.tokenID", Value: 1}, {Key: "inittime", Value: 1}}},
+	{Keys: bson.D{{Key: "bind", Value: 1}, {Key: "inittime", Value: 1}}},
+	{Keys: bson.D{{Key: "bindlower", Value: 1}, {Key: "inittime", Value: 1}}},
+	{Keys: bson.D{{Key: "valuenum", Value: 1}, {Key: "inittime", Value: 1}}},
+}
@@ -0,0 +1,122 @@
+package mongodb
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func mustDecimal128(t *testing.T, value int64) primitive.Decimal128 {
+	d, err := toDecimal128(big.NewInt(value))
+	if err != nil {
+		t.Fatalf("to decimal128 failed: %v", err)
+	}
+	return d
+}
+
+func TestGetSwapSearchQuery(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter *SwapSearchFilter
+		want   bson.M
+	}{
+		{
+			name:   "empty filter",
+			filter: &SwapSearchFilter{},
+			want:   bson.M{},
+		},
+		{
+			name:   "bind is normalized",
+			filter: &SwapSearchFilter{Bind: "0xAbCd"},
+			want: bson.M{"$or": []bson.M{
+				{"bind": "0xAbCd"},
+				{"bindlower": "0xabcd"},
+			}},
+		},
+		{
+			name:   "value range uses numeric field",
+			filter: &SwapSearchFilter{MinValue: big.NewInt(100), MaxValue: big.NewInt(2000)},
+			want:   bson.M{"valuenum": bson.M{"$gte": mustDecimal128(t, 100), "$lte": mustDecimal128(t, 2000)}},
+		},
+		{
+			name:   "chain, statuses and time",
+			filter: &SwapSearchFilter{FromChainID: "1", Statuses: []SwapStatus{MatchTxNotStable, MatchTxFailed}, StartTime: 10, EndTime: 20},
+			want: bson.M{"$and": []bson.M{
+				{"fromChainID": "1"},
+				{"status": bson.M{"$in": []SwapStatus{MatchTxNotStable, MatchTxFailed}}},
+				{"inittime": bson.M{"$gte": int64(10), "$lt": int64(20)}},
+			}},
+		},
+		{
+			name:   "ascending cursor",
+			filter: &SwapSearchFilter{Ascending: true, Cursor: &SwapSearchCursor{InitTime: 10, Key: "k"}},
+			want: bson.M{"$or": []bson.M{
+				{"inittime": bson.M{"$gt": int64(10)}},
+				{"inittime": int64(10), "_id": bson.M{"$gt": "k"}},
+			}},
+		},
+	}
+	for _, test := range tests {
+		query, err := getSwapSearchQuery(test.filter)
+		if err != nil {
+			t.Errorf("test %v: get query failed: %v", test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(query, test.want) {
+			t.Errorf("test %v: got %v, want %v", test.name, query, test.want)
+		}
+	}
+}
+
+func TestIsSearchInResultColl(t *testing.T) {
+	tests := []struct {
+		statuses []SwapStatus
+		inResult bool
+		hasErr   bool
+	}{
+		{statuses: nil, inResult: true},
+		{statuses: []SwapStatus{MatchTxNotStable, MatchTxStable}, inResult: true},
+		{statuses: []SwapStatus{TxNotStable, TxNotSwapped}, inResult: false},
+		{statuses: []SwapStatus{TxNotStable, MatchTxStable}, hasErr: true},
+	}
+	for i, test := range tests {
+		inResult, err := isSearchInResultColl(test.statuses)
+		if (err != nil) != test.hasErr {
+			t.Errorf("test %v: got err %v, want err %v", i, err, test.hasErr)
+			continue
+		}
+		if err == nil && inResult != test.inResult {
+			t.Errorf("test %v: got in result coll %v, want %v", i, inResult, test.inResult)
+		}
+	}
+}
+
+func TestGetSearchFields(t *testing.T) {
+	tests := []struct {
+		bind      string
+		value     string
+		bindLower string
+		valueNum  string
+	}{
+		{bind: "0xAbC", value: "123", bindLower: "0xabc", valueNum: "123"},
+		{bind: "abc", value: "0", bindLower: "abc", valueNum: "0"},
+		{bind: "", value: "", bindLower: ""},
+		{bind: "A", value: "1.5", bindLower: "a"},
+	}
+	for i, test := range tests {
+		bindLower, valueNum := getSearchFields(test.bind, test.value)
+		if bindLower != test.bindLower {
+			t.Errorf("test %v: got bind %v, want %v", i, bindLower, test.bindLower)
+		}
+		got := ""
+		if valueNum != nil {
+			got = valueNum.String()
+		}
+		if got != test.valueNum {
+			t.Errorf("test %v: got value %v, want %v", i, got, test.valueNum)
+		}
+	}
+}
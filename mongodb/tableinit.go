@@ -1,6 +1,8 @@
 package mongodb
 
 import (
+	"github.com/anyswap/CrossChain-Router/v3/log"
+
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
@@ -8,12 +10,24 @@ const (
 	tbRouterSwaps       string = "RouterSwaps"
 	tbRouterSwapResults string = "RouterSwapResults"
 	tbUsedRValues       string = "UsedRValues"
+	tbMaintainItems     string = "MaintainItems"
+	tbCounters          string = "Counters"
+	tbAdminProposals    string = "AdminProposals"
+	tbLeases            string = "Leases"
+	tbConfigAudits      string = "ConfigAudits"
+	tbNFTIDMappings     string = "NFTIDMappings"
 )
 
 var (
 	collRouterSwap       *mongo.Collection
 	collRouterSwapResult *mongo.Collection
 	collUsedRValue       *mongo.Collection
+	collMaintainItem     *mongo.Collection
+	collCounter          *mongo.Collection
+	collAdminProposal    *mongo.Collection
+	collLease            *mongo.Collection
+	collConfigAudit      *mongo.Collection
+	collNFTIDMapping     *mongo.Collection
 )
 
 func initCollections() {
@@ -22,4 +36,35 @@ func initCollections() {
 	collRouterSwap = database.Collection(tbRouterSwaps)
 	collRouterSwapResult = database.Collection(tbRouterSwapResults)
 	collUsedRValue = database.Collection(tbUsedRValues)
+	collMaintainItem = database.Collection(tbMaintainItems)
+	collCounter = database.Collection(tbCounters)
+	collAdminProposal = database.Collection(tbAdminProposals)
+	collLease = database.Collection(tbLeases)
+	collConfigAudit = database.Collection(tbConfigAudits)
+	collNFTIDMapping = database.Collection(tbNFTIDMappings)
+}
+
+// initIndexes create indexes in background, creating existing indexes is a no-op
+func initIndexes() {
+	createIndexes(collNFTIDMapping, nftIDMappingIndexes)
+	createIndexes(collRouterSwapResult, keyNonceIndexes)
+}
+
+// InitSearchIndexes create indexes used by swap search in background.
+// building them on big collections is expensive, so it is opt-in by config.
+func InitSearchIndexes() {
+	for _, coll := range []*mongo.Collection{collRouterSwap, collRouterSwapResult} {
+		createIndexes(coll, swapSearchIndexes)
+	}
+}
+
+func createIndexes(coll *mongo.Collection, indexes []mongo.IndexModel) {
+	go func() {
+		names, err := coll.Indexes().CreateMany(clientCtx, indexes)
+		if err != nil {
+			log.Warn("[mongodb] create indexes failed", "collection", coll.Name(), "err", err)
+			return
+		}
+		log.Info("[mongodb] create indexes success", "collection", coll.Name(), "indexes", names)
+	}()
 }
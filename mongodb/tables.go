@@ -1,7 +1,9 @@
 package mongodb
 
 import (
+	"github.com/anyswap/CrossChain-Router/v3/params"
 	"github.com/anyswap/CrossChain-Router/v3/tokens"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // MgoSwap registered swap
@@ -22,6 +24,12 @@ type MgoSwap struct {
 	InitTime    int64      `bson:"inittime"`
 	Timestamp   int64      `bson:"timestamp"`
 	Memo        string     `bson:"memo" json:",omitempty"`
+	StatusTime  int64      `bson:"statustime,omitempty" json:",omitempty"` // time of entering the current status
+
+	DisagreeCount uint64 `bson:"disagreecount,omitempty" json:",omitempty"` // times of mpc sign disagreed since the last success
+
+	BindLower string                `bson:"bindlower,omitempty" json:"-"` // lowercase bind, used by swap search
+	ValueNum  *primitive.Decimal128 `bson:"valuenum,omitempty" json:"-"`  // numeric value, used by swap search
 }
 
 // IsValid is valid
@@ -73,6 +81,12 @@ func (swap *MgoSwap) ToSwapResult() *MgoSwapResult {
 		InitTime:    swap.InitTime,
 		Timestamp:   swap.Timestamp,
 		Memo:        swap.Memo,
+		StatusTime:  swap.StatusTime,
+
+		DisagreeCount: swap.DisagreeCount,
+
+		BindLower: swap.BindLower,
+		ValueNum:  swap.ValueNum,
 	}
 }
 
@@ -102,8 +116,26 @@ type MgoSwapResult struct {
 	InitTime    int64      `bson:"inittime"`
 	Timestamp   int64      `bson:"timestamp"`
 	Memo        string     `bson:"memo" json:",omitempty"`
+	StatusTime  int64      `bson:"statustime,omitempty" json:",omitempty"` // time of entering the current status
 	MPC         string     `bson:"mpc"`
 	TTL         uint64     `bson:"ttl"`
+	BatchSize   int        `bson:"batchsize,omitempty" json:",omitempty"`  // count of swaps in the batch swap tx
+	BatchIndex  int        `bson:"batchindex,omitempty" json:",omitempty"` // index of this swap in the batch swap tx
+
+	Payout           string `bson:"payout,omitempty" json:",omitempty"`           // payout type by liquidity policy: underlying, anyToken, split
+	PayoutUnderlying string `bson:"payoutunderlying,omitempty" json:",omitempty"` // underlying amount of split payout
+	DelayTime        int64  `bson:"delaytime,omitempty" json:",omitempty"`        // time of entering the delayed state (eg. insufficient liquidity)
+
+	Delivery string `bson:"delivery,omitempty" json:",omitempty"` // delivery method on dest chain: payment, claimableBalance, createAccount
+
+	KeyIndex *uint64 `bson:"keyindex,omitempty" json:",omitempty"` // account key whose sequence is the swap nonce (eg. flow proposal key)
+
+	FenceToken uint64 `bson:"fencetoken,omitempty" json:",omitempty"` // fencing token (leader lease epoch) of the last writer
+
+	DisagreeCount uint64 `bson:"disagreecount,omitempty" json:",omitempty"` // copied from the registered swap (not stored in swap result)
+
+	BindLower string                `bson:"bindlower,omitempty" json:"-"` // lowercase bind, used by swap search
+	ValueNum  *primitive.Decimal128 `bson:"valuenum,omitempty" json:"-"`  // numeric value, used by swap search
 }
 
 // MgoUsedRValue security enhancement
@@ -112,6 +144,80 @@ type MgoUsedRValue struct {
 	Timestamp int64  `bson:"timestamp"`
 }
 
+// MgoMaintainItem maintenance state item (pause, blacklist, whitelist)
+type MgoMaintainItem struct {
+	Key       string `bson:"_id" json:"-"` // category:type:scope:value
+	Category  string `bson:"category" json:"category"`
+	Type      string `bson:"type" json:"type,omitempty"`
+	Scope     string `bson:"scope" json:"scope,omitempty"` // chainID or tokenID of whitelist
+	Value     string `bson:"value" json:"value"`
+	Enabled   bool   `bson:"enabled" json:"enabled"` // false means removed
+	Caller    string `bson:"caller" json:"caller"`
+	Version   uint64 `bson:"version" json:"version"`
+	Timestamp int64  `bson:"timestamp" json:"timestamp"`
+}
+
+// admin proposal status
+const (
+	ProposalPending   = "pending"
+	ProposalExecuting = "executing"
+	ProposalExecuted  = "executed"
+	ProposalFailed    = "failed"
+)
+
+// MgoAdminProposal admin call proposal which need multi-admin approval
+type MgoAdminProposal struct {
+	Key        string   `bson:"_id" json:"id"`
+	Method     string   `bson:"method" json:"method"`
+	Params     []string `bson:"params" json:"params"`
+	Proposer   string   `bson:"proposer" json:"proposer"`
+	Approvals  []string `bson:"approvals" json:"approvals"`
+	Threshold  int      `bson:"threshold" json:"threshold"`
+	Status     string   `bson:"status" json:"status"`
+	Result     string   `bson:"result" json:"result,omitempty"`
+	CreateTime int64    `bson:"createTime" json:"createTime"`
+	ExpireTime int64    `bson:"expireTime" json:"expireTime"`
+	Timestamp  int64    `bson:"timestamp" json:"timestamp"`
+}
+
+// MgoLease lease of leader election
+type MgoLease struct {
+	Key        string `bson:"_id" json:"key"`
+	Holder     string `bson:"holder" json:"holder"`
+	ExpireTime int64  `bson:"expireTime" json:"expireTime"` // unix milliseconds
+	Epoch      uint64 `bson:"epoch" json:"epoch"`           // fencing token, increased when the lease changes holder
+	Timestamp  int64  `bson:"timestamp" json:"timestamp"`
+}
+
+// MgoConfigAudit audit record of reloading config
+type MgoConfigAudit struct {
+	Key       uint64               `bson:"_id" json:"version"`
+	Source    string               `bson:"source" json:"source"` // local or onchain
+	Status    string               `bson:"status" json:"status"`
+	Changes   []*params.ConfigDiff `bson:"changes" json:"changes"`
+	Message   string               `bson:"message" json:"message,omitempty"`
+	Server    string               `bson:"server" json:"server"`
+	Timestamp int64                `bson:"timestamp" json:"timestamp"`
+}
+
+// MgoNFTIDMapping mapping between the uint256 nft id used by router and the native nft item of chain
+// (eg. solana mint address, aptos token object address)
+type MgoNFTIDMapping struct {
+	Key       string `bson:"_id" json:"-"` // chainID:token:id
+	ChainID   string `bson:"chainID" json:"chainID"`
+	TokenID   string `bson:"tokenID" json:"tokenID"`
+	Token     string `bson:"token" json:"token"`
+	ID        string `bson:"id" json:"id"`
+	NativeID  string `bson:"nativeID" json:"nativeID"`
+	Timestamp int64  `bson:"timestamp" json:"timestamp"`
+}
+
+// MgoCounter counter
+type MgoCounter struct {
+	Key   string `bson:"_id"`
+	Value uint64 `bson:"value"`
+}
+
 // SwapResultUpdateItems swap update items
 type SwapResultUpdateItems struct {
 	MPC        string
@@ -124,6 +230,13 @@ type SwapResultUpdateItems struct {
 	Timestamp  int64
 	Memo       string
 	TTL        uint64
+	BatchSize  int
+	BatchIndex int
+
+	Payout           string
+	PayoutUnderlying string
+	Delivery         string
+	DelayTime        int64 // only set when entering the delayed state
 }
 
 // SwapInfo struct
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"math/big"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/anyswap/CrossChain-Router/v3/common"
@@ -64,13 +63,10 @@ type Config struct {
 
 	// delete if fail too many times consecutively, 0 means disable checking
 	maxSignGroupFailures      int
-	minIntervalToAddSignGroup int64                   // seconds
-	signGroupFailuresMap      map[string]signFailures // key is groupID
-}
+	minIntervalToAddSignGroup int64 // seconds
+	maxSignsPerGroup          int   // max in-flight sign requests per group, 0 means no limit
 
-type signFailures struct {
-	count    int
-	lastTime int64
+	scheduler *signScheduler // server only
 }
 
 func newConfig() *Config {
@@ -87,7 +83,7 @@ func newConfig() *Config {
 
 		maxSignGroupFailures:      0,
 		minIntervalToAddSignGroup: int64(3600),
-		signGroupFailuresMap:      make(map[string]signFailures),
+		scheduler:                 newSignScheduler(),
 	}
 }
 
@@ -105,13 +101,10 @@ func isEC(signType string) bool {
 
 // NodeInfo mpc node info
 type NodeInfo struct {
-	keyWrapper             *keystore.Key
-	mpcUser                common.Address
-	mpcRPCAddress          string
-	originSignGroups       []string // origin sub groups for sign
-	usableSignGroupIndexes []int    // usable sign groups indexes
-
-	signGroupsLock sync.RWMutex
+	keyWrapper       *keystore.Key
+	mpcUser          common.Address
+	mpcRPCAddress    string
+	originSignGroups []string // origin sub groups for sign
 
 	parent *Config
 }
@@ -165,6 +158,11 @@ func InitConfig(mpcParams *params.MPCConfig, isServer bool) *Config {
 	if mpcParams.MinIntervalToAddSignGroup > 0 {
 		c.minIntervalToAddSignGroup = mpcParams.MinIntervalToAddSignGroup
 	}
+	c.maxSignsPerGroup = mpcParams.MaxSignsPerGroup
+	c.scheduler.maxFailures = c.maxSignGroupFailures
+	c.scheduler.disableSeconds = c.minIntervalToAddSignGroup
+	c.scheduler.maxInFlight = c.maxSignsPerGroup
+	c.scheduler.maxWait = c.mpcSignTimeout
 
 	c.verifySignatureInAccept = mpcParams.VerifySignatureInAccept
 
@@ -186,6 +184,7 @@ func InitConfig(mpcParams *params.MPCConfig, isServer bool) *Config {
 		"rpcTimeout", c.mpcRPCTimeout, "signTimeout", c.mpcSignTimeout.String(),
 		"maxSignGroupFailures", c.maxSignGroupFailures,
 		"minIntervalToAddSignGroup", c.minIntervalToAddSignGroup,
+		"maxSignsPerGroup", c.maxSignsPerGroup,
 	)
 
 	return c
@@ -219,6 +218,7 @@ func (c *Config) addInitiatorNode(nodeInfo *NodeInfo) {
 		}
 	}
 	c.allInitiatorNodes = append(c.allInitiatorNodes, nodeInfo)
+	c.scheduler.addNode(nodeInfo)
 }
 
 // IsSwapServer returns if this mpc user is the swap server
@@ -264,81 +264,6 @@ func (ni *NodeInfo) GetMPCRPCAddress() string {
 // setOriginSignGroups set origin sign subgroups
 func (ni *NodeInfo) setOriginSignGroups(groups []string) {
 	ni.originSignGroups = groups
-	ni.usableSignGroupIndexes = make([]int, len(groups))
-	for i := range groups {
-		ni.usableSignGroupIndexes[i] = i
-	}
-
-	if ni.parent.maxSignGroupFailures > 0 {
-		go ni.checkAndAddSignGroups()
-	}
-}
-
-// getUsableSignGroupIndexes get usable sign group indexes (by copy in case of parallel)
-func (ni *NodeInfo) getUsableSignGroupIndexes() []int {
-	if ni.parent.maxSignGroupFailures == 0 {
-		return ni.usableSignGroupIndexes
-	}
-
-	ni.signGroupsLock.RLock()
-	defer ni.signGroupsLock.RUnlock()
-
-	groupIndexes := make([]int, len(ni.usableSignGroupIndexes))
-	copy(groupIndexes, ni.usableSignGroupIndexes)
-
-	return groupIndexes
-}
-
-// deleteSignGroup delete sign group
-func (ni *NodeInfo) deleteSignGroup(groupIndex int) {
-	ni.signGroupsLock.Lock()
-	defer ni.signGroupsLock.Unlock()
-
-	for i, groupInd := range ni.usableSignGroupIndexes {
-		if groupInd == groupIndex {
-			ni.usableSignGroupIndexes = append(ni.usableSignGroupIndexes[:i], ni.usableSignGroupIndexes[i+1:]...)
-			return
-		}
-	}
-
-	if len(ni.usableSignGroupIndexes) == 0 { // reinit to all origins
-		ni.usableSignGroupIndexes = make([]int, len(ni.originSignGroups))
-		for i := range ni.originSignGroups {
-			ni.usableSignGroupIndexes[i] = i
-		}
-	}
-}
-
-// checkAndAddSignGroups add sign group
-func (ni *NodeInfo) checkAndAddSignGroups() {
-	for {
-		usableGroupIndexes := ni.getUsableSignGroupIndexes()
-		for i := range ni.originSignGroups {
-			usable := false
-			for _, groupInd := range usableGroupIndexes {
-				if groupInd == i {
-					usable = true
-					break
-				}
-			}
-			if usable {
-				continue
-			}
-			signGroup := ni.originSignGroups[i]
-			signFailure := ni.parent.signGroupFailuresMap[signGroup]
-			if signFailure.lastTime+ni.parent.minIntervalToAddSignGroup > time.Now().Unix() {
-				continue
-			}
-			log.Info("check and add sign group", "signGroup", signGroup)
-			ni.usableSignGroupIndexes = append(ni.usableSignGroupIndexes, i)
-			// reset when readd
-			ni.parent.signGroupFailuresMap[signGroup] = signFailures{
-				count:    0,
-				lastTime: time.Now().Unix(),
-			}
-		}
-		time.Sleep(60 * time.Second)
-	}
 }
 
 // GetMPCUser returns the mpc user of specified keystore
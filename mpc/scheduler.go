@@ -0,0 +1,302 @@
+package mpc
+
+import (
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/anyswap/CrossChain-Router/v3/log"
+)
+
+// sign priorities, higher priority requests are scheduled first when sign groups are busy
+const (
+	SignPriorityNormal = iota
+	SignPriorityHigh   // replace and reswap
+
+	numSignPriorities
+)
+
+// weight of the latest sample in the exponential moving averages
+const healthEMAWeight = 0.2
+
+var errSignGroupsBusy = errors.New("all sign groups are busy")
+
+// SignGroupHealth health of sign group of initiator
+type SignGroupHealth struct {
+	Initiator           string  `json:"initiator"`
+	GroupID             string  `json:"groupID"`
+	Total               uint64  `json:"total"`
+	Success             uint64  `json:"success"`
+	Failure             uint64  `json:"failure"`
+	ConsecutiveFailures int     `json:"consecutiveFailures"`
+	SuccessRate         float64 `json:"successRate"` // exponential moving average
+	AvgLatency          float64 `json:"avgLatency"`  // seconds, exponential moving average of successful signs
+	InFlight            int     `json:"inFlight"`
+	DisabledUntil       int64   `json:"disabledUntil,omitempty"`
+	LastSuccessTime     int64   `json:"lastSuccessTime,omitempty"`
+	LastFailureTime     int64   `json:"lastFailureTime,omitempty"`
+}
+
+type signGroupState struct {
+	SignGroupHealth
+	index int // index of node's origin sign groups
+}
+
+// signScheduler schedules sign requests to healthy and idle sign groups.
+// it is thread-safe, and is used by swap server (the initiators) only.
+type signScheduler struct {
+	lock    sync.Mutex
+	cond    *sync.Cond
+	groups  map[*NodeInfo][]*signGroupState
+	waiting map[*NodeInfo]*[numSignPriorities]int
+	rand    *rand.Rand
+
+	maxInFlight    int   // max in-flight sign requests per group, 0 means no limit
+	maxFailures    int   // disable group after consecutive failures, 0 means never
+	disableSeconds int64 // seconds to disable group
+	maxWait        time.Duration
+}
+
+func newSignScheduler() *signScheduler {
+	s := &signScheduler{
+		groups:  make(map[*NodeInfo][]*signGroupState),
+		waiting: make(map[*NodeInfo]*[numSignPriorities]int),
+		rand:    rand.New(rand.NewSource(time.Now().UnixNano())), //nolint:gosec // used for shuffle only
+	}
+	s.cond = sync.NewCond(&s.lock)
+	return s
+}
+
+func (s *signScheduler) addNode(node *NodeInfo) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	states := make([]*signGroupState, len(node.originSignGroups))
+	for i, groupID := range node.originSignGroups {
+		states[i] = &signGroupState{
+			SignGroupHealth: SignGroupHealth{
+				Initiator:   node.mpcUser.String(),
+				GroupID:     groupID,
+				SuccessRate: 1,
+			},
+			index: i,
+		}
+	}
+	s.groups[node] = states
+	s.waiting[node] = new([numSignPriorities]int)
+}
+
+// getSignPriority get sign priority from the first msg context (which is the build tx args)
+func getSignPriority(msgContext []string) int {
+	if len(msgContext) == 0 {
+		return SignPriorityNormal
+	}
+	var args struct {
+		SwapArgs struct {
+			Reswapping bool `json:"reswapping"`
+		} `json:"swapArgs"`
+		Extra *struct {
+			ReplaceNum uint64 `json:"replaceNum"`
+		} `json:"extra"`
+	}
+	if err := json.Unmarshal([]byte(msgContext[0]), &args); err != nil {
+		return SignPriorityNormal
+	}
+	if args.SwapArgs.Reswapping || (args.Extra != nil && args.Extra.ReplaceNum > 0) {
+		return SignPriorityHigh
+	}
+	return SignPriorityNormal
+}
+
+// isUsable should hold lock
+func (s *signScheduler) isUsable(g *signGroupState, now int64) bool {
+	if g.DisabledUntil == 0 {
+		return true
+	}
+	if now < g.DisabledUntil {
+		return false
+	}
+	log.Info("add back sign group", "initiator", g.Initiator, "signGroup", g.GroupID)
+	g.DisabledUntil = 0
+	g.ConsecutiveFailures = 0
+	return true
+}
+
+// usableGroups get usable groups sorted by health, should hold lock
+func (s *signScheduler) usableGroups(node *NodeInfo, excludes map[int]bool) []*signGroupState {
+	now := time.Now().Unix()
+	states := s.groups[node]
+	result := make([]*signGroupState, 0, len(states))
+	disabled := 0
+	for _, g := range states {
+		if !s.isUsable(g, now) {
+			disabled++
+			continue
+		}
+		if !excludes[g.index] {
+			result = append(result, g)
+		}
+	}
+	if disabled > 0 && disabled == len(states) { // reinit to all origins
+		log.Warn("all sign groups are disabled, add back all", "initiator", node.mpcUser.String())
+		for _, g := range states {
+			g.DisabledUntil = 0
+			g.ConsecutiveFailures = 0
+			if !excludes[g.index] {
+				result = append(result, g)
+			}
+		}
+	}
+	// shuffle to spread load among groups with same health
+	s.rand.Shuffle(len(result), func(i, j int) { result[i], result[j] = result[j], result[i] })
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].SuccessRate != result[j].SuccessRate {
+			return result[i].SuccessRate > result[j].SuccessRate
+		}
+		return result[i].AvgLatency < result[j].AvgLatency
+	})
+	return result
+}
+
+// acquire pick the healthiest idle sign group of node (not in `excludes`),
+// wait if all usable groups are busy or higher priority requests are waiting.
+func (s *signScheduler) acquire(node *NodeInfo, priority int, excludes map[int]bool) (*signGroupState, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	waiting := s.waiting[node]
+	if waiting == nil {
+		return nil, errNoUsableSignGroups
+	}
+	waiting[priority]++
+	defer func() {
+		waiting[priority]--
+		s.cond.Broadcast()
+	}()
+
+	timedOut := false
+	if s.maxWait > 0 {
+		timer := time.AfterFunc(s.maxWait, func() {
+			s.lock.Lock()
+			timedOut = true
+			s.cond.Broadcast()
+			s.lock.Unlock()
+		})
+		defer timer.Stop()
+	}
+
+	for {
+		candidates := s.usableGroups(node, excludes)
+		if len(candidates) == 0 {
+			return nil, errNoUsableSignGroups
+		}
+		if !hasHigherPriorityWaiting(waiting, priority) {
+			for _, g := range candidates {
+				if s.maxInFlight == 0 || g.InFlight < s.maxInFlight {
+					g.InFlight++
+					return g, nil
+				}
+			}
+		}
+		if timedOut {
+			return nil, errSignGroupsBusy
+		}
+		s.cond.Wait()
+	}
+}
+
+func hasHigherPriorityWaiting(waiting *[numSignPriorities]int, priority int) bool {
+	for p := priority + 1; p < numSignPriorities; p++ {
+		if waiting[p] > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// release sign group, update health with the sign result.
+// only failure of getting sign result is counted, other errors (eg. rpc errors) are not related to sign group.
+func (s *signScheduler) release(g *signGroupState, latency time.Duration, err error) {
+	s.lock.Lock()
+	defer func() {
+		s.cond.Broadcast()
+		s.lock.Unlock()
+	}()
+
+	g.InFlight--
+	now := time.Now().Unix()
+	switch {
+	case err == nil:
+		g.Total++
+		g.Success++
+		g.ConsecutiveFailures = 0
+		g.LastSuccessTime = now
+		g.SuccessRate = g.SuccessRate*(1-healthEMAWeight) + healthEMAWeight
+		if g.AvgLatency == 0 {
+			g.AvgLatency = latency.Seconds()
+		} else {
+			g.AvgLatency = g.AvgLatency*(1-healthEMAWeight) + latency.Seconds()*healthEMAWeight
+		}
+	case errors.Is(err, errGetSignResultFailed):
+		g.Total++
+		g.Failure++
+		g.ConsecutiveFailures++
+		g.LastFailureTime = now
+		g.SuccessRate *= 1 - healthEMAWeight
+		if s.maxFailures > 0 && g.ConsecutiveFailures >= s.maxFailures && g.DisabledUntil == 0 {
+			g.DisabledUntil = now + s.disableSeconds
+			log.Error("disable sign group as consecutive failures", "initiator", g.Initiator, "signGroup", g.GroupID, "failures", g.ConsecutiveFailures, "until", g.DisabledUntil)
+		}
+	}
+}
+
+// orderedNodes get initiator nodes sorted by average success rate of their sign groups
+func (s *signScheduler) orderedNodes(nodes []*NodeInfo) []*NodeInfo {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	scores := make(map[*NodeInfo]float64, len(nodes))
+	for _, node := range nodes {
+		states := s.groups[node]
+		if len(states) == 0 {
+			continue
+		}
+		sum := 0.0
+		for _, g := range states {
+			sum += g.SuccessRate
+		}
+		scores[node] = sum / float64(len(states))
+	}
+	result := make([]*NodeInfo, len(nodes))
+	copy(result, nodes)
+	sort.SliceStable(result, func(i, j int) bool {
+		return scores[result[i]] > scores[result[j]]
+	})
+	return result
+}
+
+// health get health of all sign groups
+func (s *signScheduler) health(nodes []*NodeInfo) []*SignGroupHealth {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	result := make([]*SignGroupHealth, 0)
+	for _, node := range nodes {
+		for _, g := range s.groups[node] {
+			h := g.SignGroupHealth
+			result = append(result, &h)
+		}
+	}
+	return result
+}
+
+// GetSignGroupHealth get health of all sign groups of initiators (server only)
+func (c *Config) GetSignGroupHealth() []*SignGroupHealth {
+	if c == nil || c.scheduler == nil {
+		return nil
+	}
+	return c.scheduler.health(c.allInitiatorNodes)
+}
@@ -0,0 +1,85 @@
+package mpc
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetSignPriority(t *testing.T) {
+	tests := []struct {
+		msgContext []string
+		want       int
+	}{
+		{nil, SignPriorityNormal},
+		{[]string{"not json"}, SignPriorityNormal},
+		{[]string{`{"swapArgs":{"swapid":"0x1"},"extra":{"gas":21000}}`}, SignPriorityNormal},
+		{[]string{`{"swapArgs":{"reswapping":true}}`}, SignPriorityHigh},
+		{[]string{`{"extra":{"replaceNum":1}}`}, SignPriorityHigh},
+	}
+	for i, tt := range tests {
+		if got := getSignPriority(tt.msgContext); got != tt.want {
+			t.Errorf("test %v: got %v, want %v", i, got, tt.want)
+		}
+	}
+}
+
+func TestSignScheduler(t *testing.T) {
+	s := newSignScheduler()
+	s.maxInFlight = 1
+	s.maxFailures = 2
+	s.disableSeconds = 3600
+	s.maxWait = 100 * time.Millisecond
+
+	node := &NodeInfo{originSignGroups: []string{"group0", "group1"}}
+	s.addNode(node)
+
+	// unhealthy group is disabled after consecutive failures
+	g, err := s.acquire(node, SignPriorityNormal, nil)
+	if err != nil {
+		t.Fatalf("acquire failed: %v", err)
+	}
+	bad := g.index
+	for i := 0; i < s.maxFailures; i++ {
+		s.release(g, time.Second, errGetSignResultFailed)
+		if i+1 < s.maxFailures {
+			if g, err = s.acquire(node, SignPriorityNormal, map[int]bool{1 - bad: true}); err != nil {
+				t.Fatalf("acquire failed: %v", err)
+			}
+		}
+	}
+	if g.DisabledUntil == 0 {
+		t.Fatalf("sign group is not disabled after %v failures", s.maxFailures)
+	}
+
+	// only the healthy group is usable, and in-flight requests are limited
+	g, err = s.acquire(node, SignPriorityNormal, nil)
+	if err != nil || g.index == bad {
+		t.Fatalf("should acquire healthy group, got %v err %v", g, err)
+	}
+	if _, err = s.acquire(node, SignPriorityNormal, nil); err != errSignGroupsBusy {
+		t.Fatalf("should wait and fail when groups are busy, got err %v", err)
+	}
+
+	// high priority request is scheduled before normal request
+	s.maxWait = 2 * time.Second
+	order := make(chan int, 2)
+	for _, priority := range []int{SignPriorityNormal, SignPriorityHigh} {
+		go func(priority int) {
+			if g2, errf := s.acquire(node, priority, nil); errf == nil {
+				order <- priority
+				s.release(g2, time.Second, nil)
+			}
+		}(priority)
+		time.Sleep(50 * time.Millisecond)
+	}
+	s.release(g, time.Second, nil)
+	if first := <-order; first != SignPriorityHigh {
+		t.Errorf("high priority request should be scheduled first")
+	}
+	<-order
+
+	health := s.health([]*NodeInfo{node})
+	if len(health) != 2 || health[bad].Failure != uint64(s.maxFailures) || health[1-bad].Success != 3 {
+		t.Errorf("wrong sign group health %+v %+v", health[0], health[1])
+	}
+}
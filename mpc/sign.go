@@ -1,7 +1,6 @@
 package mpc
 
 import (
-	"crypto/rand"
 	"encoding/json"
 	"errors"
 	"math/big"
@@ -66,30 +65,28 @@ func (c *Config) DoSign(signType, signPubkey string, msgHash, msgContext []strin
 	if signPubkey == "" {
 		return "", nil, errSignWithoutPublickey
 	}
+	priority := getSignPriority(msgContext)
 	for i := 0; i < retrySignLoop; i++ {
-		for _, mpcNode := range c.allInitiatorNodes {
+		for _, mpcNode := range c.scheduler.orderedNodes(c.allInitiatorNodes) {
 			if err = c.pingMPCNode(mpcNode); err != nil {
 				continue
 			}
-			signGroupIndexes := mpcNode.getUsableSignGroupIndexes()
-			signGroupsCount := int64(len(signGroupIndexes))
-			if signGroupsCount == 0 {
-				err = errNoUsableSignGroups
-				continue
-			}
-			// randomly pick first subgroup to sign
-			randIndex, _ := rand.Int(rand.Reader, big.NewInt(signGroupsCount))
-			startIndex := randIndex.Int64()
-			i := startIndex
+			tried := make(map[int]bool)
 			for {
-				keyID, rsvs, err = c.doSignImpl(mpcNode, signGroupIndexes[i], signType, signPubkey, msgHash, msgContext)
+				signGroup, errf := c.scheduler.acquire(mpcNode, priority, tried)
+				if errf != nil {
+					if len(tried) == 0 {
+						err = errf
+					}
+					break
+				}
+				tried[signGroup.index] = true
+				startTime := time.Now()
+				keyID, rsvs, err = c.doSignImpl(mpcNode, signGroup.index, signType, signPubkey, msgHash, msgContext)
+				c.scheduler.release(signGroup, time.Since(startTime), err)
 				if err == nil {
 					return keyID, rsvs, nil
 				}
-				i = (i + 1) % signGroupsCount
-				if i == startIndex {
-					break
-				}
 			}
 		}
 		time.Sleep(2 * time.Second)
@@ -149,26 +146,8 @@ func (c *Config) doSignImpl(mpcNode *NodeInfo, signGroupIndex int, signType, sig
 
 	rsvs, err = c.getSignResult(keyID, rpcAddr, msgContext)
 	if err != nil {
-		if c.maxSignGroupFailures > 0 {
-			old := c.signGroupFailuresMap[signGroup]
-			c.signGroupFailuresMap[signGroup] = signFailures{
-				count:    old.count + 1,
-				lastTime: time.Now().Unix(),
-			}
-			if old.count+1 >= c.maxSignGroupFailures {
-				log.Error("delete sign group as consecutive failures", "signGroup", signGroup)
-				mpcNode.deleteSignGroup(signGroupIndex)
-			}
-		}
 		return keyID, nil, err
 	}
-	if c.maxSignGroupFailures > 0 {
-		// reset when succeed
-		c.signGroupFailuresMap[signGroup] = signFailures{
-			count:    0,
-			lastTime: time.Now().Unix(),
-		}
-	}
 	if mongodb.HasClient() && isEC(signType) { // prevent multiple use of same r value
 		for _, rsv := range rsvs {
 			signature := common.FromHex(rsv)
@@ -254,6 +233,23 @@ func BuildMPCRawTx(nonce uint64, payload []byte, keyWrapper *keystore.Key) (stri
 	return rawTX, nil
 }
 
+// ParseMPCRawTx parse mpc raw tx built by `BuildMPCRawTx`, returns sender, nonce and payload
+func ParseMPCRawTx(rawTx string) (sender common.Address, nonce uint64, payload []byte, err error) {
+	var tx types.Transaction
+	err = rlp.DecodeBytes(common.FromHex(rawTx), &tx)
+	if err != nil {
+		return sender, 0, nil, err
+	}
+	if tx.To() == nil || *tx.To() != mpcToAddr {
+		return sender, 0, nil, errors.New("wrong mpc tx to address")
+	}
+	sender, err = mpcSigner.Sender(&tx)
+	if err != nil {
+		return sender, 0, nil, err
+	}
+	return sender, tx.Nonce(), tx.Data(), nil
+}
+
 // HasValidSignature has valid signature
 func (s *SignInfoData) HasValidSignature() bool {
 	msgContextLen := len(s.MsgContext)
@@ -0,0 +1,172 @@
+package simulator
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Router/v3/common"
+	"github.com/anyswap/CrossChain-Router/v3/mpc"
+	"github.com/anyswap/CrossChain-Router/v3/tools/crypto"
+)
+
+// key types
+const (
+	KeyTypeEC256K1 = "EC256K1"
+	KeyTypeED25519 = "ED25519"
+)
+
+const (
+	defaultAPIPrefix   = "smpc_"
+	defaultSignTimeout = 120
+)
+
+// Config simulator config
+type Config struct {
+	APIPrefix   string `toml:",omitempty" json:",omitempty"` // default to `smpc_`
+	SignTimeout int64  `toml:",omitempty" json:",omitempty"` // seconds, default to 120
+
+	Groups []*GroupConfig
+	Nodes  []*NodeConfig
+	Keys   []*KeyConfig
+	Faults *FaultConfig `toml:",omitempty" json:",omitempty"` // global faults
+}
+
+// GroupConfig sign group config
+type GroupConfig struct {
+	GroupID string
+	Enodes  []string
+}
+
+// NodeConfig simulated mpc node config
+type NodeConfig struct {
+	Enode  string
+	Listen string              // http listen address, eg. `127.0.0.1:5871`
+	Users  []string            // addresses of keystores (of server or oracle) using this node
+	Faults *FaultConfig        `toml:",omitempty" json:",omitempty"` // overwrite global faults
+	users  map[string]struct{} // lower case users
+}
+
+// KeyConfig local key used to sign
+type KeyConfig struct {
+	KeyType    string // EC256K1 or ED25519
+	PrivateKey string // hex string of ec private key or ed25519 seed
+}
+
+// FaultConfig injected faults, rates are probabilities in range [0, 1]
+type FaultConfig struct {
+	TimeoutRate  float64 `toml:",omitempty" json:"timeoutRate,omitempty"`  // sign never completes and times out
+	DisagreeRate float64 `toml:",omitempty" json:"disagreeRate,omitempty"` // accepter replies disagree
+	WrongRSVRate float64 `toml:",omitempty" json:"wrongRSVRate,omitempty"` // returns signature of wrong message
+	RPCErrorRate float64 `toml:",omitempty" json:"rpcErrorRate,omitempty"` // returns error status
+}
+
+// CheckConfig check fault config
+func (c *FaultConfig) CheckConfig() error {
+	for _, rate := range []float64{c.TimeoutRate, c.DisagreeRate, c.WrongRSVRate, c.RPCErrorRate} {
+		if rate < 0 || rate > 1 {
+			return fmt.Errorf("fault rate %v is not in range [0, 1]", rate)
+		}
+	}
+	return nil
+}
+
+// CheckConfig check simulator config
+func (c *Config) CheckConfig() error {
+	if c.APIPrefix == "" {
+		c.APIPrefix = defaultAPIPrefix
+	}
+	if c.SignTimeout <= 0 {
+		c.SignTimeout = defaultSignTimeout
+	}
+	if len(c.Nodes) == 0 {
+		return errors.New("no simulated nodes")
+	}
+	enodes := make(map[string]struct{}, len(c.Nodes))
+	for _, node := range c.Nodes {
+		if mpc.GetEnodeID(node.Enode) == "" {
+			return fmt.Errorf("wrong enode '%v'", node.Enode)
+		}
+		if _, exist := enodes[node.Enode]; exist {
+			return fmt.Errorf("duplicate enode '%v'", node.Enode)
+		}
+		enodes[node.Enode] = struct{}{}
+		if node.Listen == "" {
+			return fmt.Errorf("node %v has no listen address", node.Enode)
+		}
+		node.users = make(map[string]struct{}, len(node.Users))
+		for _, user := range node.Users {
+			if !common.IsHexAddress(user) {
+				return fmt.Errorf("node %v has wrong user address '%v'", node.Enode, user)
+			}
+			node.users[strings.ToLower(user)] = struct{}{}
+		}
+		if node.Faults != nil {
+			if err := node.Faults.CheckConfig(); err != nil {
+				return err
+			}
+		}
+	}
+	if len(c.Groups) == 0 {
+		return errors.New("no sign groups")
+	}
+	for _, group := range c.Groups {
+		if group.GroupID == "" || len(group.Enodes) == 0 {
+			return fmt.Errorf("wrong sign group '%v'", group.GroupID)
+		}
+		for _, enode := range group.Enodes {
+			if _, exist := enodes[enode]; !exist {
+				return fmt.Errorf("sign group %v has unknown enode '%v'", group.GroupID, enode)
+			}
+		}
+	}
+	if len(c.Keys) == 0 {
+		return errors.New("no sign keys")
+	}
+	for _, key := range c.Keys {
+		if _, _, err := key.parse(); err != nil {
+			return err
+		}
+	}
+	if c.Faults != nil {
+		if err := c.Faults.CheckConfig(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parse returns public key (hex string as used in mpc sign) and private key
+func (k *KeyConfig) parse() (pubkey string, privKey interface{}, err error) {
+	switch k.KeyType {
+	case KeyTypeEC256K1:
+		ecKey, errf := crypto.HexToECDSA(strings.TrimPrefix(k.PrivateKey, "0x"))
+		if errf != nil {
+			return "", nil, fmt.Errorf("wrong ec private key, %w", errf)
+		}
+		return common.ToHex(crypto.FromECDSAPub(&ecKey.PublicKey)), ecKey, nil
+	case KeyTypeED25519:
+		seed := common.FromHex(k.PrivateKey)
+		if len(seed) != ed25519.SeedSize {
+			return "", nil, fmt.Errorf("wrong ed25519 seed length %v", len(seed))
+		}
+		edKey := ed25519.NewKeyFromSeed(seed)
+		return common.ToHex(edKey.Public().(ed25519.PublicKey)), edKey, nil
+	default:
+		return "", nil, fmt.Errorf("unknown key type '%v'", k.KeyType)
+	}
+}
+
+func signWithKey(privKey interface{}, msgHash string) ([]byte, error) {
+	msg := common.FromHex(msgHash)
+	switch key := privKey.(type) {
+	case *ecdsa.PrivateKey:
+		return crypto.Sign(msg, key)
+	case ed25519.PrivateKey:
+		return ed25519.Sign(key, msg), nil
+	default:
+		return nil, errors.New("unknown private key type")
+	}
+}
@@ -0,0 +1,186 @@
+package simulator
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Router/v3/log"
+	"github.com/anyswap/CrossChain-Router/v3/mpc"
+)
+
+// SetFaultsMethod rpc method to set faults at runtime (without api prefix),
+// params are `[enode, faults]`, empty enode means global faults.
+const SetFaultsMethod = "simulator_setFaults"
+
+type rpcRequest struct {
+	Version string            `json:"jsonrpc"`
+	Method  string            `json:"method"`
+	Params  []json.RawMessage `json:"params"`
+	ID      json.RawMessage   `json:"id"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type rpcResponse struct {
+	Version string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// smpc response which has `Status`, `Tip`, `Error` and `Data` fields
+type smpcResponse struct {
+	Status string
+	Tip    string
+	Error  string
+	Data   interface{}
+}
+
+func newSuccessResponse(data interface{}) *smpcResponse {
+	return &smpcResponse{Status: "Success", Data: data}
+}
+
+func newErrorResponse(err error) *smpcResponse {
+	return &smpcResponse{Status: "Error", Error: err.Error()}
+}
+
+// Handler get http handler of simulated node
+func (s *Simulator) Handler(enode string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req rpcRequest
+		resp := &rpcResponse{Version: "2.0"}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			resp.Error = &rpcError{Code: -32700, Message: "parse error: " + err.Error()}
+		} else {
+			resp.ID = req.ID
+			resp.Result, resp.Error = s.dispatch(enode, &req)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			log.Warn("simulator write response failed", "err", err)
+		}
+	})
+}
+
+func getStringParam(req *rpcRequest, index int) (string, error) {
+	if len(req.Params) <= index {
+		return "", fmt.Errorf("miss param %v", index)
+	}
+	var param string
+	if err := json.Unmarshal(req.Params[index], &param); err != nil {
+		return "", fmt.Errorf("wrong param %v, %w", index, err)
+	}
+	return param, nil
+}
+
+//nolint:gocyclo // dispatch all methods
+func (s *Simulator) dispatch(enode string, req *rpcRequest) (interface{}, *rpcError) {
+	log.Trace("simulator receive request", "enode", enode, "method", req.Method)
+	if req.Method == SetFaultsMethod {
+		target, err := getStringParam(req, 0)
+		if err != nil {
+			return nil, &rpcError{Code: -32602, Message: err.Error()}
+		}
+		var faults *FaultConfig
+		if len(req.Params) > 1 {
+			if err = json.Unmarshal(req.Params[1], &faults); err != nil {
+				return nil, &rpcError{Code: -32602, Message: err.Error()}
+			}
+		}
+		if err = s.SetFaults(target, faults); err != nil {
+			return nil, &rpcError{Code: -32000, Message: err.Error()}
+		}
+		return "Success", nil
+	}
+
+	if !strings.HasPrefix(req.Method, s.cfg.APIPrefix) {
+		return nil, &rpcError{Code: -32601, Message: "method not found: " + req.Method}
+	}
+	method := strings.TrimPrefix(req.Method, s.cfg.APIPrefix)
+	if err := s.injectRPCError(enode); err != nil {
+		return newErrorResponse(err), nil
+	}
+
+	param, paramErr := getStringParam(req, 0)
+	switch method {
+	case "getEnode":
+		return newSuccessResponse(&mpc.DataEnode{Enode: enode}), nil
+	case "getGroupByID":
+		if paramErr != nil {
+			return newErrorResponse(paramErr), nil
+		}
+		group, err := s.GetGroupByID(param)
+		if err != nil {
+			return newErrorResponse(err), nil
+		}
+		return newSuccessResponse(group), nil
+	case "getSignNonce":
+		if paramErr != nil {
+			return newErrorResponse(paramErr), nil
+		}
+		nonce := s.GetSignNonce(param)
+		return newSuccessResponse(&mpc.DataResult{Result: fmt.Sprintf("%d", nonce)}), nil
+	case "sign":
+		if paramErr != nil {
+			return newErrorResponse(paramErr), nil
+		}
+		keyID, err := s.Sign(enode, param)
+		if err != nil {
+			return newErrorResponse(err), nil
+		}
+		return newSuccessResponse(&mpc.DataResult{Result: keyID}), nil
+	case "getSignStatus":
+		if paramErr != nil {
+			return newErrorResponse(paramErr), nil
+		}
+		status, err := s.GetSignStatus(param)
+		if err != nil {
+			return newErrorResponse(err), nil
+		}
+		data, err := json.Marshal(status)
+		if err != nil {
+			return newErrorResponse(err), nil
+		}
+		return newSuccessResponse(&mpc.DataResult{Result: string(data)}), nil
+	case "getCurNodeSignInfo":
+		if paramErr != nil {
+			return newErrorResponse(paramErr), nil
+		}
+		infos, err := s.GetCurNodeSignInfo(enode, param)
+		if err != nil {
+			return newErrorResponse(err), nil
+		}
+		return newSuccessResponse(infos), nil
+	case "acceptSign":
+		if paramErr != nil {
+			return newErrorResponse(paramErr), nil
+		}
+		res, err := s.AcceptSign(enode, param)
+		if err != nil {
+			return newErrorResponse(err), nil
+		}
+		return newSuccessResponse(&mpc.DataResult{Result: res}), nil
+	default:
+		return nil, &rpcError{Code: -32601, Message: "method not found: " + req.Method}
+	}
+}
+
+// Start start http servers of all simulated nodes (non blocking)
+func (s *Simulator) Start() {
+	for _, node := range s.cfg.Nodes {
+		mux := http.NewServeMux()
+		mux.Handle("/", s.Handler(node.Enode))
+		server := &http.Server{Addr: node.Listen, Handler: mux} //nolint:gosec // simulation only
+		log.Info("simulator start node", "enode", node.Enode, "listen", node.Listen, "users", node.Users)
+		go func(enode string) {
+			if err := server.ListenAndServe(); err != nil {
+				log.Fatal("simulator node stopped", "enode", enode, "err", err)
+			}
+		}(node.Enode)
+	}
+}
@@ -0,0 +1,455 @@
+// Package simulator provides a local mpc network simulator serving the smpc rpc api,
+// which signs with local keys and supports injected faults, to test the sign and accept flow offline.
+package simulator
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anyswap/CrossChain-Router/v3/common"
+	"github.com/anyswap/CrossChain-Router/v3/log"
+	"github.com/anyswap/CrossChain-Router/v3/mpc"
+)
+
+// sign status and reply status
+const (
+	StatusPending  = "Pending"
+	StatusSuccess  = "Success"
+	StatusFailure  = "Failure"
+	StatusTimeout  = "Timeout"
+	ReplyAgree     = "Agree"
+	ReplyDisagree  = "DisAgree"
+	ReplyNoReponse = "NoReponse"
+)
+
+var (
+	errUnknownGroup    = errors.New("unknown sign group")
+	errUnknownPubkey   = errors.New("unknown sign public key")
+	errUnknownKeyID    = errors.New("unknown sign key id")
+	errNotGroupMember  = errors.New("node is not member of sign group")
+	errNotNodeUser     = errors.New("sender is not user of node")
+	errWrongThreshold  = errors.New("wrong sign threshold")
+	errWrongSignNonce  = errors.New("wrong sign nonce")
+	errAlreadyReplied  = errors.New("already replied")
+	errSignNotPending  = errors.New("sign is not pending")
+	errInjectedFailure = errors.New("injected rpc failure")
+)
+
+type signTask struct {
+	keyID     string
+	account   string
+	initiator string
+	nonce     uint64
+	data      *mpc.SignData
+	members   []string
+	required  int
+	replies   map[string]*mpc.SignReply // key is enode
+	status    string
+	rsvs      []string
+	errInfo   string
+	startTime time.Time
+
+	injectTimeout  bool
+	injectWrongRSV bool
+}
+
+// Simulator simulated mpc network
+type Simulator struct {
+	cfg *Config
+
+	lock   sync.Mutex
+	nodes  map[string]*NodeConfig // key is enode
+	groups map[string]*GroupConfig
+	keys   map[string]interface{}  // key is lower case public key without 0x prefix
+	nonces map[string]uint64       // key is lower case user address
+	tasks  map[string]*signTask    // key is keyID
+	faults map[string]*FaultConfig // key is enode, empty enode means global
+	rand   *rand.Rand
+}
+
+// NewSimulator new simulator
+func NewSimulator(cfg *Config) (*Simulator, error) {
+	if err := cfg.CheckConfig(); err != nil {
+		return nil, err
+	}
+	s := &Simulator{
+		cfg:    cfg,
+		nodes:  make(map[string]*NodeConfig, len(cfg.Nodes)),
+		groups: make(map[string]*GroupConfig, len(cfg.Groups)),
+		keys:   make(map[string]interface{}, len(cfg.Keys)),
+		nonces: make(map[string]uint64),
+		tasks:  make(map[string]*signTask),
+		faults: make(map[string]*FaultConfig),
+		rand:   rand.New(rand.NewSource(time.Now().UnixNano())), //nolint:gosec // simulation only
+	}
+	for _, node := range cfg.Nodes {
+		s.nodes[node.Enode] = node
+		if node.Faults != nil {
+			s.faults[node.Enode] = node.Faults
+		}
+	}
+	for _, group := range cfg.Groups {
+		s.groups[group.GroupID] = group
+	}
+	for _, key := range cfg.Keys {
+		pubkey, privKey, _ := key.parse()
+		s.keys[normalizeHex(pubkey)] = privKey
+		log.Info("simulator add sign key", "keyType", key.KeyType, "pubkey", pubkey)
+	}
+	if cfg.Faults != nil {
+		s.faults[""] = cfg.Faults
+	}
+	return s, nil
+}
+
+func normalizeHex(s string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X"))
+}
+
+// SetFaults set faults of node at runtime, empty enode means global faults, nil faults means no fault
+func (s *Simulator) SetFaults(enode string, faults *FaultConfig) error {
+	if faults != nil {
+		if err := faults.CheckConfig(); err != nil {
+			return err
+		}
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if enode != "" {
+		if _, exist := s.nodes[enode]; !exist {
+			return fmt.Errorf("unknown enode '%v'", enode)
+		}
+	}
+	if faults == nil {
+		delete(s.faults, enode)
+	} else {
+		s.faults[enode] = faults
+	}
+	log.Info("simulator set faults", "enode", enode, "faults", faults)
+	return nil
+}
+
+// getFaults should hold lock
+func (s *Simulator) getFaults(enode string) *FaultConfig {
+	if faults, exist := s.faults[enode]; exist {
+		return faults
+	}
+	if faults, exist := s.faults[""]; exist {
+		return faults
+	}
+	return &FaultConfig{}
+}
+
+// hit should hold lock
+func (s *Simulator) hit(rate float64) bool {
+	return rate > 0 && s.rand.Float64() < rate
+}
+
+func (s *Simulator) injectRPCError(enode string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.hit(s.getFaults(enode).RPCErrorRate) {
+		return errInjectedFailure
+	}
+	return nil
+}
+
+// GetGroupByID get sign group
+func (s *Simulator) GetGroupByID(groupID string) (*mpc.GroupInfo, error) {
+	group, exist := s.groups[groupID]
+	if !exist {
+		return nil, errUnknownGroup
+	}
+	return &mpc.GroupInfo{
+		GID:    group.GroupID,
+		Count:  len(group.Enodes),
+		Enodes: group.Enodes,
+	}, nil
+}
+
+// GetSignNonce get sign nonce of user
+func (s *Simulator) GetSignNonce(account string) uint64 {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.nonces[strings.ToLower(account)]
+}
+
+func (s *Simulator) checkNodeUser(enode string, user common.Address) error {
+	node := s.nodes[enode]
+	if _, exist := node.users[strings.ToLower(user.String())]; !exist {
+		return fmt.Errorf("%w, node %v sender %v", errNotNodeUser, enode, user.String())
+	}
+	return nil
+}
+
+func parseThreshold(threshold string, members int) (int, error) {
+	parts := strings.Split(threshold, "/")
+	if len(parts) != 2 {
+		return 0, errWrongThreshold
+	}
+	required, err := common.GetIntFromStr(parts[0])
+	if err != nil || required <= 0 || required > members {
+		return 0, fmt.Errorf("%w '%v' of %v members", errWrongThreshold, threshold, members)
+	}
+	return required, nil
+}
+
+// Sign start sign task, returns key id
+func (s *Simulator) Sign(enode, rawTx string) (string, error) {
+	sender, nonce, payload, err := mpc.ParseMPCRawTx(rawTx)
+	if err != nil {
+		return "", err
+	}
+	var data mpc.SignData
+	if err = json.Unmarshal(payload, &data); err != nil {
+		return "", err
+	}
+	if len(data.MsgHash) == 0 {
+		return "", errors.New("empty msg hash")
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err = s.checkNodeUser(enode, sender); err != nil {
+		return "", err
+	}
+	account := strings.ToLower(sender.String())
+	if nonce != s.nonces[account] {
+		return "", fmt.Errorf("%w, have %v want %v", errWrongSignNonce, nonce, s.nonces[account])
+	}
+	group, exist := s.groups[data.GroupID]
+	if !exist {
+		return "", fmt.Errorf("%w '%v'", errUnknownGroup, data.GroupID)
+	}
+	if !isMember(group.Enodes, enode) {
+		return "", errNotGroupMember
+	}
+	if _, exist = s.keys[normalizeHex(data.PubKey)]; !exist {
+		return "", fmt.Errorf("%w '%v'", errUnknownPubkey, data.PubKey)
+	}
+	required, err := parseThreshold(data.ThresHold, len(group.Enodes))
+	if err != nil {
+		return "", err
+	}
+	s.nonces[account] = nonce + 1
+
+	keyID := common.Keccak256Hash([]byte(rawTx)).Hex()
+	faults := s.getFaults(enode)
+	task := &signTask{
+		keyID:     keyID,
+		account:   sender.String(),
+		initiator: enode,
+		nonce:     nonce,
+		data:      &data,
+		members:   group.Enodes,
+		required:  required,
+		replies:   make(map[string]*mpc.SignReply, len(group.Enodes)),
+		status:    StatusPending,
+		startTime: time.Now(),
+
+		injectTimeout:  s.hit(faults.TimeoutRate),
+		injectWrongRSV: s.hit(faults.WrongRSVRate),
+	}
+	s.tasks[keyID] = task
+	// initiator agrees automatically
+	s.addReply(task, enode, ReplyAgree)
+	log.Info("simulator start sign", "keyID", keyID, "initiator", enode, "account", task.account,
+		"groupID", data.GroupID, "threshold", data.ThresHold, "msgHash", data.MsgHash,
+		"injectTimeout", task.injectTimeout, "injectWrongRSV", task.injectWrongRSV)
+	return keyID, nil
+}
+
+func isMember(enodes []string, enode string) bool {
+	for _, item := range enodes {
+		if item == enode {
+			return true
+		}
+	}
+	return false
+}
+
+// addReply should hold lock
+func (s *Simulator) addReply(task *signTask, enode, status string) {
+	task.replies[enode] = &mpc.SignReply{
+		Enode:     mpc.GetEnodeID(enode),
+		Status:    status,
+		TimeStamp: common.NowMilliStr(),
+		Initiator: fmt.Sprintf("%v", enode == task.initiator),
+	}
+	s.updateTask(task)
+}
+
+// updateTask update task status, should hold lock
+func (s *Simulator) updateTask(task *signTask) {
+	if task.status != StatusPending {
+		return
+	}
+	agrees := 0
+	for _, reply := range task.replies {
+		switch reply.Status {
+		case ReplyDisagree:
+			task.status = StatusFailure
+			task.errInfo = "has disagree reply"
+			return
+		case ReplyAgree:
+			agrees++
+		}
+	}
+	if !task.injectTimeout && agrees >= task.required {
+		rsvs, err := s.signTask(task)
+		if err != nil {
+			task.status = StatusFailure
+			task.errInfo = err.Error()
+			return
+		}
+		task.status = StatusSuccess
+		task.rsvs = rsvs
+		log.Info("simulator sign success", "keyID", task.keyID, "agrees", agrees, "wrongRSV", task.injectWrongRSV)
+		return
+	}
+	if time.Since(task.startTime) > time.Duration(s.cfg.SignTimeout)*time.Second {
+		task.status = StatusTimeout
+		log.Info("simulator sign timeout", "keyID", task.keyID, "agrees", agrees, "required", task.required)
+	}
+}
+
+func (s *Simulator) signTask(task *signTask) ([]string, error) {
+	privKey := s.keys[normalizeHex(task.data.PubKey)]
+	rsvs := make([]string, 0, len(task.data.MsgHash))
+	for _, msgHash := range task.data.MsgHash {
+		if task.injectWrongRSV {
+			msgHash = getWrongMessage(msgHash)
+		}
+		sig, err := signWithKey(privKey, msgHash)
+		if err != nil {
+			return nil, err
+		}
+		rsvs = append(rsvs, strings.ToUpper(hex.EncodeToString(sig)))
+	}
+	return rsvs, nil
+}
+
+// getWrongMessage get another message of the same length (ec sign requires 32 bytes hash)
+func getWrongMessage(msgHash string) string {
+	msg := common.FromHex(msgHash)
+	if len(msg) == common.HashLength {
+		return common.Keccak256Hash(msg).Hex()
+	}
+	wrong := make([]byte, len(msg))
+	copy(wrong, msg)
+	if len(wrong) > 0 {
+		wrong[0] ^= 0xff
+	}
+	return common.ToHex(wrong)
+}
+
+// GetSignStatus get sign status
+func (s *Simulator) GetSignStatus(keyID string) (*mpc.SignStatus, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	task, exist := s.tasks[keyID]
+	if !exist {
+		return nil, errUnknownKeyID
+	}
+	s.updateTask(task)
+	status := &mpc.SignStatus{
+		Status:    task.status,
+		Rsv:       task.rsvs,
+		Error:     task.errInfo,
+		TimeStamp: common.NowMilliStr(),
+	}
+	for _, enode := range task.members {
+		reply, exist := task.replies[enode]
+		if !exist {
+			reply = &mpc.SignReply{Enode: mpc.GetEnodeID(enode), Status: ReplyNoReponse}
+		}
+		status.AllReply = append(status.AllReply, reply)
+	}
+	return status, nil
+}
+
+// GetCurNodeSignInfo get pending sign infos which wait for accepting by node
+func (s *Simulator) GetCurNodeSignInfo(enode, account string) ([]*mpc.SignInfoData, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err := s.checkNodeUser(enode, common.HexToAddress(account)); err != nil {
+		return nil, err
+	}
+	result := make([]*mpc.SignInfoData, 0)
+	for _, task := range s.tasks {
+		s.updateTask(task)
+		if task.status != StatusPending || !isMember(task.members, enode) {
+			continue
+		}
+		if _, replied := task.replies[enode]; replied {
+			continue
+		}
+		result = append(result, &mpc.SignInfoData{
+			Account:    task.account,
+			GroupID:    task.data.GroupID,
+			Key:        task.keyID,
+			KeyType:    task.data.Keytype,
+			Mode:       task.data.Mode,
+			MsgHash:    task.data.MsgHash,
+			MsgContext: task.data.MsgContext,
+			Nonce:      fmt.Sprintf("%d", task.nonce),
+			PubKey:     task.data.PubKey,
+			ThresHold:  task.data.ThresHold,
+			TimeStamp:  task.data.TimeStamp,
+		})
+	}
+	return result, nil
+}
+
+// AcceptSign accept or disagree sign task
+func (s *Simulator) AcceptSign(enode, rawTx string) (string, error) {
+	sender, _, payload, err := mpc.ParseMPCRawTx(rawTx)
+	if err != nil {
+		return "", err
+	}
+	var data mpc.AcceptData
+	if err = json.Unmarshal(payload, &data); err != nil {
+		return "", err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err = s.checkNodeUser(enode, sender); err != nil {
+		return "", err
+	}
+	task, exist := s.tasks[data.Key]
+	if !exist {
+		return "", errUnknownKeyID
+	}
+	if !isMember(task.members, enode) {
+		return "", errNotGroupMember
+	}
+	if _, replied := task.replies[enode]; replied {
+		return "", errAlreadyReplied
+	}
+	s.updateTask(task)
+	if task.status != StatusPending {
+		return "", fmt.Errorf("%w, status is %v", errSignNotPending, task.status)
+	}
+	reply := ReplyDisagree
+	if strings.EqualFold(data.Accept, "AGREE") {
+		reply = ReplyAgree
+		if s.hit(s.getFaults(enode).DisagreeRate) {
+			reply = ReplyDisagree
+			log.Info("simulator inject disagree", "keyID", task.keyID, "enode", enode)
+		}
+	}
+	s.addReply(task, enode, reply)
+	log.Info("simulator accept sign", "keyID", task.keyID, "enode", enode, "accept", data.Accept, "reply", reply, "status", task.status)
+	return "Success", nil
+}
@@ -0,0 +1,134 @@
+package simulator
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Router/v3/common"
+	"github.com/anyswap/CrossChain-Router/v3/mpc"
+	"github.com/anyswap/CrossChain-Router/v3/rpc/client"
+	"github.com/anyswap/CrossChain-Router/v3/tools/crypto"
+	"github.com/anyswap/CrossChain-Router/v3/tools/keystore"
+)
+
+const (
+	serverEnode = "enode://1111@127.0.0.1:1111"
+	oracleEnode = "enode://2222@127.0.0.1:2222"
+	signKey     = "0x4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+)
+
+func newTestKey(t *testing.T) *keystore.Key {
+	privKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &keystore.Key{Address: crypto.PubkeyToAddress(privKey.PublicKey), PrivateKey: privKey}
+}
+
+func post(t *testing.T, url, method string, result interface{}, params ...interface{}) {
+	if err := client.RPCPost(result, url, "smpc_"+method, params...); err != nil {
+		t.Fatalf("call %v failed: %v", method, err)
+	}
+}
+
+func TestSignAndAccept(t *testing.T) {
+	serverKey, oracleKey := newTestKey(t), newTestKey(t)
+	cfg := &Config{
+		Groups: []*GroupConfig{{GroupID: "group1", Enodes: []string{serverEnode, oracleEnode}}},
+		Nodes: []*NodeConfig{
+			{Enode: serverEnode, Listen: "127.0.0.1:1111", Users: []string{serverKey.Address.String()}},
+			{Enode: oracleEnode, Listen: "127.0.0.1:2222", Users: []string{oracleKey.Address.String()}},
+		},
+		Keys: []*KeyConfig{{KeyType: KeyTypeEC256K1, PrivateKey: signKey}},
+	}
+	sim, err := NewSimulator(cfg)
+	if err != nil {
+		t.Fatalf("new simulator failed: %v", err)
+	}
+	serverNode := httptest.NewServer(sim.Handler(serverEnode))
+	defer serverNode.Close()
+	oracleNode := httptest.NewServer(sim.Handler(oracleEnode))
+	defer oracleNode.Close()
+
+	pubkey, _, _ := cfg.Keys[0].parse()
+	msgHash := common.Keccak256Hash([]byte("test message")).Hex()
+
+	startSign := func() string {
+		var nonceRes mpc.DataResultResp
+		post(t, serverNode.URL, "getSignNonce", &nonceRes, serverKey.Address.String())
+		nonce, _ := common.GetUint64FromStr(nonceRes.Data.Result)
+		payload, _ := json.Marshal(&mpc.SignData{
+			TxType: "SIGN", PubKey: pubkey, MsgHash: []string{msgHash}, MsgContext: []string{"ctx"},
+			Keytype: KeyTypeEC256K1, GroupID: "group1", ThresHold: "2/2", Mode: "0", TimeStamp: common.NowMilliStr(),
+		})
+		rawTx, _ := mpc.BuildMPCRawTx(nonce, payload, serverKey)
+		var signRes mpc.DataResultResp
+		post(t, serverNode.URL, "sign", &signRes, rawTx)
+		if signRes.Status != "Success" {
+			t.Fatalf("sign failed: %v", signRes.Error)
+		}
+		return signRes.Data.Result
+	}
+	acceptSign := func(keyID, accept string) {
+		var infoRes mpc.SignInfoResp
+		post(t, oracleNode.URL, "getCurNodeSignInfo", &infoRes, oracleKey.Address.String())
+		if len(infoRes.Data) != 1 || infoRes.Data[0].Key != keyID || !infoRes.Data[0].IsValid(false) {
+			t.Fatalf("wrong sign info %v", infoRes.Data)
+		}
+		payload, _ := json.Marshal(&mpc.AcceptData{TxType: "ACCEPTSIGN", Key: keyID, Accept: accept, TimeStamp: common.NowMilliStr()})
+		rawTx, _ := mpc.BuildMPCRawTx(0, payload, oracleKey)
+		var acceptRes mpc.DataResultResp
+		post(t, oracleNode.URL, "acceptSign", &acceptRes, rawTx)
+		if acceptRes.Status != "Success" {
+			t.Fatalf("accept sign failed: %v", acceptRes.Error)
+		}
+	}
+	getStatus := func(keyID string) *mpc.SignStatus {
+		var statusRes mpc.DataResultResp
+		post(t, serverNode.URL, "getSignStatus", &statusRes, keyID)
+		var status mpc.SignStatus
+		_ = json.Unmarshal([]byte(statusRes.Data.Result), &status)
+		return &status
+	}
+	verifyRSV := func(rsv string) bool {
+		pub, errf := crypto.Ecrecover(common.FromHex(msgHash), common.FromHex(rsv))
+		return errf == nil && common.ToHex(pub) == pubkey
+	}
+
+	keyID := startSign()
+	if status := getStatus(keyID); status.Status != StatusPending {
+		t.Fatalf("sign should be pending before accept, status %v", status.Status)
+	}
+	acceptSign(keyID, "AGREE")
+	if status := getStatus(keyID); status.Status != StatusSuccess || len(status.Rsv) != 1 || !verifyRSV(status.Rsv[0]) {
+		t.Fatalf("sign with agree failed, status %+v", status)
+	}
+
+	keyID = startSign()
+	acceptSign(keyID, "DISAGREE")
+	if status := getStatus(keyID); status.Status != StatusFailure || !status.HasDisagree() {
+		t.Fatalf("sign with disagree should fail, status %+v", status)
+	}
+
+	_ = sim.SetFaults("", &FaultConfig{WrongRSVRate: 1})
+	keyID = startSign()
+	acceptSign(keyID, "AGREE")
+	if status := getStatus(keyID); status.Status != StatusSuccess || verifyRSV(status.Rsv[0]) {
+		t.Fatalf("sign with wrong rsv fault, status %+v", status)
+	}
+
+	_ = sim.SetFaults(oracleEnode, &FaultConfig{DisagreeRate: 1})
+	keyID = startSign()
+	acceptSign(keyID, "AGREE")
+	if status := getStatus(keyID); status.Status != StatusFailure {
+		t.Fatalf("sign with disagree fault should fail, status %+v", status)
+	}
+
+	_ = sim.SetFaults(serverEnode, &FaultConfig{RPCErrorRate: 1})
+	var enodeRes mpc.GetEnodeResp
+	post(t, serverNode.URL, "getEnode", &enodeRes)
+	if enodeRes.Status == "Success" {
+		t.Fatalf("rpc error fault is not injected")
+	}
+}
@@ -0,0 +1,58 @@
+package params
+
+import "testing"
+
+func TestAdminRoleIsAllowed(t *testing.T) {
+	operator := &AdminRoleConfig{
+		Members:         []string{"0x7777777777777777777777777777777777777777"},
+		Methods:         []string{"maintain", "replaceswap"},
+		MaintainActions: []string{"pause", "unpause"},
+		ChainIDs:        []string{"56"},
+	}
+	global := &AdminRoleConfig{
+		Methods: []string{"maintain"},
+	}
+	tokenOperator := &AdminRoleConfig{
+		Methods:  []string{"maintain", "replaceswap"},
+		TokenIDs: []string{"USDC"},
+	}
+	chainOperator := &AdminRoleConfig{
+		Methods:  []string{"maintain"},
+		ChainIDs: []string{"56"},
+	}
+	tests := []struct {
+		role     *AdminRoleConfig
+		method   string
+		action   string
+		chainIDs []string
+		tokenIDs []string
+		want     bool
+	}{
+		{operator, "maintain", "pause", []string{"56"}, nil, true},
+		{operator, "maintain", "pause", []string{"56", "1"}, nil, false},
+		{operator, "maintain", "blacklist", []string{"56"}, nil, false},
+		{operator, "maintain", "blacklist", nil, nil, false},
+		{operator, "replaceswap", "", []string{"56"}, []string{"USDC"}, true},
+		{operator, "reswap", "", []string{"56"}, nil, false},
+		{global, "maintain", "blacklist", nil, nil, true},
+		{global, "passbigvalue", "", []string{"1"}, nil, false},
+		// restricted dimension which is not scoped by the call
+		{tokenOperator, "maintain", "pause", []string{"56"}, nil, false},
+		{tokenOperator, "maintain", "pause", []string{"1"}, nil, false},
+		{tokenOperator, "maintain", "blacklist", nil, []string{"USDC"}, true},
+		{tokenOperator, "replaceswap", "", []string{"56"}, []string{"USDC"}, true},
+		{tokenOperator, "replaceswap", "", []string{"56"}, []string{"USDT"}, false},
+		{chainOperator, "maintain", "blacklist", nil, []string{"X"}, false},
+		{chainOperator, "maintain", "whitelist", nil, []string{"X"}, false},
+		{chainOperator, "maintain", "pause", []string{"56"}, nil, true},
+		{chainOperator, "maintain", "blacklist", []string{"56"}, []string{"X"}, true},
+	}
+	for i, tt := range tests {
+		if got := tt.role.IsAllowed(tt.method, tt.action, tt.chainIDs, tt.tokenIDs); got != tt.want {
+			t.Errorf("test %v: got %v, want %v", i, got, tt.want)
+		}
+	}
+	if !operator.IsMember("0x7777777777777777777777777777777777777777") || operator.IsMember("0x6666666666666666666666666666666666666666") {
+		t.Errorf("check role member failed")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math/big"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -35,16 +36,10 @@ func CallContractWithGateway(gateway, contract string, data hexutil.Bytes, block
 
 // CheckConfig check router config
 func (config *RouterConfig) CheckConfig(isServer bool) (err error) {
-	if !strings.HasPrefix(config.Identifier, RouterSwapPrefixID) || config.Identifier == RouterSwapPrefixID {
-		return fmt.Errorf("wrong identifier '%v', missing prefix '%v'", config.Identifier, RouterSwapPrefixID)
-	}
-	if config.SwapType == "" {
-		return errors.New("empty router swap type")
-	}
-	if config.SwapType == "anycallswap" && config.SwapSubType == "" {
-		return errors.New("anycall must config 'SwapSubType'")
+	err = config.CheckIdentifier(isServer)
+	if err != nil {
+		return err
 	}
-	log.Info("check identifier pass", "identifier", config.Identifier, "swaptype", config.SwapType, "swapsubtype", config.SwapSubType, "isServer", isServer)
 
 	err = config.CheckBlacklistConfig()
 	if err != nil {
@@ -94,6 +89,56 @@ func (config *RouterConfig) CheckConfig(isServer bool) (err error) {
 	return nil
 }
 
+// CheckConfigAll check router config like `CheckConfig`,
+// but continue to check other sections after errors and return all errors found.
+func (config *RouterConfig) CheckConfigAll(isServer bool) (errs []error) {
+	addError := func(section string, err error) {
+		if err != nil {
+			errs = append(errs, fmt.Errorf("[%v] %w", section, err))
+		}
+	}
+
+	addError("Identifier", config.CheckIdentifier(isServer))
+	addError("Blacklist", config.CheckBlacklistConfig())
+	if config.Extra != nil {
+		addError("Extra", config.Extra.CheckConfig())
+	}
+	if isServer {
+		addError("Server", config.Server.CheckConfig())
+	} else {
+		addError("Oracle", config.Oracle.CheckConfig())
+	}
+	if config.MPC == nil {
+		addError("MPC", errors.New("server must config 'MPC'"))
+	} else {
+		addError("MPC", config.MPC.CheckConfig(isServer))
+	}
+	if config.FastMPC != nil {
+		addError("FastMPC", config.FastMPC.CheckConfig(isServer))
+	}
+	if config.Onchain == nil {
+		addError("Onchain", errors.New("server must config 'Onchain'"))
+	} else {
+		addError("Onchain", config.Onchain.CheckConfig())
+	}
+	return errs
+}
+
+// CheckIdentifier check identifier and swap type
+func (config *RouterConfig) CheckIdentifier(isServer bool) error {
+	if !strings.HasPrefix(config.Identifier, RouterSwapPrefixID) || config.Identifier == RouterSwapPrefixID {
+		return fmt.Errorf("wrong identifier '%v', missing prefix '%v'", config.Identifier, RouterSwapPrefixID)
+	}
+	if config.SwapType == "" {
+		return errors.New("empty router swap type")
+	}
+	if config.SwapType == "anycallswap" && config.SwapSubType == "" {
+		return errors.New("anycall must config 'SwapSubType'")
+	}
+	log.Info("check identifier pass", "identifier", config.Identifier, "swaptype", config.SwapType, "swapsubtype", config.SwapSubType, "isServer", isServer)
+	return nil
+}
+
 // CheckBlacklistConfig check black list config
 func (config *RouterConfig) CheckBlacklistConfig() (err error) {
 	tempCidMap := make(map[string]struct{})
@@ -275,6 +320,34 @@ func (s *RouterServerConfig) CheckConfig() error {
 	if err != nil {
 		return err
 	}
+	err = s.CheckBatchSwapConfig()
+	if err != nil {
+		return err
+	}
+	err = s.CheckGasOracleConfig()
+	if err != nil {
+		return err
+	}
+	err = s.CheckLiquidityPolicyConfig()
+	if err != nil {
+		return err
+	}
+	err = s.CheckAdminApprovalConfig()
+	if err != nil {
+		return err
+	}
+	err = s.CheckAdminRolesConfig()
+	if err != nil {
+		return err
+	}
+	err = s.CheckLeaderElectionConfig()
+	if err != nil {
+		return err
+	}
+	err = s.CheckStuckSwapConfig()
+	if err != nil {
+		return err
+	}
 	err = s.CheckExtra()
 	if err != nil {
 		return err
@@ -336,6 +409,189 @@ func (s *RouterServerConfig) CheckDynamicFeeTxConfig() error {
 	return nil
 }
 
+// CheckBatchSwapConfig check batch swap config
+func (s *RouterServerConfig) CheckBatchSwapConfig() error {
+	for cid, c := range s.BatchSwap {
+		if _, ok := new(big.Int).SetString(cid, 0); !ok {
+			return fmt.Errorf("wrong chain id '%v' in 'BatchSwap'", cid)
+		}
+		if c.Window <= 0 {
+			return fmt.Errorf("chain %v batch swap must config positive 'Window'", cid)
+		}
+		if c.MaxSize < 2 || c.MaxSize > MaxBatchSwapSize {
+			return fmt.Errorf("chain %v batch swap 'MaxSize' %v is not in range [2, %v]", cid, c.MaxSize, MaxBatchSwapSize)
+		}
+	}
+	log.Info("check server batch swap config success")
+	return nil
+}
+
+// CheckAdminApprovalConfig check multi-admin approval config
+func (s *RouterServerConfig) CheckAdminApprovalConfig() error {
+	for method, c := range s.AdminApproval {
+		if !containsFold(adminMethods, method) {
+			return fmt.Errorf("admin method '%v' does not support multi-admin approval", method)
+		}
+		if c.Threshold < 1 || c.Threshold > len(s.Admins) {
+			return fmt.Errorf("admin method %v approval 'Threshold' %v is not in range [1, %v]", method, c.Threshold, len(s.Admins))
+		}
+		if c.ExpireSeconds < 0 {
+			return fmt.Errorf("admin method %v approval has negative 'ExpireSeconds'", method)
+		}
+	}
+	return nil
+}
+
+// CheckLeaderElectionConfig check leader election config
+func (s *RouterServerConfig) CheckLeaderElectionConfig() error {
+	c := s.LeaderElection
+	if c == nil || !c.Enable {
+		return nil
+	}
+	if c.LeaseSeconds < 0 || c.RenewInterval < 0 {
+		return errors.New("leader election has negative 'LeaseSeconds' or 'RenewInterval'")
+	}
+	if c.GetRenewInterval() >= c.GetLeaseSeconds() {
+		return fmt.Errorf("leader election 'RenewInterval' %v is not less than 'LeaseSeconds' %v", c.GetRenewInterval(), c.GetLeaseSeconds())
+	}
+	log.Info("check leader election config success", "leaseSeconds", c.GetLeaseSeconds(), "renewInterval", c.GetRenewInterval())
+	return nil
+}
+
+// CheckStuckSwapConfig check stuck swap detector config
+func (s *RouterServerConfig) CheckStuckSwapConfig() error {
+	c := s.StuckSwap
+	if c == nil || !c.Enable {
+		return nil
+	}
+	if c.CheckInterval < 0 || c.MaxLookBack < 0 || c.CriticalMultiple < 0 {
+		return errors.New("stuck swap config has negative 'CheckInterval', 'MaxLookBack' or 'CriticalMultiple'")
+	}
+	for status, slas := range c.SLA {
+		if _, exist := defaultStuckSwapSLA[status]; !exist {
+			return fmt.Errorf("stuck swap SLA has unsupported status '%v', supported are %v", status, stuckSwapStatuses)
+		}
+		for pair, sla := range slas {
+			parts := strings.Split(pair, "-")
+			if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+				return fmt.Errorf("stuck swap SLA of %v has wrong chain pair '%v'", status, pair)
+			}
+			if sla <= 0 {
+				return fmt.Errorf("stuck swap SLA of %v %v is not positive", status, pair)
+			}
+		}
+	}
+	log.Info("check stuck swap config success", "checkInterval", c.GetCheckInterval(), "maxLookBack", c.GetMaxLookBack(), "criticalMultiple", c.GetCriticalMultiple())
+	return nil
+}
+
+// CheckAdminRolesConfig check admin roles config
+func (s *RouterServerConfig) CheckAdminRolesConfig() error {
+	for name, role := range s.AdminRoles {
+		if len(role.Members) == 0 {
+			return fmt.Errorf("admin role %v has no members", name)
+		}
+		for _, member := range role.Members {
+			if !common.IsHexAddress(member) {
+				return fmt.Errorf("admin role %v has wrong member address '%v'", name, member)
+			}
+		}
+		if len(role.Methods) == 0 {
+			return fmt.Errorf("admin role %v has no methods", name)
+		}
+		for _, method := range role.Methods {
+			if !containsFold(adminMethods, method) {
+				return fmt.Errorf("admin role %v has unknown method '%v'", name, method)
+			}
+		}
+		for _, action := range role.MaintainActions {
+			if !containsFold(maintainActions, action) {
+				return fmt.Errorf("admin role %v has unknown maintain action '%v'", name, action)
+			}
+		}
+		for _, chainID := range role.ChainIDs {
+			if _, err := common.GetBigIntFromStr(chainID); err != nil {
+				return fmt.Errorf("admin role %v has wrong chainID '%v'", name, chainID)
+			}
+		}
+		log.Info("check admin role success", "name", name, "members", len(role.Members), "methods", role.Methods, "actions", role.MaintainActions, "chainIDs", role.ChainIDs, "tokenIDs", role.TokenIDs)
+	}
+	return nil
+}
+
+// CheckLiquidityPolicyConfig check liquidity policy config
+func (s *RouterServerConfig) CheckLiquidityPolicyConfig() error {
+	for tokenID, c := range s.LiquidityPolicy {
+		if tokenID == "" {
+			return errors.New("empty token id in 'LiquidityPolicy'")
+		}
+		switch c.Policy {
+		case LiquidityPolicyDelay, LiquidityPolicyAnyToken, LiquidityPolicySplit:
+		default:
+			return fmt.Errorf("token %v has unknown liquidity 'Policy' %v", tokenID, c.Policy)
+		}
+		if c.MaxDelay < 0 {
+			return fmt.Errorf("token %v liquidity policy has negative 'MaxDelay'", tokenID)
+		}
+		if c.MaxDelay > 0 && c.Policy != LiquidityPolicyDelay {
+			return fmt.Errorf("token %v liquidity policy %v does not support 'MaxDelay'", tokenID, c.Policy)
+		}
+	}
+	log.Info("check server liquidity policy config success")
+	return nil
+}
+
+// CheckGasOracleConfig check gas oracle config
+func (s *RouterServerConfig) CheckGasOracleConfig() error {
+	for cid, c := range s.GasOracle {
+		if _, ok := new(big.Int).SetString(cid, 0); !ok {
+			return fmt.Errorf("wrong chain id '%v' in 'GasOracle'", cid)
+		}
+		switch c.Method {
+		case "", "median", "max", "min", "first":
+		default:
+			return fmt.Errorf("chain %v gas oracle has unknown 'Method' %v", cid, c.Method)
+		}
+		if c.CacheSeconds < 0 {
+			return fmt.Errorf("chain %v gas oracle has negative 'CacheSeconds'", cid)
+		}
+		if c.BlockCount < 0 || c.BlockCount > 1024 {
+			return fmt.Errorf("chain %v gas oracle 'BlockCount' is not in range [0, 1024]", cid)
+		}
+		if c.Percentile < 0 || c.Percentile > 100 {
+			return fmt.Errorf("chain %v gas oracle 'Percentile' is not in range [0, 100]", cid)
+		}
+		c.minGasPrice, c.maxGasPrice = nil, nil
+		if c.MinGasPrice != "" {
+			bi, err := common.GetBigIntFromStr(c.MinGasPrice)
+			if err != nil {
+				return fmt.Errorf("chain %v gas oracle has wrong 'MinGasPrice'", cid)
+			}
+			c.minGasPrice = bi
+		}
+		if c.MaxGasPrice != "" {
+			bi, err := common.GetBigIntFromStr(c.MaxGasPrice)
+			if err != nil {
+				return fmt.Errorf("chain %v gas oracle has wrong 'MaxGasPrice'", cid)
+			}
+			c.maxGasPrice = bi
+		}
+		if c.minGasPrice != nil && c.maxGasPrice != nil && c.minGasPrice.Cmp(c.maxGasPrice) > 0 {
+			return fmt.Errorf("chain %v gas oracle must satisfy 'MinGasPrice <= MaxGasPrice'", cid)
+		}
+		for _, src := range c.HTTPSources {
+			if src.URL == "" || src.Field == "" {
+				return fmt.Errorf("chain %v gas oracle http source must config 'URL' and 'Field'", cid)
+			}
+			if src.Decimals > 18 {
+				return fmt.Errorf("chain %v gas oracle http source has too large 'Decimals'", cid)
+			}
+		}
+	}
+	log.Info("check server gas oracle config success")
+	return nil
+}
+
 // CheckExtra check extra server config
 func (s *RouterServerConfig) CheckExtra() error {
 	if s.MaxPlusGasPricePercentage == 0 {
@@ -483,6 +739,17 @@ func (c *ExtraConfig) CheckConfig() (err error) {
 		GetBalanceBlockNumberOpt = "pending"
 	}
 
+	for appID, chains := range c.AnycallApps {
+		for chainID, cfg := range chains {
+			if _, ok := new(big.Int).SetString(chainID, 0); !ok {
+				return fmt.Errorf("wrong chain id '%v' in 'AnycallApps' of app %v", chainID, appID)
+			}
+			if err = cfg.CheckConfig(); err != nil {
+				return fmt.Errorf("anycall app %v on chain %v: %w", appID, chainID, err)
+			}
+		}
+	}
+
 	for chainID, baseFeePercent := range c.BaseFeePercent {
 		if _, ok := new(big.Int).SetString(chainID, 0); !ok {
 			return fmt.Errorf("wrong chain id '%v' in 'BaseFeePercent'", chainID)
@@ -506,5 +773,98 @@ func (c *LocalChainConfig) CheckConfig() (err error) {
 	if c.BigValueDiscount > 100 {
 		return errors.New("'BigValueDiscount' is larger than 100")
 	}
+	if c.ConfirmPolicy != nil {
+		if err = c.ConfirmPolicy.CheckConfig(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CheckConfig check anycall app config
+func (c *AnycallAppConfig) CheckConfig() error {
+	if c.Target == "" {
+		return errors.New("empty 'Target'")
+	}
+	if c.Function == "" {
+		return errors.New("empty 'Function'")
+	}
+	switch c.GetEncoding() {
+	case AnycallEncodingBytes, AnycallEncodingUTF8, AnycallEncodingHex:
+	case AnycallEncodingRaw:
+		if len(c.Args) > 0 {
+			return errors.New("'Args' is not used in raw encoding")
+		}
+	default:
+		return fmt.Errorf("unsupported 'Encoding' '%v'", c.Encoding)
+	}
+	names := make(map[string]struct{})
+	for _, arg := range c.GetArgs() {
+		if arg.Name == "" {
+			return fmt.Errorf("wrong arg '%v:%v'", arg.Name, arg.Field)
+		}
+		if !isAnycallArgField(arg.Field) {
+			return fmt.Errorf("unsupported arg field '%v', supported are %v", arg.Field, anycallArgFields)
+		}
+		if _, exist := names[arg.Name]; exist {
+			return fmt.Errorf("duplicate arg name '%v'", arg.Name)
+		}
+		names[arg.Name] = struct{}{}
+	}
+	for _, account := range c.Accounts {
+		parts := strings.Split(account, ":")
+		if parts[0] == "" || len(parts) > 2 || (len(parts) == 2 && parts[1] != "w") {
+			return fmt.Errorf("wrong account '%v', should be 'pubkey' or 'pubkey:w'", account)
+		}
+	}
+	return nil
+}
+
+func isAnycallArgField(field string) bool {
+	for _, f := range anycallArgFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckConfig check confirm rule
+func (r *ConfirmRule) CheckConfig() error {
+	switch r.Mode {
+	case "", ConfirmModeConfirmations:
+	case ConfirmModeFinalized, ConfirmModeSafe, ConfirmModeL1Batch:
+		if r.Confirmations != 0 {
+			return fmt.Errorf("confirm mode '%v' does not support 'Confirmations'", r.Mode)
+		}
+	default:
+		return fmt.Errorf("unknown confirm mode '%v'", r.Mode)
+	}
+	return nil
+}
+
+// CheckConfig check confirmation policy config
+func (c *ConfirmPolicyConfig) CheckConfig() error {
+	if err := c.ConfirmRule.CheckConfig(); err != nil {
+		return err
+	}
+	for tokenID, tiers := range c.ValueTiers {
+		for _, tier := range tiers {
+			if tier.MinValue <= 0 {
+				return fmt.Errorf("token %v confirm tier must config positive 'MinValue'", tokenID)
+			}
+			if err := tier.ConfirmRule.CheckConfig(); err != nil {
+				return fmt.Errorf("token %v confirm tier: %w", tokenID, err)
+			}
+		}
+		sort.Slice(tiers, func(i, j int) bool {
+			return tiers[i].MinValue < tiers[j].MinValue
+		})
+		for i := 1; i < len(tiers); i++ {
+			if tiers[i].MinValue == tiers[i-1].MinValue {
+				return fmt.Errorf("token %v has duplicate confirm tier of 'MinValue' %v", tokenID, tiers[i].MinValue)
+			}
+		}
+	}
 	return nil
 }
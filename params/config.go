@@ -3,6 +3,7 @@ package params
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"math/big"
 	"strings"
 	"sync"
@@ -15,6 +16,9 @@ import (
 // router swap constants
 const (
 	RouterSwapPrefixID = "routerswap"
+
+	// MaxBatchSwapSize max count of swaps aggregated into one tx
+	MaxBatchSwapSize = 50
 )
 
 // CustomizeConfigFunc customize config items
@@ -85,6 +89,12 @@ type RouterServerConfig struct {
 	MongoDB    *MongoDBConfig
 	APIServer  *APIServerConfig
 
+	AdminApproval map[string]*AdminApprovalConfig `toml:",omitempty" json:",omitempty"` // key is admin method
+	AdminRoles    map[string]*AdminRoleConfig     `toml:",omitempty" json:",omitempty"` // key is role name
+
+	LeaderElection *LeaderElectionConfig `toml:",omitempty" json:",omitempty"`
+	StuckSwap      *StuckSwapConfig      `toml:",omitempty" json:",omitempty"`
+
 	AutoSwapNonceEnabledChains []string `toml:",omitempty" json:",omitempty"`
 
 	// extras
@@ -111,6 +121,10 @@ type RouterServerConfig struct {
 	MaxTokenGasLimit map[string]map[string]uint64 `toml:",omitempty" json:",omitempty"` // key is tokenID,chainID
 
 	DynamicFeeTx map[string]*DynamicFeeTxConfig `toml:",omitempty" json:",omitempty"` // key is chain ID
+	BatchSwap    map[string]*BatchSwapConfig    `toml:",omitempty" json:",omitempty"` // key is chain ID
+	GasOracle    map[string]*GasOracleConfig    `toml:",omitempty" json:",omitempty"` // key is chain ID
+
+	LiquidityPolicy map[string]*LiquidityPolicyConfig `toml:",omitempty" json:",omitempty"` // key is tokenID
 }
 
 // RouterOracleConfig only for oracle
@@ -199,6 +213,9 @@ type ExtraConfig struct {
 	SpecialFlags map[string]string `toml:",omitempty" json:",omitempty"`
 
 	AttestationServer string `toml:",omitempty" json:",omitempty"`
+
+	// appID -> chainID -> config of delivering anycall messages to non-evm chain
+	AnycallApps map[string]map[string]*AnycallAppConfig `toml:",omitempty" json:",omitempty"`
 }
 
 // LocalChainConfig local chain config
@@ -216,11 +233,69 @@ type LocalChainConfig struct {
 	ChargeFeeOnDestChain   map[string][]string `toml:",omitempty" json:",omitempty"`
 	FeeReceiverOnDestChain string              `toml:",omitempty" json:",omitempty"`
 
+	ConfirmPolicy *ConfirmPolicyConfig `toml:",omitempty" json:",omitempty"`
+
+	// router contract supports `multicall(bytes[])`, required by batch swap of eth like chain
+	RouterSupportsMulticall bool `toml:",omitempty" json:",omitempty"`
+
+	// max priority fee of solana (micro-lamports per compute unit), checked by both server and oracles
+	MaxPriorityFee uint64 `toml:",omitempty" json:",omitempty"`
+
+	// anycall executor delivering messages to non-evm targets (solana program id or aptos module address)
+	AnyCallExecutor string `toml:",omitempty" json:",omitempty"`
+
 	forbidSwapoutTokenIDMap map[string]struct{}
 
 	lock *sync.Mutex
 }
 
+// confirm modes
+const (
+	ConfirmModeConfirmations = "confirmations"
+	ConfirmModeFinalized     = "finalized"
+	ConfirmModeSafe          = "safe"
+	ConfirmModeL1Batch       = "l1batch"
+)
+
+// ConfirmRule confirm rule
+// `Confirmations` is only used in confirmations mode, 0 means using chain config
+type ConfirmRule struct {
+	Mode          string `toml:",omitempty" json:",omitempty"` // confirmations (default), finalized, safe, l1batch
+	Confirmations uint64 `toml:",omitempty" json:",omitempty"`
+}
+
+// ConfirmTier confirm rule of swaps with value not less than `MinValue` (in token unit)
+type ConfirmTier struct {
+	ConfirmRule
+	MinValue float64
+}
+
+// ConfirmPolicyConfig confirmation policy of chain,
+// swaps must satisfy the base rule and the value tier matched (if any)
+type ConfirmPolicyConfig struct {
+	ConfirmRule
+	ValueTiers map[string][]*ConfirmTier `toml:",omitempty" json:",omitempty"` // key is tokenID
+}
+
+// GetValueTier get the value tier with the largest matched min value (nil if not matched)
+func (c *ConfirmPolicyConfig) GetValueTier(tokenID string, value *big.Int, decimals uint8) *ConfirmTier {
+	tiers := c.ValueTiers[tokenID]
+	if len(tiers) == 0 || value == nil {
+		return nil
+	}
+	fValue := new(big.Float).SetInt(value)
+	if decimals > 0 {
+		fValue.Quo(fValue, new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)))
+	}
+	// tiers are sorted by min value in ascending order when checking config
+	for i := len(tiers) - 1; i >= 0; i-- {
+		if fValue.Cmp(big.NewFloat(tiers[i].MinValue)) >= 0 {
+			return tiers[i]
+		}
+	}
+	return nil
+}
+
 // OnchainConfig struct
 type OnchainConfig struct {
 	Contract    string
@@ -239,6 +314,7 @@ type MPCConfig struct {
 	SignTimeout               uint64 `toml:",omitempty" json:",omitempty"`
 	MaxSignGroupFailures      int    `toml:",omitempty" json:",omitempty"`
 	MinIntervalToAddSignGroup int64  `toml:",omitempty" json:",omitempty"`
+	MaxSignsPerGroup          int    `toml:",omitempty" json:",omitempty"`
 
 	VerifySignatureInAccept bool `toml:",omitempty" json:",omitempty"`
 
@@ -281,6 +357,8 @@ type MongoDBConfig struct {
 	DBName   string
 	UserName string `json:"-"`
 	Password string `json:"-"`
+
+	CreateSearchIndexes bool `toml:",omitempty" json:",omitempty"` // create indexes used by swap search on startup
 }
 
 // DynamicFeeTxConfig dynamic fee tx config
@@ -306,6 +384,343 @@ func (c *DynamicFeeTxConfig) GetMaxGasFeeCap() *big.Int {
 	return c.maxGasFeeCap
 }
 
+// BatchSwapConfig batch swap config
+// aggregate pending swaps of the same token into one tx
+type BatchSwapConfig struct {
+	Window  int64 `toml:",omitempty" json:",omitempty"` // seconds to wait for more swaps
+	MaxSize int   `toml:",omitempty" json:",omitempty"` // max count of swaps in one tx
+}
+
+// admin methods (all support multi-admin approval and roles)
+var adminMethods = []string{
+	"maintain", "passbigvalue", "reswap", "replaceswap", "forbidswap", "passforbiddenswapout",
+}
+
+// maintain actions of admin method `maintain`
+var maintainActions = []string{
+	"pause", "unpause", "whitelist", "unwhitelist", "blacklist", "unblacklist",
+}
+
+// AdminRoleConfig admin role config, grant members the allowed methods in the scopes.
+// empty `MaintainActions` means all maintain actions are allowed (if `maintain` is allowed),
+// empty `ChainIDs` (`TokenIDs`) means no restriction of chainID (tokenID).
+// a role with scopes can not call method which affects all chains (eg. blacklist account).
+type AdminRoleConfig struct {
+	Members         []string
+	Methods         []string
+	MaintainActions []string `toml:",omitempty" json:",omitempty"`
+	ChainIDs        []string `toml:",omitempty" json:",omitempty"`
+	TokenIDs        []string `toml:",omitempty" json:",omitempty"`
+}
+
+// IsMember is member of role
+func (c *AdminRoleConfig) IsMember(account string) bool {
+	return containsFold(c.Members, account)
+}
+
+// IsScoped is role restricted to some chainIDs or tokenIDs
+func (c *AdminRoleConfig) IsScoped() bool {
+	return len(c.ChainIDs) != 0 || len(c.TokenIDs) != 0
+}
+
+// IsAllowed is role allowed to call `method` (with maintain `action`) on `chainIDs` and `tokenIDs`
+func (c *AdminRoleConfig) IsAllowed(method, action string, chainIDs, tokenIDs []string) bool {
+	if !containsFold(c.Methods, method) {
+		return false
+	}
+	if method == "maintain" && len(c.MaintainActions) != 0 && !containsFold(c.MaintainActions, action) {
+		return false
+	}
+	if !c.IsScoped() {
+		return true
+	}
+	// every restricted dimension must be scoped by the call
+	if len(c.ChainIDs) != 0 {
+		if len(chainIDs) == 0 {
+			return false
+		}
+		for _, chainID := range chainIDs {
+			if !containsFold(c.ChainIDs, chainID) {
+				return false
+			}
+		}
+	}
+	if len(c.TokenIDs) != 0 {
+		if len(tokenIDs) == 0 {
+			return false
+		}
+		for _, tokenID := range tokenIDs {
+			if !containsFold(c.TokenIDs, tokenID) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func containsFold(list []string, item string) bool {
+	for _, s := range list {
+		if strings.EqualFold(s, item) {
+			return true
+		}
+	}
+	return false
+}
+
+// AdminApprovalConfig multi-admin approval config of admin method
+// the method is executed after `Threshold` distinct admins approved within `ExpireSeconds`
+type AdminApprovalConfig struct {
+	Threshold     int
+	ExpireSeconds int64 `toml:",omitempty" json:",omitempty"` // default to 1 day
+}
+
+// GetExpireSeconds get expire seconds of proposal
+func (c *AdminApprovalConfig) GetExpireSeconds() int64 {
+	if c.ExpireSeconds > 0 {
+		return c.ExpireSeconds
+	}
+	return 86400
+}
+
+// LeaderElectionConfig leader election config of multiple router servers sharing the same mongodb.
+// only the leader which holds the lease runs the swap jobs.
+type LeaderElectionConfig struct {
+	Enable        bool
+	LeaseSeconds  int64 `toml:",omitempty" json:",omitempty"` // default to 30 seconds
+	RenewInterval int64 `toml:",omitempty" json:",omitempty"` // seconds, default to 1/3 of lease seconds
+}
+
+// GetLeaseSeconds get lease seconds
+func (c *LeaderElectionConfig) GetLeaseSeconds() int64 {
+	if c.LeaseSeconds > 0 {
+		return c.LeaseSeconds
+	}
+	return 30
+}
+
+// GetRenewInterval get renew interval seconds
+func (c *LeaderElectionConfig) GetRenewInterval() int64 {
+	if c.RenewInterval > 0 {
+		return c.RenewInterval
+	}
+	if interval := c.GetLeaseSeconds() / 3; interval > 0 {
+		return interval
+	}
+	return 1
+}
+
+// stuckSwapStatuses statuses checked by stuck swap detector
+var stuckSwapStatuses = []string{"TxNotStable", "TxNotSwapped", "MatchTxEmpty", "MatchTxNotStable"}
+
+// default max seconds of swap staying in status
+var defaultStuckSwapSLA = map[string]int64{
+	"TxNotStable":      1800,
+	"TxNotSwapped":     1800,
+	"MatchTxEmpty":     1800,
+	"MatchTxNotStable": 3600,
+}
+
+// StuckSwapConfig stuck swap detector config
+type StuckSwapConfig struct {
+	Enable           bool
+	CheckInterval    int64 `toml:",omitempty" json:",omitempty"` // seconds, default to 300
+	MaxLookBack      int64 `toml:",omitempty" json:",omitempty"` // seconds of swap age, default to 30 days
+	CriticalMultiple int64 `toml:",omitempty" json:",omitempty"` // escalate to critical if age exceeds SLA times it, default to 3
+
+	// key is status,chain pair (`fromChainID-toChainID`, `*` matches any chain), value is max seconds in status
+	SLA map[string]map[string]int64 `toml:",omitempty" json:",omitempty"`
+}
+
+// GetCheckInterval get check interval seconds
+func (c *StuckSwapConfig) GetCheckInterval() int64 {
+	if c.CheckInterval > 0 {
+		return c.CheckInterval
+	}
+	return 300
+}
+
+// GetMaxLookBack get max look back seconds
+func (c *StuckSwapConfig) GetMaxLookBack() int64 {
+	if c.MaxLookBack > 0 {
+		return c.MaxLookBack
+	}
+	return 30 * 24 * 3600
+}
+
+// GetCriticalMultiple get critical multiple of SLA
+func (c *StuckSwapConfig) GetCriticalMultiple() int64 {
+	if c.CriticalMultiple > 0 {
+		return c.CriticalMultiple
+	}
+	return 3
+}
+
+// GetSLA get max seconds of swap staying in status, the most specific chain pair is preferred
+func (c *StuckSwapConfig) GetSLA(status, fromChainID, toChainID string) int64 {
+	if slas := c.SLA[status]; len(slas) > 0 {
+		for _, pair := range []string{
+			fromChainID + "-" + toChainID,
+			fromChainID + "-*",
+			"*-" + toChainID,
+			"*-*",
+		} {
+			if sla, exist := slas[pair]; exist {
+				return sla
+			}
+		}
+	}
+	return defaultStuckSwapSLA[status]
+}
+
+// anycall message fields passed to the target on non-evm chain
+const (
+	AnycallArgCallFrom    = "callFrom"
+	AnycallArgFromChainID = "fromChainID"
+	AnycallArgSwapID      = "swapID"
+	AnycallArgAppID       = "appID"
+	AnycallArgNonce       = "nonce"
+	AnycallArgFlags       = "flags"
+	AnycallArgData        = "data"
+	AnycallArgExtData     = "extData"
+)
+
+// anycall data encodings
+const (
+	AnycallEncodingBytes = "bytes"
+	AnycallEncodingUTF8  = "utf8"
+	AnycallEncodingHex   = "hex"
+	AnycallEncodingRaw   = "raw" // call data is the encoded arguments as a whole
+)
+
+var (
+	anycallArgFields = []string{
+		AnycallArgCallFrom,
+		AnycallArgFromChainID,
+		AnycallArgSwapID,
+		AnycallArgAppID,
+		AnycallArgNonce,
+		AnycallArgFlags,
+		AnycallArgData,
+		AnycallArgExtData,
+	}
+
+	defaultAnycallArgs = []string{
+		AnycallArgCallFrom,
+		AnycallArgFromChainID,
+		AnycallArgSwapID,
+		AnycallArgData,
+	}
+)
+
+// AnycallAppConfig config of delivering anycall messages of app to non-evm chain.
+// `Args` are message fields passed to the target in order, in format of `field` or `name:field`.
+type AnycallAppConfig struct {
+	Target   string   // solana program id, aptos module address, near contract account or flow contract address
+	Function string   // solana anchor instruction name, aptos `module::function`, near method name or flow `Contract.method`
+	Args     []string `toml:",omitempty" json:",omitempty"` // default to callFrom, fromChainID, swapID, data
+	Encoding string   `toml:",omitempty" json:",omitempty"` // encoding of data and extData: bytes (default), utf8, hex or raw
+	Gas      uint64   `toml:",omitempty" json:",omitempty"` // near attached gas, aptos max gas amount or flow gas limit
+	TypeArgs []string `toml:",omitempty" json:",omitempty"` // aptos type arguments
+	Accounts []string `toml:",omitempty" json:",omitempty"` // solana accounts passed to the target, in format of `pubkey` or `pubkey:w` (writable)
+	Script   string   `toml:",omitempty" json:",omitempty"` // flow cadence script file used instead of the generated one
+}
+
+// AnycallAppArg message field passed to the target
+type AnycallAppArg struct {
+	Name  string
+	Field string
+}
+
+// GetArgs get message fields passed to the target in order
+func (c *AnycallAppConfig) GetArgs() []*AnycallAppArg {
+	args := c.Args
+	if len(args) == 0 {
+		args = defaultAnycallArgs
+	}
+	result := make([]*AnycallAppArg, len(args))
+	for i, arg := range args {
+		name, field := arg, arg
+		if pos := strings.Index(arg, ":"); pos >= 0 {
+			name, field = arg[:pos], arg[pos+1:]
+		}
+		result[i] = &AnycallAppArg{Name: name, Field: field}
+	}
+	return result
+}
+
+// GetEncoding get encoding of data and extData
+func (c *AnycallAppConfig) GetEncoding() string {
+	if c.Encoding == "" {
+		return AnycallEncodingBytes
+	}
+	return c.Encoding
+}
+
+// IsRawEncoding is call data the encoded arguments as a whole
+func (c *AnycallAppConfig) IsRawEncoding() bool {
+	return c.Encoding == AnycallEncodingRaw
+}
+
+// HasArgField is the message field passed to the target
+func (c *AnycallAppConfig) HasArgField(field string) bool {
+	if c.IsRawEncoding() {
+		return false
+	}
+	for _, arg := range c.GetArgs() {
+		if arg.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// liquidity policies
+const (
+	LiquidityPolicyDelay    = "delay"
+	LiquidityPolicyAnyToken = "anyToken"
+	LiquidityPolicySplit    = "split"
+)
+
+// LiquidityPolicyConfig liquidity policy config
+// decide how to pay when router lacks underlying liquidity on destination chain
+type LiquidityPolicyConfig struct {
+	Policy   string // delay, anyToken, split
+	MaxDelay int64  `toml:",omitempty" json:",omitempty"` // seconds to delay before paying anyToken, 0 means delay until liquidity arrives
+}
+
+// GasOracleConfig gas oracle config
+type GasOracleConfig struct {
+	CacheSeconds int64                  `toml:",omitempty" json:",omitempty"` // seconds to reuse the last estimate
+	Method       string                 `toml:",omitempty" json:",omitempty"` // how to combine sources, eg. median (default), max, min, first
+	BlockCount   int                    `toml:",omitempty" json:",omitempty"` // count of recent blocks to analyse, 0 to disable
+	Percentile   float64                `toml:",omitempty" json:",omitempty"` // percentile of recent fees, default 50
+	MinGasPrice  string                 `toml:",omitempty" json:",omitempty"`
+	MaxGasPrice  string                 `toml:",omitempty" json:",omitempty"`
+	HTTPSources  []*GasOracleHTTPSource `toml:",omitempty" json:",omitempty"`
+
+	// cached values
+	minGasPrice *big.Int
+	maxGasPrice *big.Int
+}
+
+// GasOracleHTTPSource external http gas oracle
+type GasOracleHTTPSource struct {
+	Name     string
+	URL      string
+	Field    string // dot separated path of gas price in the json response, eg. result.ProposeGasPrice
+	Decimals uint8  `toml:",omitempty" json:",omitempty"` // convert value to the smallest unit, eg. 9 for gwei
+}
+
+// GetMinGasPrice get min gas price bound
+func (c *GasOracleConfig) GetMinGasPrice() *big.Int {
+	return c.minGasPrice
+}
+
+// GetMaxGasPrice get max gas price bound
+func (c *GasOracleConfig) GetMaxGasPrice() *big.Int {
+	return c.maxGasPrice
+}
+
 // GetIdentifier get identifier (to distiguish in mpc accept)
 func GetIdentifier() string {
 	return GetRouterConfig().Identifier
@@ -388,6 +803,33 @@ func GetNoncePassedConfirmInterval(chainID string) int64 {
 	return 0
 }
 
+// GetBatchSwapConfig get batch swap config (nil if not enabled)
+func GetBatchSwapConfig(chainID string) *BatchSwapConfig {
+	serverCfg := GetRouterServerConfig()
+	if serverCfg == nil {
+		return nil
+	}
+	return serverCfg.BatchSwap[chainID]
+}
+
+// GetLiquidityPolicy get liquidity policy of token (nil if not configed)
+func GetLiquidityPolicy(tokenID string) *LiquidityPolicyConfig {
+	serverCfg := GetRouterServerConfig()
+	if serverCfg == nil {
+		return nil
+	}
+	return serverCfg.LiquidityPolicy[tokenID]
+}
+
+// GetGasOracleConfig get gas oracle config (nil if not configed)
+func GetGasOracleConfig(chainID string) *GasOracleConfig {
+	serverCfg := GetRouterServerConfig()
+	if serverCfg == nil {
+		return nil
+	}
+	return serverCfg.GasOracle[chainID]
+}
+
 // GetCalcGasPriceMethod get calc gas price method eg. median (default), first, max, etc.
 func GetCalcGasPriceMethod(chainID string) string {
 	serverCfg := GetRouterServerConfig()
@@ -767,6 +1209,16 @@ func GetLocalChainConfig(chainID string) *LocalChainConfig {
 	return &LocalChainConfig{}
 }
 
+// GetConfirmPolicy get confirmation policy of chain (nil if not configed)
+func GetConfirmPolicy(chainID string) *ConfirmPolicyConfig {
+	if GetExtraConfig() != nil {
+		if c := GetExtraConfig().LocalChainConfig[chainID]; c != nil {
+			return c.ConfirmPolicy
+		}
+	}
+	return nil
+}
+
 // GetSpecialFlag get special flag
 func GetSpecialFlag(key string) string {
 	if GetExtraConfig() != nil {
@@ -810,6 +1262,47 @@ func IsRouterAdmin(account string) bool {
 	return false
 }
 
+// GetAdminApprovalConfig get multi-admin approval config of admin method (nil if not configed)
+func GetAdminApprovalConfig(method string) *AdminApprovalConfig {
+	serverCfg := GetRouterServerConfig()
+	if serverCfg == nil {
+		return nil
+	}
+	return serverCfg.AdminApproval[method]
+}
+
+// GetLeaderElectionConfig get leader election config (nil if not enabled)
+func GetLeaderElectionConfig() *LeaderElectionConfig {
+	serverCfg := GetRouterServerConfig()
+	if serverCfg == nil || serverCfg.LeaderElection == nil || !serverCfg.LeaderElection.Enable {
+		return nil
+	}
+	return serverCfg.LeaderElection
+}
+
+// GetStuckSwapConfig get stuck swap detector config (nil if not enabled)
+func GetStuckSwapConfig() *StuckSwapConfig {
+	serverCfg := GetRouterServerConfig()
+	if serverCfg == nil || serverCfg.StuckSwap == nil || !serverCfg.StuckSwap.Enable {
+		return nil
+	}
+	return serverCfg.StuckSwap
+}
+
+// GetAdminRoles get admin roles which `account` is member of
+func GetAdminRoles(account string) (roles []*AdminRoleConfig) {
+	serverCfg := GetRouterServerConfig()
+	if serverCfg == nil {
+		return nil
+	}
+	for _, role := range serverCfg.AdminRoles {
+		if role.IsMember(account) {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
 // IsRouterAssistant is router assistants
 func IsRouterAssistant(account string) bool {
 	for _, assistant := range routerConfig.Server.Assistants {
@@ -1201,8 +1694,9 @@ func LoadRouterConfig(configFile string, isServer, check bool) *RouterConfig {
 	return routerConfig
 }
 
-// ReloadRouterConfig reload config
-func ReloadRouterConfig() {
+// ReloadRouterConfig reload config.
+// the whole reload is rejected if any change requires restarting program.
+func ReloadRouterConfig() (result *ConfigReloadResult) {
 	defer func() {
 		IsReload = false
 	}()
@@ -1213,10 +1707,13 @@ func ReloadRouterConfig() {
 
 	log.Info("reload router config file", "configFile", configFile, "isServer", isServer)
 
+	result = &ConfigReloadResult{Status: ConfigReloadFailed}
+
 	config := NewRouterConfig()
 	if _, err := toml.DecodeFile(configFile, &config); err != nil {
 		log.Errorf("ReloadRouterConfig error (toml DecodeFile): %v", err)
-		return
+		result.Message = fmt.Sprintf("decode config file failed: %v", err)
+		return result
 	}
 
 	if !isServer {
@@ -1238,12 +1735,49 @@ func ReloadRouterConfig() {
 		}
 	}
 
+	oldConfig := routerConfig
+
 	if err := config.CheckConfig(isServer); err != nil {
 		log.Errorf("ReloadRouterConfig check config failed. %v", err)
-		return
+		result.Message = fmt.Sprintf("check config failed: %v", err)
+		restoreRouterConfig(oldConfig, isServer)
+		return result
+	}
+
+	changes, err := DiffRouterConfig(oldConfig, config)
+	if err != nil {
+		log.Errorf("ReloadRouterConfig diff config failed. %v", err)
+		result.Message = fmt.Sprintf("diff config failed: %v", err)
+		restoreRouterConfig(oldConfig, isServer)
+		return result
+	}
+	result.Changes = changes
+	if len(changes) == 0 {
+		log.Info("ReloadRouterConfig finished with config unchanged")
+		result.Status = ConfigReloadUnchanged
+		return result
+	}
+
+	restartRequired := make([]string, 0)
+	for _, change := range changes {
+		if IsRestartRequiredConfigPath(change.Key) {
+			restartRequired = append(restartRequired, change.Key)
+		}
+	}
+	if len(restartRequired) > 0 {
+		log.Error("ReloadRouterConfig rejected as changes require restart", "paths", restartRequired)
+		result.Status = ConfigReloadRejected
+		result.Message = fmt.Sprintf("changes of %v require restart", strings.Join(restartRequired, ", "))
+		restoreRouterConfig(oldConfig, isServer)
+		return result
 	}
 
 	routerConfig = config
+	result.Status = ConfigReloadApplied
+
+	for _, change := range changes {
+		log.Info("ReloadRouterConfig apply change", "change", change.String())
+	}
 
 	var bs []byte
 	if log.JSONFormat {
@@ -1252,6 +1786,15 @@ func ReloadRouterConfig() {
 		bs, _ = json.MarshalIndent(config, "", "  ")
 	}
 	log.Println("ReloadRouterConfig finished.", string(bs))
+	return result
+}
+
+// restoreRouterConfig check old config again to restore the derived states
+// which may be changed in checking the rejected new config
+func restoreRouterConfig(oldConfig *RouterConfig, isServer bool) {
+	if err := oldConfig.CheckConfig(isServer); err != nil {
+		log.Error("ReloadRouterConfig restore old config failed", "err", err)
+	}
 }
 
 // LoadGatewayConfigs load gateway configs
@@ -1333,6 +1876,14 @@ func ChargeFeeOnDestChain(tokenID, fromChainID, toChainID string) bool {
 	return false
 }
 
+// GetAnycallAppConfig get config of delivering anycall messages of app to non-evm chain (nil if not configed)
+func GetAnycallAppConfig(appID, chainID string) *AnycallAppConfig {
+	if GetExtraConfig() != nil {
+		return GetExtraConfig().AnycallApps[appID][chainID]
+	}
+	return nil
+}
+
 // GetAttestationServer get attestation server
 func GetAttestationServer() string {
 	if GetExtraConfig() != nil {
@@ -0,0 +1,65 @@
+package params
+
+import "testing"
+
+func TestStuckSwapSLA(t *testing.T) {
+	c := &StuckSwapConfig{
+		SLA: map[string]map[string]int64{
+			"MatchTxNotStable": {
+				"*-*":  600,
+				"1-*":  500,
+				"*-56": 400,
+				"1-56": 300,
+			},
+		},
+	}
+	tests := []struct {
+		status, from, to string
+		want             int64
+	}{
+		{"MatchTxNotStable", "1", "56", 300},
+		{"MatchTxNotStable", "1", "137", 500},
+		{"MatchTxNotStable", "137", "56", 400},
+		{"MatchTxNotStable", "137", "250", 600},
+		{"TxNotSwapped", "1", "56", 1800},
+		{"MatchTxFailed", "1", "56", 0},
+	}
+	for i, tt := range tests {
+		if got := c.GetSLA(tt.status, tt.from, tt.to); got != tt.want {
+			t.Errorf("test %v: got %v, want %v", i, got, tt.want)
+		}
+	}
+}
+
+func TestAnycallAppConfig(t *testing.T) {
+	tests := []struct {
+		cfg     *AnycallAppConfig
+		wantErr bool
+	}{
+		{&AnycallAppConfig{Target: "app.near", Function: "exec"}, false},
+		{&AnycallAppConfig{Target: "app.near", Function: "exec", Args: []string{"from:callFrom", "msg:data"}, Encoding: "utf8"}, false},
+		{&AnycallAppConfig{Target: "app.near", Function: "exec", Encoding: "raw"}, false},
+		{&AnycallAppConfig{Function: "exec"}, true},
+		{&AnycallAppConfig{Target: "app.near"}, true},
+		{&AnycallAppConfig{Target: "app.near", Function: "exec", Encoding: "base58"}, true},
+		{&AnycallAppConfig{Target: "app.near", Function: "exec", Encoding: "raw", Args: []string{"data"}}, true},
+		{&AnycallAppConfig{Target: "app.near", Function: "exec", Args: []string{"value"}}, true},
+		{&AnycallAppConfig{Target: "app.near", Function: "exec", Args: []string{"a:data", "a:extData"}}, true},
+		{&AnycallAppConfig{Target: "app.near", Function: "exec", Args: []string{":data"}}, true},
+		{&AnycallAppConfig{Target: "prog", Function: "exec", Accounts: []string{"acc1", "acc2:w"}}, false},
+		{&AnycallAppConfig{Target: "prog", Function: "exec", Accounts: []string{"acc1:r"}}, true},
+	}
+	for i, tt := range tests {
+		if err := tt.cfg.CheckConfig(); (err != nil) != tt.wantErr {
+			t.Errorf("test %v: got err %v, want err %v", i, err, tt.wantErr)
+		}
+	}
+
+	args := (&AnycallAppConfig{Args: []string{"from:callFrom", "data"}}).GetArgs()
+	if len(args) != 2 || args[0].Name != "from" || args[0].Field != "callFrom" || args[1].Name != "data" || args[1].Field != "data" {
+		t.Errorf("wrong args %v %v", args[0], args[1])
+	}
+	if args = (&AnycallAppConfig{}).GetArgs(); len(args) != len(defaultAnycallArgs) {
+		t.Errorf("wrong default args count %v", len(args))
+	}
+}
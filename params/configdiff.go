@@ -0,0 +1,150 @@
+package params
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// config reload status
+const (
+	ConfigReloadApplied   = "applied"
+	ConfigReloadRejected  = "rejected"
+	ConfigReloadFailed    = "failed"
+	ConfigReloadUnchanged = "unchanged"
+)
+
+// config reload source
+const (
+	ConfigSourceLocal   = "local"
+	ConfigSourceOnchain = "onchain"
+)
+
+// restartRequiredConfigPaths changes of these config paths (and their sub paths)
+// can not be applied by reloading, they are only used when starting program.
+var restartRequiredConfigPaths = []string{
+	"Identifier",
+	"SwapType",
+	"SwapSubType",
+	"MPC",
+	"FastMPC",
+	"Onchain.Contract",
+	"Onchain.APIAddress",
+	"Onchain.WSServers",
+	"Onchain.ReloadCycle",
+	"Oracle.ServerAPIAddress",
+	"Server.MongoDB",
+	"Server.APIServer",
+	"Server.LeaderElection",
+	"Server.EnableReplaceSwap",
+	"Server.EnablePassBigValueSwap",
+}
+
+// ConfigDiff difference of config item, empty `Old` means added, empty `New` means removed
+type ConfigDiff struct {
+	Key string `json:"key"`
+	Old string `json:"old,omitempty"`
+	New string `json:"new,omitempty"`
+}
+
+// String implements fmt.Stringer
+func (d *ConfigDiff) String() string {
+	switch {
+	case d.Old == "":
+		return fmt.Sprintf("+ %v: %v", d.Key, d.New)
+	case d.New == "":
+		return fmt.Sprintf("- %v: %v", d.Key, d.Old)
+	default:
+		return fmt.Sprintf("~ %v: %v => %v", d.Key, d.Old, d.New)
+	}
+}
+
+// ConfigReloadResult result of reloading local config
+type ConfigReloadResult struct {
+	Status  string
+	Changes []*ConfigDiff
+	Message string
+}
+
+// DiffConfigItems compare flattened config items, returns diffs sorted by key
+func DiffConfigItems(oldItems, newItems map[string]string) []*ConfigDiff {
+	diffs := make([]*ConfigDiff, 0)
+	for key, oldValue := range oldItems {
+		newValue := newItems[key]
+		if newValue != oldValue {
+			diffs = append(diffs, &ConfigDiff{Key: key, Old: oldValue, New: newValue})
+		}
+	}
+	for key, newValue := range newItems {
+		if _, exist := oldItems[key]; !exist {
+			diffs = append(diffs, &ConfigDiff{Key: key, New: newValue})
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Key < diffs[j].Key
+	})
+	return diffs
+}
+
+// DiffRouterConfig compare router configs, the keys are dot separated config paths.
+// secret fields (eg. password) are excluded as they are not json marshaled.
+func DiffRouterConfig(oldConfig, newConfig *RouterConfig) ([]*ConfigDiff, error) {
+	oldItems, err := flattenConfig(oldConfig)
+	if err != nil {
+		return nil, err
+	}
+	newItems, err := flattenConfig(newConfig)
+	if err != nil {
+		return nil, err
+	}
+	return DiffConfigItems(oldItems, newItems), nil
+}
+
+// IsRestartRequiredConfigPath is change of config path require restarting program
+func IsRestartRequiredConfigPath(path string) bool {
+	for _, prefix := range restartRequiredConfigPaths {
+		if path == prefix || strings.HasPrefix(path, prefix+".") {
+			return true
+		}
+	}
+	return false
+}
+
+func flattenConfig(config *RouterConfig) (map[string]string, error) {
+	items := make(map[string]string)
+	if config == nil {
+		return items, nil
+	}
+	data, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	if err = json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	flattenConfigValue(items, "", value)
+	return items, nil
+}
+
+// flattenConfigValue recurse into objects, and treat arrays as a whole value
+func flattenConfigValue(items map[string]string, path string, value interface{}) {
+	switch v := value.(type) {
+	case nil:
+		return
+	case map[string]interface{}:
+		for key, subValue := range v {
+			subPath := key
+			if path != "" {
+				subPath = path + "." + key
+			}
+			flattenConfigValue(items, subPath, subValue)
+		}
+	case string:
+		items[path] = v
+	default:
+		data, _ := json.Marshal(v)
+		items[path] = string(data)
+	}
+}
@@ -0,0 +1,53 @@
+package params
+
+import "testing"
+
+func TestDiffRouterConfig(t *testing.T) {
+	oldConfig := &RouterConfig{
+		Identifier: "routerswap",
+		Server: &RouterServerConfig{
+			MongoDB:     &MongoDBConfig{DBName: "router", Password: "secret"},
+			MaxGasPrice: map[string]string{"1": "100"},
+		},
+		GatewayConfigs: &GatewayConfigs{Gateways: map[string][]string{"1": {"http://a"}}},
+	}
+	newConfig := &RouterConfig{
+		Identifier: "routerswap",
+		Server: &RouterServerConfig{
+			MongoDB:     &MongoDBConfig{DBName: "router", Password: "changed"},
+			MaxGasPrice: map[string]string{"1": "200", "56": "10"},
+		},
+		GatewayConfigs: &GatewayConfigs{Gateways: map[string][]string{"1": {"http://a"}}},
+	}
+	changes, err := DiffRouterConfig(oldConfig, newConfig)
+	if err != nil {
+		t.Fatalf("diff config failed: %v", err)
+	}
+	want := []ConfigDiff{
+		{Key: "Server.MaxGasPrice.1", Old: "100", New: "200"},
+		{Key: "Server.MaxGasPrice.56", New: "10"},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("got %v changes, want %v: %v", len(changes), len(want), changes)
+	}
+	for i, change := range changes {
+		if *change != want[i] {
+			t.Errorf("change %v: got %v, want %v", i, change, want[i])
+		}
+	}
+
+	newConfig.Server.MongoDB.DBName = "router2"
+	changes, _ = DiffRouterConfig(oldConfig, newConfig)
+	restartRequired := 0
+	for _, change := range changes {
+		if IsRestartRequiredConfigPath(change.Key) {
+			restartRequired++
+		}
+	}
+	if restartRequired != 1 {
+		t.Errorf("got %v restart required changes, want 1: %v", restartRequired, changes)
+	}
+	if IsRestartRequiredConfigPath("Server.MongoDBExtra") || !IsRestartRequiredConfigPath("MPC.GroupID") {
+		t.Errorf("check restart required config path failed")
+	}
+}
@@ -140,7 +140,7 @@ func InitRouterBridges(isServer bool) {
 	}
 	wg.Wait()
 
-	initSwapNonces()
+	InitSwapNonces()
 
 	router.AllChainIDs = chainIDs
 	router.AllTokenIDs = tokenIDs
@@ -387,6 +387,10 @@ func InitChainConfig(b tokens.IBridge, chainID *big.Int) {
 		logErrFunc("check chain config failed", "chainID", chainID, "err", err)
 		return
 	}
+	if err = tokens.CheckConfirmPolicySupport(b, chainCfg.ChainID); err != nil {
+		logErrFunc("check confirm policy failed", "chainID", chainID, "err", err)
+		return
+	}
 	b.SetChainConfig(chainCfg)
 	log.Info("init chain config success", "blockChain", chainCfg.BlockChain, "chainID", chainID, "isReload", isReload, "chainCfg", chainCfg)
 
@@ -460,7 +464,8 @@ func InitTokenConfig(b tokens.IBridge, tokenID string, chainID *big.Int) {
 	}
 }
 
-func initSwapNonces() {
+// InitSwapNonces init swap nonces of router mpc from database and onchain pending nonces
+func InitSwapNonces() {
 	if !mongodb.HasClient() {
 		return
 	}
@@ -1,6 +1,8 @@
 package bridge
 
 import (
+	"encoding/json"
+	"fmt"
 	"math/big"
 	"os"
 	"os/signal"
@@ -16,6 +18,15 @@ import (
 
 var reloadRouterConfigLock sync.Mutex
 
+// AfterReloadRouterConfig is called after reloading local config,
+// eg. to reapply the persisted maintenance state.
+var AfterReloadRouterConfig func()
+
+// OnRouterConfigChanged is called when reloading router config with changes,
+// `source` is `params.ConfigSourceLocal` or `params.ConfigSourceOnchain`,
+// eg. to record the changes into audit collection.
+var OnRouterConfigChanged func(source string, result *params.ConfigReloadResult)
+
 // StartReloadRouterConfigTask start reload config
 func StartReloadRouterConfigTask() {
 	// method 1: use web socket event subscriber
@@ -80,7 +91,16 @@ func ReloadRouterConfig() (success bool) {
 	}()
 
 	// reload local config
-	params.ReloadRouterConfig()
+	localResult := params.ReloadRouterConfig()
+	if localResult.Status != params.ConfigReloadUnchanged {
+		log.Info("[reload] reload local config", "status", localResult.Status, "changes", len(localResult.Changes), "message", localResult.Message)
+		notifyRouterConfigChanged(params.ConfigSourceLocal, localResult)
+	}
+	if AfterReloadRouterConfig != nil {
+		AfterReloadRouterConfig()
+	}
+
+	oldLoadedConfigs := flattenLoadedConfigs()
 
 	allChainIDs, err := router.GetAllChainIDs()
 	if err != nil {
@@ -228,6 +248,63 @@ func ReloadRouterConfig() (success bool) {
 		router.SetBridge(chainID, nil)
 	}
 
+	if changes := params.DiffConfigItems(oldLoadedConfigs, flattenLoadedConfigs()); len(changes) > 0 {
+		for _, change := range changes {
+			log.Info("[reload] onchain config changed", "change", change.String())
+		}
+		notifyRouterConfigChanged(params.ConfigSourceOnchain, &params.ConfigReloadResult{
+			Status:  params.ConfigReloadApplied,
+			Changes: changes,
+		})
+	}
+
 	success = true
 	return success
 }
+
+func notifyRouterConfigChanged(source string, result *params.ConfigReloadResult) {
+	if OnRouterConfigChanged != nil {
+		OnRouterConfigChanged(source, result)
+	}
+}
+
+// flattenLoadedConfigs flatten the loaded onchain configs to key value pairs
+func flattenLoadedConfigs() map[string]string {
+	toJSONString := func(v interface{}) string {
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+	items := make(map[string]string)
+	for _, tokenID := range router.AllTokenIDs {
+		items["tokenID/"+tokenID] = "exist"
+	}
+	for _, chainID := range router.AllChainIDs {
+		cid := chainID.String()
+		items["chainID/"+cid] = "exist"
+		bridge := router.GetBridgeByChainID(cid)
+		if bridge == nil {
+			continue
+		}
+		if cfg := bridge.GetChainConfig(); cfg != nil {
+			items["chain/"+cid] = toJSONString(cfg)
+		}
+		for _, tokenID := range router.AllTokenIDs {
+			tokenAddr := router.GetCachedMultichainToken(tokenID, cid)
+			if tokenAddr == "" {
+				continue
+			}
+			if cfg := bridge.GetTokenConfig(tokenAddr); cfg != nil {
+				items[fmt.Sprintf("token/%v/%v", tokenID, cid)] = toJSONString(cfg)
+			}
+		}
+	}
+	tokens.RangeSwapConfigs(func(tokenID, fromChainID, toChainID string, cfg *tokens.SwapConfig) {
+		key := fmt.Sprintf("swap/%v/%v/%v", tokenID, fromChainID, toChainID)
+		items[key] = fmt.Sprintf("max=%v min=%v bigValue=%v", cfg.MaximumSwap, cfg.MinimumSwap, cfg.BigValueThreshold)
+	})
+	tokens.RangeFeeConfigs(func(tokenID, fromChainID, toChainID string, cfg *tokens.FeeConfig) {
+		key := fmt.Sprintf("fee/%v/%v/%v", tokenID, fromChainID, toChainID)
+		items[key] = fmt.Sprintf("max=%v min=%v rate=%v", cfg.MaximumSwapFee, cfg.MinimumSwapFee, cfg.SwapFeeRatePerMillion)
+	})
+	return items
+}
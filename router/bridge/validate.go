@@ -0,0 +1,303 @@
+package bridge
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/anyswap/CrossChain-Router/v3/log"
+	"github.com/anyswap/CrossChain-Router/v3/params"
+	"github.com/anyswap/CrossChain-Router/v3/router"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+)
+
+// ConfigProblem problem found in validating config
+type ConfigProblem struct {
+	Scope   string
+	Message string
+}
+
+// String implements fmt.Stringer
+func (p *ConfigProblem) String() string {
+	return fmt.Sprintf("[%v] %v", p.Scope, p.Message)
+}
+
+type configValidator struct {
+	lock     sync.Mutex
+	problems []*ConfigProblem
+	bridges  map[string]tokens.IBridge // key is chainID, usable bridges only
+}
+
+func (v *configValidator) report(scope, format string, args ...interface{}) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	v.problems = append(v.problems, &ConfigProblem{Scope: scope, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *configValidator) setBridge(chainID string, bridge tokens.IBridge) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	v.bridges[chainID] = bridge
+}
+
+// ValidateRouterConfig validate the loaded local config and all onchain configs.
+// it does not stop at the first problem, and returns all problems found sorted by scope.
+func ValidateRouterConfig(isServer bool) []*ConfigProblem {
+	v := &configValidator{bridges: make(map[string]tokens.IBridge)}
+
+	config := params.GetRouterConfig()
+	if config.Extra == nil {
+		config.Extra = &params.ExtraConfig{}
+	}
+	// report errors instead of panic in loading
+	config.Extra.DontPanicInInitRouter = true
+
+	for _, err := range config.CheckConfigAll(isServer) {
+		v.report("config", "%v", err)
+	}
+	if onchain := config.Onchain; onchain == nil || onchain.Contract == "" || len(onchain.APIAddress) == 0 {
+		v.report("onchain", "skip validating onchain configs as missing 'Onchain' contract or gateways")
+		return v.sortedProblems()
+	}
+
+	router.InitRouterConfigClients()
+	v.validateOnchainConfigs()
+	v.validateFeeReceivers()
+	return v.sortedProblems()
+}
+
+func (v *configValidator) sortedProblems() []*ConfigProblem {
+	sort.SliceStable(v.problems, func(i, j int) bool {
+		return v.problems[i].Scope < v.problems[j].Scope
+	})
+	return v.problems
+}
+
+func (v *configValidator) validateOnchainConfigs() {
+	allChainIDs, err := router.GetAllChainIDs()
+	if err != nil {
+		v.report("onchain", "get all chainIDs failed: %v", err)
+		return
+	}
+	chainIDs := make([]*big.Int, 0, len(allChainIDs))
+	chainIDMap := make(map[string]struct{}, len(allChainIDs))
+	for _, chainID := range allChainIDs {
+		chainIDMap[chainID.String()] = struct{}{}
+		if !params.IsChainIDInBlackList(chainID.String()) {
+			chainIDs = append(chainIDs, chainID)
+		}
+	}
+	if len(chainIDs) == 0 {
+		v.report("onchain", "empty chainIDs")
+	}
+
+	allTokenIDs, err := router.GetAllTokenIDs()
+	if err != nil {
+		v.report("onchain", "get all tokenIDs failed: %v", err)
+		return
+	}
+	tokenIDs := make([]string, 0, len(allTokenIDs))
+	for _, tokenID := range allTokenIDs {
+		if !params.IsTokenIDInBlackList(tokenID) {
+			tokenIDs = append(tokenIDs, tokenID)
+		}
+	}
+	if len(tokenIDs) == 0 && !tokens.IsAnyCallRouter() {
+		v.report("onchain", "empty tokenIDs")
+	}
+
+	for chainID := range params.GetRouterConfig().Gateways {
+		if _, exist := chainIDMap[chainID]; !exist {
+			v.report("chain "+chainID, "gateways are configed but chainID is not in onchain config")
+		}
+	}
+
+	wg := new(sync.WaitGroup)
+	wg.Add(len(chainIDs))
+	for _, chainID := range chainIDs {
+		go func(chainID *big.Int) {
+			defer wg.Done()
+			v.validateChain(chainID, tokenIDs)
+		}(chainID)
+	}
+	wg.Wait()
+
+	if tokens.IsERC20Router() {
+		for _, tokenID := range tokenIDs {
+			v.validateSwapAndFeeConfigs(tokenID, chainIDMap)
+		}
+	}
+}
+
+//nolint:funlen // validate all items of chain
+func (v *configValidator) validateChain(chainID *big.Int, tokenIDs []string) {
+	cid := chainID.String()
+	scope := "chain " + cid
+
+	chainCfg, err := router.GetChainConfig(chainID)
+	if err != nil {
+		v.report(scope, "get chain config failed: %v", err)
+		return
+	}
+	if chainCfg.ChainID != cid {
+		v.report(scope, "chain ID mismatch, in config %v", chainCfg.ChainID)
+		return
+	}
+	if err = chainCfg.CheckConfig(); err != nil {
+		v.report(scope, "check chain config failed: %v", err)
+		return
+	}
+
+	bridge := NewCrossChainBridge(chainID)
+	SetGatewayConfig(bridge, cid)
+	if bridge.GetGatewayConfig().IsEmpty() {
+		v.report(scope, "missing gateways")
+		return
+	}
+	bridge.SetChainConfig(chainCfg)
+	bridge.InitAfterConfig()
+
+	if _, err = bridge.GetLatestBlockNumber(); err != nil {
+		v.report(scope, "get latest block number failed: %v", err)
+		return
+	}
+
+	routerContracts := make(map[string]struct{})
+	initRouterInfo := func(scope, routerContract, routerVersion string) {
+		if routerContract == "" {
+			return
+		}
+		if _, exist := routerContracts[strings.ToLower(routerContract)]; exist {
+			return
+		}
+		routerContracts[strings.ToLower(routerContract)] = struct{}{}
+		// check router mpc address and its public key from `GetMPCPubkey`
+		if err := bridge.InitRouterInfo(routerContract, routerVersion); err != nil {
+			v.report(scope, "init router info of %v failed: %v", routerContract, err)
+		}
+	}
+	initRouterInfo(scope, chainCfg.RouterContract, chainCfg.RouterVersion)
+	v.setBridge(cid, bridge)
+
+	for _, tokenID := range tokenIDs {
+		if params.IsTokenIDInBlackListOnChain(cid, tokenID) {
+			continue
+		}
+		tokenScope := fmt.Sprintf("token %v on chain %v", tokenID, cid)
+		tokenAddr, err := router.GetMultichainToken(tokenID, chainID)
+		if err != nil {
+			v.report(tokenScope, "get token address failed: %v", err)
+			continue
+		}
+		if tokenAddr == "" {
+			continue
+		}
+		tokenCfg, err := router.GetTokenConfig(chainID, tokenID)
+		if err != nil {
+			v.report(tokenScope, "get token config failed: %v", err)
+			continue
+		}
+		if !strings.EqualFold(tokenAddr, tokenCfg.ContractAddress) {
+			v.report(tokenScope, "token address mismatch, multichain token is %v, token config is %v", tokenAddr, tokenCfg.ContractAddress)
+			continue
+		}
+		if err = tokenCfg.CheckConfig(); err != nil {
+			v.report(tokenScope, "check token config failed: %v", err)
+			continue
+		}
+		if !bridge.IsValidAddress(tokenAddr) {
+			v.report(tokenScope, "wrong token address %v", tokenAddr)
+			continue
+		}
+		initRouterInfo(tokenScope, tokenCfg.RouterContract, tokenCfg.RouterVersion)
+		if checker, ok := bridge.(tokens.TokenConfigChecker); ok {
+			if err = checker.CheckTokenConfig(tokenCfg); err != nil {
+				v.report(tokenScope, "check token contract failed: %v", err)
+			}
+		}
+	}
+	log.Info("validate chain finished", "chainID", cid)
+}
+
+func (v *configValidator) validateSwapAndFeeConfigs(tokenID string, chainIDMap map[string]struct{}) {
+	swapCfgs, err := router.GetSwapConfigs(tokenID)
+	if err != nil {
+		v.report("token "+tokenID, "get swap configs failed: %v", err)
+	}
+	v.validateSwapConfigs(tokenID, swapCfgs, chainIDMap)
+
+	feeCfgs, err := router.GetFeeConfigs(tokenID)
+	if err != nil {
+		v.report("token "+tokenID, "get fee configs failed: %v", err)
+	}
+	v.validateFeeConfigs(tokenID, feeCfgs, chainIDMap)
+}
+
+func isKnownChainID(chainID *big.Int, chainIDMap map[string]struct{}) bool {
+	if chainID == nil {
+		return false
+	}
+	if chainID.Sign() == 0 {
+		return true
+	}
+	_, exist := chainIDMap[chainID.String()]
+	return exist
+}
+
+func (v *configValidator) validateSwapConfigs(tokenID string, swapCfgs []router.SwapConfigInContract, chainIDMap map[string]struct{}) {
+	for _, cfg := range swapCfgs {
+		scope := fmt.Sprintf("swap config of %v from %v to %v", tokenID, cfg.FromChainID, cfg.ToChainID)
+		if !isKnownChainID(cfg.FromChainID, chainIDMap) || !isKnownChainID(cfg.ToChainID, chainIDMap) {
+			v.report(scope, "unknown chainID")
+		}
+		if cfg.MinimumSwap != nil && cfg.MaximumSwap != nil && cfg.MinimumSwap.Cmp(cfg.MaximumSwap) > 0 {
+			v.report(scope, "MinimumSwap %v > MaximumSwap %v", cfg.MinimumSwap, cfg.MaximumSwap)
+			continue
+		}
+		swapCfg := &tokens.SwapConfig{
+			MaximumSwap:       cfg.MaximumSwap,
+			MinimumSwap:       cfg.MinimumSwap,
+			BigValueThreshold: cfg.BigValueThreshold,
+		}
+		if err := swapCfg.CheckConfig(); err != nil {
+			v.report(scope, "%v", err)
+		}
+	}
+}
+
+func (v *configValidator) validateFeeConfigs(tokenID string, feeCfgs []router.FeeConfigInContract, chainIDMap map[string]struct{}) {
+	for _, cfg := range feeCfgs {
+		scope := fmt.Sprintf("fee config of %v from %v to %v", tokenID, cfg.FromChainID, cfg.ToChainID)
+		if !isKnownChainID(cfg.FromChainID, chainIDMap) || !isKnownChainID(cfg.ToChainID, chainIDMap) {
+			v.report(scope, "unknown chainID")
+		}
+		feeCfg := &tokens.FeeConfig{
+			MaximumSwapFee:        cfg.MaximumSwapFee,
+			MinimumSwapFee:        cfg.MinimumSwapFee,
+			SwapFeeRatePerMillion: cfg.SwapFeeRatePerMillion,
+		}
+		if err := feeCfg.CheckConfig(); err != nil {
+			v.report(scope, "%v", err)
+		}
+	}
+}
+
+// validateFeeReceivers check fee receivers of charging fee on dest chain
+func (v *configValidator) validateFeeReceivers() {
+	extra := params.GetExtraConfig()
+	for chainID, c := range extra.LocalChainConfig {
+		if c == nil || len(c.ChargeFeeOnDestChain) == 0 {
+			continue
+		}
+		scope := "chain " + chainID
+		if c.FeeReceiverOnDestChain == "" {
+			v.report(scope, "'ChargeFeeOnDestChain' is configed but missing 'FeeReceiverOnDestChain'")
+			continue
+		}
+		if bridge := v.bridges[chainID]; bridge != nil && !bridge.IsValidAddress(c.FeeReceiverOnDestChain) {
+			v.report(scope, "wrong 'FeeReceiverOnDestChain' address %v", c.FeeReceiverOnDestChain)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package bridge
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Router/v3/params"
+	"github.com/anyswap/CrossChain-Router/v3/router"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+)
+
+type testAddressBridge struct {
+	tokens.IBridge // not implemented methods panic
+}
+
+func (b *testAddressBridge) IsValidAddress(address string) bool {
+	return strings.HasPrefix(address, "0x")
+}
+
+func newTestValidator() *configValidator {
+	return &configValidator{bridges: make(map[string]tokens.IBridge)}
+}
+
+func problemMessages(problems []*ConfigProblem) []string {
+	msgs := make([]string, len(problems))
+	for i, p := range problems {
+		msgs[i] = p.String()
+	}
+	return msgs
+}
+
+func checkProblems(t *testing.T, name string, got []*ConfigProblem, want []string) {
+	msgs := problemMessages(got)
+	if len(msgs) != len(want) {
+		t.Errorf("test %v: got %v problems, want %v: %v", name, len(msgs), len(want), msgs)
+		return
+	}
+	for i, msg := range msgs {
+		if msg != want[i] {
+			t.Errorf("test %v: problem %v got %q, want %q", name, i, msg, want[i])
+		}
+	}
+}
+
+func TestValidateSwapConfigs(t *testing.T) {
+	chainIDMap := map[string]struct{}{"1": {}, "56": {}}
+	tests := []struct {
+		name string
+		cfg  router.SwapConfigInContract
+		want []string
+	}{
+		{
+			name: "valid",
+			cfg:  router.SwapConfigInContract{FromChainID: big.NewInt(1), ToChainID: big.NewInt(56), MaximumSwap: big.NewInt(100), MinimumSwap: big.NewInt(1), BigValueThreshold: big.NewInt(50)},
+		},
+		{
+			name: "default chainIDs",
+			cfg:  router.SwapConfigInContract{FromChainID: big.NewInt(0), ToChainID: big.NewInt(0), MaximumSwap: big.NewInt(100), MinimumSwap: big.NewInt(1), BigValueThreshold: big.NewInt(50)},
+		},
+		{
+			name: "unknown chainID",
+			cfg:  router.SwapConfigInContract{FromChainID: big.NewInt(1), ToChainID: big.NewInt(137), MaximumSwap: big.NewInt(100), MinimumSwap: big.NewInt(1), BigValueThreshold: big.NewInt(50)},
+			want: []string{"[swap config of tk from 1 to 137] unknown chainID"},
+		},
+		{
+			name: "minimum greater than maximum",
+			cfg:  router.SwapConfigInContract{FromChainID: big.NewInt(1), ToChainID: big.NewInt(56), MaximumSwap: big.NewInt(10), MinimumSwap: big.NewInt(20), BigValueThreshold: big.NewInt(5)},
+			want: []string{"[swap config of tk from 1 to 56] MinimumSwap 20 > MaximumSwap 10"},
+		},
+		{
+			name: "big value threshold greater than maximum",
+			cfg:  router.SwapConfigInContract{FromChainID: big.NewInt(1), ToChainID: big.NewInt(56), MaximumSwap: big.NewInt(10), MinimumSwap: big.NewInt(1), BigValueThreshold: big.NewInt(50)},
+			want: []string{"[swap config of tk from 1 to 56] wrong token config, BigValueThreshold > MaximumSwap"},
+		},
+		{
+			name: "missing maximum",
+			cfg:  router.SwapConfigInContract{FromChainID: big.NewInt(1), ToChainID: big.NewInt(56), MinimumSwap: big.NewInt(1), BigValueThreshold: big.NewInt(50)},
+			want: []string{"[swap config of tk from 1 to 56] token must config 'MaximumSwap' (positive)"},
+		},
+	}
+	for _, test := range tests {
+		v := newTestValidator()
+		v.validateSwapConfigs("tk", []router.SwapConfigInContract{test.cfg}, chainIDMap)
+		checkProblems(t, test.name, v.problems, test.want)
+	}
+}
+
+func TestValidateFeeConfigs(t *testing.T) {
+	chainIDMap := map[string]struct{}{"1": {}, "56": {}}
+	tests := []struct {
+		name string
+		cfg  router.FeeConfigInContract
+		want []string
+	}{
+		{
+			name: "valid",
+			cfg:  router.FeeConfigInContract{FromChainID: big.NewInt(1), ToChainID: big.NewInt(56), MaximumSwapFee: big.NewInt(10), MinimumSwapFee: big.NewInt(1), SwapFeeRatePerMillion: 1000},
+		},
+		{
+			name: "unknown chainID",
+			cfg:  router.FeeConfigInContract{FromChainID: big.NewInt(250), ToChainID: big.NewInt(0), MaximumSwapFee: big.NewInt(10), MinimumSwapFee: big.NewInt(1), SwapFeeRatePerMillion: 1000},
+			want: []string{"[fee config of tk from 250 to 0] unknown chainID"},
+		},
+		{
+			name: "rate too large",
+			cfg:  router.FeeConfigInContract{FromChainID: big.NewInt(1), ToChainID: big.NewInt(56), MaximumSwapFee: big.NewInt(10), MinimumSwapFee: big.NewInt(1), SwapFeeRatePerMillion: 1000000},
+			want: []string{"[fee config of tk from 1 to 56] token must config 'SwapFeeRatePerMillion' (< 1000000)"},
+		},
+		{
+			name: "minimum fee with zero rate",
+			cfg:  router.FeeConfigInContract{FromChainID: big.NewInt(1), ToChainID: big.NewInt(56), MaximumSwapFee: big.NewInt(10), MinimumSwapFee: big.NewInt(1)},
+			want: []string{"[fee config of tk from 1 to 56] wrong token config, MinimumSwapFee should be 0 if SwapFeeRatePerMillion is 0"},
+		},
+	}
+	for _, test := range tests {
+		v := newTestValidator()
+		v.validateFeeConfigs("tk", []router.FeeConfigInContract{test.cfg}, chainIDMap)
+		checkProblems(t, test.name, v.problems, test.want)
+	}
+}
+
+func TestValidateFeeReceivers(t *testing.T) {
+	defer params.SetExtraConfig(&params.ExtraConfig{})
+
+	tests := []struct {
+		name string
+		cfg  *params.LocalChainConfig
+		want []string
+	}{
+		{
+			name: "not charge fee on dest chain",
+			cfg:  &params.LocalChainConfig{},
+		},
+		{
+			name: "missing fee receiver",
+			cfg:  &params.LocalChainConfig{ChargeFeeOnDestChain: map[string][]string{"56": {"tk"}}},
+			want: []string{"[chain 1] 'ChargeFeeOnDestChain' is configed but missing 'FeeReceiverOnDestChain'"},
+		},
+		{
+			name: "wrong fee receiver",
+			cfg:  &params.LocalChainConfig{ChargeFeeOnDestChain: map[string][]string{"56": {"tk"}}, FeeReceiverOnDestChain: "abc"},
+			want: []string{"[chain 1] wrong 'FeeReceiverOnDestChain' address abc"},
+		},
+		{
+			name: "valid fee receiver",
+			cfg:  &params.LocalChainConfig{ChargeFeeOnDestChain: map[string][]string{"56": {"tk"}}, FeeReceiverOnDestChain: "0xabc"},
+		},
+	}
+	for _, test := range tests {
+		params.SetExtraConfig(&params.ExtraConfig{
+			LocalChainConfig: map[string]*params.LocalChainConfig{"1": test.cfg},
+		})
+		v := newTestValidator()
+		v.bridges["1"] = &testAddressBridge{}
+		v.validateFeeReceivers()
+		checkProblems(t, test.name, v.problems, test.want)
+	}
+}
+
+func TestSortedProblems(t *testing.T) {
+	v := newTestValidator()
+	v.report("onchain", "b")
+	v.report("chain 1", "x")
+	v.report("onchain", "a")
+	v.report("config", "c")
+	want := []string{"[chain 1] x", "[config] c", "[onchain] b", "[onchain] a"}
+	checkProblems(t, "sorted problems", v.sortedProblems(), want)
+}
@@ -35,6 +35,9 @@ var (
 	updateConfigTopic = ethcommon.HexToHash("0x22590461e7ba17e1fe7580cb0ea47f283d3b2248f04873dfbe926d08fe4c5ab9")
 
 	latestUpdateConfigBlock uint64
+
+	// block number to call onchain config contract, nil means latest
+	onchainConfigBlockNumber *big.Int
 )
 
 // InitRouterConfigClients init router config clients
@@ -74,18 +77,30 @@ func InitRouterConfigClientsWithArgs(configContract string, gateways []string) {
 	}
 }
 
+// SetOnchainConfigBlockNumber set block number to call onchain config contract (nil means latest)
+func SetOnchainConfigBlockNumber(blockNumber *big.Int) {
+	onchainConfigBlockNumber = blockNumber
+}
+
 // CallOnchainContract call onchain contract
 func CallOnchainContract(data hexutil.Bytes, blockNumber string) (result []byte, err error) {
 	msg := ethereum.CallMsg{
 		To:   &routerConfigContract,
 		Data: data,
 	}
+	callBlockNumber := onchainConfigBlockNumber
+	if blockNumber != "" && blockNumber != "latest" {
+		callBlockNumber, err = common.GetBigIntFromStr(blockNumber)
+		if err != nil {
+			return nil, err
+		}
+	}
 LOOP:
 	for _, cli := range routerConfigClients {
-		result, err = cli.CallContract(routerConfigCtx, msg, nil)
+		result, err = cli.CallContract(routerConfigCtx, msg, callBlockNumber)
 		if err != nil && IsIniting {
 			for i := 0; i < RetryRPCCountInInit; i++ {
-				if result, err = cli.CallContract(routerConfigCtx, msg, nil); err == nil {
+				if result, err = cli.CallContract(routerConfigCtx, msg, callBlockNumber); err == nil {
 					return result, nil
 				}
 				if strings.Contains(err.Error(), "revert") ||
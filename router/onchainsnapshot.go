@@ -0,0 +1,120 @@
+package router
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/anyswap/CrossChain-Router/v3/params"
+)
+
+// OnchainConfigSnapshot snapshot of all configs in onchain config contract
+type OnchainConfigSnapshot struct {
+	ChainIDs     []string
+	TokenIDs     []string
+	ChainConfigs map[string]*ChainConfigInContract            // key is chainID
+	TokenConfigs map[string]map[string]*TokenConfigInContract // key is tokenID,chainID
+	SwapConfigs  map[string][]SwapConfigInContract            // key is tokenID
+	FeeConfigs   map[string][]FeeConfigInContract             // key is tokenID
+}
+
+// GetOnchainConfigSnapshot get snapshot of all onchain configs
+// (at the block number set by `SetOnchainConfigBlockNumber`)
+func GetOnchainConfigSnapshot() (*OnchainConfigSnapshot, error) {
+	chainIDs, err := GetAllChainIDs()
+	if err != nil {
+		return nil, fmt.Errorf("get all chainIDs failed: %w", err)
+	}
+	tokenIDs, err := GetAllTokenIDs()
+	if err != nil {
+		return nil, fmt.Errorf("get all tokenIDs failed: %w", err)
+	}
+	chainCfgs, err := GetAllChainConfig()
+	if err != nil {
+		return nil, fmt.Errorf("get all chain configs failed: %w", err)
+	}
+
+	snapshot := &OnchainConfigSnapshot{
+		ChainIDs:     make([]string, len(chainIDs)),
+		TokenIDs:     tokenIDs,
+		ChainConfigs: make(map[string]*ChainConfigInContract, len(chainCfgs)),
+		TokenConfigs: make(map[string]map[string]*TokenConfigInContract, len(tokenIDs)),
+		SwapConfigs:  make(map[string][]SwapConfigInContract, len(tokenIDs)),
+		FeeConfigs:   make(map[string][]FeeConfigInContract, len(tokenIDs)),
+	}
+	for i, chainID := range chainIDs {
+		snapshot.ChainIDs[i] = chainID.String()
+	}
+	for _, cfg := range chainCfgs {
+		snapshot.ChainConfigs[cfg.ChainID] = cfg
+	}
+
+	for _, tokenID := range tokenIDs {
+		tokenCfgs, errf := GetAllMultichainTokenConfig(tokenID)
+		if errf != nil {
+			return nil, fmt.Errorf("get token configs of %v failed: %w", tokenID, errf)
+		}
+		cfgMap := make(map[string]*TokenConfigInContract, len(tokenCfgs))
+		for _, cfg := range tokenCfgs {
+			cfgMap[cfg.ChainID] = cfg
+		}
+		snapshot.TokenConfigs[tokenID] = cfgMap
+
+		swapCfgs, errf := GetSwapConfigs(tokenID)
+		if errf != nil {
+			return nil, fmt.Errorf("get swap configs of %v failed: %w", tokenID, errf)
+		}
+		snapshot.SwapConfigs[tokenID] = swapCfgs
+
+		feeCfgs, errf := GetFeeConfigs(tokenID)
+		if errf != nil {
+			return nil, fmt.Errorf("get fee configs of %v failed: %w", tokenID, errf)
+		}
+		snapshot.FeeConfigs[tokenID] = feeCfgs
+	}
+	return snapshot, nil
+}
+
+func toJSONString(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(data)
+}
+
+// flatten snapshot to key value pairs
+func (s *OnchainConfigSnapshot) flatten() map[string]string {
+	items := make(map[string]string)
+	for _, chainID := range s.ChainIDs {
+		items["chainID/"+chainID] = "exist"
+	}
+	for _, tokenID := range s.TokenIDs {
+		items["tokenID/"+tokenID] = "exist"
+	}
+	for chainID, cfg := range s.ChainConfigs {
+		items["chain/"+chainID] = toJSONString(cfg)
+	}
+	for tokenID, cfgs := range s.TokenConfigs {
+		for chainID, cfg := range cfgs {
+			items[fmt.Sprintf("token/%v/%v", tokenID, chainID)] = toJSONString(cfg)
+		}
+	}
+	for tokenID, cfgs := range s.SwapConfigs {
+		for _, cfg := range cfgs {
+			key := fmt.Sprintf("swap/%v/%v/%v", tokenID, cfg.FromChainID, cfg.ToChainID)
+			items[key] = fmt.Sprintf("max=%v min=%v bigValue=%v", cfg.MaximumSwap, cfg.MinimumSwap, cfg.BigValueThreshold)
+		}
+	}
+	for tokenID, cfgs := range s.FeeConfigs {
+		for _, cfg := range cfgs {
+			key := fmt.Sprintf("fee/%v/%v/%v", tokenID, cfg.FromChainID, cfg.ToChainID)
+			items[key] = fmt.Sprintf("max=%v min=%v rate=%v", cfg.MaximumSwapFee, cfg.MinimumSwapFee, cfg.SwapFeeRatePerMillion)
+		}
+	}
+	return items
+}
+
+// DiffOnchainConfigSnapshots compare two snapshots, returns diffs sorted by key
+func DiffOnchainConfigSnapshots(oldSnapshot, newSnapshot *OnchainConfigSnapshot) []*params.ConfigDiff {
+	return params.DiffConfigItems(oldSnapshot.flatten(), newSnapshot.flatten())
+}
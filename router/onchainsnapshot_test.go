@@ -0,0 +1,91 @@
+package router
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Router/v3/params"
+)
+
+func newTestSnapshot() *OnchainConfigSnapshot {
+	return &OnchainConfigSnapshot{
+		ChainIDs: []string{"1", "56"},
+		TokenIDs: []string{"USDC"},
+		ChainConfigs: map[string]*ChainConfigInContract{
+			"1": {ChainID: "1", BlockChain: "Ethereum", RouterContract: "0xrouter", Confirmations: 5},
+		},
+		TokenConfigs: map[string]map[string]*TokenConfigInContract{
+			"USDC": {"1": {ChainID: "1", Decimals: 6, ContractAddress: "0xusdc", ContractVersion: 1}},
+		},
+		SwapConfigs: map[string][]SwapConfigInContract{
+			"USDC": {{FromChainID: big.NewInt(1), ToChainID: big.NewInt(56), MaximumSwap: big.NewInt(100), MinimumSwap: big.NewInt(1), BigValueThreshold: big.NewInt(50)}},
+		},
+		FeeConfigs: map[string][]FeeConfigInContract{
+			"USDC": {{FromChainID: big.NewInt(1), ToChainID: big.NewInt(56), MaximumSwapFee: big.NewInt(10), MinimumSwapFee: big.NewInt(1), SwapFeeRatePerMillion: 1000}},
+		},
+	}
+}
+
+func TestDiffOnchainConfigSnapshots(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(s *OnchainConfigSnapshot)
+		want   []params.ConfigDiff
+	}{
+		{
+			name:   "no change",
+			modify: func(s *OnchainConfigSnapshot) {},
+		},
+		{
+			name: "add chainID",
+			modify: func(s *OnchainConfigSnapshot) {
+				s.ChainIDs = append(s.ChainIDs, "137")
+			},
+			want: []params.ConfigDiff{{Key: "chainID/137", New: "exist"}},
+		},
+		{
+			name: "remove tokenID",
+			modify: func(s *OnchainConfigSnapshot) {
+				s.TokenIDs = nil
+			},
+			want: []params.ConfigDiff{{Key: "tokenID/USDC", Old: "exist"}},
+		},
+		{
+			name: "change swap and fee configs",
+			modify: func(s *OnchainConfigSnapshot) {
+				s.SwapConfigs["USDC"][0].MaximumSwap = big.NewInt(200)
+				s.FeeConfigs["USDC"][0].SwapFeeRatePerMillion = 2000
+			},
+			want: []params.ConfigDiff{
+				{Key: "fee/USDC/1/56", Old: "max=10 min=1 rate=1000", New: "max=10 min=1 rate=2000"},
+				{Key: "swap/USDC/1/56", Old: "max=100 min=1 bigValue=50", New: "max=200 min=1 bigValue=50"},
+			},
+		},
+		{
+			name: "change token config",
+			modify: func(s *OnchainConfigSnapshot) {
+				s.TokenConfigs["USDC"]["1"].ContractVersion = 2
+			},
+			want: []params.ConfigDiff{{
+				Key: "token/USDC/1",
+				Old: `{"ChainID":"1","Decimals":6,"ContractAddress":"0xusdc","ContractVersion":1,"RouterContract":"","Extra":""}`,
+				New: `{"ChainID":"1","Decimals":6,"ContractAddress":"0xusdc","ContractVersion":2,"RouterContract":"","Extra":""}`,
+			}},
+		},
+	}
+	for _, test := range tests {
+		oldSnapshot := newTestSnapshot()
+		newSnapshot := newTestSnapshot()
+		test.modify(newSnapshot)
+		diffs := DiffOnchainConfigSnapshots(oldSnapshot, newSnapshot)
+		if len(diffs) != len(test.want) {
+			t.Errorf("test %v: got %v diffs, want %v: %v", test.name, len(diffs), len(test.want), diffs)
+			continue
+		}
+		for i, diff := range diffs {
+			if *diff != test.want[i] {
+				t.Errorf("test %v: diff %v got %v, want %v", test.name, i, diff, test.want[i])
+			}
+		}
+	}
+}
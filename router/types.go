@@ -107,6 +107,19 @@ func GetRouterInfo(router, chainID string) *SwapRouterInfo {
 	return nil
 }
 
+// IsRouterMPC is account the router mpc of any router contract on chain
+func IsRouterMPC(chainID, account string) (isMPC bool) {
+	suffix := strings.ToLower(":" + chainID)
+	RouterInfos.Range(func(key, value interface{}) bool {
+		if !strings.HasSuffix(key.(string), suffix) {
+			return true
+		}
+		isMPC = strings.EqualFold(value.(*SwapRouterInfo).RouterMPC, account)
+		return !isMPC
+	})
+	return isMPC
+}
+
 // GetTokenRouterContract get token router contract
 func GetTokenRouterContract(tokenID, chainID string) (string, error) {
 	bridge := GetBridgeByChainID(chainID)
@@ -0,0 +1,32 @@
+package router
+
+import "testing"
+
+func TestIsRouterMPC(t *testing.T) {
+	SetRouterInfo("0xRouter1", "1", &SwapRouterInfo{RouterMPC: "0xMPC1"})
+	SetRouterInfo("0xRouter2", "1", &SwapRouterInfo{RouterMPC: "0xMPC2"})
+	SetRouterInfo("0xRouter3", "56", &SwapRouterInfo{RouterMPC: "0xMPC3"})
+	defer func() {
+		for _, key := range []string{"0xrouter1:1", "0xrouter2:1", "0xrouter3:56"} {
+			RouterInfos.Delete(key)
+		}
+	}()
+
+	tests := []struct {
+		chainID string
+		account string
+		isMPC   bool
+	}{
+		{chainID: "1", account: "0xmpc1", isMPC: true},
+		{chainID: "1", account: "0xMPC2", isMPC: true},
+		{chainID: "1", account: "0xMPC3", isMPC: false},
+		{chainID: "56", account: "0xMPC3", isMPC: true},
+		{chainID: "5", account: "0xMPC1", isMPC: false},
+		{chainID: "1", account: "0xother", isMPC: false},
+	}
+	for _, test := range tests {
+		if got := IsRouterMPC(test.chainID, test.account); got != test.isMPC {
+			t.Errorf("test %v/%v: got is mpc %v, want %v", test.chainID, test.account, got, test.isMPC)
+		}
+	}
+}
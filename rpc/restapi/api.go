@@ -4,15 +4,18 @@ package restapi
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/anyswap/CrossChain-Router/v3/common"
 	"github.com/anyswap/CrossChain-Router/v3/internal/swapapi"
 	"github.com/anyswap/CrossChain-Router/v3/log"
+	"github.com/anyswap/CrossChain-Router/v3/mpc"
 	"github.com/anyswap/CrossChain-Router/v3/params"
 	"github.com/anyswap/CrossChain-Router/v3/router"
 	"github.com/anyswap/CrossChain-Router/v3/tokens"
 	"github.com/anyswap/CrossChain-Router/v3/tokens/tests/config"
+	"github.com/anyswap/CrossChain-Router/v3/worker"
 	"github.com/gorilla/mux"
 )
 
@@ -174,6 +177,52 @@ func GetRouterSwapHistoryHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// SearchRouterSwapsHandler handler
+func SearchRouterSwapsHandler(w http.ResponseWriter, r *http.Request) {
+	args, err := getSearchRequestValues(r)
+	if err != nil {
+		writeResponse(w, nil, err)
+	} else {
+		res, err := swapapi.SearchRouterSwaps(args)
+		writeResponse(w, res, err)
+	}
+}
+
+func getSearchRequestValues(r *http.Request) (args *swapapi.SwapSearchArgs, err error) {
+	vals := r.URL.Query()
+	args = &swapapi.SwapSearchArgs{
+		FromChainID: vals.Get("fromchainid"),
+		ToChainID:   vals.Get("tochainid"),
+		TokenID:     vals.Get("tokenid"),
+		Bind:        vals.Get("bind"),
+		Status:      vals.Get("status"),
+		MinValue:    vals.Get("minvalue"),
+		MaxValue:    vals.Get("maxvalue"),
+		Order:       vals.Get("order"),
+		Cursor:      vals.Get("cursor"),
+	}
+	if str := vals.Get("starttime"); str != "" {
+		value, errf := common.GetUint64FromStr(str)
+		if errf != nil {
+			return nil, errf
+		}
+		args.StartTime = int64(value)
+	}
+	if str := vals.Get("endtime"); str != "" {
+		value, errf := common.GetUint64FromStr(str)
+		if errf != nil {
+			return nil, errf
+		}
+		args.EndTime = int64(value)
+	}
+	if str := vals.Get("limit"); str != "" {
+		if args.Limit, err = common.GetIntFromStr(str); err != nil {
+			return nil, err
+		}
+	}
+	return args, nil
+}
+
 // GetAllChainIDsHandler handler
 func GetAllChainIDsHandler(w http.ResponseWriter, r *http.Request) {
 	allChainIDs := router.AllChainIDs
@@ -207,6 +256,107 @@ func GetChainConfigHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// GetGasPriceEstimateHandler handler
+func GetGasPriceEstimateHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	chainID := vars["chainid"]
+	res, err := swapapi.GetGasPriceEstimate(chainID)
+	writeResponse(w, res, err)
+}
+
+// GetGasPriceEstimatesHandler handler
+func GetGasPriceEstimatesHandler(w http.ResponseWriter, r *http.Request) {
+	res := swapapi.GetGasPriceEstimates()
+	writeResponse(w, res, nil)
+}
+
+// GetAccountBalanceHandler handler
+func GetAccountBalanceHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	chainID := vars["chainid"]
+	account := vars["account"]
+	res, err := swapapi.GetAccountBalance(chainID, account)
+	writeResponse(w, res, err)
+}
+
+// SignGroupHealthHandler handler
+func SignGroupHealthHandler(w http.ResponseWriter, r *http.Request) {
+	res := swapapi.GetSignGroupHealth()
+	writeResponse(w, res, nil)
+}
+
+// StuckSwapsHandler handler
+func StuckSwapsHandler(w http.ResponseWriter, r *http.Request) {
+	res := swapapi.GetStuckSwaps()
+	writeResponse(w, res, nil)
+}
+
+// GetNFTIDMappingHandler handler
+func GetNFTIDMappingHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	args := &swapapi.NFTIDMappingArgs{
+		ChainID:  vars["chainid"],
+		Token:    vars["token"],
+		ID:       vars["id"],
+		NativeID: vars["nativeid"],
+	}
+	res, err := swapapi.GetNFTIDMapping(args)
+	writeResponse(w, res, err)
+}
+
+// MetricsHandler handler, write metrics in prometheus text format
+func MetricsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	writeSignGroupMetrics(w, swapapi.GetSignGroupHealth())
+	writeStuckSwapMetrics(w, swapapi.GetStuckSwaps())
+}
+
+func writeStuckSwapMetrics(w io.Writer, report *worker.StuckSwapReport) {
+	if !report.Enabled {
+		return
+	}
+	type labels struct{ fromChainID, toChainID, status, level string }
+	counts := make(map[labels]int)
+	for _, swap := range report.Swaps {
+		counts[labels{swap.FromChainID, swap.ToChainID, swap.Status, swap.Level}]++
+	}
+	name := "router_stuck_swaps"
+	fmt.Fprintf(w, "# HELP %v %v\n# TYPE %v %v\n", name, "Swaps staying in status longer than the SLA.", name, "gauge")
+	for l, count := range counts {
+		fmt.Fprintf(w, "%v{fromChainID=%q,toChainID=%q,status=%q,level=%q} %v\n", name, l.fromChainID, l.toChainID, l.status, l.level, count)
+	}
+}
+
+func writeSignGroupMetrics(w io.Writer, health map[string][]*mpc.SignGroupHealth) {
+	metrics := []struct {
+		name  string
+		help  string
+		kind  string
+		value func(h *mpc.SignGroupHealth) interface{}
+	}{
+		{"router_sign_group_requests_total", "Total sign requests of sign group.", "counter", func(h *mpc.SignGroupHealth) interface{} { return h.Total }},
+		{"router_sign_group_failures_total", "Total failed sign requests of sign group.", "counter", func(h *mpc.SignGroupHealth) interface{} { return h.Failure }},
+		{"router_sign_group_success_rate", "Moving average success rate of sign group.", "gauge", func(h *mpc.SignGroupHealth) interface{} { return h.SuccessRate }},
+		{"router_sign_group_latency_seconds", "Moving average latency of successful signs of sign group.", "gauge", func(h *mpc.SignGroupHealth) interface{} { return h.AvgLatency }},
+		{"router_sign_group_in_flight", "In-flight sign requests of sign group.", "gauge", func(h *mpc.SignGroupHealth) interface{} { return h.InFlight }},
+		{"router_sign_group_disabled", "Is sign group disabled by consecutive failures.", "gauge", func(h *mpc.SignGroupHealth) interface{} {
+			if h.DisabledUntil > 0 {
+				return 1
+			}
+			return 0
+		}},
+	}
+	for _, m := range metrics {
+		fmt.Fprintf(w, "# HELP %v %v\n# TYPE %v %v\n", m.name, m.help, m.name, m.kind)
+		for mpcType, list := range health {
+			for _, h := range list {
+				fmt.Fprintf(w, "%v{mpc=%q,initiator=%q,group=%q} %v\n", m.name, mpcType, h.Initiator, h.GroupID, m.value(h))
+			}
+		}
+	}
+}
+
 // GetTokenConfigHandler handler
 func GetTokenConfigHandler(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
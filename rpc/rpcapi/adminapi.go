@@ -51,32 +51,33 @@ func (s *RouterSwapAPI) AdminCall(r *http.Request, rawTx, result *string) (err e
 		return err
 	}
 	senderAddress := sender.String()
-	if !params.IsRouterAdmin(senderAddress) {
-		switch args.Method {
-		case reswapCmd, passForbiddenSwapoutCmd:
+	switch args.Method {
+	case proposeCmd, approveCmd:
+		if !params.IsRouterAdmin(senderAddress) {
 			return fmt.Errorf("sender %v is not admin", senderAddress)
-		case maintainCmd:
-			action := args.Params[0]
-			switch action {
-			case actPause, actUnpause:
-				return fmt.Errorf("sender %v is not admin", senderAddress)
-			}
-		case passbigvalueCmd, replaceswapCmd, forbidSwapCmd:
-		default:
-			return fmt.Errorf("unknown admin method '%v'", args.Method)
 		}
-		if !params.IsRouterAssistant(senderAddress) {
-			return fmt.Errorf("sender %v is not assistant", senderAddress)
+		log.Info("admin call", "caller", senderAddress, "args", args)
+		if args.Method == proposeCmd {
+			return proposeAdminCall(senderAddress, args, result)
+		}
+		return approveAdminProposal(senderAddress, args, result)
+	}
+	if isApprovalRequired(args.Method) {
+		return fmt.Errorf("admin method '%v' need multi-admin approval, please use '%v' instead", args.Method, proposeCmd)
+	}
+	if !params.IsRouterAdmin(senderAddress) {
+		if err = checkAdminPermission(senderAddress, args); err != nil {
+			return err
 		}
 	}
 	log.Info("admin call", "caller", senderAddress, "args", args, "result", result)
-	return doRouterAdminCall(args, result)
+	return doRouterAdminCall(senderAddress, args, result)
 }
 
-func doRouterAdminCall(args *admin.CallArgs, result *string) error {
+func doRouterAdminCall(caller string, args *admin.CallArgs, result *string) error {
 	switch args.Method {
 	case maintainCmd:
-		return maintain(args, result)
+		return maintain(caller, args, result)
 	case passbigvalueCmd:
 		return routerPassBigValue(args, result)
 	case reswapCmd:
@@ -115,60 +116,51 @@ func getKeys(args *admin.CallArgs, startPos int) (chainID, txid string, logIndex
 	return
 }
 
-//nolint:gocyclo // allow big switch
-func maintain(args *admin.CallArgs, result *string) (err error) {
+// maintain changes are persisted, and applied at startup and after reloading config
+func maintain(caller string, args *admin.CallArgs, result *string) (err error) {
 	if len(args.Params) != 2 {
 		return fmt.Errorf("wrong number of params, have %v want 2", len(args.Params))
 	}
 	action := args.Params[0]
 	arguments := args.Params[1]
 
+	var items []*mongodb.MgoMaintainItem
 	switch action {
 	case actPause, actUnpause:
 		chainIDs := strings.Split(arguments, ",")
-		if action == actPause {
-			router.AddPausedChainIDs(chainIDs)
-		} else {
-			router.RemovePausedChainIDs(chainIDs)
+		for _, chainID := range chainIDs {
+			if _, err = common.GetBigIntFromStr(chainID); err != nil || chainID == "" {
+				return fmt.Errorf("wrong chain id '%v'", chainID)
+			}
 		}
-		log.Infof("after action %v, the paused chainIDs are %v", action, router.GetPausedChainIDs())
+		items = worker.NewMaintainItems(worker.MaintainPause, "", "", chainIDs, action == actPause, caller)
 	case actWhitelist, actUnwhitelist:
 		isAdd := strings.EqualFold(action, actWhitelist)
 		args := strings.Split(arguments, ",")
 		if len(args) < 3 {
 			return fmt.Errorf("miss arguments")
 		}
-		whitelistType := strings.ToLower(args[0])
-		switch whitelistType {
-		case "callbycontract":
-			params.AddOrRemoveCallByContractWhitelist(args[1], args[2:], isAdd)
-		case "callbycontractcodehash":
-			params.AddOrRemoveCallByContractCodeHashWhitelist(args[1], args[2:], isAdd)
-		case "bigvalue":
-			params.AddOrRemoveBigValueWhitelist(args[1], args[2:], isAdd)
-		default:
-			return fmt.Errorf("unknown whitelist type '%v'", whitelistType)
-		}
+		items = worker.NewMaintainItems(worker.MaintainWhitelist, args[0], args[1], args[2:], isAdd, caller)
 	case actBlacklist, actUnblacklist:
 		isAdd := strings.EqualFold(action, actBlacklist)
 		args := strings.Split(arguments, ",")
 		if len(args) < 2 {
 			return fmt.Errorf("miss arguments")
 		}
-		blacklistType := strings.ToLower(args[0])
-		switch blacklistType {
-		case "chainid":
-			params.AddOrRemoveChainIDBlackList(args[1:], isAdd)
-		case "tokenid":
-			params.AddOrRemoveTokenIDBlackList(args[1:], isAdd)
-		case "account":
-			params.AddOrRemoveAccountBlackList(args[1:], isAdd)
-		default:
-			return fmt.Errorf("unknown blacklist type '%v'", blacklistType)
-		}
+		items = worker.NewMaintainItems(worker.MaintainBlacklist, args[0], "", args[1:], isAdd, caller)
 	default:
 		return fmt.Errorf("unknown maintain action '%v'", action)
 	}
+	if len(items) == 0 {
+		return fmt.Errorf("miss arguments")
+	}
+	err = worker.SaveMaintainItems(items)
+	if err != nil {
+		return err
+	}
+	if action == actPause || action == actUnpause {
+		log.Infof("after action %v, the paused chainIDs are %v", action, router.GetPausedChainIDs())
+	}
 	*result = successReuslt
 	return nil
 }
@@ -0,0 +1,163 @@
+package rpcapi
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/anyswap/CrossChain-Router/v3/admin"
+	"github.com/anyswap/CrossChain-Router/v3/common"
+	"github.com/anyswap/CrossChain-Router/v3/log"
+	"github.com/anyswap/CrossChain-Router/v3/mongodb"
+	"github.com/anyswap/CrossChain-Router/v3/params"
+)
+
+const (
+	proposeCmd = "propose"
+	approveCmd = "approve"
+)
+
+// admin proposal steps, replaced in tests
+var (
+	addAdminProposal          = mongodb.AddAdminProposal
+	findAdminProposal         = mongodb.FindAdminProposal
+	approveAdminProposalInDB  = mongodb.ApproveAdminProposal
+	startExecuteAdminProposal = mongodb.StartExecuteAdminProposal
+	finishAdminProposal       = mongodb.FinishAdminProposal
+	doProposalAdminCall       = doRouterAdminCall
+)
+
+// isApprovalRequired a method need multi-admin approval if its threshold is greater than 1
+func isApprovalRequired(method string) bool {
+	cfg := params.GetAdminApprovalConfig(method)
+	return cfg != nil && cfg.Threshold > 1
+}
+
+func getProposalID(proposer string, args *admin.CallArgs) string {
+	return common.Keccak256Hash(
+		[]byte(strings.ToLower(proposer)),
+		[]byte(args.Method),
+		[]byte(strings.Join(args.Params, "\n")),
+		[]byte(fmt.Sprint(args.Timestamp)),
+	).Hex()
+}
+
+func checkProposalParams(args *admin.CallArgs) error {
+	switch args.Method {
+	case maintainCmd:
+		if len(args.Params) != 2 {
+			return fmt.Errorf("wrong number of params, have %v want 2", len(args.Params))
+		}
+		return nil
+	case passbigvalueCmd, reswapCmd, forbidSwapCmd, passForbiddenSwapoutCmd:
+		_, _, _, err := getKeys(args, 0)
+		return err
+	case replaceswapCmd:
+		if _, _, _, err := getKeys(args, 0); err != nil {
+			return err
+		}
+		_, err := getGasPrice(args, 3)
+		return err
+	default:
+		return fmt.Errorf("unknown admin method '%v'", args.Method)
+	}
+}
+
+// only count approvals of current admins (admins may be changed by reloading config),
+// and count once per admin
+func countAdminApprovals(approvals []string) (count int) {
+	approved := make(map[string]struct{}, len(approvals))
+	for _, approver := range approvals {
+		key := strings.ToLower(approver)
+		if _, exist := approved[key]; exist {
+			continue
+		}
+		approved[key] = struct{}{}
+		if params.IsRouterAdmin(approver) {
+			count++
+		}
+	}
+	return count
+}
+
+// propose params: [method, params...]
+// the proposer is the first approver
+func proposeAdminCall(proposer string, args *admin.CallArgs, result *string) error {
+	if len(args.Params) == 0 {
+		return fmt.Errorf("miss admin method of proposal")
+	}
+	method := args.Params[0]
+	cfg := params.GetAdminApprovalConfig(method)
+	if cfg == nil {
+		return fmt.Errorf("admin method '%v' does not need approval", method)
+	}
+	callArgs := &admin.CallArgs{
+		Method:    method,
+		Params:    args.Params[1:],
+		Timestamp: args.Timestamp,
+	}
+	if err := checkProposalParams(callArgs); err != nil {
+		return err
+	}
+	now := time.Now().Unix()
+	proposal := &mongodb.MgoAdminProposal{
+		Key:        getProposalID(proposer, callArgs),
+		Method:     callArgs.Method,
+		Params:     callArgs.Params,
+		Proposer:   proposer,
+		Approvals:  []string{strings.ToLower(proposer)},
+		Threshold:  cfg.Threshold,
+		Status:     mongodb.ProposalPending,
+		CreateTime: now,
+		ExpireTime: now + cfg.GetExpireSeconds(),
+	}
+	err := addAdminProposal(proposal)
+	if err != nil {
+		return err
+	}
+	if countAdminApprovals(proposal.Approvals) >= proposal.Threshold {
+		return executeAdminProposal(proposal, result)
+	}
+	*result = proposal.Key
+	return nil
+}
+
+// approve params: [proposalID]
+func approveAdminProposal(approver string, args *admin.CallArgs, result *string) error {
+	if len(args.Params) != 1 {
+		return fmt.Errorf("wrong number of params, have %v want 1", len(args.Params))
+	}
+	proposalID := args.Params[0]
+	proposal, err := approveAdminProposalInDB(proposalID, approver)
+	if err != nil {
+		if old, errf := findAdminProposal(proposalID); errf == nil {
+			return fmt.Errorf("can not approve proposal with status '%v' and expire time %v", old.Status, old.ExpireTime)
+		}
+		return err
+	}
+	if approvals := countAdminApprovals(proposal.Approvals); approvals < proposal.Threshold {
+		*result = fmt.Sprintf("Approved %v/%v", approvals, proposal.Threshold)
+		return nil
+	}
+	return executeAdminProposal(proposal, result)
+}
+
+func executeAdminProposal(proposal *mongodb.MgoAdminProposal, result *string) error {
+	err := startExecuteAdminProposal(proposal.Key)
+	if err != nil {
+		return err
+	}
+	callArgs := &admin.CallArgs{
+		Method: proposal.Method,
+		Params: proposal.Params,
+	}
+	caller := strings.Join(proposal.Approvals, ",")
+	log.Info("execute admin proposal", "id", proposal.Key, "method", proposal.Method, "params", proposal.Params, "approvals", proposal.Approvals)
+	err = doProposalAdminCall(caller, callArgs, result)
+	if err != nil {
+		_ = finishAdminProposal(proposal.Key, mongodb.ProposalFailed, err.Error())
+		return err
+	}
+	_ = finishAdminProposal(proposal.Key, mongodb.ProposalExecuted, *result)
+	return nil
+}
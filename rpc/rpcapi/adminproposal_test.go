@@ -0,0 +1,268 @@
+package rpcapi
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anyswap/CrossChain-Router/v3/admin"
+	"github.com/anyswap/CrossChain-Router/v3/mongodb"
+	"github.com/anyswap/CrossChain-Router/v3/params"
+)
+
+const (
+	testAdmin1 = "0x00000000000000000000000000000000000000A1"
+	testAdmin2 = "0x00000000000000000000000000000000000000a2"
+	testAdmin3 = "0x00000000000000000000000000000000000000a3"
+	testOthers = "0x00000000000000000000000000000000000000b1"
+)
+
+var errProposalNotFound = errors.New("proposal not found")
+
+// proposalTestStore keeps proposals in memory as the mongodb collection does
+type proposalTestStore struct {
+	proposals map[string]*mongodb.MgoAdminProposal
+	now       int64
+	executed  []*admin.CallArgs
+}
+
+func (s *proposalTestStore) approve(id, approver string) (*mongodb.MgoAdminProposal, error) {
+	p, exist := s.proposals[id]
+	if !exist || p.Status != mongodb.ProposalPending || p.ExpireTime <= s.now {
+		return nil, errProposalNotFound
+	}
+	approver = strings.ToLower(approver)
+	for _, item := range p.Approvals {
+		if item == approver {
+			return p, nil
+		}
+	}
+	p.Approvals = append(p.Approvals, approver)
+	return p, nil
+}
+
+func (s *proposalTestStore) startExecute(id string) error {
+	p, exist := s.proposals[id]
+	if !exist || p.Status != mongodb.ProposalPending {
+		return fmt.Errorf("admin proposal %v is not pending", id)
+	}
+	p.Status = mongodb.ProposalExecuting
+	return nil
+}
+
+func setupAdminProposalTest(t *testing.T, threshold int) *proposalTestStore {
+	routerCfg := params.GetRouterConfig()
+	prevServer := routerCfg.Server
+	routerCfg.Server = &params.RouterServerConfig{
+		Admins: []string{testAdmin1, testAdmin2, testAdmin3},
+		AdminApproval: map[string]*params.AdminApprovalConfig{
+			reswapCmd: {Threshold: threshold, ExpireSeconds: 600},
+		},
+	}
+
+	store := &proposalTestStore{
+		proposals: make(map[string]*mongodb.MgoAdminProposal),
+		now:       time.Now().Unix(),
+	}
+	prevAdd, prevFind, prevApprove := addAdminProposal, findAdminProposal, approveAdminProposalInDB
+	prevStart, prevFinish, prevCall := startExecuteAdminProposal, finishAdminProposal, doProposalAdminCall
+	addAdminProposal = func(p *mongodb.MgoAdminProposal) error {
+		if _, exist := store.proposals[p.Key]; exist {
+			return errors.New("duplicate proposal")
+		}
+		store.proposals[p.Key] = p
+		return nil
+	}
+	findAdminProposal = func(id string) (*mongodb.MgoAdminProposal, error) {
+		if p, exist := store.proposals[id]; exist {
+			return p, nil
+		}
+		return nil, errProposalNotFound
+	}
+	approveAdminProposalInDB = store.approve
+	startExecuteAdminProposal = store.startExecute
+	finishAdminProposal = func(id, status, result string) error {
+		store.proposals[id].Status = status
+		store.proposals[id].Result = result
+		return nil
+	}
+	doProposalAdminCall = func(caller string, args *admin.CallArgs, result *string) error {
+		store.executed = append(store.executed, args)
+		*result = successReuslt
+		return nil
+	}
+
+	t.Cleanup(func() {
+		addAdminProposal, findAdminProposal, approveAdminProposalInDB = prevAdd, prevFind, prevApprove
+		startExecuteAdminProposal, finishAdminProposal, doProposalAdminCall = prevStart, prevFinish, prevCall
+		routerCfg.Server = prevServer
+	})
+	return store
+}
+
+func newReswapProposalArgs(timestamp int64) *admin.CallArgs {
+	return &admin.CallArgs{
+		Method:    proposeCmd,
+		Params:    []string{reswapCmd, "1", "0x0000000000000000000000000000000000000000000000000000000000000001", "0"},
+		Timestamp: timestamp,
+	}
+}
+
+func approveArgs(proposalID string) *admin.CallArgs {
+	return &admin.CallArgs{Method: approveCmd, Params: []string{proposalID}}
+}
+
+func TestCountAdminApprovals(t *testing.T) {
+	setupAdminProposalTest(t, 2)
+
+	tests := []struct {
+		approvals []string
+		want      int
+	}{
+		{nil, 0},
+		{[]string{testAdmin1}, 1},
+		{[]string{testAdmin1, testAdmin2, testAdmin3}, 3},
+		// duplicate approvals of one admin
+		{[]string{testAdmin1, testAdmin1}, 1},
+		{[]string{testAdmin1, strings.ToLower(testAdmin1), strings.ToUpper(testAdmin1)}, 1},
+		// approvals of non-admins (eg. removed by reloading config)
+		{[]string{testAdmin1, testOthers}, 1},
+	}
+	for i, tt := range tests {
+		if got := countAdminApprovals(tt.approvals); got != tt.want {
+			t.Errorf("test %v: got %v, want %v", i, got, tt.want)
+		}
+	}
+}
+
+func TestAdminProposalThreshold(t *testing.T) {
+	store := setupAdminProposalTest(t, 3)
+
+	var proposalID string
+	if err := proposeAdminCall(testAdmin1, newReswapProposalArgs(1), &proposalID); err != nil {
+		t.Fatalf("propose failed: %v", err)
+	}
+	if _, exist := store.proposals[proposalID]; !exist {
+		t.Fatalf("proposal %v is not saved", proposalID)
+	}
+
+	var result string
+	steps := []struct {
+		approver string
+		want     string
+	}{
+		{testAdmin1, "Approved 1/3"},
+		// duplicate approvals of proposer with other case
+		{strings.ToLower(testAdmin1), "Approved 1/3"},
+		{testAdmin2, "Approved 2/3"},
+		{testAdmin2, "Approved 2/3"},
+	}
+	for i, step := range steps {
+		if err := approveAdminProposal(step.approver, approveArgs(proposalID), &result); err != nil {
+			t.Fatalf("step %v: approve failed: %v", i, err)
+		}
+		if result != step.want {
+			t.Errorf("step %v: got result %v, want %v", i, result, step.want)
+		}
+		if len(store.executed) != 0 {
+			t.Fatalf("step %v: proposal is executed before reaching threshold", i)
+		}
+	}
+
+	if err := approveAdminProposal(testAdmin3, approveArgs(proposalID), &result); err != nil {
+		t.Fatalf("approve failed: %v", err)
+	}
+	if len(store.executed) != 1 || result != successReuslt {
+		t.Fatalf("proposal is not executed when reaching threshold, result %v", result)
+	}
+	executed := store.executed[0]
+	if executed.Method != reswapCmd || len(executed.Params) != 3 {
+		t.Errorf("executed call: got %v %v", executed.Method, executed.Params)
+	}
+	if status := store.proposals[proposalID].Status; status != mongodb.ProposalExecuted {
+		t.Errorf("proposal status: got %v, want %v", status, mongodb.ProposalExecuted)
+	}
+
+	// executed proposal can not be approved or executed again
+	err := approveAdminProposal(testAdmin3, approveArgs(proposalID), &result)
+	if err == nil || !strings.Contains(err.Error(), mongodb.ProposalExecuted) {
+		t.Errorf("approve executed proposal: got err %v", err)
+	}
+	if len(store.executed) != 1 {
+		t.Errorf("proposal is executed more than once")
+	}
+}
+
+func TestAdminProposalExecuteByProposer(t *testing.T) {
+	store := setupAdminProposalTest(t, 1)
+
+	var result string
+	if err := proposeAdminCall(testAdmin1, newReswapProposalArgs(1), &result); err != nil {
+		t.Fatalf("propose failed: %v", err)
+	}
+	if len(store.executed) != 1 || result != successReuslt {
+		t.Errorf("proposal of threshold 1 is not executed by proposer, result %v", result)
+	}
+}
+
+func TestAdminProposalExpiry(t *testing.T) {
+	store := setupAdminProposalTest(t, 2)
+
+	var proposalID, result string
+	if err := proposeAdminCall(testAdmin1, newReswapProposalArgs(1), &proposalID); err != nil {
+		t.Fatalf("propose failed: %v", err)
+	}
+	proposal := store.proposals[proposalID]
+	if proposal.ExpireTime != proposal.CreateTime+600 {
+		t.Errorf("expire time: got %v, want %v", proposal.ExpireTime, proposal.CreateTime+600)
+	}
+
+	store.now = proposal.ExpireTime
+	err := approveAdminProposal(testAdmin2, approveArgs(proposalID), &result)
+	if err == nil || !strings.Contains(err.Error(), "expire time") {
+		t.Errorf("approve expired proposal: got err %v", err)
+	}
+	if len(store.executed) != 0 {
+		t.Errorf("expired proposal is executed")
+	}
+
+	if err = approveAdminProposal(testAdmin2, approveArgs("0x1"), &result); !errors.Is(err, errProposalNotFound) {
+		t.Errorf("approve unknown proposal: got err %v, want %v", err, errProposalNotFound)
+	}
+}
+
+func TestProposeAdminCallErrors(t *testing.T) {
+	store := setupAdminProposalTest(t, 2)
+
+	tests := []*admin.CallArgs{
+		{Method: proposeCmd},
+		// method without approval config
+		{Method: proposeCmd, Params: []string{maintainCmd, "pause", "1"}},
+		// wrong params of method
+		{Method: proposeCmd, Params: []string{reswapCmd, "1"}},
+		{Method: proposeCmd, Params: []string{reswapCmd, "chain", "0x1", "0"}},
+	}
+	for i, args := range tests {
+		var result string
+		if err := proposeAdminCall(testAdmin1, args, &result); err == nil {
+			t.Errorf("test %v: propose %v should fail", i, args.Params)
+		}
+	}
+	if len(store.proposals) != 0 {
+		t.Errorf("wrong proposals are saved")
+	}
+
+	// the same proposal can not be proposed twice
+	var result string
+	if err := proposeAdminCall(testAdmin1, newReswapProposalArgs(1), &result); err != nil {
+		t.Fatalf("propose failed: %v", err)
+	}
+	if err := proposeAdminCall(testAdmin1, newReswapProposalArgs(1), &result); err == nil {
+		t.Errorf("duplicate proposal should fail")
+	}
+	if err := proposeAdminCall(testAdmin2, newReswapProposalArgs(1), &result); err != nil {
+		t.Errorf("propose by other admin failed: %v", err)
+	}
+}
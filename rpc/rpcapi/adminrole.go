@@ -0,0 +1,126 @@
+package rpcapi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Router/v3/admin"
+	"github.com/anyswap/CrossChain-Router/v3/mongodb"
+	"github.com/anyswap/CrossChain-Router/v3/params"
+)
+
+// checkAdminPermission check permission of non admin caller,
+// the caller is permitted if any of its roles allows the call, or it is an assistant.
+func checkAdminPermission(caller string, args *admin.CallArgs) error {
+	roles := params.GetAdminRoles(caller)
+	if len(roles) != 0 {
+		action := getMaintainAction(args)
+		var chainIDs, tokenIDs []string
+		var scopeErr error
+		scopesLoaded := false
+		for _, role := range roles {
+			if role.IsScoped() && !scopesLoaded {
+				chainIDs, tokenIDs, scopeErr = getAdminCallScopes(args)
+				scopesLoaded = true
+			}
+			if role.IsScoped() && scopeErr != nil {
+				continue
+			}
+			if role.IsAllowed(args.Method, action, chainIDs, tokenIDs) {
+				return nil
+			}
+		}
+		if !params.IsRouterAssistant(caller) {
+			if scopeErr != nil {
+				return scopeErr
+			}
+			return fmt.Errorf("sender %v has no permission to call '%v %v' on chainIDs %v tokenIDs %v", caller, args.Method, action, chainIDs, tokenIDs)
+		}
+	}
+	return checkAssistantPermission(caller, args)
+}
+
+func checkAssistantPermission(caller string, args *admin.CallArgs) error {
+	switch args.Method {
+	case reswapCmd, passForbiddenSwapoutCmd:
+		return fmt.Errorf("sender %v is not admin", caller)
+	case maintainCmd:
+		switch getMaintainAction(args) {
+		case actPause, actUnpause:
+			return fmt.Errorf("sender %v is not admin", caller)
+		}
+	case passbigvalueCmd, replaceswapCmd, forbidSwapCmd:
+	default:
+		return fmt.Errorf("unknown admin method '%v'", args.Method)
+	}
+	if !params.IsRouterAssistant(caller) {
+		return fmt.Errorf("sender %v is not assistant", caller)
+	}
+	return nil
+}
+
+func getMaintainAction(args *admin.CallArgs) string {
+	if args.Method != maintainCmd || len(args.Params) == 0 {
+		return ""
+	}
+	return args.Params[0]
+}
+
+// getAdminCallScopes get chainIDs and tokenIDs affected by admin call.
+// `replaceswap` affects the dest chain, other swap methods affect the source chain.
+// empty scopes mean the call affects all chains (eg. blacklist account).
+func getAdminCallScopes(args *admin.CallArgs) (chainIDs, tokenIDs []string, err error) {
+	switch args.Method {
+	case maintainCmd:
+		if len(args.Params) != 2 {
+			return nil, nil, fmt.Errorf("wrong number of params, have %v want 2", len(args.Params))
+		}
+		arguments := strings.Split(args.Params[1], ",")
+		switch args.Params[0] {
+		case actPause, actUnpause:
+			chainIDs = arguments
+		case actWhitelist, actUnwhitelist:
+			if len(arguments) < 3 {
+				return nil, nil, fmt.Errorf("miss arguments")
+			}
+			if strings.EqualFold(arguments[0], "bigvalue") {
+				tokenIDs = arguments[1:2]
+			} else {
+				chainIDs = arguments[1:2]
+			}
+		case actBlacklist, actUnblacklist:
+			if len(arguments) < 2 {
+				return nil, nil, fmt.Errorf("miss arguments")
+			}
+			switch strings.ToLower(arguments[0]) {
+			case "chainid":
+				chainIDs = arguments[1:]
+			case "tokenid":
+				tokenIDs = arguments[1:]
+			}
+		}
+		return chainIDs, tokenIDs, nil
+	case replaceswapCmd:
+		chainID, txid, logIndex, errk := getKeys(args, 0)
+		if errk != nil {
+			return nil, nil, errk
+		}
+		res, errf := mongodb.FindRouterSwapResult(chainID, txid, logIndex)
+		if errf != nil {
+			return nil, nil, errf
+		}
+		return []string{res.ToChainID}, []string{res.GetTokenID()}, nil
+	case passbigvalueCmd, reswapCmd, forbidSwapCmd, passForbiddenSwapoutCmd:
+		chainID, txid, logIndex, errk := getKeys(args, 0)
+		if errk != nil {
+			return nil, nil, errk
+		}
+		swap, errf := mongodb.FindRouterSwap(chainID, txid, logIndex)
+		if errf != nil {
+			return nil, nil, errf
+		}
+		return []string{chainID}, []string{swap.GetTokenID()}, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown admin method '%v'", args.Method)
+	}
+}
@@ -8,9 +8,13 @@ import (
 	"time"
 
 	"github.com/anyswap/CrossChain-Router/v3/internal/swapapi"
+	"github.com/anyswap/CrossChain-Router/v3/mongodb"
+	"github.com/anyswap/CrossChain-Router/v3/mpc"
 	"github.com/anyswap/CrossChain-Router/v3/params"
 	"github.com/anyswap/CrossChain-Router/v3/router"
 	"github.com/anyswap/CrossChain-Router/v3/tokens"
+	"github.com/anyswap/CrossChain-Router/v3/tokens/gasoracle"
+	"github.com/anyswap/CrossChain-Router/v3/worker"
 )
 
 // RouterSwapAPI rpc api handler
@@ -129,6 +133,15 @@ func (s *RouterSwapAPI) GetRouterSwapHistory(r *http.Request, args *RouterGetSwa
 	return err
 }
 
+// SearchRouterSwaps api
+func (s *RouterSwapAPI) SearchRouterSwaps(r *http.Request, args *swapapi.SwapSearchArgs, result *swapapi.SwapSearchResult) error {
+	res, err := swapapi.SearchRouterSwaps(args)
+	if err == nil && res != nil {
+		*result = *res
+	}
+	return err
+}
+
 // GetAllChainIDs api
 func (s *RouterSwapAPI) GetAllChainIDs(r *http.Request, args *RPCNullArgs, result *[]*big.Int) error {
 	*result = router.AllChainIDs
@@ -164,6 +177,86 @@ func (s *RouterSwapAPI) GetChainConfig(r *http.Request, args *string, result *sw
 	return fmt.Errorf("chain config not found")
 }
 
+// GetGasPriceEstimate api
+func (s *RouterSwapAPI) GetGasPriceEstimate(r *http.Request, args *string, result *gasoracle.Estimate) error {
+	est, err := swapapi.GetGasPriceEstimate(*args)
+	if err == nil && est != nil {
+		*result = *est
+	}
+	return err
+}
+
+// GetGasPriceEstimates api
+func (s *RouterSwapAPI) GetGasPriceEstimates(r *http.Request, args *RPCNullArgs, result *map[string]*gasoracle.Estimate) error {
+	*result = swapapi.GetGasPriceEstimates()
+	return nil
+}
+
+// GetAccountBalanceArgs args
+type GetAccountBalanceArgs struct {
+	ChainID string `json:"chainid"`
+	Account string `json:"account"`
+}
+
+// GetAccountBalance api
+func (s *RouterSwapAPI) GetAccountBalance(r *http.Request, args *GetAccountBalanceArgs, result *swapapi.AccountBalance) error {
+	res, err := swapapi.GetAccountBalance(args.ChainID, args.Account)
+	if err == nil && res != nil {
+		*result = *res
+	}
+	return err
+}
+
+// GetMaintenanceState api
+func (s *RouterSwapAPI) GetMaintenanceState(r *http.Request, args *RPCNullArgs, result *worker.MaintenanceState) error {
+	*result = *swapapi.GetMaintenanceState()
+	return nil
+}
+
+// GetSignGroupHealth api
+func (s *RouterSwapAPI) GetSignGroupHealth(r *http.Request, args *RPCNullArgs, result *map[string][]*mpc.SignGroupHealth) error {
+	*result = swapapi.GetSignGroupHealth()
+	return nil
+}
+
+// GetAdminProposals api
+func (s *RouterSwapAPI) GetAdminProposals(r *http.Request, args *RPCNullArgs, result *[]*mongodb.MgoAdminProposal) error {
+	res, err := swapapi.GetAdminProposals()
+	if err == nil && res != nil {
+		*result = res
+	}
+	return err
+}
+
+// GetStuckSwaps api
+func (s *RouterSwapAPI) GetStuckSwaps(r *http.Request, args *RPCNullArgs, result *worker.StuckSwapReport) error {
+	*result = *swapapi.GetStuckSwaps()
+	return nil
+}
+
+// GetConfigAuditsArgs args
+type GetConfigAuditsArgs struct {
+	Limit int `json:"limit"`
+}
+
+// GetConfigAudits api
+func (s *RouterSwapAPI) GetConfigAudits(r *http.Request, args *GetConfigAuditsArgs, result *[]*mongodb.MgoConfigAudit) error {
+	res, err := swapapi.GetConfigAudits(args.Limit)
+	if err == nil && res != nil {
+		*result = res
+	}
+	return err
+}
+
+// GetNFTIDMapping api
+func (s *RouterSwapAPI) GetNFTIDMapping(r *http.Request, args *swapapi.NFTIDMappingArgs, result *mongodb.MgoNFTIDMapping) error {
+	res, err := swapapi.GetNFTIDMapping(args)
+	if err == nil && res != nil {
+		*result = *res
+	}
+	return err
+}
+
 // GetTokenConfigArgs args
 type GetTokenConfigArgs struct {
 	ChainID string `json:"chainid"`
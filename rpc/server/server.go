@@ -137,11 +137,21 @@ func initRouterSwapRouter(r *mux.Router) {
 	r.HandleFunc("/swap/status/{chainid}/{txid}", restapi.GetRouterSwapHandler).Methods("GET")
 	r.HandleFunc("/swap/status/{chainid}/{txid}/all", restapi.GetRouterSwapsHandler).Methods("GET")
 	r.HandleFunc("/swap/history/{chainid}/{address}", restapi.GetRouterSwapHistoryHandler).Methods("GET")
+	r.HandleFunc("/swap/search", restapi.SearchRouterSwapsHandler).Methods("GET")
 
 	r.HandleFunc("/allchainids", restapi.GetAllChainIDsHandler).Methods("GET")
 	r.HandleFunc("/alltokenids", restapi.GetAllTokenIDsHandler).Methods("GET")
 	r.HandleFunc("/allmultichaintokens/{tokenid}", restapi.GetAllMultichainTokensHandler).Methods("GET")
 	r.HandleFunc("/chainconfig/{chainid}", restapi.GetChainConfigHandler).Methods("GET")
+	r.HandleFunc("/gasprice/{chainid}", restapi.GetGasPriceEstimateHandler).Methods("GET")
+	r.HandleFunc("/gasprices", restapi.GetGasPriceEstimatesHandler).Methods("GET")
+	r.HandleFunc("/balance/{chainid}", restapi.GetAccountBalanceHandler).Methods("GET")
+	r.HandleFunc("/balance/{chainid}/{account}", restapi.GetAccountBalanceHandler).Methods("GET")
+	r.HandleFunc("/signgrouphealth", restapi.SignGroupHealthHandler).Methods("GET")
+	r.HandleFunc("/stuckswaps", restapi.StuckSwapsHandler).Methods("GET")
+	r.HandleFunc("/metrics", restapi.MetricsHandler).Methods("GET")
+	r.HandleFunc("/nftid/{chainid}/{token}/{id}", restapi.GetNFTIDMappingHandler).Methods("GET")
+	r.HandleFunc("/nftnativeid/{chainid}/{nativeid}", restapi.GetNFTIDMappingHandler).Methods("GET")
 	r.HandleFunc("/tokenconfig/{chainid}/{address:.*}", restapi.GetTokenConfigHandler).Methods("GET")
 	r.HandleFunc("/swapconfig/{tokenid}/{fromchainid}/{tochainid}", restapi.GetSwapConfigHandler).Methods("GET")
 	r.HandleFunc("/feeconfig/{tokenid}/{fromchainid}/{tochainid}", restapi.GetFeeConfigHandler).Methods("GET")
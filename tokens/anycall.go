@@ -0,0 +1,135 @@
+package tokens
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/anyswap/CrossChain-Router/v3/common"
+	"github.com/anyswap/CrossChain-Router/v3/common/hexutil"
+	"github.com/anyswap/CrossChain-Router/v3/params"
+)
+
+// AnyCallArg anycall message field passed to the target on non-evm chain
+type AnyCallArg struct {
+	Name  string
+	Field string
+	// value type is string, uint64 or []byte
+	// (data and extData are string in utf8 and hex encoding)
+	Value interface{}
+}
+
+// GetAnyCallAppConfig get config of delivering anycall message to non-evm chain,
+// the message must call to the configed target.
+func GetAnyCallAppConfig(info *AnyCallSwapInfo, chainID string) (*params.AnycallAppConfig, error) {
+	if info == nil {
+		return nil, ErrSwapTypeNotSupported
+	}
+	cfg := params.GetAnycallAppConfig(info.AppID, chainID)
+	if cfg == nil {
+		return nil, fmt.Errorf("%w: appID '%v' chainID %v", ErrAnyCallAppNotConfiged, info.AppID, chainID)
+	}
+	if !isSameAnyCallTarget(info.CallTo, cfg.Target) {
+		return nil, fmt.Errorf("%w: have '%v' want '%v'", ErrAnyCallTargetMismatch, info.CallTo, cfg.Target)
+	}
+	return cfg, nil
+}
+
+// GetAnyCallExecutor get anycall executor of dest chain,
+// the mpc calls the executor, which rejects replayed swapIDs and calls the target without the mpc signer.
+func GetAnyCallExecutor(chainID string) (string, error) {
+	executor := params.GetLocalChainConfig(chainID).AnyCallExecutor
+	if executor == "" {
+		return "", fmt.Errorf("%w: chainID %v", ErrAnyCallNoExecutor, chainID)
+	}
+	return executor, nil
+}
+
+// CheckAnyCallSwapIDArg check the target is passed swapID to reject replayed messages,
+// it is required on chains where the mpc calls the target directly.
+func CheckAnyCallSwapIDArg(cfg *params.AnycallAppConfig) error {
+	if !cfg.HasArgField(params.AnycallArgSwapID) {
+		return fmt.Errorf("%w: target '%v'", ErrAnyCallNoSwapID, cfg.Target)
+	}
+	return nil
+}
+
+// hex addresses are case insensitive, others (eg. base58) are not
+func isSameAnyCallTarget(callTo, target string) bool {
+	if strings.HasPrefix(target, "0x") {
+		return strings.EqualFold(callTo, target)
+	}
+	return callTo == target
+}
+
+// GetAnyCallArgs get message fields passed to the target in order
+func GetAnyCallArgs(args *BuildTxArgs, cfg *params.AnycallAppConfig) ([]*AnyCallArg, error) {
+	info := args.AnyCallSwapInfo
+	if info == nil {
+		return nil, ErrSwapTypeNotSupported
+	}
+	appArgs := cfg.GetArgs()
+	result := make([]*AnyCallArg, len(appArgs))
+	for i, appArg := range appArgs {
+		var value interface{}
+		var err error
+		switch appArg.Field {
+		case params.AnycallArgCallFrom:
+			value = info.CallFrom
+		case params.AnycallArgFromChainID:
+			if !args.FromChainID.IsUint64() {
+				return nil, fmt.Errorf("from chain id %v overflows uint64", args.FromChainID)
+			}
+			value = args.FromChainID.Uint64()
+		case params.AnycallArgSwapID:
+			value = args.SwapID
+		case params.AnycallArgAppID:
+			value = info.AppID
+		case params.AnycallArgNonce:
+			value, err = getAnyCallUint64(info.Nonce)
+		case params.AnycallArgFlags:
+			value, err = getAnyCallUint64(info.Flags)
+		case params.AnycallArgData:
+			value, err = encodeAnyCallData(info.CallData, cfg.GetEncoding())
+		case params.AnycallArgExtData:
+			value, err = encodeAnyCallData(info.ExtData, cfg.GetEncoding())
+		default:
+			err = fmt.Errorf("unsupported anycall arg field '%v'", appArg.Field)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("anycall arg %v: %w", appArg.Name, err)
+		}
+		result[i] = &AnyCallArg{Name: appArg.Name, Field: appArg.Field, Value: value}
+	}
+	return result, nil
+}
+
+func getAnyCallUint64(str string) (uint64, error) {
+	if str == "" {
+		return 0, nil
+	}
+	bi, err := common.GetBigIntFromStr(str)
+	if err != nil {
+		return 0, err
+	}
+	if !bi.IsUint64() {
+		return 0, fmt.Errorf("%v overflows uint64", str)
+	}
+	return bi.Uint64(), nil
+}
+
+func encodeAnyCallData(data []byte, encoding string) (interface{}, error) {
+	switch encoding {
+	case params.AnycallEncodingBytes:
+		return data, nil
+	case params.AnycallEncodingUTF8:
+		if !utf8.Valid(data) {
+			return nil, fmt.Errorf("data is not valid utf8 string")
+		}
+		return string(data), nil
+	case params.AnycallEncodingHex:
+		return hexutil.Encode(data), nil
+	default:
+		return nil, fmt.Errorf("unsupported anycall data encoding '%v'", encoding)
+	}
+}
@@ -0,0 +1,55 @@
+package tokens
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Router/v3/params"
+)
+
+func TestCheckAnyCallSwapIDArg(t *testing.T) {
+	tests := []struct {
+		cfg     *params.AnycallAppConfig
+		wantErr bool
+	}{
+		{&params.AnycallAppConfig{}, false}, // default args contain swapID
+		{&params.AnycallAppConfig{Args: []string{"swap_id:swapID", "msg:data"}}, false},
+		{&params.AnycallAppConfig{Args: []string{"callFrom", "data"}}, true},
+		{&params.AnycallAppConfig{Args: []string{"swapID:data"}}, true}, // name is not field
+		{&params.AnycallAppConfig{Encoding: params.AnycallEncodingRaw}, true},
+	}
+	for i, test := range tests {
+		err := CheckAnyCallSwapIDArg(test.cfg)
+		if (err != nil) != test.wantErr {
+			t.Errorf("test %v: want error %v, but got %v", i, test.wantErr, err)
+		}
+		if err != nil && !errors.Is(err, ErrAnyCallNoSwapID) {
+			t.Errorf("test %v: want error %v, but got %v", i, ErrAnyCallNoSwapID, err)
+		}
+	}
+}
+
+func TestGetAnyCallExecutor(t *testing.T) {
+	defer params.SetExtraConfig(&params.ExtraConfig{})
+	params.SetExtraConfig(&params.ExtraConfig{
+		LocalChainConfig: map[string]*params.LocalChainConfig{
+			"1": {AnyCallExecutor: "executor"},
+			"2": {},
+		},
+	})
+
+	tests := []struct {
+		chainID string
+		want    string
+	}{
+		{"1", "executor"},
+		{"2", ""},
+		{"3", ""},
+	}
+	for i, test := range tests {
+		have, err := GetAnyCallExecutor(test.chainID)
+		if have != test.want || (err != nil) != (test.want == "") {
+			t.Errorf("test %v: want %v, but got %v (error %v)", i, test.want, have, err)
+		}
+	}
+}
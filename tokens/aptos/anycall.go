@@ -0,0 +1,185 @@
+package aptos
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Router/v3/common"
+	"github.com/anyswap/CrossChain-Router/v3/common/hexutil"
+	"github.com/anyswap/CrossChain-Router/v3/log"
+	"github.com/anyswap/CrossChain-Router/v3/params"
+	"github.com/anyswap/CrossChain-Router/v3/router"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+)
+
+var (
+	// ensure Bridge impl tokens.AnyCallAppChecker
+	_ tokens.AnyCallAppChecker = &Bridge{}
+)
+
+// CheckAnyCallApp check anycall message can be delivered to move module
+func (b *Bridge) CheckAnyCallApp(info *tokens.AnyCallSwapInfo) error {
+	cfg, err := tokens.GetAnyCallAppConfig(info, b.ChainConfig.ChainID)
+	if err != nil {
+		return err
+	}
+	if !b.IsValidAddress(cfg.Target) {
+		return fmt.Errorf("invalid anycall target '%v'", cfg.Target)
+	}
+	if len(strings.Split(cfg.Function, "::")) != 2 {
+		return fmt.Errorf("anycall function '%v' is not in format of 'module::function'", cfg.Function)
+	}
+	_, err = b.getAnyCallExecutor()
+	return err
+}
+
+func (b *Bridge) getAnyCallExecutor() (string, error) {
+	executor, err := tokens.GetAnyCallExecutor(b.ChainConfig.ChainID)
+	if err != nil {
+		return "", err
+	}
+	if !b.IsValidAddress(executor) {
+		return "", fmt.Errorf("invalid anycall executor '%v'", executor)
+	}
+	return executor, nil
+}
+
+// buildAnyCallSwapTx deliver anycall message by calling `execute` of the executor module,
+// the executor rejects replayed swapIDs and keeps the message for the target module,
+// which takes it by its own module witness. the mpc signer is never passed to the target.
+func (b *Bridge) buildAnyCallSwapTx(args *tokens.BuildTxArgs) (*Transaction, error) {
+	cfg, err := tokens.GetAnyCallAppConfig(args.AnyCallSwapInfo, b.ChainConfig.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := b.buildAnyCallPayload(args, cfg)
+	if err != nil {
+		return nil, err
+	}
+	args.SwapValue = big.NewInt(0)
+
+	if args.Extra == nil {
+		args.Extra = &tokens.AllExtras{}
+	}
+	if args.Extra.Fee == nil && cfg.Gas > 0 {
+		maxGasAmount := strconv.FormatUint(cfg.Gas, 10)
+		args.Extra.Fee = &maxGasAmount
+	}
+	err = b.SetExtraArgs(args, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := &Transaction{
+		Sender:                  args.From,
+		SequenceNumber:          strconv.FormatUint(*args.Extra.Sequence, 10),
+		MaxGasAmount:            *args.Extra.Fee,
+		GasUnitPrice:            strconv.FormatUint(*args.Extra.Gas, 10),
+		ExpirationTimestampSecs: *args.Extra.BlockHash,
+		Payload:                 payload,
+	}
+
+	if params.IsSwapServer {
+		mpcPubkey := router.GetMPCPublicKey(args.From)
+		if mpcPubkey == "" {
+			return nil, tokens.ErrMissMPCPublicKey
+		}
+		err = b.SimulateTranscation(tx, mpcPubkey)
+		if err != nil {
+			return nil, fmt.Errorf("%w %v", tokens.ErrBuildTxErrorAndDelay, err)
+		}
+	}
+	log.Info("build anycall raw tx", "identifier", args.Identifier, "swapID", args.SwapID,
+		"fromChainID", args.FromChainID, "toChainID", args.ToChainID, "from", args.From,
+		"function", payload.Function, "nonce", tx.SequenceNumber, "gasPrice", tx.GasUnitPrice,
+		"maxGasAmount", tx.MaxGasAmount, "replaceNum", args.GetReplaceNum())
+	return tx, nil
+}
+
+// buildAnyCallPayload build payload of `executor::execute(swap_id: String, target: address, function: String,
+// type_args: vector<String>, args: vector<vector<u8>>)`, each message arg is bcs encoded (u64, String or vector<u8>),
+// or the call data itself is the only one in raw encoding.
+func (b *Bridge) buildAnyCallPayload(args *tokens.BuildTxArgs, cfg *params.AnycallAppConfig) (*TransactionPayload, error) {
+	executor, err := b.getAnyCallExecutor()
+	if err != nil {
+		return nil, err
+	}
+	var messageArgs []string
+	if cfg.IsRawEncoding() {
+		messageArgs = []string{hexutil.Encode(args.AnyCallSwapInfo.CallData)}
+	} else {
+		anycallArgs, errf := tokens.GetAnyCallArgs(args, cfg)
+		if errf != nil {
+			return nil, errf
+		}
+		messageArgs = make([]string, len(anycallArgs))
+		for i, arg := range anycallArgs {
+			encoded, errf := bcsEncodeAnyCallArg(arg.Value)
+			if errf != nil {
+				return nil, errf
+			}
+			messageArgs[i] = hexutil.Encode(encoded)
+		}
+	}
+	typeArgs := cfg.TypeArgs
+	if typeArgs == nil {
+		typeArgs = []string{}
+	}
+	return &TransactionPayload{
+		Type:          SCRIPT_FUNCTION_PAYLOAD,
+		Function:      executor + "::executor::execute",
+		TypeArguments: []string{},
+		Arguments:     []interface{}{args.SwapID, cfg.Target, cfg.Function, typeArgs, messageArgs},
+	}, nil
+}
+
+// bcsEncodeAnyCallArg encode u64 in little endian, string and bytes with uleb128 length prefix
+func bcsEncodeAnyCallArg(value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case uint64:
+		var buf [8]byte
+		binary.LittleEndian.PutUint64(buf[:], v)
+		return buf[:], nil
+	case string:
+		return bcsEncodeBytes([]byte(v)), nil
+	case []byte:
+		return bcsEncodeBytes(v), nil
+	default:
+		return nil, fmt.Errorf("unsupported anycall arg type %T", value)
+	}
+}
+
+func bcsEncodeBytes(data []byte) []byte {
+	result := make([]byte, 0, len(data)+5)
+	length := uint32(len(data))
+	for length >= 0x80 {
+		result = append(result, byte(length)|0x80)
+		length >>= 7
+	}
+	result = append(result, byte(length))
+	return append(result, data...)
+}
+
+func (b *Bridge) verifyAnyCallTransactionWithArgs(tx *Transaction, args *tokens.BuildTxArgs) error {
+	cfg, err := tokens.GetAnyCallAppConfig(args.AnyCallSwapInfo, b.ChainConfig.ChainID)
+	if err != nil {
+		return err
+	}
+	payload, err := b.buildAnyCallPayload(args, cfg)
+	if err != nil {
+		return err
+	}
+	if tx.Payload == nil {
+		return errors.New("[sign] anycall tx without payload")
+	}
+	have := common.ToJSONString(tx.Payload, false)
+	want := common.ToJSONString(payload, false)
+	if have != want {
+		return fmt.Errorf("[sign] anycall payload mismatch: have %v want %v", have, want)
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package aptos
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Router/v3/common"
+	"github.com/anyswap/CrossChain-Router/v3/params"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+)
+
+const (
+	testAnyCallChainID  = "1000004280404"
+	testAnyCallExecutor = "0x2222222222222222222222222222222222222222222222222222222222222222"
+	testAnyCallTarget   = "0x1111111111111111111111111111111111111111111111111111111111111111"
+)
+
+// newAnyCallTestBridge set anycall extra config, and restore it after test
+func newAnyCallTestBridge(t *testing.T, encoding string) *Bridge {
+	prevExtra := params.GetExtraConfig()
+	t.Cleanup(func() {
+		if prevExtra == nil {
+			prevExtra = &params.ExtraConfig{}
+		}
+		_ = params.SetExtraConfig(prevExtra)
+	})
+	err := params.SetExtraConfig(&params.ExtraConfig{
+		LocalChainConfig: map[string]*params.LocalChainConfig{
+			testAnyCallChainID: {AnyCallExecutor: testAnyCallExecutor},
+		},
+		AnycallApps: map[string]map[string]*params.AnycallAppConfig{
+			"app": {testAnyCallChainID: {
+				Target:   testAnyCallTarget,
+				Function: "receiver::anycall_exec",
+				Encoding: encoding,
+			}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("set extra config failed: %v", err)
+	}
+	b := NewCrossChainBridge()
+	b.SetChainConfig(&tokens.ChainConfig{ChainID: testAnyCallChainID})
+	return b
+}
+
+func newAnyCallTestArgs(swapID string) *tokens.BuildTxArgs {
+	return &tokens.BuildTxArgs{
+		SwapArgs: tokens.SwapArgs{
+			SwapID:      swapID,
+			SwapType:    tokens.AnyCallSwapType,
+			FromChainID: big.NewInt(1),
+			ToChainID:   big.NewInt(1000004280404),
+			SwapInfo: tokens.SwapInfo{AnyCallSwapInfo: &tokens.AnyCallSwapInfo{
+				CallFrom: "0xaa",
+				CallTo:   testAnyCallTarget,
+				CallData: []byte{1, 2, 3},
+				AppID:    "app",
+			}},
+		},
+	}
+}
+
+func TestBCSEncodeAnyCallArg(t *testing.T) {
+	tests := []struct {
+		value   interface{}
+		want    []byte
+		wantErr bool
+	}{
+		{uint64(1), []byte{1, 0, 0, 0, 0, 0, 0, 0}, false},
+		{"ab", []byte{2, 'a', 'b'}, false},
+		{[]byte{}, []byte{0}, false},
+		{bytes.Repeat([]byte{7}, 128), append([]byte{0x80, 0x01}, bytes.Repeat([]byte{7}, 128)...), false},
+		{int64(1), nil, true},
+	}
+	for i, test := range tests {
+		have, err := bcsEncodeAnyCallArg(test.value)
+		if (err != nil) != test.wantErr {
+			t.Errorf("test %v: want error %v, but got %v", i, test.wantErr, err)
+			continue
+		}
+		if !bytes.Equal(have, test.want) {
+			t.Errorf("test %v: want %x, but got %x", i, test.want, have)
+		}
+	}
+}
+
+func TestBuildAnyCallPayload(t *testing.T) {
+	tests := []struct {
+		encoding    string
+		messageArgs []string
+	}{
+		// default args: callFrom, fromChainID, swapID, data
+		{"", []string{
+			common.ToHex(append([]byte{4}, "0xaa"...)),
+			"0x0100000000000000",
+			common.ToHex(append([]byte{6}, "0xswap"...)),
+			"0x03010203",
+		}},
+		{params.AnycallEncodingRaw, []string{"0x010203"}},
+	}
+	for i, test := range tests {
+		b := newAnyCallTestBridge(t, test.encoding)
+		args := newAnyCallTestArgs("0xswap")
+		cfg, err := tokens.GetAnyCallAppConfig(args.AnyCallSwapInfo, testAnyCallChainID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		payload, err := b.buildAnyCallPayload(args, cfg)
+		if err != nil {
+			t.Errorf("test %v: build payload failed: %v", i, err)
+			continue
+		}
+		// the mpc calls the executor instead of the target
+		if payload.Function != testAnyCallExecutor+"::executor::execute" {
+			t.Errorf("test %v: want executor function, but got %v", i, payload.Function)
+		}
+		if len(payload.Arguments) != 5 ||
+			payload.Arguments[0] != args.SwapID ||
+			payload.Arguments[1] != testAnyCallTarget ||
+			payload.Arguments[2] != cfg.Function {
+			t.Errorf("test %v: wrong arguments %v", i, payload.Arguments)
+			continue
+		}
+		messageArgs := strings.Join(payload.Arguments[4].([]string), ",")
+		want := strings.Join(test.messageArgs, ",")
+		if messageArgs != want {
+			t.Errorf("test %v: want message args %v, but got %v", i, want, messageArgs)
+		}
+	}
+}
+
+func TestVerifyAnyCallTransactionWithArgs(t *testing.T) {
+	b := newAnyCallTestBridge(t, "")
+	args := newAnyCallTestArgs("0xswap")
+	cfg, _ := tokens.GetAnyCallAppConfig(args.AnyCallSwapInfo, testAnyCallChainID)
+	payload, err := b.buildAnyCallPayload(args, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := &Transaction{Payload: payload}
+
+	if err = b.verifyAnyCallTransactionWithArgs(tx, args); err != nil {
+		t.Errorf("verify anycall tx failed: %v", err)
+	}
+	if err = b.verifyAnyCallTransactionWithArgs(tx, newAnyCallTestArgs("0xother")); err == nil {
+		t.Errorf("verify anycall tx of other swap should fail")
+	}
+	if err = b.verifyAnyCallTransactionWithArgs(&Transaction{}, args); err == nil {
+		t.Errorf("verify anycall tx without payload should fail")
+	}
+
+	direct := *payload
+	direct.Function = testAnyCallTarget + "::" + cfg.Function
+	if err = b.verifyAnyCallTransactionWithArgs(&Transaction{Payload: &direct}, args); err == nil {
+		t.Errorf("verify anycall tx calling target directly should fail")
+	}
+
+	params.GetExtraConfig().LocalChainConfig = nil
+	if err = b.verifyAnyCallTransactionWithArgs(tx, args); err == nil {
+		t.Errorf("verify anycall tx without executor should fail")
+	}
+}
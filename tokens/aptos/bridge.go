@@ -103,8 +103,13 @@ func (b *Bridge) SetTokenConfig(tokenAddr string, tokenCfg *tokens.TokenConfig)
 		}
 	}
 	// aptos every coin has Extra, Extra is underlying if anytoken, Extra is ContractAddress when others
+	// fungible asset is the same but with metadata object address instead of coin type
 
-	if !strings.Contains(tokenCfg.Extra, SPLIT_SYMBOL) {
+	if IsFungibleAsset(tokenCfg.ContractAddress) {
+		if !IsFungibleAsset(tokenCfg.Extra) {
+			tokenCfg.Extra = tokenCfg.ContractAddress
+		}
+	} else if !strings.Contains(tokenCfg.Extra, SPLIT_SYMBOL) {
 		tokenCfg.Extra = tokenCfg.ContractAddress
 	}
 	b.CrossChainBridgeBase.SetTokenConfig(tokenAddr, tokenCfg)
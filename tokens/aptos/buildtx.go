@@ -26,12 +26,19 @@ func (b *Bridge) BuildRawTransaction(args *tokens.BuildTxArgs) (rawTx interface{
 	if args.ToChainID.String() != b.ChainConfig.ChainID {
 		return nil, tokens.ErrToChainIDMismatch
 	}
-	if args.SwapType != tokens.ERC20SwapType {
+	switch args.SwapType {
+	case tokens.ERC20SwapType:
+		if args.ERC20SwapInfo == nil || args.ERC20SwapInfo.TokenID == "" {
+			return nil, tokens.ErrEmptyTokenID
+		}
+	case tokens.NFTSwapType:
+		if args.NFTSwapInfo == nil || args.NFTSwapInfo.TokenID == "" {
+			return nil, tokens.ErrEmptyTokenID
+		}
+	case tokens.AnyCallSwapType:
+	default:
 		return nil, tokens.ErrSwapTypeNotSupported
 	}
-	if args.ERC20SwapInfo == nil || args.ERC20SwapInfo.TokenID == "" {
-		return nil, tokens.ErrEmptyTokenID
-	}
 
 	if args.From == "" {
 		return nil, errors.New("forbid empty sender")
@@ -45,6 +52,13 @@ func (b *Bridge) BuildRawTransaction(args *tokens.BuildTxArgs) (rawTx interface{
 		return nil, tokens.ErrSenderMismatch
 	}
 
+	switch args.SwapType {
+	case tokens.AnyCallSwapType:
+		return b.buildAnyCallSwapTx(args)
+	case tokens.NFTSwapType:
+		return b.buildNFTSwapinTx(args)
+	}
+
 	erc20SwapInfo := args.ERC20SwapInfo
 	tokenID := erc20SwapInfo.TokenID
 	chainID := b.ChainConfig.ChainID
@@ -60,7 +74,7 @@ func (b *Bridge) BuildRawTransaction(args *tokens.BuildTxArgs) (rawTx interface{
 		return nil, tokens.ErrMissTokenConfig
 	}
 
-	err = b.HasRegisterAptosCoin(args.Bind, tokenCfg)
+	err = b.setSwapinDelivery(args, tokenCfg)
 	if err != nil {
 		return nil, err
 	}
@@ -93,28 +107,21 @@ func (b *Bridge) BuildRawTransaction(args *tokens.BuildTxArgs) (rawTx interface{
 		"gasPrice", tx.GasUnitPrice, "gasCurrency", tx.GasCurrencyCode,
 		"originValue", args.OriginValue, "swapValue", args.SwapValue,
 		"replaceNum", args.GetReplaceNum(), "tokenID", tokenID,
+		"function", tx.Payload.Function, "delivery", args.Extra.Delivery,
 	}
 	log.Info(fmt.Sprintf("build %s raw tx", args.SwapType.String()), ctx...)
 	return tx, nil
 }
 
+// HasRegisterAptosCoin check address has registered coin stores of token
 func (b *Bridge) HasRegisterAptosCoin(address string, tokenCfg *tokens.TokenConfig) error {
-	result, err := b.GetAccountBalance(address, tokenCfg.ContractAddress)
+	registered, err := b.IsCoinStoreRegistered(address, tokenCfg)
 	if err != nil {
 		return err
 	}
-	if result == nil || result.Data == nil {
+	if !registered {
 		return fmt.Errorf("%s not register coin %s ", address, tokenCfg.ContractAddress)
 	}
-	if !strings.EqualFold(tokenCfg.ContractAddress, tokenCfg.Extra) {
-		result, err := b.GetAccountBalance(address, tokenCfg.Extra)
-		if err != nil {
-			return err
-		}
-		if result == nil || result.Data == nil {
-			return fmt.Errorf("%s not register coin %s ", address, tokenCfg.Extra)
-		}
-	}
 	return nil
 }
 
@@ -159,6 +166,8 @@ func (b *Bridge) BuildSwapinTransferTransaction(args *tokens.BuildTxArgs, tokenC
 	if err != nil {
 		return nil, err
 	}
+	function, typeArgs, moreArgs := getSwapinFunction(args.From, tokenCfg, args.Extra.Delivery)
+	arguments := []interface{}{receiver, strconv.FormatUint(amount.Uint64(), 10), args.GetUniqueSwapIdentifier(), args.FromChainID.String()}
 	tx := &Transaction{
 		Sender:                  args.From,
 		SequenceNumber:          strconv.FormatUint(*args.Extra.Sequence, 10),
@@ -167,9 +176,9 @@ func (b *Bridge) BuildSwapinTransferTransaction(args *tokens.BuildTxArgs, tokenC
 		ExpirationTimestampSecs: *args.Extra.BlockHash,
 		Payload: &TransactionPayload{
 			Type:          SCRIPT_FUNCTION_PAYLOAD,
-			Function:      GetRouterFunctionId(args.From, CONTRACT_NAME_ROUTER, CONTRACT_FUNC_SWAPIN),
-			TypeArguments: []string{tokenCfg.Extra, tokenCfg.ContractAddress},
-			Arguments:     []interface{}{receiver, strconv.FormatUint(amount.Uint64(), 10), args.GetUniqueSwapIdentifier(), args.FromChainID.String()},
+			Function:      function,
+			TypeArguments: typeArgs,
+			Arguments:     append(arguments, moreArgs...),
 		},
 	}
 	args.SwapValue = amount
@@ -481,6 +490,12 @@ func (b *Bridge) BuildSwapoutTransaction(sender, router, coin, toAddress, tochai
 			Arguments:     []interface{}{strconv.FormatUint(amount, 10), toAddress, tochainId},
 		},
 	}
+	if IsFungibleAsset(coin) {
+		// swapout_fa(account: &signer, token: address, amount: u64, _receiver: String, _toChainID: u64)
+		tx.Payload.Function = GetRouterFunctionId(router, CONTRACT_NAME_ROUTER, CONTRACT_FUNC_SWAPOUT_FA)
+		tx.Payload.TypeArguments = []string{}
+		tx.Payload.Arguments = append([]interface{}{coin}, tx.Payload.Arguments...)
+	}
 	return tx, nil
 }
 
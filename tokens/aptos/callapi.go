@@ -14,6 +14,9 @@ var errTxResultType = errors.New("tx type is not TransactionInfo")
 
 // GetTokenDecimals query
 func (b *Bridge) GetTokenDecimals(resource string) (uint8, error) {
+	if IsFungibleAsset(resource) {
+		return b.getFungibleAssetDecimals(resource)
+	}
 	infos := strings.Split(resource, SPLIT_SYMBOL)
 	resp := &CoinInfoResource{}
 	err := b.GetAccountResource(infos[0], fmt.Sprintf(COIN_INFO_PREFIX, resource), resp)
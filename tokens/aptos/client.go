@@ -31,9 +31,13 @@ var (
 	SPLIT_SYMBOL         = "::"
 	CONTRACT_NAME_ROUTER = "Router"
 	CONTRACT_NAME_POOL   = "Pool"
+	CONTRACT_NAME_NFT    = "NFT"
 
 	CONTRACT_FUNC_SWAPIN             = "swapin"
+	CONTRACT_FUNC_SWAPIN_REGISTER    = "swapin_register"
+	CONTRACT_FUNC_SWAPIN_FA          = "swapin_fa"
 	CONTRACT_FUNC_SWAPOUT            = "swapout"
+	CONTRACT_FUNC_SWAPOUT_FA         = "swapout_fa"
 	CONTRACT_FUNC_REGISTER_COIN      = "register_coin"
 	CONTRACT_FUNC_SET_COIN           = "set_coin"
 	CONTRACT_FUNC_SET_POOLCOIN_CAP   = "set_poolcoin_cap"
@@ -43,12 +47,18 @@ var (
 	CONTRACT_FUNC_DEPOSIT  = "deposit"
 	CONTRACT_FUNC_WITHDRAW = "withdraw"
 
+	CONTRACT_FUNC_NFT_SWAPIN = "nft_swapin"
+
+	COLLECTION_RESOURCE = "0x4::collection::Collection"
+
 	NATIVE_COIN = "0x1::aptos_coin::AptosCoin"
 
 	PUBLISH_PACKAGE = "0x1::code::publish_package_txn"
 
 	COIN_INFO_PREFIX = "0x1::coin::CoinInfo<%s>"
 
+	FA_METADATA_RESOURCE = "0x1::fungible_asset::Metadata"
+
 	SUCCESS_HTTP_STATUS_CODE = map[int]bool{200: true, 202: true}
 )
 
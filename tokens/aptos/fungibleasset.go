@@ -0,0 +1,148 @@
+package aptos
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Router/v3/common"
+	"github.com/anyswap/CrossChain-Router/v3/log"
+	"github.com/anyswap/CrossChain-Router/v3/router"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+)
+
+var errFAWithoutStore = errors.New("fungible asset is deposited to primary store without registering")
+
+// IsFungibleAsset is token of fungible asset standard, which is identified by its metadata object address,
+// while the legacy coin is identified by coin type `address::module::struct`
+func IsFungibleAsset(token string) bool {
+	if strings.Contains(token, SPLIT_SYMBOL) || !common.HasHexPrefix(token) {
+		return false
+	}
+	s := token[2:]
+	if len(s) == 0 || len(s) > 64 {
+		return false
+	}
+	for _, c := range []byte(s) {
+		if !common.IsHexCharacter(c) {
+			return false
+		}
+	}
+	return true
+}
+
+func (b *Bridge) getFungibleAssetDecimals(metadata string) (uint8, error) {
+	// fungible asset metadata has the same name, symbol and decimals fields as coin info
+	resp := &CoinInfoResource{}
+	err := b.GetAccountResource(metadata, FA_METADATA_RESOURCE, resp)
+	if err != nil {
+		return 0, err
+	}
+	return resp.Data.Decimals, nil
+}
+
+// IsCoinStoreRegistered is coin stores of anycoin and underlying coin registered by address
+func (b *Bridge) IsCoinStoreRegistered(address string, tokenCfg *tokens.TokenConfig) (bool, error) {
+	coins := []string{tokenCfg.ContractAddress}
+	if !strings.EqualFold(tokenCfg.ContractAddress, tokenCfg.Extra) {
+		coins = append(coins, tokenCfg.Extra)
+	}
+	for _, coin := range coins {
+		result, err := b.GetAccountBalance(address, coin)
+		if err != nil {
+			return false, err
+		}
+		if result == nil || result.Data == nil {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// setSwapinDelivery choose how to deliver swapin value to receiver.
+// fungible asset is always deposited to the primary store of receiver (created automatically if not exist).
+// coin is deposited by `swapin` if receiver has registered the coin stores,
+// otherwise by `swapin_register` which registers coin stores for receiver and then deposit.
+// the decision is kept in `args.Extra.Delivery` to be followed when rebuilding tx.
+func (b *Bridge) setSwapinDelivery(args *tokens.BuildTxArgs, tokenCfg *tokens.TokenConfig) error {
+	if args.Extra == nil {
+		args.Extra = &tokens.AllExtras{}
+	}
+	isFA := IsFungibleAsset(tokenCfg.ContractAddress)
+	delivery := args.Extra.Delivery
+	switch delivery {
+	case tokens.DeliveryPayment:
+	case tokens.DeliveryRegisterDeposit:
+		if isFA {
+			return errFAWithoutStore
+		}
+	case "":
+		delivery = tokens.DeliveryPayment
+		if !isFA {
+			registered, err := b.IsCoinStoreRegistered(args.Bind, tokenCfg)
+			if err != nil {
+				return err
+			}
+			if !registered {
+				delivery = tokens.DeliveryRegisterDeposit
+			}
+		}
+		args.Extra.Delivery = delivery
+	default:
+		return fmt.Errorf("unknown delivery '%v'", delivery)
+	}
+	if delivery == tokens.DeliveryRegisterDeposit {
+		log.Info("aptos receiver has not registered coin store, swapin by register and deposit",
+			"receiver", args.Bind, "coin", tokenCfg.ContractAddress, "underlying", tokenCfg.Extra, "swapID", args.SwapID)
+	}
+	return nil
+}
+
+// getSwapinFunction get swapin function, type arguments and the arguments following
+// the common ones (receiver, amount, swapID, fromChainID) according to token standard and delivery
+//
+//	swapin<CoinType, PoolCoin>(admin: &signer, receiver: address, amount: u64, _fromEvent: String, _fromChainID: u64)
+//	swapin_register<CoinType, PoolCoin>(admin: &signer, receiver: address, amount: u64, _fromEvent: String, _fromChainID: u64)
+//	swapin_fa(admin: &signer, receiver: address, amount: u64, _fromEvent: String, _fromChainID: u64, underlying: address, token: address)
+func getSwapinFunction(routerAddr string, tokenCfg *tokens.TokenConfig, delivery string) (function string, typeArgs []string, moreArgs []interface{}) {
+	switch {
+	case IsFungibleAsset(tokenCfg.ContractAddress):
+		function = GetRouterFunctionId(routerAddr, CONTRACT_NAME_ROUTER, CONTRACT_FUNC_SWAPIN_FA)
+		return function, []string{}, []interface{}{tokenCfg.Extra, tokenCfg.ContractAddress}
+	case delivery == tokens.DeliveryRegisterDeposit:
+		function = GetRouterFunctionId(routerAddr, CONTRACT_NAME_ROUTER, CONTRACT_FUNC_SWAPIN_REGISTER)
+	default:
+		function = GetRouterFunctionId(routerAddr, CONTRACT_NAME_ROUTER, CONTRACT_FUNC_SWAPIN)
+	}
+	return function, []string{tokenCfg.Extra, tokenCfg.ContractAddress}, nil
+}
+
+// verifySwapinFunction verify swapin function, type arguments and the trailing arguments
+func (b *Bridge) verifySwapinFunction(tx *Transaction, args *tokens.BuildTxArgs) error {
+	multichainToken := ""
+	if args.ERC20SwapInfo != nil {
+		multichainToken = router.GetCachedMultichainToken(args.ERC20SwapInfo.TokenID, b.ChainConfig.ChainID)
+	}
+	tokenCfg := b.GetTokenConfig(multichainToken)
+	if tokenCfg == nil {
+		return tokens.ErrMissTokenConfig
+	}
+	delivery := ""
+	if args.Extra != nil {
+		delivery = args.Extra.Delivery
+	}
+	function, typeArgs, moreArgs := getSwapinFunction(args.From, tokenCfg, delivery)
+	if !strings.EqualFold(tx.Payload.Function, function) {
+		return fmt.Errorf("[sign] swapin function mismatch: have %v want %v", tx.Payload.Function, function)
+	}
+	if have, want := common.ToJSONString(tx.Payload.TypeArguments, false), common.ToJSONString(typeArgs, false); have != want {
+		return fmt.Errorf("[sign] swapin type arguments mismatch: have %v want %v", have, want)
+	}
+	if len(tx.Payload.Arguments) != 4+len(moreArgs) {
+		return fmt.Errorf("[sign] swapin arguments length mismatch: have %v want %v", len(tx.Payload.Arguments), 4+len(moreArgs))
+	}
+	if have, want := common.ToJSONString(tx.Payload.Arguments[4:], false), common.ToJSONString(moreArgs, false); len(moreArgs) > 0 && have != want {
+		return fmt.Errorf("[sign] swapin arguments mismatch: have %v want %v", have, want)
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package aptos
+
+import (
+	"testing"
+
+	"github.com/anyswap/CrossChain-Router/v3/common"
+	"github.com/anyswap/CrossChain-Router/v3/router"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+)
+
+const (
+	aptosRouterAccount = "0x3333333333333333333333333333333333333333333333333333333333333333"
+
+	// legacy coin, the pool coin is wrapped by router
+	aptosUnderlyingCoin = "0x1::aptos_coin::AptosCoin"
+	aptosPoolCoin       = aptosRouterAccount + "::PoolCoin::AnyCoin"
+	// fungible asset, the token is the metadata object address
+	aptosFAUnderlying = "0x000000000000000000000000000000000000000000000000000000000000000a"
+	aptosFAToken      = "0xa"
+)
+
+var (
+	coinTokenConfig = &tokens.TokenConfig{TokenID: "anyCoin", ContractAddress: aptosPoolCoin, Extra: aptosUnderlyingCoin}
+	faTokenConfig   = &tokens.TokenConfig{TokenID: "anyFA", ContractAddress: aptosFAToken, Extra: aptosFAUnderlying}
+)
+
+func TestIsFungibleAsset(t *testing.T) {
+	cases := []struct {
+		Token    string
+		Expected bool
+	}{
+		{aptosFAUnderlying, true},
+		{aptosFAToken, true},
+		{"0xABCdef", true},
+		{aptosUnderlyingCoin, false},
+		{aptosPoolCoin, false},
+		{"0xa::", false},
+		{"", false},
+		{"0x", false},
+		{"a", false},
+		{"0xg", false},
+		{aptosFAUnderlying + "0", false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Token, func(t *testing.T) {
+			if ans := IsFungibleAsset(c.Token); ans != c.Expected {
+				t.Fatalf("%s expected %v, but got %v", c.Token, c.Expected, ans)
+			}
+		})
+	}
+}
+
+func TestSetSwapinDelivery(t *testing.T) {
+	cases := []struct {
+		Name     string
+		TokenCfg *tokens.TokenConfig
+		Delivery string
+		Expected string // empty means error
+	}{
+		{"fungible asset default", faTokenConfig, "", tokens.DeliveryPayment},
+		{"fungible asset payment", faTokenConfig, tokens.DeliveryPayment, tokens.DeliveryPayment},
+		{"fungible asset has no coin store to register", faTokenConfig, tokens.DeliveryRegisterDeposit, ""},
+		{"coin payment", coinTokenConfig, tokens.DeliveryPayment, tokens.DeliveryPayment},
+		{"coin register deposit", coinTokenConfig, tokens.DeliveryRegisterDeposit, tokens.DeliveryRegisterDeposit},
+		{"coin create account", coinTokenConfig, tokens.DeliveryCreateAccount, ""},
+		{"unknown delivery", faTokenConfig, "unknown", ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			args := &tokens.BuildTxArgs{}
+			if c.Delivery != "" {
+				args.Extra = &tokens.AllExtras{Delivery: c.Delivery}
+			}
+			err := aptosBridge.setSwapinDelivery(args, c.TokenCfg)
+			if c.Expected == "" {
+				if err == nil {
+					t.Fatalf("%s expected error, but got delivery %v", c.Name, args.Extra.Delivery)
+				}
+				return
+			}
+			if err != nil || args.Extra.Delivery != c.Expected {
+				t.Fatalf("%s expected %v, but got %v %v", c.Name, c.Expected, args.Extra, err)
+			}
+		})
+	}
+}
+
+func TestGetSwapinFunction(t *testing.T) {
+	coinTypeArgs := []string{aptosUnderlyingCoin, aptosPoolCoin}
+	faMoreArgs := []interface{}{aptosFAUnderlying, aptosFAToken}
+
+	cases := []struct {
+		Name     string
+		TokenCfg *tokens.TokenConfig
+		Delivery string
+		Function string
+		TypeArgs []string
+		MoreArgs []interface{}
+	}{
+		{"coin default", coinTokenConfig, "", CONTRACT_FUNC_SWAPIN, coinTypeArgs, nil},
+		{"coin payment", coinTokenConfig, tokens.DeliveryPayment, CONTRACT_FUNC_SWAPIN, coinTypeArgs, nil},
+		{"coin register deposit", coinTokenConfig, tokens.DeliveryRegisterDeposit, CONTRACT_FUNC_SWAPIN_REGISTER, coinTypeArgs, nil},
+		{"fungible asset payment", faTokenConfig, tokens.DeliveryPayment, CONTRACT_FUNC_SWAPIN_FA, []string{}, faMoreArgs},
+		{"fungible asset ignores delivery", faTokenConfig, tokens.DeliveryRegisterDeposit, CONTRACT_FUNC_SWAPIN_FA, []string{}, faMoreArgs},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			function, typeArgs, moreArgs := getSwapinFunction(aptosRouterAccount, c.TokenCfg, c.Delivery)
+			if expected := GetRouterFunctionId(aptosRouterAccount, CONTRACT_NAME_ROUTER, c.Function); function != expected {
+				t.Fatalf("%s expected function %v, but got %v", c.Name, expected, function)
+			}
+			// fungible asset passes underlying and token as arguments instead of type arguments
+			ans := common.ToJSONString([]interface{}{typeArgs, moreArgs}, false)
+			expected := common.ToJSONString([]interface{}{c.TypeArgs, c.MoreArgs}, false)
+			if ans != expected {
+				t.Fatalf("%s expected arguments %v, but got %v", c.Name, expected, ans)
+			}
+		})
+	}
+}
+
+func TestVerifySwapinFunction(t *testing.T) {
+	aptosBridge.SetTokenConfig(aptosPoolCoin, coinTokenConfig)
+	router.SetMultichainToken(coinTokenConfig.TokenID, aptosTestnetChainID, aptosPoolCoin)
+	t.Cleanup(func() { router.SetMultichainTokens(coinTokenConfig.TokenID, nil) })
+
+	swapinTx := func(function string) *Transaction {
+		return &Transaction{Payload: &TransactionPayload{
+			Function:      GetRouterFunctionId(aptosRouterAccount, CONTRACT_NAME_ROUTER, function),
+			TypeArguments: []string{aptosUnderlyingCoin, aptosPoolCoin},
+			Arguments:     []interface{}{"0xb", "1", "0xswap", "1"},
+		}}
+	}
+
+	cases := []struct {
+		Name     string
+		Function string
+		Delivery string
+		Valid    bool
+	}{
+		{"payment", CONTRACT_FUNC_SWAPIN, tokens.DeliveryPayment, true},
+		{"register deposit", CONTRACT_FUNC_SWAPIN_REGISTER, tokens.DeliveryRegisterDeposit, true},
+		{"payment with register deposit function", CONTRACT_FUNC_SWAPIN_REGISTER, tokens.DeliveryPayment, false},
+		{"register deposit with payment function", CONTRACT_FUNC_SWAPIN, tokens.DeliveryRegisterDeposit, false},
+		{"coin with fungible asset function", CONTRACT_FUNC_SWAPIN_FA, tokens.DeliveryPayment, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			args := &tokens.BuildTxArgs{
+				SwapArgs: tokens.SwapArgs{
+					SwapInfo: tokens.SwapInfo{ERC20SwapInfo: &tokens.ERC20SwapInfo{TokenID: coinTokenConfig.TokenID}},
+				},
+				From:  aptosRouterAccount,
+				Extra: &tokens.AllExtras{Delivery: c.Delivery},
+			}
+			if err := aptosBridge.verifySwapinFunction(swapinTx(c.Function), args); (err == nil) != c.Valid {
+				t.Fatalf("%s expected valid %v, but got %v", c.Name, c.Valid, err)
+			}
+		})
+	}
+}
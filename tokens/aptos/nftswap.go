@@ -0,0 +1,348 @@
+package aptos
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"sync"
+
+	"github.com/anyswap/CrossChain-Router/v3/common"
+	"github.com/anyswap/CrossChain-Router/v3/log"
+	"github.com/anyswap/CrossChain-Router/v3/params"
+	"github.com/anyswap/CrossChain-Router/v3/router"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+	"golang.org/x/crypto/sha3"
+)
+
+var (
+	// ensure Bridge impl tokens.NFTIDMapper
+	_ tokens.NFTIDMapper = &Bridge{}
+	// ensure Bridge impl tokens.NFTSwapChecker
+	_ tokens.NFTSwapChecker = &Bridge{}
+
+	// collection address -> *CollectionData
+	collectionsCache sync.Map
+
+	errWrongNFTIDs = fmt.Errorf("%w: aptos only supports swap of single nft", tokens.ErrNFTSwapNotSupported)
+)
+
+// object address of named object is sha3_256(creator | seed | 0xFE)
+const objectFromSeedAddressScheme = 0xFE
+
+// CheckNFTSwap impl tokens.NFTSwapChecker, only swap of single nft is supported
+func (b *Bridge) CheckNFTSwap(info *tokens.NFTSwapInfo) error {
+	if info == nil || !info.IsSingleNFT() {
+		return errWrongNFTIDs
+	}
+	return nil
+}
+
+// GetNFTNativeID impl tokens.NFTIDMapper, the native id is the token object address (digital asset standard).
+// token of native collection (contract version is 0) is identified by its object address as uint256,
+// token minted by router is the named token whose name is the decimal id in the router created collection.
+func (b *Bridge) GetNFTNativeID(token string, id *big.Int) (string, error) {
+	tokenCfg := b.GetTokenConfig(token)
+	if tokenCfg == nil {
+		return "", tokens.ErrMissTokenConfig
+	}
+	if id == nil || id.Sign() < 0 || id.BitLen() > 256 {
+		return "", fmt.Errorf("invalid nft id %v", id)
+	}
+	if tokenCfg.ContractVersion == 0 {
+		return common.ToHex(common.LeftPadBytes(id.Bytes(), 32)), nil
+	}
+	collection, err := b.getCollectionData(tokenCfg.ContractAddress)
+	if err != nil {
+		return "", err
+	}
+	creator := common.LeftPadBytes(common.FromHex(collection.Creator), 32)
+	seed := collection.Name + SPLIT_SYMBOL + id.String()
+	hasher := sha3.New256()
+	hasher.Write(creator)
+	hasher.Write([]byte(seed))
+	hasher.Write([]byte{objectFromSeedAddressScheme})
+	return common.ToHex(hasher.Sum(nil)), nil
+}
+
+func (b *Bridge) getCollectionData(collection string) (*CollectionData, error) {
+	if data, exist := collectionsCache.Load(collection); exist {
+		return data.(*CollectionData), nil
+	}
+	resource := &CollectionResource{}
+	err := b.GetAccountResource(collection, COLLECTION_RESOURCE, resource)
+	if err != nil {
+		return nil, err
+	}
+	if resource.Data == nil || resource.Data.Creator == "" {
+		return nil, fmt.Errorf("collection %v not found", collection)
+	}
+	collectionsCache.Store(collection, resource.Data)
+	return resource.Data, nil
+}
+
+func (b *Bridge) registerNFTSwapTx(txHash string, logIndex int) ([]*tokens.SwapTxInfo, []error) {
+	commonInfo := &tokens.SwapTxInfo{SwapInfo: tokens.SwapInfo{NFTSwapInfo: &tokens.NFTSwapInfo{}}}
+	commonInfo.SwapType = tokens.NFTSwapType            // SwapType
+	commonInfo.Hash = txHash                            // Hash
+	commonInfo.LogIndex = logIndex                      // LogIndex
+	commonInfo.FromChainID = b.ChainConfig.GetChainID() // FromChainID
+
+	txres, err := b.GetTransactionInfo(txHash, true)
+	if err != nil {
+		return []*tokens.SwapTxInfo{commonInfo}, []error{err}
+	}
+
+	swapInfos := make([]*tokens.SwapTxInfo, 0)
+	errs := make([]error, 0)
+	startIndex, endIndex := 0, len(txres.Events)
+
+	if logIndex != 0 {
+		if logIndex >= endIndex || logIndex < 0 {
+			return []*tokens.SwapTxInfo{commonInfo}, []error{tokens.ErrLogIndexOutOfRange}
+		}
+		startIndex = logIndex
+		endIndex = logIndex + 1
+	}
+
+	for i := startIndex; i < endIndex; i++ {
+		swapInfo := &tokens.SwapTxInfo{}
+		*swapInfo = *commonInfo
+		swapInfo.NFTSwapInfo = &tokens.NFTSwapInfo{}
+		swapInfo.LogIndex = i // LogIndex
+
+		err = b.verifyNFTSwapoutEvents(swapInfo, txres)
+
+		switch {
+		case errors.Is(err, tokens.ErrSwapoutLogNotFound),
+			errors.Is(err, tokens.ErrTxWithWrongTopics),
+			errors.Is(err, tokens.ErrTxWithWrongContract):
+			continue
+		case err == nil:
+			err = b.checkNFTSwapInfo(swapInfo)
+		default:
+			log.Info(b.ChainConfig.BlockChain+" register nft swap error", "txHash", txHash, "logIndex", swapInfo.LogIndex, "err", err)
+		}
+
+		swapInfos = append(swapInfos, swapInfo)
+		errs = append(errs, err)
+	}
+
+	if len(swapInfos) == 0 {
+		return []*tokens.SwapTxInfo{commonInfo}, []error{tokens.ErrSwapoutLogNotFound}
+	}
+
+	return swapInfos, errs
+}
+
+func (b *Bridge) verifyNFTSwapTx(txHash string, logIndex int, allowUnstable bool) (*tokens.SwapTxInfo, error) {
+	swapInfo := &tokens.SwapTxInfo{SwapInfo: tokens.SwapInfo{NFTSwapInfo: &tokens.NFTSwapInfo{}}}
+	swapInfo.SwapType = tokens.NFTSwapType            // SwapType
+	swapInfo.Hash = txHash                            // Hash
+	swapInfo.LogIndex = logIndex                      // LogIndex
+	swapInfo.FromChainID = b.ChainConfig.GetChainID() // FromChainID
+
+	txres, err := b.GetTransactionInfo(txHash, allowUnstable)
+	if err != nil {
+		return swapInfo, err
+	}
+
+	err = b.verifyNFTSwapoutEvents(swapInfo, txres)
+	if err != nil {
+		return swapInfo, err
+	}
+
+	err = b.checkNFTSwapInfo(swapInfo)
+	if err != nil {
+		return swapInfo, err
+	}
+
+	if params.IsSwapoutForbidden(b.ChainConfig.ChainID, swapInfo.NFTSwapInfo.TokenID) {
+		return swapInfo, tokens.ErrSwapoutForbidden
+	}
+
+	if !allowUnstable {
+		log.Info("verify nft swapout pass",
+			"from", swapInfo.From, "to", swapInfo.To, "bind", swapInfo.Bind,
+			"fromChainId", swapInfo.FromChainID, "toChainId", swapInfo.ToChainID,
+			"token", swapInfo.NFTSwapInfo.Token, "ids", swapInfo.NFTSwapInfo.IDs, "txid", swapInfo.Hash,
+			"height", swapInfo.Height, "timestamp", swapInfo.Timestamp, "logIndex", swapInfo.LogIndex)
+	}
+	return swapInfo, nil
+}
+
+func (b *Bridge) verifyNFTSwapoutEvents(swapInfo *tokens.SwapTxInfo, txInfo *TransactionInfo) error {
+	if swapInfo.LogIndex >= len(txInfo.Events) {
+		return tokens.ErrLogIndexOutOfRange
+	}
+	swapOutInfo := &txInfo.Events[swapInfo.LogIndex]
+
+	routerProgramID := b.ChainConfig.RouterContract
+
+	if !common.IsEqualIgnoreCase(swapOutInfo.Type, GetRouterFunctionId(routerProgramID, CONTRACT_NAME_NFT, "NFTSwapOutEvent")) {
+		return tokens.ErrSwapoutLogNotFound
+	}
+
+	err := b.checkTxTo(swapInfo, txInfo)
+	if err != nil {
+		return err
+	}
+
+	swapInfo.To = routerProgramID
+	nftSwapInfo := swapInfo.NFTSwapInfo
+	// NFTSwapOutEvent in NFT.move
+	// struct NFTSwapOutEvent has drop, store {
+	//     collection: address,
+	//     token: address,
+	//     id: u256,
+	//     from: address,
+	//     to: string::String,
+	//     to_chain_id: u64
+	// }
+	swapInfo.Bind = swapOutInfo.Data["to"]
+	swapInfo.From = swapOutInfo.Data["from"]
+	nftSwapInfo.Token = swapOutInfo.Data["collection"]
+	tokenCfg := b.GetTokenConfig(nftSwapInfo.Token)
+	if tokenCfg == nil {
+		return tokens.ErrMissTokenConfig
+	}
+	nftSwapInfo.TokenID = tokenCfg.TokenID
+	id, err := common.GetBigIntFromStr(swapOutInfo.Data["id"])
+	if err != nil {
+		return err
+	}
+	nativeID, err := b.GetNFTNativeID(nftSwapInfo.Token, id)
+	if err != nil {
+		return err
+	}
+	token := swapOutInfo.Data["token"]
+	if common.ToHex(common.LeftPadBytes(common.FromHex(token), 32)) != nativeID {
+		log.Warn("nft swapout token mismatch", "txid", swapInfo.Hash, "logIndex", swapInfo.LogIndex, "id", id, "have", token, "want", nativeID)
+		return tokens.ErrNFTNativeIDMismatch
+	}
+	nftSwapInfo.IDs = []*big.Int{id}
+	swapInfo.Value = big.NewInt(0)
+	toChainID, err := common.GetUint64FromStr(swapOutInfo.Data["to_chain_id"])
+	if err != nil {
+		return err
+	}
+	swapInfo.ToChainID = new(big.Int).SetUint64(toChainID)
+	return nil
+}
+
+func (b *Bridge) checkNFTSwapInfo(swapInfo *tokens.SwapTxInfo) error {
+	if swapInfo.FromChainID.Cmp(swapInfo.ToChainID) == 0 {
+		return tokens.ErrSameFromAndToChainID
+	}
+	nftSwapInfo := swapInfo.NFTSwapInfo
+	if nftSwapInfo.TokenID == "" {
+		return tokens.ErrMissTokenConfig
+	}
+	multichainToken := router.GetCachedMultichainToken(nftSwapInfo.TokenID, swapInfo.ToChainID.String())
+	if multichainToken == "" {
+		log.Warn("get multichain token failed", "tokenID", nftSwapInfo.TokenID, "chainID", swapInfo.ToChainID, "txid", swapInfo.Hash)
+		return tokens.ErrMissTokenConfig
+	}
+	toBridge := router.GetBridgeByChainID(swapInfo.ToChainID.String())
+	if toBridge == nil {
+		return tokens.ErrNoBridgeForChainID
+	}
+	if !toBridge.IsValidAddress(swapInfo.Bind) {
+		log.Warn("wrong bind address in nft swap", "bind", swapInfo.Bind)
+		return tokens.ErrWrongBindAddress
+	}
+	// dest chain may support part of nft swaps
+	if checker, ok := toBridge.(tokens.NFTSwapChecker); ok {
+		if err := checker.CheckNFTSwap(swapInfo.NFTSwapInfo); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildNFTSwapinTx build nft swapin tx, the router module transfers the nft of native collection
+// and mints (or releases if swapped out before) the nft of router created collection.
+func (b *Bridge) buildNFTSwapinTx(args *tokens.BuildTxArgs) (*Transaction, error) {
+	payload, err := b.buildNFTSwapinPayload(args)
+	if err != nil {
+		return nil, err
+	}
+	args.SwapValue = big.NewInt(0)
+
+	err = b.SetExtraArgs(args, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := &Transaction{
+		Sender:                  args.From,
+		SequenceNumber:          strconv.FormatUint(*args.Extra.Sequence, 10),
+		MaxGasAmount:            *args.Extra.Fee,
+		GasUnitPrice:            strconv.FormatUint(*args.Extra.Gas, 10),
+		ExpirationTimestampSecs: *args.Extra.BlockHash,
+		Payload:                 payload,
+	}
+
+	if params.IsSwapServer {
+		mpcPubkey := router.GetMPCPublicKey(args.From)
+		if mpcPubkey == "" {
+			return nil, tokens.ErrMissMPCPublicKey
+		}
+		err = b.SimulateTranscation(tx, mpcPubkey)
+		if err != nil {
+			return nil, fmt.Errorf("%w %v", tokens.ErrBuildTxErrorAndDelay, err)
+		}
+	}
+	log.Info("build nft swapin raw tx", "identifier", args.Identifier, "swapID", args.SwapID,
+		"fromChainID", args.FromChainID, "toChainID", args.ToChainID, "from", args.From,
+		"bind", args.Bind, "tokenID", args.NFTSwapInfo.TokenID, "ids", args.NFTSwapInfo.IDs,
+		"nonce", tx.SequenceNumber, "gasPrice", tx.GasUnitPrice, "replaceNum", args.GetReplaceNum())
+	return tx, nil
+}
+
+// buildNFTSwapinPayload build payload of
+// `nft_swapin(collection: address, id: u256, receiver: address, swapid: String, from_chain_id: u64)`
+func (b *Bridge) buildNFTSwapinPayload(args *tokens.BuildTxArgs) (*TransactionPayload, error) {
+	nftSwapInfo := args.NFTSwapInfo
+	if nftSwapInfo == nil || nftSwapInfo.TokenID == "" {
+		return nil, tokens.ErrEmptyTokenID
+	}
+	if err := b.CheckNFTSwap(nftSwapInfo); err != nil {
+		return nil, err
+	}
+	if !b.IsValidAddress(args.Bind) {
+		log.Warn("nft swapout to wrong receiver", "receiver", args.Bind)
+		return nil, tokens.ErrWrongBindAddress
+	}
+	multichainToken := router.GetCachedMultichainToken(nftSwapInfo.TokenID, b.ChainConfig.ChainID)
+	if multichainToken == "" {
+		log.Warn("get multichain token failed", "tokenID", nftSwapInfo.TokenID, "chainID", b.ChainConfig.ChainID)
+		return nil, tokens.ErrMissTokenConfig
+	}
+	tokenCfg := b.GetTokenConfig(multichainToken)
+	if tokenCfg == nil {
+		return nil, tokens.ErrMissTokenConfig
+	}
+	return &TransactionPayload{
+		Type:          SCRIPT_FUNCTION_PAYLOAD,
+		Function:      GetRouterFunctionId(args.From, CONTRACT_NAME_NFT, CONTRACT_FUNC_NFT_SWAPIN),
+		TypeArguments: []string{},
+		Arguments:     []interface{}{tokenCfg.ContractAddress, nftSwapInfo.IDs[0].String(), args.Bind, args.GetUniqueSwapIdentifier(), args.FromChainID.String()},
+	}, nil
+}
+
+func (b *Bridge) verifyNFTSwapinTransactionWithArgs(tx *Transaction, args *tokens.BuildTxArgs) error {
+	payload, err := b.buildNFTSwapinPayload(args)
+	if err != nil {
+		return err
+	}
+	if tx.Payload == nil {
+		return errors.New("[sign] nft swapin tx without payload")
+	}
+	have := common.ToJSONString(tx.Payload, false)
+	want := common.ToJSONString(payload, false)
+	if have != want {
+		return fmt.Errorf("[sign] nft swapin payload mismatch: have %v want %v", have, want)
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package aptos
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Router/v3/common"
+	"github.com/anyswap/CrossChain-Router/v3/router"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	aptosTestnetChainID = "1000004280404"
+
+	// collection not created by router, token id is the token object address
+	aptosNativeCollection = "0x000000000000000000000000000000000000000000000000000000000000000a"
+	// collection created by router (ContractVersion 1), token is named by id
+	aptosRouterCollection = "0x000000000000000000000000000000000000000000000000000000000000000b"
+	aptosCollectionOwner  = "0xc"
+	aptosCollectionName   = "Minted"
+
+	aptosRouterMPC = "0x2222222222222222222222222222222222222222222222222222222222222222"
+	aptosNFTOwner  = "0x1111111111111111111111111111111111111111111111111111111111111111"
+)
+
+var aptosBridge = NewCrossChainBridge()
+
+func init() {
+	aptosBridge.SetChainConfig(&tokens.ChainConfig{ChainID: aptosTestnetChainID})
+	aptosBridge.SetTokenConfig(aptosNativeCollection, &tokens.TokenConfig{TokenID: "nativeNFT", ContractAddress: aptosNativeCollection})
+	aptosBridge.SetTokenConfig(aptosRouterCollection, &tokens.TokenConfig{TokenID: "mintedNFT", ContractAddress: aptosRouterCollection, ContractVersion: 1})
+}
+
+// cacheRouterCollection cache the router collection as if queried from chain
+func cacheRouterCollection(t *testing.T) {
+	collectionsCache.Store(aptosRouterCollection, &CollectionData{Creator: aptosCollectionOwner, Name: aptosCollectionName})
+	t.Cleanup(func() { collectionsCache.Delete(aptosRouterCollection) })
+}
+
+// namedTokenAddress is sha3_256(creator (32 bytes) | "<collection name>::<id>" | 0xFE)
+func namedTokenAddress(creator byte, collection, id string) string {
+	hasher := sha3.New256()
+	hasher.Write(common.LeftPadBytes([]byte{creator}, 32))
+	hasher.Write([]byte(collection + "::" + id))
+	hasher.Write([]byte{0xFE})
+	return common.ToHex(hasher.Sum(nil))
+}
+
+func TestGetNFTNativeID(t *testing.T) {
+	cacheRouterCollection(t)
+
+	cases := []struct {
+		Name       string
+		Collection string
+		ID         *big.Int
+		Expected   string
+	}{
+		{"token object address", aptosNativeCollection, big.NewInt(0xab), "0x00000000000000000000000000000000000000000000000000000000000000ab"},
+		{"named token of router collection", aptosRouterCollection, big.NewInt(1), namedTokenAddress(0xc, aptosCollectionName, "1")},
+		{"negative id", aptosRouterCollection, big.NewInt(-1), ""},
+		{"id overflows uint256", aptosRouterCollection, new(big.Int).Lsh(big.NewInt(1), 256), ""},
+		{"unknown collection", "0xunknown", big.NewInt(1), ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			ans, err := aptosBridge.GetNFTNativeID(c.Collection, c.ID)
+			if c.Expected == "" {
+				if err == nil {
+					t.Fatalf("%s expected error, but got %v", c.Name, ans)
+				}
+				return
+			}
+			if err != nil || ans != c.Expected {
+				t.Fatalf("%s expected %v, but got %v %v", c.Name, c.Expected, ans, err)
+			}
+		})
+	}
+}
+
+func TestCheckNFTSwap(t *testing.T) {
+	one, two := big.NewInt(1), big.NewInt(2)
+
+	cases := []struct {
+		Name      string
+		Info      *tokens.NFTSwapInfo
+		Supported bool
+	}{
+		{"single id", &tokens.NFTSwapInfo{IDs: []*big.Int{one}}, true},
+		{"single id of amount 1", &tokens.NFTSwapInfo{IDs: []*big.Int{one}, Amounts: []*big.Int{one}}, true},
+		{"amount is not 1", &tokens.NFTSwapInfo{IDs: []*big.Int{one}, Amounts: []*big.Int{two}}, false},
+		{"multiple ids", &tokens.NFTSwapInfo{IDs: []*big.Int{one, two}}, false},
+		{"batch", &tokens.NFTSwapInfo{IDs: []*big.Int{one}, Batch: true}, false},
+		{"no swap info", nil, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			err := aptosBridge.CheckNFTSwap(c.Info)
+			if c.Supported && err != nil {
+				t.Fatalf("%s expected supported, but got %v", c.Name, err)
+			}
+			if !c.Supported && !errors.Is(err, tokens.ErrNFTSwapNotSupported) {
+				t.Fatalf("%s expected %v, but got %v", c.Name, tokens.ErrNFTSwapNotSupported, err)
+			}
+		})
+	}
+}
+
+func TestVerifyNFTSwapinTransactionWithArgs(t *testing.T) {
+	cacheRouterCollection(t)
+	router.SetMultichainToken("mintedNFT", aptosTestnetChainID, aptosRouterCollection)
+	t.Cleanup(func() { router.SetMultichainTokens("mintedNFT", nil) })
+
+	swapinArgs := func() *tokens.BuildTxArgs {
+		return &tokens.BuildTxArgs{
+			SwapArgs: tokens.SwapArgs{
+				SwapID:      "0xswap",
+				SwapType:    tokens.NFTSwapType,
+				Bind:        aptosNFTOwner,
+				FromChainID: big.NewInt(1),
+				ToChainID:   big.NewInt(1000004280404),
+				SwapInfo: tokens.SwapInfo{NFTSwapInfo: &tokens.NFTSwapInfo{
+					TokenID: "mintedNFT",
+					IDs:     []*big.Int{big.NewInt(7)},
+				}},
+			},
+			From: aptosRouterMPC,
+		}
+	}
+	payload, err := aptosBridge.buildNFTSwapinPayload(swapinArgs())
+	if err != nil {
+		t.Fatalf("build nft swapin payload expected not err, but got %v", err)
+	}
+	tx := &Transaction{Payload: payload}
+
+	cases := []struct {
+		Name   string
+		Tx     *Transaction
+		Tamper func(args *tokens.BuildTxArgs)
+		Valid  bool
+	}{
+		{"same args", tx, func(*tokens.BuildTxArgs) {}, true},
+		{"other from chain", tx, func(args *tokens.BuildTxArgs) { args.FromChainID = big.NewInt(56) }, false},
+		{"other swap", tx, func(args *tokens.BuildTxArgs) { args.SwapID = "0xother" }, false},
+		{"other log index", tx, func(args *tokens.BuildTxArgs) { args.LogIndex = 1 }, false},
+		{"other token id", tx, func(args *tokens.BuildTxArgs) { args.NFTSwapInfo.IDs = []*big.Int{big.NewInt(8)} }, false},
+		{"more token ids", tx, func(args *tokens.BuildTxArgs) {
+			args.NFTSwapInfo.IDs = append(args.NFTSwapInfo.IDs, big.NewInt(8))
+		}, false},
+		{"bind to mpc", tx, func(args *tokens.BuildTxArgs) { args.Bind = aptosRouterMPC }, false},
+		{"bind to other owner", tx, func(args *tokens.BuildTxArgs) { args.Bind = "0x1" }, false},
+		{"sent by other account", tx, func(args *tokens.BuildTxArgs) { args.From = aptosNFTOwner }, false},
+		{"native collection", tx, func(args *tokens.BuildTxArgs) { args.NFTSwapInfo.TokenID = "nativeNFT" }, false},
+		{"no payload", &Transaction{}, func(*tokens.BuildTxArgs) {}, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			args := swapinArgs()
+			c.Tamper(args)
+			if err := aptosBridge.verifyNFTSwapinTransactionWithArgs(c.Tx, args); (err == nil) != c.Valid {
+				t.Fatalf("%s expected valid %v, but got %v", c.Name, c.Valid, err)
+			}
+		})
+	}
+}
@@ -41,6 +41,16 @@ type CoinValue struct {
 	Value string `json:"value"`
 }
 
+type CollectionResource struct {
+	Type string          `json:"type"`
+	Data *CollectionData `json:"data,omitempty"`
+}
+
+type CollectionData struct {
+	Creator string `json:"creator"`
+	Name    string `json:"name"`
+}
+
 type TransactionInfo struct {
 	Type                    string             `json:"type"`
 	Version                 string             `json:"version,omitempty"`
@@ -14,6 +14,17 @@ import (
 )
 
 func (b *Bridge) verifyTransactionWithArgs(tx *Transaction, args *tokens.BuildTxArgs) error {
+	if args.SwapType == tokens.AnyCallSwapType {
+		return b.verifyAnyCallTransactionWithArgs(tx, args)
+	}
+	if args.SwapType == tokens.NFTSwapType {
+		return b.verifyNFTSwapinTransactionWithArgs(tx, args)
+	}
+
+	if err := b.verifySwapinFunction(tx, args); err != nil {
+		return err
+	}
+
 	swapin := tx.Payload.Arguments
 
 	// receiver: address, amount: u64, _fromEvent: string, _fromChainID: u64
@@ -34,17 +34,23 @@ func (b *Bridge) VerifyMsgHash(rawTx interface{}, msgHashes []string) (err error
 }
 
 // VerifyTransaction impl
-func (b *Bridge) VerifyTransaction(txHash string, args *tokens.VerifyArgs) (*tokens.SwapTxInfo, error) {
+func (b *Bridge) VerifyTransaction(txHash string, args *tokens.VerifyArgs) (swapInfo *tokens.SwapTxInfo, err error) {
 	swapType := args.SwapType
 	logIndex := args.LogIndex
 	allowUnstable := args.AllowUnstable
 
 	switch swapType {
 	case tokens.ERC20SwapType:
-		return b.verifySwapoutTx(txHash, logIndex, allowUnstable)
+		swapInfo, err = b.verifySwapoutTx(txHash, logIndex, allowUnstable)
+	case tokens.NFTSwapType:
+		swapInfo, err = b.verifyNFTSwapTx(txHash, logIndex, allowUnstable)
 	default:
 		return nil, tokens.ErrSwapTypeNotSupported
 	}
+	if err == nil && !allowUnstable {
+		err = tokens.CheckSwapTxStable(b, swapInfo)
+	}
+	return swapInfo, err
 }
 
 // GetTransactionInfo get tx info (verify tx status and check stable)
@@ -132,12 +138,9 @@ func (b *Bridge) verifySwapoutEvents(swapInfo *tokens.SwapTxInfo, txInfo *Transa
 		return tokens.ErrSwapoutLogNotFound
 	}
 
-	swapInfo.TxTo = strings.Split(txInfo.PayLoad.Function, SPLIT_SYMBOL)[0]
-
-	if !params.AllowCallByContract() &&
-		!common.IsEqualIgnoreCase(swapInfo.TxTo, b.ChainConfig.RouterContract) &&
-		!params.IsInCallByContractWhitelist(b.ChainConfig.ChainID, swapInfo.TxTo) {
-		return tokens.ErrTxWithWrongContract
+	err := b.checkTxTo(swapInfo, txInfo)
+	if err != nil {
+		return err
 	}
 
 	swapInfo.To = routerProgramID
@@ -172,6 +175,18 @@ func (b *Bridge) verifySwapoutEvents(swapInfo *tokens.SwapTxInfo, txInfo *Transa
 	return nil
 }
 
+// checkTxTo check the called module of tx is router unless call by contract is allowed
+func (b *Bridge) checkTxTo(swapInfo *tokens.SwapTxInfo, txInfo *TransactionInfo) error {
+	swapInfo.TxTo = strings.Split(txInfo.PayLoad.Function, SPLIT_SYMBOL)[0]
+
+	if !params.AllowCallByContract() &&
+		!common.IsEqualIgnoreCase(swapInfo.TxTo, b.ChainConfig.RouterContract) &&
+		!params.IsInCallByContractWhitelist(b.ChainConfig.ChainID, swapInfo.TxTo) {
+		return tokens.ErrTxWithWrongContract
+	}
+	return nil
+}
+
 func (b *Bridge) checkSwapoutInfo(swapInfo *tokens.SwapTxInfo) error {
 	if strings.EqualFold(swapInfo.From, swapInfo.To) {
 		return tokens.ErrTxWithWrongSender
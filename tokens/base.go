@@ -228,6 +228,13 @@ func GetSwapConfig(tokenID, fromChainID, toChainID string) *SwapConfig {
 	return mmm.(*SwapConfig)
 }
 
+// RangeSwapConfigs calls f sequentially for each swap config
+func RangeSwapConfigs(f func(tokenID, fromChainID, toChainID string, cfg *SwapConfig)) {
+	rangeTokenChainsMap(swapConfigMap, func(tokenID, fromChainID, toChainID string, value interface{}) {
+		f(tokenID, fromChainID, toChainID, value.(*SwapConfig))
+	})
+}
+
 // SetFeeConfigs set fee configs
 func SetFeeConfigs(feeCfgs *sync.Map) {
 	feeConfigMap = feeCfgs
@@ -250,6 +257,27 @@ func GetFeeConfig(tokenID, fromChainID, toChainID string) *FeeConfig {
 	return mmm.(*FeeConfig)
 }
 
+// RangeFeeConfigs calls f sequentially for each fee config
+func RangeFeeConfigs(f func(tokenID, fromChainID, toChainID string, cfg *FeeConfig)) {
+	rangeTokenChainsMap(feeConfigMap, func(tokenID, fromChainID, toChainID string, value interface{}) {
+		f(tokenID, fromChainID, toChainID, value.(*FeeConfig))
+	})
+}
+
+// rangeTokenChainsMap range map with key tokenID,fromChainID,toChainID
+func rangeTokenChainsMap(m *sync.Map, f func(tokenID, fromChainID, toChainID string, value interface{})) {
+	m.Range(func(k1, v1 interface{}) bool {
+		v1.(*sync.Map).Range(func(k2, v2 interface{}) bool {
+			v2.(*sync.Map).Range(func(k3, v3 interface{}) bool {
+				f(k1.(string), k2.(string), k3.(string), v3)
+				return true
+			})
+			return true
+		})
+		return true
+	})
+}
+
 // SetOnchainCustomConfig set onchain custom config
 func SetOnchainCustomConfig(chainID, tokenID string, config *OnchainCustomConfig) {
 	m, exist := onchainCustomCfg.Load(chainID)
@@ -0,0 +1,61 @@
+package tokens
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Router/v3/params"
+)
+
+// CheckBatchSwaps check swaps can be aggregated into the batch tx of `args`.
+// only erc20 swaps of the same token to the same chain can be aggregated.
+func CheckBatchSwaps(args *BuildTxArgs, swaps []*BuildTxArgs) error {
+	if len(swaps) == 0 {
+		return fmt.Errorf("%w: empty swaps", ErrBatchSwapMismatch)
+	}
+	if len(swaps) > params.MaxBatchSwapSize {
+		return fmt.Errorf("%w: %v > %v", ErrTooManyBatchSwaps, len(swaps), params.MaxBatchSwapSize)
+	}
+	tokenID := swaps[0].GetTokenID()
+	if tokenID == "" {
+		return ErrEmptyTokenID
+	}
+	exist := make(map[string]struct{}, len(swaps))
+	for _, swap := range swaps {
+		if swap.SwapType != ERC20SwapType || swap.ERC20SwapInfo == nil {
+			return fmt.Errorf("%w: only support erc20 swap type", ErrBatchSwapMismatch)
+		}
+		if swap.ERC20SwapInfo.CallProxy != "" {
+			return fmt.Errorf("%w: swap with call proxy", ErrBatchSwapMismatch)
+		}
+		if swap.GetTokenID() != tokenID {
+			return fmt.Errorf("%w: tokenID %v != %v", ErrBatchSwapMismatch, swap.GetTokenID(), tokenID)
+		}
+		if swap.ToChainID == nil || args.ToChainID == nil ||
+			swap.ToChainID.Cmp(args.ToChainID) != 0 {
+			return ErrToChainIDMismatch
+		}
+		if !strings.EqualFold(swap.From, args.From) {
+			return ErrSenderMismatch
+		}
+		key := swap.GetUniqueSwapIdentifier()
+		if _, dup := exist[key]; dup {
+			return fmt.Errorf("%w: duplicate swap %v", ErrBatchSwapMismatch, key)
+		}
+		exist[key] = struct{}{}
+	}
+	return nil
+}
+
+// SetBatchSwaps record swaps of the batch tx in extras
+func (args *BuildTxArgs) SetBatchSwaps(swaps []*BuildTxArgs) {
+	if args.Extra == nil {
+		args.Extra = &AllExtras{}
+	}
+	batchSwaps := make([]*SwapArgs, len(swaps))
+	for i, swap := range swaps {
+		swapArgs := swap.SwapArgs
+		batchSwaps[i] = &swapArgs
+	}
+	args.Extra.BatchSwaps = batchSwaps
+}
@@ -0,0 +1,125 @@
+package tokens
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Router/v3/params"
+)
+
+const (
+	testBatchMPC      = "0x00000000000000000000000000000000000000aa"
+	testBatchSwapHash = "0x00000000000000000000000000000000000000000000000000000000000000ab"
+)
+
+func newTestBatchSwap(swapID string, modify func(*BuildTxArgs)) *BuildTxArgs {
+	swap := &BuildTxArgs{
+		SwapArgs: SwapArgs{
+			SwapID:      swapID,
+			SwapType:    ERC20SwapType,
+			FromChainID: big.NewInt(1),
+			ToChainID:   big.NewInt(56),
+			SwapInfo:    SwapInfo{ERC20SwapInfo: &ERC20SwapInfo{TokenID: "USDC"}},
+		},
+		From: testBatchMPC,
+	}
+	if modify != nil {
+		modify(swap)
+	}
+	return swap
+}
+
+func TestCheckBatchSwaps(t *testing.T) {
+	args := newTestBatchSwap("0x1", nil)
+	maxSwaps := make([]*BuildTxArgs, params.MaxBatchSwapSize+1)
+	for i := range maxSwaps {
+		maxSwaps[i] = newTestBatchSwap(fmt.Sprintf("0x%x", i+1), nil)
+	}
+
+	tests := []struct {
+		swaps   []*BuildTxArgs
+		wantErr error
+	}{
+		{[]*BuildTxArgs{newTestBatchSwap("0x1", nil), newTestBatchSwap("0x2", nil)}, nil},
+		{maxSwaps[:params.MaxBatchSwapSize], nil},
+		// same swap id of other log index or source chain is not duplicate
+		{[]*BuildTxArgs{
+			newTestBatchSwap("0x1", nil),
+			newTestBatchSwap("0x1", func(s *BuildTxArgs) { s.LogIndex = 1 }),
+			newTestBatchSwap("0x1", func(s *BuildTxArgs) { s.FromChainID = big.NewInt(137) }),
+		}, nil},
+		{nil, ErrBatchSwapMismatch},
+		{maxSwaps, ErrTooManyBatchSwaps},
+		{[]*BuildTxArgs{newTestBatchSwap("0x1", func(s *BuildTxArgs) { s.ERC20SwapInfo.TokenID = "" })}, ErrEmptyTokenID},
+		// mixed token
+		{[]*BuildTxArgs{
+			newTestBatchSwap("0x1", nil),
+			newTestBatchSwap("0x2", func(s *BuildTxArgs) { s.ERC20SwapInfo.TokenID = "USDT" }),
+		}, ErrBatchSwapMismatch},
+		// call proxy
+		{[]*BuildTxArgs{
+			newTestBatchSwap("0x1", nil),
+			newTestBatchSwap("0x2", func(s *BuildTxArgs) { s.ERC20SwapInfo.CallProxy = "0x1" }),
+		}, ErrBatchSwapMismatch},
+		// other swap types
+		{[]*BuildTxArgs{
+			newTestBatchSwap("0x1", nil),
+			newTestBatchSwap("0x2", func(s *BuildTxArgs) { s.SwapType = ERC20SwapTypeMixPool }),
+		}, ErrBatchSwapMismatch},
+		{[]*BuildTxArgs{
+			newTestBatchSwap("0x1", nil),
+			newTestBatchSwap("0x2", func(s *BuildTxArgs) { s.SwapType = AnyCallSwapType }),
+		}, ErrBatchSwapMismatch},
+		// to chain mismatch
+		{[]*BuildTxArgs{
+			newTestBatchSwap("0x1", nil),
+			newTestBatchSwap("0x2", func(s *BuildTxArgs) { s.ToChainID = big.NewInt(137) }),
+		}, ErrToChainIDMismatch},
+		{[]*BuildTxArgs{
+			newTestBatchSwap("0x1", nil),
+			newTestBatchSwap("0x2", func(s *BuildTxArgs) { s.ToChainID = nil }),
+		}, ErrToChainIDMismatch},
+		// sender mismatch
+		{[]*BuildTxArgs{
+			newTestBatchSwap("0x1", nil),
+			newTestBatchSwap("0x2", func(s *BuildTxArgs) { s.From = "0x00000000000000000000000000000000000000bb" }),
+		}, ErrSenderMismatch},
+		// duplicates, hash swap id is compared case insensitively
+		{[]*BuildTxArgs{newTestBatchSwap("0x1", nil), newTestBatchSwap("0x1", nil)}, ErrBatchSwapMismatch},
+		{[]*BuildTxArgs{newTestBatchSwap(testBatchSwapHash, nil), newTestBatchSwap(strings.ToUpper(testBatchSwapHash[2:]), nil)}, ErrBatchSwapMismatch},
+	}
+	for i, tt := range tests {
+		err := CheckBatchSwaps(args, tt.swaps)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("test %v: got err %v, want %v", i, err, tt.wantErr)
+		}
+	}
+
+	// the batch args decides the to chain and sender
+	other := newTestBatchSwap("0x1", func(s *BuildTxArgs) { s.ToChainID = big.NewInt(137) })
+	if err := CheckBatchSwaps(other, maxSwaps[:2]); !errors.Is(err, ErrToChainIDMismatch) {
+		t.Errorf("batch args of other to chain: got err %v, want %v", err, ErrToChainIDMismatch)
+	}
+}
+
+func TestSetBatchSwaps(t *testing.T) {
+	args := newTestBatchSwap("0x1", nil)
+	swaps := []*BuildTxArgs{newTestBatchSwap("0x1", nil), newTestBatchSwap("0x2", nil)}
+	args.SetBatchSwaps(swaps)
+	if !args.IsBatchSwap() || len(args.Extra.BatchSwaps) != len(swaps) {
+		t.Fatalf("batch swaps are not recorded: %v", args.Extra)
+	}
+	for i, swap := range args.Extra.BatchSwaps {
+		if swap.SwapID != swaps[i].SwapID {
+			t.Errorf("batch swap %v: got swap id %v, want %v", i, swap.SwapID, swaps[i].SwapID)
+		}
+	}
+	// recorded swap args are copies
+	swaps[0].SwapID = "0x3"
+	if args.Extra.BatchSwaps[0].SwapID != "0x1" {
+		t.Errorf("batch swap should not be changed by the original swap")
+	}
+}
@@ -0,0 +1,85 @@
+package btc
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/anyswap/CrossChain-Router/v3/common"
+	"github.com/anyswap/CrossChain-Router/v3/log"
+	"github.com/anyswap/CrossChain-Router/v3/params"
+	"github.com/anyswap/CrossChain-Router/v3/router"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+)
+
+// BatchUnlockMemoPrefix memo prefix of batch swapin tx (followed by the first swap id)
+var BatchUnlockMemoPrefix = "SWAPTXS:"
+
+// IsBatchSwapSupported impl tokens.BatchSwapBuilder
+func (b *Bridge) IsBatchSwapSupported() bool {
+	return true
+}
+
+// BuildBatchRawTransaction build batch swapin tx (one output per swap)
+func (b *Bridge) BuildBatchRawTransaction(args *tokens.BuildTxArgs, swaps []*tokens.BuildTxArgs) (rawTx interface{}, err error) {
+	if !params.IsTestMode && args.ToChainID.String() != b.ChainConfig.ChainID {
+		return nil, tokens.ErrToChainIDMismatch
+	}
+	if args.Input != nil {
+		return nil, fmt.Errorf("forbid build raw swap tx with input data")
+	}
+	if args.From == "" {
+		return nil, fmt.Errorf("forbid empty sender")
+	}
+	err = tokens.CheckBatchSwaps(args, swaps)
+	if err != nil {
+		return nil, err
+	}
+	tokenID := swaps[0].GetTokenID()
+	routerMPC, err := router.GetRouterMPC(tokenID, b.ChainConfig.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	if !common.IsEqualIgnoreCase(args.From, routerMPC) {
+		log.Error("build batch tx mpc mismatch", "have", args.From, "want", routerMPC)
+		return nil, tokens.ErrSenderMismatch
+	}
+	multichainToken := router.GetCachedMultichainToken(tokenID, b.ChainConfig.ChainID)
+	if multichainToken == "" {
+		log.Warn("get multichain token failed", "tokenID", tokenID, "chainID", b.ChainConfig.ChainID)
+		return nil, tokens.ErrMissTokenConfig
+	}
+	if b.GetTokenConfig(multichainToken) == nil {
+		return nil, tokens.ErrMissTokenConfig
+	}
+
+	var txOuts []*wireTxOutType
+	swapValue := big.NewInt(0)
+	for _, swap := range swaps {
+		receiver, amount, errf := b.getReceiverAndAmount(swap, multichainToken)
+		if errf != nil {
+			return nil, errf
+		}
+		swap.SwapValue = amount // SwapValue
+		swapValue.Add(swapValue, amount)
+
+		err = b.addPayToAddrOutput(&txOuts, receiver, amount.Int64())
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = b.addMemoOutput(&txOuts, BatchUnlockMemoPrefix+swaps[0].SwapID)
+	if err != nil {
+		return nil, err
+	}
+	args.SwapValue = swapValue
+	args.SetBatchSwaps(swaps)
+
+	log.Info("build batch swapin tx", "chainID", b.ChainConfig.ChainID, "tokenID", tokenID, "count", len(swaps), "swapValue", swapValue)
+
+	rawTx, err = b.buildUnsignedTransaction(args.From, txOuts)
+	if err != nil {
+		return nil, err
+	}
+
+	return rawTx, nil
+}
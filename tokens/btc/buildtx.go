@@ -79,6 +79,15 @@ func (b *Bridge) BuildRawTransaction(args *tokens.BuildTxArgs) (rawTx interface{
 		return nil, err
 	}
 
+	rawTx, err = b.buildUnsignedTransaction(args.From, txOuts)
+	if err != nil {
+		return nil, err
+	}
+
+	return rawTx, nil
+}
+
+func (b *Bridge) buildUnsignedTransaction(from string, txOuts []*wireTxOutType) (*txauthor.AuthoredTx, error) {
 	relayFee, errf := b.getRelayFeePerKb()
 	if errf != nil {
 		return nil, errf
@@ -86,18 +95,13 @@ func (b *Bridge) BuildRawTransaction(args *tokens.BuildTxArgs) (rawTx interface{
 	relayFeePerKb := btcAmountType(relayFee)
 
 	inputSource := func(target btcAmountType) (total btcAmountType, inputs []*wireTxInType, inputValues []btcAmountType, scripts [][]byte, err error) {
-		return b.selectUtxos(args.From, target)
+		return b.selectUtxos(from, target)
 	}
 
 	changeSource := func() ([]byte, error) {
-		return b.GetPayToAddrScript(args.From)
-	}
-	rawTx, err = b.NewUnsignedTransaction(txOuts, relayFeePerKb, inputSource, changeSource)
-	if err != nil {
-		return nil, err
+		return b.GetPayToAddrScript(from)
 	}
-
-	return rawTx, nil
+	return b.NewUnsignedTransaction(txOuts, relayFeePerKb, inputSource, changeSource)
 }
 
 func (b *Bridge) NewUnsignedTransaction(outputs []*wire.TxOut, relayFeePerKb btcutil.Amount,
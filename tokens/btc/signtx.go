@@ -41,7 +41,18 @@ func (b *Bridge) MPCSignTransaction(rawTx interface{}, args *tokens.BuildTxArgs)
 }
 
 func (b *Bridge) verifyTransactionWithArgs(tx *txauthor.AuthoredTx, args *tokens.BuildTxArgs) error {
-	checkReceiver := args.Bind
+	if args.IsBatchSwap() {
+		for _, swapArgs := range args.Extra.BatchSwaps {
+			if err := b.verifyTransactionReceiver(tx, swapArgs.Bind); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return b.verifyTransactionReceiver(tx, args.Bind)
+}
+
+func (b *Bridge) verifyTransactionReceiver(tx *txauthor.AuthoredTx, checkReceiver string) error {
 	payToReceiverScript, err := b.GetPayToAddrScript(checkReceiver)
 	if err != nil {
 		return err
@@ -19,6 +19,10 @@ func (b *Bridge) BuildAggregateTx(swapId string, utxos map[UtxoKey]AssetsMap) (*
 	if err != nil {
 		return nil, err
 	}
+	pp := b.GetProtocolParams()
+	if pp == nil {
+		return nil, fmt.Errorf("ProtocolParams is empty")
+	}
 	rawTransaction := &RawTransaction{
 		// Fee:     "0",
 		SwapId:           swapId,
@@ -26,10 +30,10 @@ func (b *Bridge) BuildAggregateTx(swapId string, utxos map[UtxoKey]AssetsMap) (*
 		TxIns:            []UtxoKey{},
 		TxInsAssets:      []AssetsMap{},
 		Slot:             nodeTip.Slot,
-		CoinsPerUTXOWord: uint64(b.ProtocolParams.CoinsPerUTXOWord),
-		KeyDeposit:       uint64(b.ProtocolParams.KeyDeposit),
-		MinFeeA:          uint64(b.ProtocolParams.MinFeeA),
-		MinFeeB:          uint64(b.ProtocolParams.MinFeeB),
+		CoinsPerUTxOByte: pp.CoinsPerUTxOByte,
+		KeyDeposit:       uint64(pp.KeyDeposit),
+		MinFeeA:          uint64(pp.MinFeeA),
+		MinFeeB:          uint64(pp.MinFeeB),
 	}
 	allAssetsMap := map[string]uint64{}
 	for utxoKey, assetsMap := range utxos {
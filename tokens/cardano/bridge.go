@@ -3,10 +3,8 @@ package cardano
 import (
 	"crypto/ed25519"
 	"crypto/rand"
-	"encoding/json"
 	"errors"
 	"math/big"
-	"strconv"
 	"sync"
 
 	"github.com/anyswap/CrossChain-Router/v3/common"
@@ -41,9 +39,14 @@ const (
 type Bridge struct {
 	*tokens.CrossChainBridgeBase
 	*base.ReSwapableBridgeBase
-	RpcClient      cardanosdk.Node
-	FakePrikey     crypto.PrvKey
-	ProtocolParams *cardanosdk.ProtocolParams
+	Provider   ChainDataProvider
+	TxProvider ChainDataProvider
+	FakePrikey crypto.PrvKey
+
+	// protocol params are refreshed by `GetTip` when epoch changes
+	protocolParams      *EpochProtocolParams
+	protocolParamsEpoch uint64
+	protocolParamsLock  sync.RWMutex
 }
 
 // NewCrossChainBridge new bridge
@@ -95,20 +98,12 @@ func GetStubChainID(network string) *big.Int {
 // InitAfterConfig init variables (ie. extra members) after loading config
 func (b *Bridge) InitAfterConfig() {
 	b.CrossChainBridgeBase.InitAfterConfig()
-	chainId := b.GetChainConfig().GetChainID()
-	apiKey := params.GetCustom(b.ChainConfig.ChainID, "APIKey")
-	if apiKey != "" {
-		if chainId.Cmp(GetStubChainID(mainnetNetWork)) == 0 {
-			b.RpcClient = NewNode(cardanosdk.Mainnet, CardanoMainNet, apiKey)
-		} else {
-			b.RpcClient = NewNode(cardanosdk.Testnet, CardanoPreProd, apiKey)
-		}
-		protocolParams, err := b.RpcClient.ProtocolParams()
-		if err != nil {
-			log.Error("cardano ProtocolParams", "err", err)
-		} else {
-			b.ProtocolParams = protocolParams
-		}
+	if err := b.initChainDataProviders(); err != nil {
+		log.Fatal("cardano init chain data provider failed", "err", err)
+	}
+	log.Info("cardano init chain data provider success", "provider", b.Provider.Name(), "txQueryProvider", b.TxProvider.Name())
+	if _, err := b.GetTip(); err != nil {
+		log.Error("cardano init protocol params failed", "err", err)
 	}
 
 	timeoutStr := params.GetCustom(b.ChainConfig.ChainID, "TxTimeout")
@@ -134,73 +129,49 @@ func (b *Bridge) InitAfterConfig() {
 	}
 }
 
+// GetTip get tip and refresh protocol params if epoch changes
 func (b *Bridge) GetTip() (tip *cardanosdk.NodeTip, err error) {
-	useAPI, _ := strconv.ParseBool(params.GetCustom(b.ChainConfig.ChainID, "UseAPI"))
-	if useAPI {
-		if b.RpcClient == nil {
-			return nil, nil
-		}
-		return b.RpcClient.Tip()
-	} else {
-		urls := b.GatewayConfig.AllGatewayURLs
-		for _, url := range urls {
-			result, err := GetCardanoTip(url)
-			if err == nil {
-				b.flushProtocolParams(result)
-				return &cardanosdk.NodeTip{
-					Block: result.Cardano.Tip.BlockNumber,
-					Epoch: result.Cardano.Tip.Epoch.Number,
-					Slot:  result.Cardano.Tip.SlotNo,
-				}, nil
-			}
-		}
-		return nil, tokens.ErrTxNotFound
-	}
-}
-
-// GetLatestBlockNumber gets latest block number
-func (b *Bridge) GetLatestBlockNumber() (num uint64, err error) {
-	useAPI := false
-	if b.ChainConfig != nil {
-		useAPI, _ = strconv.ParseBool(params.GetCustom(b.ChainConfig.ChainID, "UseAPI"))
+	tip, err = b.Provider.Tip()
+	if err != nil {
+		return nil, err
 	}
-	if useAPI {
-		if b.RpcClient == nil {
-			return 0, nil
-		}
-		if tip, err := b.RpcClient.Tip(); err == nil {
-			protocolParams, err := b.RpcClient.ProtocolParams()
-			if err != nil {
-				b.ProtocolParams = protocolParams
-			}
-			return tip.Block, nil
+	b.protocolParamsLock.RLock()
+	needUpdate := b.protocolParams == nil || tip.Epoch != b.protocolParamsEpoch
+	b.protocolParamsLock.RUnlock()
+	if needUpdate {
+		protocolParams, err := b.Provider.ProtocolParams()
+		if err != nil {
+			log.Warn("cardano get protocol params failed", "epoch", tip.Epoch, "err", err)
 		} else {
-			return 0, err
-		}
-	} else {
-		urls := b.GatewayConfig.AllGatewayURLs
-		for _, url := range urls {
-			result, err := GetCardanoTip(url)
-			if err == nil {
-				b.flushProtocolParams(result)
-				return result.Cardano.Tip.BlockNumber, nil
-			}
+			b.SetProtocolParams(protocolParams, tip.Epoch)
+			log.Info("cardano update protocol params", "epoch", tip.Epoch, "params", protocolParams)
 		}
-		return 0, tokens.ErrTxNotFound
 	}
+	return tip, nil
+}
+
+// GetProtocolParams get protocol params (nil if not loaded yet), the returned value must not be modified
+func (b *Bridge) GetProtocolParams() *EpochProtocolParams {
+	b.protocolParamsLock.RLock()
+	defer b.protocolParamsLock.RUnlock()
+	return b.protocolParams
 }
 
-func (b *Bridge) flushProtocolParams(result *TipResponse) {
-	b.ProtocolParams = &cardanosdk.ProtocolParams{
-		CoinsPerUTXOWord: cardanosdk.Coin(result.Cardano.Tip.Epoch.ProtocolParams.CoinsPerUtxoByte),
-		KeyDeposit:       cardanosdk.Coin(result.Cardano.Tip.Epoch.ProtocolParams.KeyDeposit),
-		MaxBlockBodySize: uint(result.Cardano.Tip.Epoch.ProtocolParams.MaxBlockBodySize),
-		MaxTxExUnits:     result.Cardano.Tip.Epoch.ProtocolParams.MaxBlockExMem,
-		MaxTxSize:        uint(result.Cardano.Tip.Epoch.ProtocolParams.MaxTxSize),
-		MinFeeA:          cardanosdk.Coin(result.Cardano.Tip.Epoch.ProtocolParams.MinFeeA),
-		MinFeeB:          cardanosdk.Coin(result.Cardano.Tip.Epoch.ProtocolParams.MinFeeB),
-		MinPoolCost:      cardanosdk.Coin(result.Cardano.Tip.Epoch.ProtocolParams.MinPoolCost),
+// SetProtocolParams set protocol params of epoch
+func (b *Bridge) SetProtocolParams(protocolParams *EpochProtocolParams, epoch uint64) {
+	b.protocolParamsLock.Lock()
+	defer b.protocolParamsLock.Unlock()
+	b.protocolParams = protocolParams
+	b.protocolParamsEpoch = epoch
+}
+
+// GetLatestBlockNumber gets latest block number
+func (b *Bridge) GetLatestBlockNumber() (num uint64, err error) {
+	tip, err := b.GetTip()
+	if err != nil {
+		return 0, err
 	}
+	return tip.Block, nil
 }
 
 // GetLatestBlockNumberOf gets latest block number from single api
@@ -213,111 +184,17 @@ func (b *Bridge) GetTransaction(txHash string) (tx interface{}, err error) {
 	return b.GetTransactionByHash(txHash)
 }
 
-// GetTransactionByHash call eth_getTransactionByHash
+// GetTransactionByHash get tx by hash
 func (b *Bridge) GetTransactionByHash(txHash string) (*Transaction, error) {
-	useAPI, _ := strconv.ParseBool(params.GetCustom(b.ChainConfig.ChainID, "UseAPI"))
-	if useAPI {
-		txt, err := b.RpcClient.(*BlockfrostNode).GetTransactionByHash(txHash)
-		if err != nil {
-			return nil, err
-		}
-		txMetadata, err := b.RpcClient.(*BlockfrostNode).GetTransactionMetadataByHash(txHash)
-		if err != nil {
-			return nil, err
-		}
-		utxos, err := b.RpcClient.(*BlockfrostNode).GetTransactionUtxoByHash(txHash)
-		if err != nil {
-			return nil, err
-		}
-
-		metadata := []Metadata{}
-		for _, md := range *txMetadata {
-			if md.Label == MetadataKey {
-				tmp, _ := json.Marshal(md.JsonMetadata)
-				var mv MetadataValue
-				err = json.Unmarshal(tmp, &mv)
-				if err != nil {
-					return nil, err
-				}
-				metadata = append(metadata, Metadata{
-					Key:   md.Label,
-					Value: mv,
-				})
-			}
-		}
-
-		input := []Input{}
-		for _, v := range utxos.Inputs {
-			details, _ := json.Marshal(v.Amount)
-			input = append(input, Input{
-				Address: v.Address,
-				Value:   string(details),
-			})
-		}
-		output := []Output{}
-		for index, v := range utxos.Outputs {
-			ts := []Token{}
-			var value string
-			for _, a := range v.Amount {
-				if a.Unit == AdaAsset {
-					value = a.Quantity
-					continue
-				}
-				ts = append(ts, Token{
-					Asset: Asset{
-						PolicyId:  a.Unit[:56],
-						AssetName: a.Unit[56:],
-					},
-					Quantity: a.Quantity,
-				})
-			}
-
-			output = append(output, Output{
-				Address: v.Address,
-				Value:   value,
-				Tokens:  ts,
-				Index:   uint64(index),
-			})
-		}
-
-		tx := &Transaction{
-			Block: Block{
-				SlotNo: uint64(txt.Slot),
-				Number: uint64(txt.BlockHeight),
-			},
-			Hash:          txt.Hash,
-			Metadata:      metadata,
-			Inputs:        input,
-			Outputs:       output,
-			ValidContract: true,
-		}
-		return tx, nil
-	} else {
-		urls := b.GatewayConfig.AllGatewayURLs
-		for _, url := range urls {
-			result, err := GetTransactionByHash(url, txHash)
-			if err == nil {
-				return result, nil
-			}
-		}
-		return nil, tokens.ErrTxNotFound
-	}
-
+	return b.TxProvider.GetTransactionByHash(txHash)
 }
 
-// GetTransactionByHash call eth_getTransactionByHash
-func (b *Bridge) GetUtxosByAddress(address string) (*[]Output, error) {
+// GetUtxosByAddress get utxos by address
+func (b *Bridge) GetUtxosByAddress(address string) ([]Output, error) {
 	if !b.IsValidAddress(address) {
-		return nil, errors.New("GetUtxosByAddress address is empty")
-	}
-	urls := b.GatewayConfig.AllGatewayURLs
-	for _, url := range urls {
-		result, err := GetUtxosByAddress(url, address)
-		if err == nil {
-			return result, nil
-		}
+		return nil, errors.New("GetUtxosByAddress address is invalid")
 	}
-	return nil, tokens.ErrOutputLength
+	return b.Provider.GetUtxosByAddress(address)
 }
 
 // GetTransactionStatus impl
@@ -94,8 +94,6 @@ func (b *Bridge) BuildRawTransaction(args *tokens.BuildTxArgs) (rawTx interface{
 }
 
 func (b *Bridge) BuildTx(swapId, receiver, assetId string, amount *big.Int, utxos map[UtxoKey]AssetsMap) (*RawTransaction, error) {
-	log.Infof("Cardano BuildTx:\nreceiver:%+v\nassetId:%+v\namount:%+v\nutxos:%+v\nprotocolParams:%+v \n", receiver, assetId, amount, utxos, b.ProtocolParams)
-
 	txIns := []UtxoKey{}
 	txInsAssets := []AssetsMap{}
 
@@ -104,22 +102,34 @@ func (b *Bridge) BuildTx(swapId, receiver, assetId string, amount *big.Int, utxo
 		return nil, err
 	}
 
-	if b.ProtocolParams == nil || b.calcMaxFee() == 0 {
+	pp := b.GetProtocolParams()
+	log.Infof("Cardano BuildTx:\nreceiver:%+v\nassetId:%+v\namount:%+v\nutxos:%+v\nprotocolParams:%+v \n", receiver, assetId, amount, utxos, pp)
+	if pp == nil || calcMaxFee(pp) == 0 {
 		return nil, fmt.Errorf("ProtocolParams is empty")
 	}
 
-	// max tx size fee + output min ada
-	adaRequired := new(big.Int).SetUint64(b.calcMaxFee() + FixAdaAmount.Uint64())
+	receiverAssets := AssetsMap{}
+	if assetId != AdaAsset {
+		receiverAssets[assetId] = amount.String()
+	}
+	receiverMinAda, err := calcOutputMinAda(pp, receiver, receiverAssets)
+	if err != nil {
+		return nil, err
+	}
+
+	// max tx size fee + receiver output ada + change output min ada
+	maxFee := calcMaxFee(pp)
+	adaRequired := maxFee + receiverMinAda
 	if assetId == AdaAsset {
-		if amount.Cmp(FixAdaAmount) < 0 {
-			return nil, fmt.Errorf("%w %v", tokens.ErrBuildTxErrorAndDelay, "ada not enough, below "+FixAdaAmount.String())
+		if amount.Uint64() < receiverMinAda {
+			return nil, fmt.Errorf("%w ada not enough, below min utxo value %v", tokens.ErrBuildTxErrorAndDelay, receiverMinAda)
 		}
-		adaRequired.Add(adaRequired, amount)
-	} else {
-		adaRequired.Add(adaRequired, FixAdaAmount)
+		adaRequired = maxFee + amount.Uint64()
 	}
 
+	routerMpc := b.GetRouterContract("")
 	allAssetsMap := map[string]uint64{}
+	changeMinAda := uint64(0)
 	for utxoKey, assetsMap := range utxos {
 		for asset, assetAmount := range assetsMap {
 			value, err := common.GetBigIntFromStr(assetAmount)
@@ -132,18 +142,18 @@ func (b *Bridge) BuildTx(swapId, receiver, assetId string, amount *big.Int, utxo
 
 		txIns = append(txIns, utxoKey)
 		txInsAssets = append(txInsAssets, assetsMap)
-		if assetId == AdaAsset {
-			if allAssetsMap[assetId] > adaRequired.Uint64() {
-				break
-			}
-		} else {
-			if allAssetsMap[assetId] > amount.Uint64() && allAssetsMap[AdaAsset] > adaRequired.Uint64() {
-				break
-			}
+
+		changeMinAda, err = calcOutputMinAda(pp, routerMpc, calcChangeAssets(allAssetsMap, assetId, amount.Uint64()))
+		if err != nil {
+			return nil, err
+		}
+		if allAssetsMap[AdaAsset] > adaRequired+changeMinAda &&
+			(assetId == AdaAsset || allAssetsMap[assetId] > amount.Uint64()) {
+			break
 		}
 	}
-	if allAssetsMap[AdaAsset] <= adaRequired.Uint64() {
-		return nil, fmt.Errorf("%w %v", tokens.ErrBuildTxErrorAndDelay, "ada not enough, below "+FixAdaAmount.String())
+	if allAssetsMap[AdaAsset] <= adaRequired+changeMinAda {
+		return nil, fmt.Errorf("%w ada not enough, require %v", tokens.ErrBuildTxErrorAndDelay, adaRequired+changeMinAda)
 	}
 
 	rawTransaction := &RawTransaction{
@@ -153,19 +163,18 @@ func (b *Bridge) BuildTx(swapId, receiver, assetId string, amount *big.Int, utxo
 		TxInsAssets:      txInsAssets,
 		TxIndex:          uint64(0),
 		Slot:             nodeTip.Slot,
-		CoinsPerUTXOWord: uint64(b.ProtocolParams.CoinsPerUTXOWord),
-		KeyDeposit:       uint64(b.ProtocolParams.KeyDeposit),
-		MinFeeA:          uint64(b.ProtocolParams.MinFeeA),
-		MinFeeB:          uint64(b.ProtocolParams.MinFeeB),
+		CoinsPerUTxOByte: pp.CoinsPerUTxOByte,
+		KeyDeposit:       uint64(pp.KeyDeposit),
+		MinFeeA:          uint64(pp.MinFeeA),
+		MinFeeB:          uint64(pp.MinFeeB),
 	}
 	rawTransaction.TxOuts[receiver] = map[string]string{}
-	routerMpc := b.GetRouterContract("")
 	rawTransaction.TxOuts[routerMpc] = map[string]string{}
 	var adaAmount *big.Int
 	if assetId == AdaAsset {
 		adaAmount = amount
 	} else {
-		adaAmount = FixAdaAmount
+		adaAmount = new(big.Int).SetUint64(receiverMinAda)
 		if allAssetsMap[assetId] >= amount.Uint64() {
 			rawTransaction.TxOuts[receiver][assetId] = amount.String()
 			if allAssetsMap[assetId] > amount.Uint64() {
@@ -199,8 +208,26 @@ func (b *Bridge) BuildTx(swapId, receiver, assetId string, amount *big.Int, utxo
 	return rawTransaction, nil
 }
 
-func (b *Bridge) calcMaxFee() uint64 {
-	return uint64(b.ProtocolParams.MinFeeA)*uint64(b.ProtocolParams.MaxTxSize) + uint64(b.ProtocolParams.MinFeeB)
+// calcChangeAssets calc the assets left to router mpc after paying `amount` of `assetId`
+func calcChangeAssets(allAssetsMap map[string]uint64, assetId string, amount uint64) AssetsMap {
+	changeAssets := AssetsMap{}
+	for asset, assetAmount := range allAssetsMap {
+		if asset == AdaAsset {
+			continue
+		}
+		if asset == assetId {
+			if assetAmount <= amount {
+				continue
+			}
+			assetAmount -= amount
+		}
+		changeAssets[asset] = fmt.Sprint(assetAmount)
+	}
+	return changeAssets
+}
+
+func calcMaxFee(pp *EpochProtocolParams) uint64 {
+	return CalcMinFee(uint64(pp.MinFeeA), uint64(pp.MinFeeB), uint64(pp.MaxTxSize))
 }
 
 func (b *Bridge) CreateRawTx(rawTransaction *RawTransaction, mpcAddr string) (*cardanosdk.Tx, error) {
@@ -273,7 +300,8 @@ func buildTxAndAdjustRestAda(txBuilder *cardanosdk.TxBuilder, rawTransaction *Ra
 func (b *Bridge) genTxBuilder(mpcAddr string, rawTransaction *RawTransaction, data *cardanosdk.AuxiliaryData) (*cardanosdk.TxBuilder, error) {
 	mpc, _ := cardanosdk.NewAddress(mpcAddr)
 	txBuilder := cardanosdk.NewTxBuilder(&cardanosdk.ProtocolParams{
-		CoinsPerUTXOWord: cardanosdk.Coin(rawTransaction.CoinsPerUTXOWord),
+		// the sdk calcs min change coins by the pre-babbage per word formula
+		CoinsPerUTXOWord: cardanosdk.Coin(rawTransaction.CoinsPerUTxOByte * utxoWordBytes),
 		KeyDeposit:       cardanosdk.Coin(rawTransaction.KeyDeposit),
 		MinFeeA:          cardanosdk.Coin(rawTransaction.MinFeeA),
 		MinFeeB:          cardanosdk.Coin(rawTransaction.MinFeeB),
@@ -284,33 +312,11 @@ func (b *Bridge) genTxBuilder(mpcAddr string, rawTransaction *RawTransaction, da
 		if err != nil {
 			return nil, err
 		}
-		adaAmount, err := common.GetBigIntFromStr(rawTransaction.TxInsAssets[index][AdaAsset])
+		assetValue, err := NewValue(rawTransaction.TxInsAssets[index])
 		if err != nil {
 			return nil, err
 		}
-		assetValue := cardanosdk.NewValue(cardanosdk.Coin(adaAmount.Uint64()))
-		for assetkey, assetAmount := range rawTransaction.TxInsAssets[index] {
-			if assetkey == AdaAsset {
-				continue
-			}
-			tmp := strings.Split(assetkey, ".")
-
-			value, err := common.GetBigIntFromStr(assetAmount)
-			if err != nil {
-				return nil, err
-			}
-			p := cardanosdk.NewPolicyIDFromHash(common.Hex2Bytes(tmp[0]))
-			an := cardanosdk.NewAssetName(string(common.Hex2Bytes(tmp[1])))
-			av := cardanosdk.BigNum(value.Uint64())
-			if assetValue.MultiAsset.Get(p) == nil {
-				assetValue.MultiAsset.Set(p, cardanosdk.NewAssets().Set(an, av))
-			} else {
-				assetValue.MultiAsset.Get(p).Set(an, av)
-			}
-			log.Info("TxInsAssets", "origin assetkey", assetkey, "policy", p.String(), "assetName", an.String(), "amount", value.Uint64())
-		}
-
-		log.Info("AddTxInsAssets", "txHash", txHash.String(), "TxIndex", utxoKey.TxIndex, "assetValue", assetValue.Coin)
+		log.Info("AddTxInsAssets", "txHash", txHash.String(), "TxIndex", utxoKey.TxIndex, "assetValue", assetValue)
 		inputs = append(inputs, cardanosdk.NewTxInput(txHash, uint(utxoKey.TxIndex), assetValue))
 	}
 	if len(rawTransaction.TxOuts) > 2 {
@@ -321,37 +327,12 @@ func (b *Bridge) genTxBuilder(mpcAddr string, rawTransaction *RawTransaction, da
 		if address == mpcAddr {
 			continue
 		}
-		receiver, err := cardanosdk.NewAddress(strings.TrimSpace(address))
-		if err != nil {
-			return nil, err
-		}
-		adaAmount, err := common.GetBigIntFromStr(assets[AdaAsset])
+		var err error
+		txOut, err = NewTxOutput(address, assets)
 		if err != nil {
 			return nil, err
 		}
-		outValue := cardanosdk.NewValue(cardanosdk.Coin(adaAmount.Uint64()))
-		txOut = cardanosdk.NewTxOutput(receiver, outValue)
-		for asset, amount := range assets {
-			if asset == AdaAsset {
-				continue
-			}
-			tmp := strings.Split(asset, ".")
-
-			value, err := common.GetBigIntFromStr(amount)
-			if err != nil {
-				return nil, err
-			}
-			p := cardanosdk.NewPolicyIDFromHash(common.Hex2Bytes(tmp[0]))
-			an := cardanosdk.NewAssetName(string(common.Hex2Bytes(tmp[1])))
-			av := cardanosdk.BigNum(value.Uint64())
-			if outValue.MultiAsset.Get(p) == nil {
-				outValue.MultiAsset.Set(p, cardanosdk.NewAssets().Set(an, av))
-			} else {
-				outValue.MultiAsset.Get(p).Set(an, av)
-			}
-			log.Info("TxOutAssets", "origin assetkey", asset, "policy", p.String(), "assetName", an.String(), "amount", value.Uint64())
-		}
-		log.Info("AddTxOutAssets", "receiver", receiver.String(), "ada", adaAmount.Uint64())
+		log.Info("AddTxOutAssets", "receiver", txOut.Address.String(), "assetValue", txOut.Amount)
 	}
 	if rawTransaction.Mint != nil {
 		for assetNameStr, amount := range rawTransaction.Mint {
@@ -453,7 +434,11 @@ func (b *Bridge) GetTransactionChainingMap(assetName string, amount *big.Int) (m
 		if balance, err := common.GetBigIntFromStr(assetBalance); err != nil {
 			return nil, err
 		} else {
-			maxfee := big.NewInt(0).SetUint64(b.calcMaxFee())
+			pp := b.GetProtocolParams()
+			if pp == nil {
+				return nil, fmt.Errorf("ProtocolParams is empty")
+			}
+			maxfee := big.NewInt(0).SetUint64(calcMaxFee(pp))
 			if assetName == AdaAsset {
 				needAmount.Add(needAmount, maxfee)
 			} else {
@@ -474,49 +459,18 @@ func (b *Bridge) GetTransactionChainingMap(assetName string, amount *big.Int) (m
 }
 
 func (b *Bridge) QueryUtxoOnChain(address string) (map[UtxoKey]AssetsMap, error) {
-	useAPI, _ := strconv.ParseBool(params.GetCustom(b.ChainConfig.ChainID, "UseAPI"))
-	if useAPI {
-		return b.QueryUtxoByAPI(address)
-	} else {
-		return b.QueryUtxoByGQL(address)
-	}
-}
-
-func (b *Bridge) QueryUtxoByGQL(address string) (map[UtxoKey]AssetsMap, error) {
-	utxos := make(map[UtxoKey]AssetsMap)
-	if outputs, err := b.GetUtxosByAddress(address); err != nil {
-		return nil, err
-	} else {
-		for _, output := range *outputs {
-			utxoKey := UtxoKey{TxHash: output.TxHash, TxIndex: output.Index}
-			if !TransactionChainingKeyCache.SpentUtxoMap[utxoKey] {
-				utxos[utxoKey] = make(AssetsMap)
-				utxos[utxoKey][AdaAsset] = output.Value
-				for _, token := range output.Tokens {
-					utxos[utxoKey][token.Asset.PolicyId+"."+token.Asset.AssetName] = token.Quantity
-				}
-			}
-		}
-		return utxos, nil
-	}
-}
-
-func (b *Bridge) QueryUtxoByAPI(address string) (map[UtxoKey]AssetsMap, error) {
 	utxos := make(map[UtxoKey]AssetsMap)
-	addr, _ := cardanosdk.NewAddress(address)
-	outputs, err := b.RpcClient.UTxOs(addr)
+	outputs, err := b.GetUtxosByAddress(address)
 	if err != nil {
 		return nil, err
 	}
 	for _, output := range outputs {
-		utxoKey := UtxoKey{TxHash: output.TxHash.String(), TxIndex: output.Index}
+		utxoKey := UtxoKey{TxHash: output.TxHash, TxIndex: output.Index}
 		if !TransactionChainingKeyCache.SpentUtxoMap[utxoKey] {
 			utxos[utxoKey] = make(AssetsMap)
-			utxos[utxoKey][AdaAsset] = strconv.FormatUint(uint64(output.Amount.Coin), 10)
-			for _, policyID := range output.Amount.MultiAsset.Keys() {
-				for _, assetName := range output.Amount.MultiAsset.Get(policyID).Keys() {
-					utxos[utxoKey][policyID.String()+"."+common.Bytes2Hex(assetName.Bytes())] = strconv.FormatUint(uint64(output.Amount.MultiAsset.Get(policyID).Get(assetName)), 10)
-				}
+			utxos[utxoKey][AdaAsset] = output.Value
+			for _, token := range output.Tokens {
+				utxos[utxoKey][token.Asset.PolicyId+"."+token.Asset.AssetName] = token.Quantity
 			}
 		}
 	}
@@ -528,7 +482,7 @@ func (b *Bridge) QueryUtxoOnChainByAsset(address string, asset string) (map[Utxo
 	if outputs, err := b.GetUtxosByAddress(address); err != nil {
 		return nil, err
 	} else {
-		for _, output := range *outputs {
+		for _, output := range outputs {
 			utxoKey := UtxoKey{TxHash: output.TxHash, TxIndex: output.Index}
 			if !TransactionChainingKeyCache.SpentUtxoMap[utxoKey] {
 				if asset == AdaAsset {
@@ -577,6 +531,18 @@ func (b *Bridge) VerifyRawTransaction(raw *RawTransaction, args *tokens.BuildTxA
 		return tokens.ErrMissTokenConfig
 	}
 
+	receiverOut, err := NewTxOutput(args.SwapArgs.Bind, receiverAssetsMap)
+	if err != nil {
+		return err
+	}
+	minAda, err := CalcMinUTxOValue(raw.CoinsPerUTxOByte, receiverOut)
+	if err != nil {
+		return err
+	}
+	if uint64(receiverOut.Amount.Coin) < minAda {
+		return fmt.Errorf("%w: receiver ada is below min utxo value %v", tokens.ErrTxWithWrongValue, minAda)
+	}
+
 	switch len(receiverAssetsMap) {
 	case 1:
 		adaAmount := receiverAssetsMap[AdaAsset]
@@ -0,0 +1,111 @@
+package cardano
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Router/v3/common"
+	cardanosdk "github.com/echovl/cardano-go"
+	"github.com/fxamacker/cbor/v2"
+)
+
+const (
+	// utxoEntryOverhead is the constant overhead (in bytes) of an utxo entry
+	// which is added to the serialized output size when calc min utxo value.
+	utxoEntryOverhead = 160
+
+	// utxoWordBytes bytes of a word, `coinsPerUTxOWord = coinsPerUTxOByte * 8` (CIP-55)
+	utxoWordBytes = 8
+)
+
+var cborEnc, _ = cbor.CanonicalEncOptions().EncMode()
+
+// CalcMinFee calc min fee of tx with size `txSize` (`minFeeA * txSize + minFeeB`)
+func CalcMinFee(minFeeA, minFeeB, txSize uint64) uint64 {
+	return minFeeA*txSize + minFeeB
+}
+
+// CalcMinUTxOValue calc the min lovelace an output must hold (babbage era),
+// `(160 + serialized output size) * coinsPerUTxOByte`.
+// The lovelace amount is part of the output, so iterate until it's stable.
+func CalcMinUTxOValue(coinsPerUTxOByte uint64, txOut *cardanosdk.TxOutput) (uint64, error) {
+	output := &cardanosdk.TxOutput{
+		Address: txOut.Address,
+		Amount:  cardanosdk.NewValueWithAssets(txOut.Amount.Coin, txOut.Amount.MultiAsset),
+	}
+	for {
+		data, err := cborEnc.Marshal(output)
+		if err != nil {
+			return 0, err
+		}
+		minValue := (utxoEntryOverhead + uint64(len(data))) * coinsPerUTxOByte
+		if uint64(output.Amount.Coin) >= minValue {
+			return minValue, nil
+		}
+		output.Amount.Coin = cardanosdk.Coin(minValue)
+	}
+}
+
+// NewTxOutput new tx output from assets map
+func NewTxOutput(address string, assets AssetsMap) (*cardanosdk.TxOutput, error) {
+	addr, err := cardanosdk.NewAddress(strings.TrimSpace(address))
+	if err != nil {
+		return nil, err
+	}
+	value, err := NewValue(assets)
+	if err != nil {
+		return nil, err
+	}
+	return cardanosdk.NewTxOutput(addr, value), nil
+}
+
+// NewValue new multi asset value from assets map
+func NewValue(assets AssetsMap) (*cardanosdk.Value, error) {
+	var adaAmount uint64
+	if assets[AdaAsset] != "" {
+		amount, err := common.GetBigIntFromStr(assets[AdaAsset])
+		if err != nil {
+			return nil, err
+		}
+		adaAmount = amount.Uint64()
+	}
+	value := cardanosdk.NewValue(cardanosdk.Coin(adaAmount))
+	for asset, assetAmount := range assets {
+		if asset == AdaAsset {
+			continue
+		}
+		tmp := strings.Split(asset, ".")
+		if len(tmp) != 2 {
+			return nil, fmt.Errorf("invalid cardano asset %v", asset)
+		}
+
+		amount, err := common.GetBigIntFromStr(assetAmount)
+		if err != nil {
+			return nil, err
+		}
+		p := cardanosdk.NewPolicyIDFromHash(common.Hex2Bytes(tmp[0]))
+		an := cardanosdk.NewAssetName(string(common.Hex2Bytes(tmp[1])))
+		av := cardanosdk.BigNum(amount.Uint64())
+		if value.MultiAsset.Get(p) == nil {
+			value.MultiAsset.Set(p, cardanosdk.NewAssets().Set(an, av))
+		} else {
+			value.MultiAsset.Get(p).Set(an, av)
+		}
+	}
+	return value, nil
+}
+
+// calcOutputMinAda calc min ada of output with assets (ada amount in assets is ignored)
+func calcOutputMinAda(pp *EpochProtocolParams, address string, assets AssetsMap) (uint64, error) {
+	tokenAssets := make(AssetsMap, len(assets))
+	for asset, amount := range assets {
+		if asset != AdaAsset {
+			tokenAssets[asset] = amount
+		}
+	}
+	txOut, err := NewTxOutput(address, tokenAssets)
+	if err != nil {
+		return 0, err
+	}
+	return CalcMinUTxOValue(pp.CoinsPerUTxOByte, txOut)
+}
@@ -0,0 +1,37 @@
+package cardano
+
+import (
+	"testing"
+)
+
+const testBaseAddress = "addr1qx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzer3n0d3vllmyqwsx5wktcd8cc3sq835lu7drv2xwl2wywfgse35a3x"
+
+func TestCalcMinUTxOValue(t *testing.T) {
+	tests := []struct {
+		assets AssetsMap
+		want   uint64
+	}{
+		{AssetsMap{}, 969750},
+		{AssetsMap{AdaAsset: "100000000"}, 969750},
+		{AssetsMap{"8c7d04a9146bff45aadc19d3f3e4cdb8a989ff35182989dbfb93f5b4.746f6b656e4e616d65": "1000"}, 1168010},
+	}
+	for i, test := range tests {
+		txOut, err := NewTxOutput(testBaseAddress, test.assets)
+		if err != nil {
+			t.Fatalf("test %v new tx output failed: %v", i, err)
+		}
+		got, err := CalcMinUTxOValue(4310, txOut)
+		if err != nil {
+			t.Fatalf("test %v calc min utxo value failed: %v", i, err)
+		}
+		if got != test.want {
+			t.Errorf("test %v calc min utxo value mismatch, got %v want %v", i, got, test.want)
+		}
+	}
+}
+
+func TestCalcMinFee(t *testing.T) {
+	if fee := CalcMinFee(44, 155381, 16384); fee != 876277 {
+		t.Errorf("calc min fee mismatch, got %v want %v", fee, 876277)
+	}
+}
@@ -0,0 +1,183 @@
+package cardano
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/anyswap/CrossChain-Router/v3/rpc/client"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+	cardanosdk "github.com/echovl/cardano-go"
+)
+
+// OgmiosNode implements ChainDataProvider using the ogmios (v6) json-rpc api.
+// Ogmios has no tx index, so it can not be used to query txs by hash.
+type OgmiosNode struct {
+	getURLs func() []string
+}
+
+type ogmiosLovelace struct {
+	Ada struct {
+		Lovelace uint64 `json:"lovelace"`
+	} `json:"ada"`
+}
+
+type ogmiosBytes struct {
+	Bytes uint64 `json:"bytes"`
+}
+
+type ogmiosTip struct {
+	Slot uint64 `json:"slot"`
+	ID   string `json:"id"`
+}
+
+type ogmiosProtocolParams struct {
+	MinFeeCoefficient         uint64         `json:"minFeeCoefficient"`
+	MinFeeConstant            ogmiosLovelace `json:"minFeeConstant"`
+	MinUtxoDepositCoefficient uint64         `json:"minUtxoDepositCoefficient"`
+	MaxBlockBodySize          ogmiosBytes    `json:"maxBlockBodySize"`
+	MaxTransactionSize        ogmiosBytes    `json:"maxTransactionSize"`
+	StakeCredentialDeposit    ogmiosLovelace `json:"stakeCredentialDeposit"`
+	StakePoolDeposit          ogmiosLovelace `json:"stakePoolDeposit"`
+	MinStakePoolCost          ogmiosLovelace `json:"minStakePoolCost"`
+}
+
+type ogmiosUtxo struct {
+	Transaction struct {
+		ID string `json:"id"`
+	} `json:"transaction"`
+	Index   uint64                       `json:"index"`
+	Address string                       `json:"address"`
+	Value   map[string]map[string]uint64 `json:"value"`
+}
+
+type ogmiosSubmitResult struct {
+	Transaction struct {
+		ID string `json:"id"`
+	} `json:"transaction"`
+}
+
+func (o *OgmiosNode) call(result interface{}, method string, params interface{}) (err error) {
+	if params == nil {
+		params = struct{}{}
+	}
+	request := &client.Request{
+		Method:  method,
+		Params:  params,
+		ID:      int(time.Now().UnixNano()),
+		Timeout: rpcTimeout,
+	}
+	for _, url := range o.getURLs() {
+		err = client.RPCPostRequest(url, request, result)
+		if err == nil {
+			return nil
+		}
+	}
+	return tokens.WrapRPCQueryError(err, method, params)
+}
+
+// Name impl ChainDataProvider
+func (o *OgmiosNode) Name() string {
+	return OgmiosProvider
+}
+
+// Tip impl ChainDataProvider
+func (o *OgmiosNode) Tip() (*cardanosdk.NodeTip, error) {
+	var tip ogmiosTip
+	if err := o.call(&tip, "queryNetwork/tip", nil); err != nil {
+		return nil, err
+	}
+	var height, epoch uint64
+	if err := o.call(&height, "queryNetwork/blockHeight", nil); err != nil {
+		return nil, err
+	}
+	if err := o.call(&epoch, "queryLedgerState/epoch", nil); err != nil {
+		return nil, err
+	}
+	return &cardanosdk.NodeTip{
+		Block: height,
+		Epoch: epoch,
+		Slot:  tip.Slot,
+	}, nil
+}
+
+// ProtocolParams impl ChainDataProvider
+func (o *OgmiosNode) ProtocolParams() (*EpochProtocolParams, error) {
+	var pp ogmiosProtocolParams
+	if err := o.call(&pp, "queryLedgerState/protocolParameters", nil); err != nil {
+		return nil, err
+	}
+	return &EpochProtocolParams{
+		ProtocolParams: cardanosdk.ProtocolParams{
+			MinFeeA:          cardanosdk.Coin(pp.MinFeeCoefficient),
+			MinFeeB:          cardanosdk.Coin(pp.MinFeeConstant.Ada.Lovelace),
+			MaxBlockBodySize: uint(pp.MaxBlockBodySize.Bytes),
+			MaxTxSize:        uint(pp.MaxTransactionSize.Bytes),
+			KeyDeposit:       cardanosdk.Coin(pp.StakeCredentialDeposit.Ada.Lovelace),
+			PoolDeposit:      cardanosdk.Coin(pp.StakePoolDeposit.Ada.Lovelace),
+			MinPoolCost:      cardanosdk.Coin(pp.MinStakePoolCost.Ada.Lovelace),
+		},
+		// ogmios v6 returns the babbage per byte value
+		CoinsPerUTxOByte: pp.MinUtxoDepositCoefficient,
+	}, nil
+}
+
+// GetTransactionByHash impl ChainDataProvider
+func (o *OgmiosNode) GetTransactionByHash(txHash string) (*Transaction, error) {
+	return nil, fmt.Errorf("%w: ogmios can not query tx by hash", tokens.ErrNotImplemented)
+}
+
+// GetUtxosByAddress impl ChainDataProvider
+func (o *OgmiosNode) GetUtxosByAddress(address string) ([]Output, error) {
+	var utxos []ogmiosUtxo
+	params := map[string]interface{}{
+		"addresses": []string{address},
+	}
+	if err := o.call(&utxos, "queryLedgerState/utxo", params); err != nil {
+		return nil, err
+	}
+	outputs := make([]Output, 0, len(utxos))
+	for _, utxo := range utxos {
+		output := Output{
+			Address: utxo.Address,
+			Index:   utxo.Index,
+			TxHash:  utxo.Transaction.ID,
+		}
+		for policyID, assets := range utxo.Value {
+			if policyID == "ada" {
+				output.Value = strconv.FormatUint(assets["lovelace"], 10)
+				continue
+			}
+			for assetName, quantity := range assets {
+				output.Tokens = append(output.Tokens, Token{
+					Asset: Asset{
+						PolicyId:  policyID,
+						AssetName: assetName,
+					},
+					Quantity: strconv.FormatUint(quantity, 10),
+				})
+			}
+		}
+		outputs = append(outputs, output)
+	}
+	return outputs, nil
+}
+
+// SubmitTx impl ChainDataProvider
+func (o *OgmiosNode) SubmitTx(tx *cardanosdk.Tx) (string, error) {
+	var result ogmiosSubmitResult
+	params := map[string]interface{}{
+		"transaction": map[string]string{
+			"cbor": hex.EncodeToString(tx.Bytes()),
+		},
+	}
+	if err := o.call(&result, "submitTransaction", params); err != nil {
+		return "", err
+	}
+	if result.Transaction.ID == "" {
+		return "", errors.New("ogmios submit tx without tx id")
+	}
+	return result.Transaction.ID, nil
+}
@@ -0,0 +1,123 @@
+package cardano
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Router/v3/params"
+	cardanosdk "github.com/echovl/cardano-go"
+)
+
+// chain data provider names
+const (
+	GraphQLProvider    = "graphql"
+	OgmiosProvider     = "ogmios"
+	BlockfrostProvider = "blockfrost"
+)
+
+// EpochProtocolParams protocol params of the current epoch
+type EpochProtocolParams struct {
+	cardanosdk.ProtocolParams
+	// CoinsPerUTxOByte lovelace per utxo byte since babbage era (CIP-55),
+	// the embedded `CoinsPerUTXOWord` (per word before babbage) is not used
+	CoinsPerUTxOByte uint64
+}
+
+// ChainDataProvider provides the chain data needed to verify and build txs.
+// The bridge only talks to the chain through this interface, so the indexer
+// used (cardano-graphql, ogmios, blockfrost compatible rest api) is pluggable.
+type ChainDataProvider interface {
+	Name() string
+	Tip() (*cardanosdk.NodeTip, error)
+	ProtocolParams() (*EpochProtocolParams, error)
+	GetTransactionByHash(txHash string) (*Transaction, error)
+	GetUtxosByAddress(address string) ([]Output, error)
+	SubmitTx(tx *cardanosdk.Tx) (string, error)
+}
+
+// initChainDataProviders init providers from chain customs
+//
+// customs used:
+//
+//	DataProvider    - provider to query tip/protocol params/utxos and submit txs
+//	                  (graphql|ogmios|blockfrost), defaults to graphql,
+//	                  or blockfrost if the legacy `UseAPI` is true
+//	TxQueryProvider - provider to query txs by hash, defaults to DataProvider
+//	                  (must be set if DataProvider is ogmios which has no tx index)
+//	GraphQLURLs     - comma separated graphql urls, defaults to gateways
+//	OgmiosURLs      - comma separated ogmios urls, defaults to gateways
+//	BlockfrostURL   - blockfrost compatible rest api url, defaults by network
+//	APIKey          - blockfrost project id
+func (b *Bridge) initChainDataProviders() error {
+	chainID := b.ChainConfig.ChainID
+
+	providerName := strings.ToLower(params.GetCustom(chainID, "DataProvider"))
+	if providerName == "" {
+		providerName = GraphQLProvider
+		if useAPI, _ := strconv.ParseBool(params.GetCustom(chainID, "UseAPI")); useAPI {
+			providerName = BlockfrostProvider
+		}
+	}
+	provider, err := b.newChainDataProvider(providerName)
+	if err != nil {
+		return err
+	}
+
+	txProvider := provider
+	txProviderName := strings.ToLower(params.GetCustom(chainID, "TxQueryProvider"))
+	if txProviderName != "" && txProviderName != providerName {
+		txProvider, err = b.newChainDataProvider(txProviderName)
+		if err != nil {
+			return err
+		}
+	}
+	if txProvider.Name() == OgmiosProvider {
+		return fmt.Errorf("ogmios can not query tx by hash, please config 'TxQueryProvider'")
+	}
+
+	b.Provider = provider
+	b.TxProvider = txProvider
+	return nil
+}
+
+func (b *Bridge) newChainDataProvider(name string) (ChainDataProvider, error) {
+	chainID := b.ChainConfig.ChainID
+	switch name {
+	case GraphQLProvider:
+		return &GraphQLNode{getURLs: b.getProviderURLsFunc("GraphQLURLs")}, nil
+	case OgmiosProvider:
+		return &OgmiosNode{getURLs: b.getProviderURLsFunc("OgmiosURLs")}, nil
+	case BlockfrostProvider:
+		apiKey := params.GetCustom(chainID, "APIKey")
+		network, url := cardanosdk.Testnet, CardanoPreProd
+		if b.ChainConfig.GetChainID().Cmp(GetStubChainID(mainnetNetWork)) == 0 {
+			network, url = cardanosdk.Mainnet, CardanoMainNet
+		}
+		if customURL := params.GetCustom(chainID, "BlockfrostURL"); customURL != "" {
+			url = customURL
+		}
+		return NewNode(network, url, apiKey), nil
+	default:
+		return nil, fmt.Errorf("unknown cardano chain data provider '%v'", name)
+	}
+}
+
+func (b *Bridge) getProviderURLsFunc(customKey string) func() []string {
+	var urls []string
+	for _, url := range strings.Split(params.GetCustom(b.ChainConfig.ChainID, customKey), ",") {
+		if url = strings.TrimSpace(url); url != "" {
+			urls = append(urls, url)
+		}
+	}
+	if len(urls) > 0 {
+		return func() []string { return urls }
+	}
+	return func() []string { return b.GatewayConfig.AllGatewayURLs }
+}
+
+// useOutputIndexAsLogIndex blockfrost registers swaps with the output index as log index,
+// while graphql flattens every asset of the outputs, keep both for compatibility.
+func (b *Bridge) useOutputIndexAsLogIndex() bool {
+	return b.TxProvider != nil && b.TxProvider.Name() == BlockfrostProvider
+}
@@ -2,10 +2,8 @@ package cardano
 
 import (
 	"errors"
-	"strconv"
 
 	"github.com/anyswap/CrossChain-Router/v3/log"
-	"github.com/anyswap/CrossChain-Router/v3/params"
 	"github.com/anyswap/CrossChain-Router/v3/tokens"
 )
 
@@ -35,8 +33,7 @@ func (b *Bridge) registerERC20SwapTx(txHash string, logIndex int) ([]*tokens.Swa
 	} else {
 		swapInfos := make([]*tokens.SwapTxInfo, 0)
 		errs := make([]error, 0)
-		useAPI, _ := strconv.ParseBool(params.GetCustom(b.ChainConfig.ChainID, "UseAPI"))
-		if useAPI {
+		if b.useOutputIndexAsLogIndex() {
 			for i := 0; i < len(outputs); i++ {
 				output := outputs[i]
 				if output.Address != b.GetChainConfig().RouterContract {
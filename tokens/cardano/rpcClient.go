@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -68,7 +69,108 @@ func GetCardanoTip(url string) (*TipResponse, error) {
 	return &result, nil
 }
 
-// BlockfrostNode implements Node using the blockfrost API.
+func SubmitTransaction(url string, txBytes []byte) (string, error) {
+	request := &client.Request{}
+	request.Params = fmt.Sprintf(MutationSubmitTx, hex.EncodeToString(txBytes))
+	request.ID = int(time.Now().UnixNano())
+	request.Timeout = rpcTimeout
+	var result SubmitTransactionResult
+	if err := client.CardanoPostRequest(url, request, &result); err != nil {
+		return "", err
+	}
+	return result.SubmitTransaction.Hash, nil
+}
+
+// GraphQLNode implements ChainDataProvider using the cardano-graphql api.
+type GraphQLNode struct {
+	getURLs func() []string
+}
+
+// Name impl ChainDataProvider
+func (g *GraphQLNode) Name() string {
+	return GraphQLProvider
+}
+
+// Tip impl ChainDataProvider
+func (g *GraphQLNode) Tip() (*cardanosdk.NodeTip, error) {
+	result, err := g.getTip()
+	if err != nil {
+		return nil, err
+	}
+	return &cardanosdk.NodeTip{
+		Block: result.Cardano.Tip.BlockNumber,
+		Epoch: result.Cardano.Tip.Epoch.Number,
+		Slot:  result.Cardano.Tip.SlotNo,
+	}, nil
+}
+
+// ProtocolParams impl ChainDataProvider
+func (g *GraphQLNode) ProtocolParams() (*EpochProtocolParams, error) {
+	result, err := g.getTip()
+	if err != nil {
+		return nil, err
+	}
+	pp := result.Cardano.Tip.Epoch.ProtocolParams
+	return &EpochProtocolParams{
+		ProtocolParams: cardanosdk.ProtocolParams{
+			KeyDeposit:       cardanosdk.Coin(pp.KeyDeposit),
+			MaxBlockBodySize: uint(pp.MaxBlockBodySize),
+			MaxTxExUnits:     pp.MaxBlockExMem,
+			MaxTxSize:        uint(pp.MaxTxSize),
+			MinFeeA:          cardanosdk.Coin(pp.MinFeeA),
+			MinFeeB:          cardanosdk.Coin(pp.MinFeeB),
+			MinPoolCost:      cardanosdk.Coin(pp.MinPoolCost),
+			MaxEpoch:         uint(result.Cardano.Tip.Epoch.Number),
+		},
+		CoinsPerUTxOByte: pp.CoinsPerUtxoByte,
+	}, nil
+}
+
+func (g *GraphQLNode) getTip() (result *TipResponse, err error) {
+	for _, url := range g.getURLs() {
+		result, err = GetCardanoTip(url)
+		if err == nil {
+			return result, nil
+		}
+	}
+	return nil, tokens.WrapRPCQueryError(err, "tip")
+}
+
+// GetTransactionByHash impl ChainDataProvider
+func (g *GraphQLNode) GetTransactionByHash(txHash string) (*Transaction, error) {
+	for _, url := range g.getURLs() {
+		result, err := GetTransactionByHash(url, txHash)
+		if err == nil {
+			return result, nil
+		}
+	}
+	return nil, tokens.ErrTxNotFound
+}
+
+// GetUtxosByAddress impl ChainDataProvider
+func (g *GraphQLNode) GetUtxosByAddress(address string) ([]Output, error) {
+	for _, url := range g.getURLs() {
+		result, err := GetUtxosByAddress(url, address)
+		if err == nil {
+			return *result, nil
+		}
+	}
+	return nil, tokens.ErrOutputLength
+}
+
+// SubmitTx impl ChainDataProvider
+func (g *GraphQLNode) SubmitTx(tx *cardanosdk.Tx) (txHash string, err error) {
+	txBytes := tx.Bytes()
+	for _, url := range g.getURLs() {
+		txHash, err = SubmitTransaction(url, txBytes)
+		if err == nil {
+			return txHash, nil
+		}
+	}
+	return "", tokens.WrapRPCQueryError(err, "submitTransaction")
+}
+
+// BlockfrostNode implements ChainDataProvider using the blockfrost compatible rest api.
 type BlockfrostNode struct {
 	client    blockfrost.APIClient
 	projectID string
@@ -77,7 +179,7 @@ type BlockfrostNode struct {
 }
 
 // NewNode returns a new instance of BlockfrostNode.
-func NewNode(network cardanosdk.Network, networkUrl string, projectID string) cardanosdk.Node {
+func NewNode(network cardanosdk.Network, networkUrl string, projectID string) *BlockfrostNode {
 	return &BlockfrostNode{
 		network:   network,
 		url:       networkUrl,
@@ -89,6 +191,46 @@ func NewNode(network cardanosdk.Network, networkUrl string, projectID string) ca
 	}
 }
 
+// Name impl ChainDataProvider
+func (b *BlockfrostNode) Name() string {
+	return BlockfrostProvider
+}
+
+// GetUtxosByAddress impl ChainDataProvider
+func (b *BlockfrostNode) GetUtxosByAddress(address string) ([]Output, error) {
+	addr, err := cardanosdk.NewAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	utxos, err := b.UTxOs(addr)
+	if err != nil {
+		return nil, err
+	}
+	outputs := make([]Output, 0, len(utxos))
+	for _, utxo := range utxos {
+		output := Output{
+			Address: address,
+			Index:   utxo.Index,
+			TxHash:  utxo.TxHash.String(),
+			Value:   strconv.FormatUint(uint64(utxo.Amount.Coin), 10),
+		}
+		for _, policyID := range utxo.Amount.MultiAsset.Keys() {
+			assets := utxo.Amount.MultiAsset.Get(policyID)
+			for _, assetName := range assets.Keys() {
+				output.Tokens = append(output.Tokens, Token{
+					Asset: Asset{
+						PolicyId:  policyID.String(),
+						AssetName: hex.EncodeToString(assetName.Bytes()),
+					},
+					Quantity: strconv.FormatUint(uint64(assets.Get(assetName)), 10),
+				})
+			}
+		}
+		outputs = append(outputs, output)
+	}
+	return outputs, nil
+}
+
 func (b *BlockfrostNode) UTxOs(addr cardanosdk.Address) ([]cardanosdk.UTxO, error) {
 	butxos, err := b.client.AddressUTXOs(context.Background(), addr.Bech32(), blockfrost.APIQueryParams{})
 	if err != nil {
@@ -171,13 +313,14 @@ func (b *BlockfrostNode) Tip() (*cardanosdk.NodeTip, error) {
 	}, nil
 }
 
-func (b *BlockfrostNode) SubmitTx(tx *cardanosdk.Tx) (*cardanosdk.Hash32, error) {
+// SubmitTx impl ChainDataProvider
+func (b *BlockfrostNode) SubmitTx(tx *cardanosdk.Tx) (string, error) {
 	url := fmt.Sprintf("%s/tx/submit", b.url)
 	txBytes := tx.Bytes()
 
 	req, err := http.NewRequest("POST", url, bytes.NewReader(txBytes))
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
 	req.Header.Add("project_id", b.projectID)
@@ -185,28 +328,28 @@ func (b *BlockfrostNode) SubmitTx(tx *cardanosdk.Tx) (*cardanosdk.Hash32, error)
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, errors.New(string(respBody))
+		return "", errors.New(string(respBody))
 	}
 
 	txHash, err := tx.Hash()
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
-	return &txHash, nil
+	return txHash.String(), nil
 }
 
-func (b *BlockfrostNode) ProtocolParams() (*cardanosdk.ProtocolParams, error) {
+func (b *BlockfrostNode) ProtocolParams() (*EpochProtocolParams, error) {
 	eparams, err := b.client.LatestEpochParameters(context.Background())
 	if err != nil {
 		return nil, err
@@ -226,17 +369,20 @@ func (b *BlockfrostNode) ProtocolParams() (*cardanosdk.ProtocolParams, error) {
 		return nil, err
 	}
 
-	pparams := &cardanosdk.ProtocolParams{
-		MinFeeA:            cardanosdk.Coin(eparams.MinFeeA),
-		MinFeeB:            cardanosdk.Coin(eparams.MinFeeB),
-		MaxBlockBodySize:   uint(eparams.MaxBlockSize),
-		MaxTxSize:          uint(eparams.MaxTxSize),
-		MaxBlockHeaderSize: uint(eparams.MaxBlockHeaderSize),
-		KeyDeposit:         cardanosdk.Coin(keyDeposit),
-		PoolDeposit:        cardanosdk.Coin(poolDeposit),
-		MaxEpoch:           uint(eparams.Epoch),
-		NOpt:               uint(eparams.NOpt),
-		CoinsPerUTXOWord:   cardanosdk.Coin(minUTXO),
+	pparams := &EpochProtocolParams{
+		ProtocolParams: cardanosdk.ProtocolParams{
+			MinFeeA:            cardanosdk.Coin(eparams.MinFeeA),
+			MinFeeB:            cardanosdk.Coin(eparams.MinFeeB),
+			MaxBlockBodySize:   uint(eparams.MaxBlockSize),
+			MaxTxSize:          uint(eparams.MaxTxSize),
+			MaxBlockHeaderSize: uint(eparams.MaxBlockHeaderSize),
+			KeyDeposit:         cardanosdk.Coin(keyDeposit),
+			PoolDeposit:        cardanosdk.Coin(poolDeposit),
+			MaxEpoch:           uint(eparams.Epoch),
+			NOpt:               uint(eparams.NOpt),
+		},
+		// since babbage era, `min_utxo` returns the value of `coins_per_utxo_size`
+		CoinsPerUTxOByte: minUTXO,
 	}
 
 	return pparams, nil
@@ -246,17 +392,80 @@ func (b *BlockfrostNode) Network() cardanosdk.Network {
 	return b.network
 }
 
-func (b *BlockfrostNode) GetTransactionByHash(txHash string) (*blockfrost.TransactionContent, error) {
-	tx, err := b.client.Transaction(context.Background(), txHash)
-	return &tx, err
-}
+// GetTransactionByHash impl ChainDataProvider
+func (b *BlockfrostNode) GetTransactionByHash(txHash string) (*Transaction, error) {
+	txt, err := b.client.Transaction(context.Background(), txHash)
+	if err != nil {
+		return nil, err
+	}
+	txMetadata, err := b.client.TransactionMetadata(context.Background(), txHash)
+	if err != nil {
+		return nil, err
+	}
+	utxos, err := b.client.TransactionUTXOs(context.Background(), txHash)
+	if err != nil {
+		return nil, err
+	}
 
-func (b *BlockfrostNode) GetTransactionMetadataByHash(txHash string) (*[]blockfrost.TransactionMetadata, error) {
-	txm, err := b.client.TransactionMetadata(context.Background(), txHash)
-	return &txm, err
-}
+	metadata := []Metadata{}
+	for _, md := range txMetadata {
+		if md.Label == MetadataKey {
+			tmp, _ := json.Marshal(md.JsonMetadata)
+			var mv MetadataValue
+			err = json.Unmarshal(tmp, &mv)
+			if err != nil {
+				return nil, err
+			}
+			metadata = append(metadata, Metadata{
+				Key:   md.Label,
+				Value: mv,
+			})
+		}
+	}
 
-func (b *BlockfrostNode) GetTransactionUtxoByHash(txHash string) (*blockfrost.TransactionUTXOs, error) {
-	utxos, err := b.client.TransactionUTXOs(context.Background(), txHash)
-	return &utxos, err
+	input := []Input{}
+	for _, v := range utxos.Inputs {
+		details, _ := json.Marshal(v.Amount)
+		input = append(input, Input{
+			Address: v.Address,
+			Value:   string(details),
+		})
+	}
+	output := []Output{}
+	for index, v := range utxos.Outputs {
+		ts := []Token{}
+		var value string
+		for _, a := range v.Amount {
+			if a.Unit == AdaAsset {
+				value = a.Quantity
+				continue
+			}
+			ts = append(ts, Token{
+				Asset: Asset{
+					PolicyId:  a.Unit[:56],
+					AssetName: a.Unit[56:],
+				},
+				Quantity: a.Quantity,
+			})
+		}
+
+		output = append(output, Output{
+			Address: v.Address,
+			Value:   value,
+			Tokens:  ts,
+			Index:   uint64(index),
+		})
+	}
+
+	return &Transaction{
+		Block: Block{
+			SlotNo: uint64(txt.Slot),
+			Number: uint64(txt.BlockHeight),
+		},
+		Hash:          txt.Hash,
+		Metadata:      metadata,
+		Inputs:        input,
+		Outputs:       output,
+		ValidContract: true,
+	}, nil
 }
@@ -6,11 +6,11 @@ import "github.com/anyswap/CrossChain-Router/v3/log"
 func (b *Bridge) SendTransaction(signedTx interface{}) (string, error) {
 	signedTransaction := signedTx.(*SignedTransaction)
 
-	txhash, err := b.RpcClient.SubmitTx(signedTransaction.Tx)
+	txhash, err := b.Provider.SubmitTx(signedTransaction.Tx)
 	if err != nil {
 		return "", err
 	}
-	log.Info("CardanoSubmitTx", "txhash", txhash.String(), "savedTxHash", signedTransaction.TxHash)
+	log.Info("CardanoSubmitTx", "txhash", txhash, "savedTxHash", signedTransaction.TxHash, "provider", b.Provider.Name())
 
 	TransactionChaining.InputKey.TxHash = signedTransaction.TxHash
 	TransactionChaining.InputKey.TxIndex = signedTransaction.TxIndex
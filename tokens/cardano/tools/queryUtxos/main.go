@@ -33,7 +33,7 @@ func main() {
 		log.Warnf("transaction:%+v", res)
 	}
 
-	if utxos, err := b.QueryUtxoByAPI(paramAddress); err != nil {
+	if utxos, err := b.QueryUtxoOnChain(paramAddress); err != nil {
 		log.Fatal("get outputs by address error", "address", paramAddress, "err", err)
 	} else {
 		log.Warnf("utxos:%+v", utxos)
@@ -91,7 +91,7 @@ type RawTransaction struct {
 	TxIndex          uint64               `json:"txIndex"`
 	SwapId           string               `json:"swapId"`
 	KeyDeposit       uint64               `json:"keyDeposit"`
-	CoinsPerUTXOWord uint64               `json:"coinsPerUTXOWord"`
+	CoinsPerUTxOByte uint64               `json:"coinsPerUTXOWord"` // json name is kept for compatibility
 	MinFeeA          uint64               `json:"minFeeA"`
 	MinFeeB          uint64               `json:"minFeeB"`
 	Slot             uint64               `json:"slot"`
@@ -139,3 +139,9 @@ type ProtocolParams struct {
 	MinPoolCost      uint64 `json:"minPoolCost"`
 	MinUTxOValue     uint64 `json:"minUTxOValue"`
 }
+
+type SubmitTransactionResult struct {
+	SubmitTransaction struct {
+		Hash string `json:"hash"`
+	} `json:"submitTransaction"`
+}
@@ -1,12 +1,10 @@
 package cardano
 
 import (
-	"strconv"
 	"strings"
 
 	"github.com/anyswap/CrossChain-Router/v3/common"
 	"github.com/anyswap/CrossChain-Router/v3/log"
-	"github.com/anyswap/CrossChain-Router/v3/params"
 	"github.com/anyswap/CrossChain-Router/v3/router"
 	"github.com/anyswap/CrossChain-Router/v3/tokens"
 )
@@ -37,17 +35,21 @@ func (b *Bridge) VerifyMsgHash(rawTx interface{}, msgHashes []string) (err error
 }
 
 // VerifyTransaction impl
-func (b *Bridge) VerifyTransaction(txHash string, args *tokens.VerifyArgs) (*tokens.SwapTxInfo, error) {
+func (b *Bridge) VerifyTransaction(txHash string, args *tokens.VerifyArgs) (swapInfo *tokens.SwapTxInfo, err error) {
 	swapType := args.SwapType
 	logIndex := args.LogIndex
 	allowUnstable := args.AllowUnstable
 
 	switch swapType {
 	case tokens.ERC20SwapType:
-		return b.verifySwapoutTx(txHash, logIndex, allowUnstable)
+		swapInfo, err = b.verifySwapoutTx(txHash, logIndex, allowUnstable)
 	default:
 		return nil, tokens.ErrSwapTypeNotSupported
 	}
+	if err == nil && !allowUnstable {
+		err = tokens.CheckSwapTxStable(b, swapInfo)
+	}
+	return swapInfo, err
 }
 
 //nolint:gocyclo,funlen // ok
@@ -64,8 +66,7 @@ func (b *Bridge) verifySwapoutTx(txHash string, logIndex int, allowUnstable bool
 	} else {
 		outputIndex := 0
 		assetIndex := 0
-		useAPI, _ := strconv.ParseBool(params.GetCustom(b.ChainConfig.ChainID, "UseAPI"))
-		if useAPI {
+		if b.useOutputIndexAsLogIndex() {
 			output := outputs[swapInfo.LogIndex]
 			if output.Address != b.GetChainConfig().RouterContract {
 				return swapInfo, tokens.ErrTxWithWrongContract
@@ -0,0 +1,151 @@
+package tokens
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/anyswap/CrossChain-Router/v3/log"
+	"github.com/anyswap/CrossChain-Router/v3/params"
+)
+
+// GetBaseConfirmRule get the base confirm rule of chain
+func GetBaseConfirmRule(chainCfg *ChainConfig) *params.ConfirmRule {
+	rule := &params.ConfirmRule{
+		Mode:          params.ConfirmModeConfirmations,
+		Confirmations: chainCfg.Confirmations,
+	}
+	policy := params.GetConfirmPolicy(chainCfg.ChainID)
+	if policy == nil {
+		return rule
+	}
+	return completeConfirmRule(&policy.ConfirmRule, chainCfg)
+}
+
+// GetValueTierConfirmRule get the confirm rule of value tier (nil if not matched)
+func GetValueTierConfirmRule(chainCfg *ChainConfig, tokenID string, value *big.Int, decimals uint8) *params.ConfirmRule {
+	policy := params.GetConfirmPolicy(chainCfg.ChainID)
+	if policy == nil {
+		return nil
+	}
+	tier := policy.GetValueTier(tokenID, value, decimals)
+	if tier == nil {
+		return nil
+	}
+	return completeConfirmRule(&tier.ConfirmRule, chainCfg)
+}
+
+func completeConfirmRule(rule *params.ConfirmRule, chainCfg *ChainConfig) *params.ConfirmRule {
+	result := &params.ConfirmRule{Mode: rule.Mode, Confirmations: rule.Confirmations}
+	if result.Mode == "" {
+		result.Mode = params.ConfirmModeConfirmations
+	}
+	if result.Mode == params.ConfirmModeConfirmations && result.Confirmations == 0 {
+		result.Confirmations = chainCfg.Confirmations
+	}
+	return result
+}
+
+// CheckConfirmModeSupport check the confirm mode is supported by bridge
+func CheckConfirmModeSupport(bridge IBridge, mode string) error {
+	supported := true
+	switch mode {
+	case params.ConfirmModeFinalized, params.ConfirmModeSafe:
+		_, supported = bridge.(BlockTagGetter)
+	case params.ConfirmModeL1Batch:
+		_, supported = bridge.(L1BatchChecker)
+	}
+	if !supported {
+		return fmt.Errorf("%w: %v", ErrConfirmModeNotSupport, mode)
+	}
+	return nil
+}
+
+// CheckConfirmPolicySupport check all the confirm modes in the confirmation policy of chain are supported by bridge
+func CheckConfirmPolicySupport(bridge IBridge, chainID string) error {
+	policy := params.GetConfirmPolicy(chainID)
+	if policy == nil {
+		return nil
+	}
+	if err := CheckConfirmModeSupport(bridge, policy.Mode); err != nil {
+		return err
+	}
+	for tokenID, tiers := range policy.ValueTiers {
+		for _, tier := range tiers {
+			if err := CheckConfirmModeSupport(bridge, tier.Mode); err != nil {
+				return fmt.Errorf("token %v confirm tier: %w", tokenID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// IsConfirmRuleSatisfied is the tx satisfy the confirm rule
+func IsConfirmRuleSatisfied(bridge IBridge, rule *params.ConfirmRule, txStatus *TxStatus) (bool, error) {
+	if txStatus == nil || txStatus.BlockHeight == 0 {
+		return false, nil
+	}
+	if err := CheckConfirmModeSupport(bridge, rule.Mode); err != nil {
+		return false, err
+	}
+	switch rule.Mode {
+	case params.ConfirmModeFinalized, params.ConfirmModeSafe:
+		height, err := bridge.(BlockTagGetter).GetBlockNumberByTag(rule.Mode)
+		if err != nil {
+			return false, err
+		}
+		return txStatus.BlockHeight <= height, nil
+	case params.ConfirmModeL1Batch:
+		return bridge.(L1BatchChecker).IsL1BatchFinalized(txStatus.BlockHeight)
+	default:
+		return txStatus.Confirmations >= rule.Confirmations, nil
+	}
+}
+
+// IsTxStable is the tx stable according to the confirmation policy of chain,
+// `tokenID` and `value` are used to match the value tier (ignored if empty).
+func IsTxStable(bridge IBridge, txStatus *TxStatus, tokenID string, value *big.Int, decimals uint8) (bool, error) {
+	chainCfg := bridge.GetChainConfig()
+	stable, err := IsConfirmRuleSatisfied(bridge, GetBaseConfirmRule(chainCfg), txStatus)
+	if err != nil || !stable {
+		return false, err
+	}
+	if tokenID == "" || value == nil {
+		return true, nil
+	}
+	rule := GetValueTierConfirmRule(chainCfg, tokenID, value, decimals)
+	if rule == nil {
+		return true, nil
+	}
+	return IsConfirmRuleSatisfied(bridge, rule, txStatus)
+}
+
+// CheckSwapTxStable check the verified swap tx satisfies the confirmation policy of chain.
+// it is called by bridges at the end of verifying, and does nothing if no policy is configed
+// (as the confirmations of chain config are already checked by bridges).
+func CheckSwapTxStable(bridge IBridge, swapInfo *SwapTxInfo) error {
+	chainCfg := bridge.GetChainConfig()
+	if params.GetConfirmPolicy(chainCfg.ChainID) == nil {
+		return nil
+	}
+	var tokenID string
+	var decimals uint8
+	if swapInfo.ERC20SwapInfo != nil {
+		if tokenCfg := bridge.GetTokenConfig(swapInfo.ERC20SwapInfo.Token); tokenCfg != nil {
+			tokenID = swapInfo.ERC20SwapInfo.TokenID
+			decimals = tokenCfg.Decimals
+		}
+	}
+	txStatus, err := bridge.GetTransactionStatus(swapInfo.Hash)
+	if err != nil {
+		return err
+	}
+	stable, err := IsTxStable(bridge, txStatus, tokenID, swapInfo.Value, decimals)
+	if err != nil {
+		return err
+	}
+	if !stable {
+		log.Info("swap tx does not satisfy confirm policy", "chainID", chainCfg.ChainID, "txid", swapInfo.Hash, "logIndex", swapInfo.LogIndex, "tokenID", tokenID, "value", swapInfo.Value, "confirmations", txStatus.Confirmations)
+		return ErrTxNotStable
+	}
+	return nil
+}
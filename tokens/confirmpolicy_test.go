@@ -0,0 +1,174 @@
+package tokens
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Router/v3/params"
+)
+
+const testChainID = "1"
+
+type testBridge struct {
+	IBridge // not implemented methods panic
+
+	chainCfg *ChainConfig
+	txStatus *TxStatus
+}
+
+func (b *testBridge) GetChainConfig() *ChainConfig { return b.chainCfg }
+
+func (b *testBridge) GetTokenConfig(string) *TokenConfig { return &TokenConfig{Decimals: 6} }
+
+func (b *testBridge) GetTransactionStatus(string) (*TxStatus, error) { return b.txStatus, nil }
+
+type testTagBridge struct {
+	*testBridge
+	tagHeight uint64
+}
+
+func (b *testTagBridge) GetBlockNumberByTag(string) (uint64, error) { return b.tagHeight, nil }
+
+type testL1Bridge struct {
+	*testBridge
+	finalized bool
+}
+
+func (b *testL1Bridge) IsL1BatchFinalized(uint64) (bool, error) { return b.finalized, nil }
+
+func setTestConfirmPolicy(t *testing.T, policy *params.ConfirmPolicyConfig) {
+	extra := &params.ExtraConfig{
+		LocalChainConfig: map[string]*params.LocalChainConfig{
+			testChainID: {ConfirmPolicy: policy},
+		},
+	}
+	if err := params.SetExtraConfig(extra); err != nil {
+		t.Fatalf("set extra config failed: %v", err)
+	}
+}
+
+func resetTestConfirmPolicy() {
+	_ = params.SetExtraConfig(&params.ExtraConfig{})
+}
+
+func newTestBridge(confirmations uint64) *testBridge {
+	return &testBridge{
+		chainCfg: &ChainConfig{ChainID: testChainID, Confirmations: 10},
+		txStatus: &TxStatus{BlockHeight: 100, Confirmations: confirmations},
+	}
+}
+
+func TestCheckConfirmPolicySupport(t *testing.T) {
+	defer resetTestConfirmPolicy()
+
+	plain := newTestBridge(0)
+	tag := &testTagBridge{testBridge: plain}
+	l1 := &testL1Bridge{testBridge: plain}
+
+	tests := []struct {
+		mode     string
+		tierMode string
+		bridge   IBridge
+		wantErr  bool
+	}{
+		{"", "", plain, false},
+		{params.ConfirmModeConfirmations, params.ConfirmModeConfirmations, plain, false},
+		{params.ConfirmModeFinalized, "", plain, true},
+		{params.ConfirmModeSafe, "", plain, true},
+		{params.ConfirmModeL1Batch, "", plain, true},
+		{"", params.ConfirmModeFinalized, plain, true},
+		{params.ConfirmModeFinalized, params.ConfirmModeSafe, tag, false},
+		{"", params.ConfirmModeL1Batch, tag, true},
+		{params.ConfirmModeL1Batch, "", l1, false},
+		{"", params.ConfirmModeFinalized, l1, true},
+	}
+	for i, tt := range tests {
+		setTestConfirmPolicy(t, &params.ConfirmPolicyConfig{
+			ConfirmRule: params.ConfirmRule{Mode: tt.mode},
+			ValueTiers: map[string][]*params.ConfirmTier{
+				"USDC": {{MinValue: 1000, ConfirmRule: params.ConfirmRule{Mode: tt.tierMode}}},
+			},
+		})
+		err := CheckConfirmPolicySupport(tt.bridge, testChainID)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("test %v: want error %v, but got %v", i, tt.wantErr, err)
+		}
+		if err != nil && !errors.Is(err, ErrConfirmModeNotSupport) {
+			t.Errorf("test %v: want error %v, but got %v", i, ErrConfirmModeNotSupport, err)
+		}
+	}
+
+	resetTestConfirmPolicy()
+	if err := CheckConfirmPolicySupport(plain, testChainID); err != nil {
+		t.Errorf("no confirm policy should pass, but got %v", err)
+	}
+}
+
+func TestIsConfirmRuleSatisfied(t *testing.T) {
+	plain := newTestBridge(10)
+	tests := []struct {
+		rule    *params.ConfirmRule
+		bridge  IBridge
+		want    bool
+		wantErr bool
+	}{
+		{&params.ConfirmRule{Mode: params.ConfirmModeConfirmations, Confirmations: 10}, plain, true, false},
+		{&params.ConfirmRule{Mode: params.ConfirmModeConfirmations, Confirmations: 11}, plain, false, false},
+		{&params.ConfirmRule{Mode: params.ConfirmModeFinalized}, plain, false, true},
+		{&params.ConfirmRule{Mode: params.ConfirmModeFinalized}, &testTagBridge{testBridge: plain, tagHeight: 100}, true, false},
+		{&params.ConfirmRule{Mode: params.ConfirmModeSafe}, &testTagBridge{testBridge: plain, tagHeight: 99}, false, false},
+		{&params.ConfirmRule{Mode: params.ConfirmModeL1Batch}, &testL1Bridge{testBridge: plain, finalized: true}, true, false},
+		{&params.ConfirmRule{Mode: params.ConfirmModeL1Batch}, &testL1Bridge{testBridge: plain}, false, false},
+	}
+	for i, tt := range tests {
+		got, err := IsConfirmRuleSatisfied(tt.bridge, tt.rule, plain.txStatus)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("test %v: want (%v, error %v), but got (%v, %v)", i, tt.want, tt.wantErr, got, err)
+		}
+	}
+
+	if got, _ := IsConfirmRuleSatisfied(plain, &params.ConfirmRule{}, &TxStatus{}); got {
+		t.Errorf("tx not in block should not be stable")
+	}
+}
+
+func TestCheckSwapTxStable(t *testing.T) {
+	defer resetTestConfirmPolicy()
+
+	swapInfo := &SwapTxInfo{
+		SwapInfo: SwapInfo{ERC20SwapInfo: &ERC20SwapInfo{Token: "usdc", TokenID: "USDC"}},
+		Hash:     "0x1234",
+		Value:    big.NewInt(1000e6), // 1000 USDC
+	}
+
+	// no policy, the chain config confirmations are checked by bridges
+	if err := CheckSwapTxStable(newTestBridge(0), swapInfo); err != nil {
+		t.Errorf("no confirm policy should pass, but got %v", err)
+	}
+
+	setTestConfirmPolicy(t, &params.ConfirmPolicyConfig{
+		ConfirmRule: params.ConfirmRule{Confirmations: 20},
+		ValueTiers: map[string][]*params.ConfirmTier{
+			"USDC": {{MinValue: 1000, ConfirmRule: params.ConfirmRule{Confirmations: 50}}},
+		},
+	})
+
+	tests := []struct {
+		confirmations uint64
+		value         int64
+		wantErr       error
+	}{
+		{19, 1e6, ErrTxNotStable},
+		{20, 1e6, nil},
+		{20, 1000e6, ErrTxNotStable},
+		{50, 1000e6, nil},
+	}
+	for i, tt := range tests {
+		swapInfo.Value = big.NewInt(tt.value)
+		err := CheckSwapTxStable(newTestBridge(tt.confirmations), swapInfo)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("test %v: want error %v, but got %v", i, tt.wantErr, err)
+		}
+	}
+}
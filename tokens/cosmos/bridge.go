@@ -3,7 +3,6 @@ package cosmos
 import (
 	"fmt"
 	"strconv"
-	"strings"
 
 	"github.com/anyswap/CrossChain-Router/v3/log"
 	"github.com/anyswap/CrossChain-Router/v3/router"
@@ -29,8 +28,9 @@ type Bridge struct {
 
 	grpc.ClientContext
 
-	Prefix string
-	Denom  string
+	Prefix  string
+	Denom   string
+	Profile *ChainProfile
 }
 
 // NewCrossChainBridge new bridge
@@ -43,14 +43,13 @@ func NewCrossChainBridge() *Bridge {
 	}
 }
 
-func (b *Bridge) SetPrefixAndDenom(prefix, denom string) {
-	b.Prefix = prefix
-	b.Denom = denom
-	log.Info("SetPrefixAndDenom finished", "prefix", prefix, "denom", denom)
-
-	config := sdk.GetConfig()
-	config.SetBech32PrefixForAccount(b.Prefix, "")
-	config.Seal()
+func (b *Bridge) SetPrefixAndDenom(prefix, denom string) error {
+	profile := &ChainProfile{Prefix: prefix, FeeDenoms: []string{denom}}
+	if err := profile.check(); err != nil {
+		return err
+	}
+	b.SetChainProfile(profile)
+	return nil
 }
 
 // InitAfterConfig init variables (ie. extra members) after loading config
@@ -73,16 +72,13 @@ func (b *Bridge) InitRouterInfo(routerContract, routerVersion string) (err error
 	chainID := b.ChainConfig.ChainID
 	log.Info(fmt.Sprintf("[%5v] start init router info", chainID), "routerContract", routerContract)
 
-	extra := strings.Split(b.ChainConfig.Extra, ":")
-	if len(extra) != 2 {
-		return fmt.Errorf("chainConfig extra error")
-	} else if b.Prefix == "" {
-		b.SetPrefixAndDenom(extra[0], extra[1])
-		err := sdk.ValidateDenom(b.Denom)
-		if err != nil {
-			log.Warn("wrong denom: %v %w", b.Denom, err)
-			return err
+	if b.Profile == nil {
+		profile, errf := ParseChainProfile(b.ChainConfig.Extra)
+		if errf != nil {
+			log.Warn("parse chain profile failed", "chainID", chainID, "extra", b.ChainConfig.Extra, "err", errf)
+			return errf
 		}
+		b.SetChainProfile(profile)
 	}
 
 	routerMPC := routerContract
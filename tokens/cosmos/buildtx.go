@@ -3,6 +3,7 @@ package cosmos
 import (
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"regexp"
 	"strconv"
@@ -13,6 +14,7 @@ import (
 	"github.com/anyswap/CrossChain-Router/v3/params"
 	"github.com/anyswap/CrossChain-Router/v3/router"
 	"github.com/anyswap/CrossChain-Router/v3/tokens"
+	cryptoTypes "github.com/cosmos/cosmos-sdk/crypto/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -113,39 +115,104 @@ func (b *Bridge) initExtra(args *tokens.BuildTxArgs) (extra *tokens.AllExtras, e
 			return nil, err
 		}
 	}
+	return extra, nil
+}
+
+// initGasAndFee init gas limit and fee if not specified in extra args.
+// gas limit is simulated if gas adjustment is configed in chain profile,
+// fee is calculated by gas price in the first affordable fee denom, and is increased when replacing.
+func (b *Bridge) initGasAndFee(args *tokens.BuildTxArgs, msgs []sdk.Msg, memo string, pubKey cryptoTypes.PubKey, spent sdk.Coins) error {
+	extra := args.Extra
 	if extra.Gas == nil {
-		extra.Gas = &DefaultGasLimit
+		gas := DefaultGasLimit
+		if gasAdjustment := b.Profile.GasAdjustment; gasAdjustment > 0 {
+			gasUsed, err := b.simulateGas(msgs, memo, pubKey, *extra.Sequence)
+			if err != nil {
+				log.Warn("simulate tx failed", "swapID", args.SwapID, "err", err)
+				return err
+			}
+			gas = uint64(math.Ceil(float64(gasUsed) * gasAdjustment))
+			log.Info("simulate tx success", "swapID", args.SwapID, "gasUsed", gasUsed, "gasAdjustment", gasAdjustment, "gas", gas)
+		}
+		extra.Gas = &gas
 	}
 	if extra.Fee == nil {
-		fee := b.getDefaultFee()
+		fee, err := b.calcFee(args.From, *extra.Gas, spent)
+		if err != nil {
+			return err
+		}
 		replaceNum := args.GetReplaceNum()
 		if replaceNum > 0 {
 			serverCfg := params.GetRouterServerConfig()
 			if serverCfg == nil {
-				return nil, fmt.Errorf("no router server config")
+				return fmt.Errorf("no router server config")
 			}
 			coinsFee, err := ParseCoinsFee(fee)
 			if err != nil {
-				return nil, err
+				return err
 			}
-			coinFee := coinsFee[0].Amount.BigInt()
 			addPercent := serverCfg.PlusGasPricePercentage
 			addPercent += replaceNum * serverCfg.ReplacePlusGasPricePercent
 			if addPercent > serverCfg.MaxPlusGasPricePercentage {
 				addPercent = serverCfg.MaxPlusGasPricePercentage
 			}
 			if addPercent > 0 {
-				coinFee.Mul(coinFee, big.NewInt(int64(100+addPercent)))
-				coinFee.Div(coinFee, big.NewInt(100))
+				for i, coin := range coinsFee {
+					coinFee := coin.Amount.BigInt()
+					coinFee.Mul(coinFee, big.NewInt(int64(100+addPercent)))
+					coinFee.Div(coinFee, big.NewInt(100))
+					coinsFee[i].Amount = sdk.NewIntFromBigInt(coinFee)
+				}
 			}
-			coinsFee[0].Amount = sdk.NewIntFromBigInt(coinFee)
-			adjustFee := coinsFee.String()
-			extra.Fee = &adjustFee
-		} else {
-			extra.Fee = &fee
+			fee = coinsFee.String()
 		}
+		extra.Fee = &fee
 	}
-	return extra, nil
+	return nil
+}
+
+// calcFee calc fee by gas price of fee denoms in order, choose the first one whose balance
+// is enough for fee and spent value (not checked if paid by fee granter).
+// use the default fee if no gas price is configed.
+func (b *Bridge) calcFee(payer string, gas uint64, spent sdk.Coins) (string, error) {
+	profile := b.Profile
+	hasGasPrice := false
+	for _, denom := range profile.FeeDenoms {
+		gasPrice, exist := profile.GetGasPrice(denom)
+		if !exist {
+			continue
+		}
+		hasGasPrice = true
+		amount := gasPrice.MulInt64(int64(gas)).Ceil().TruncateInt()
+		fee := sdk.NewCoin(denom, amount)
+		if profile.FeeGranter != "" {
+			return fee.String(), nil
+		}
+		balance, err := b.GetDenomBalance(payer, denom)
+		if err != nil {
+			return "", err
+		}
+		if balance.GTE(amount.Add(spent.AmountOf(denom))) {
+			return fee.String(), nil
+		}
+		log.Info("balance not enough for fee", "payer", payer, "denom", denom, "balance", balance, "fee", amount, "spent", spent.AmountOf(denom))
+	}
+	if hasGasPrice {
+		return "", tokens.ErrBalanceNotEnough
+	}
+	return b.getDefaultFee(), nil
+}
+
+func (b *Bridge) simulateGas(msgs []sdk.Msg, memo string, pubKey cryptoTypes.PubKey, sequence uint64) (uint64, error) {
+	txBuilder, err := b.newTxBuilder(msgs, memo, pubKey, sdk.Coins{}, DefaultGasLimit, sequence)
+	if err != nil {
+		return 0, err
+	}
+	txBytes, err := b.TxConfig.TxEncoder()(txBuilder.GetTx())
+	if err != nil {
+		return 0, err
+	}
+	return b.SimulateTxGas(txBytes)
 }
 
 func (b *Bridge) getDefaultFee() string {
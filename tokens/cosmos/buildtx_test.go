@@ -0,0 +1,83 @@
+package cosmos
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+)
+
+func newFeeTestBridge(t *testing.T, extra string, balances map[string]string) *Bridge {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		address := strings.TrimPrefix(r.URL.Path, Balances)
+		var coins []string
+		for denom, amount := range balances {
+			coins = append(coins, fmt.Sprintf(`{"denom":"%v","amount":"%v"}`, denom, amount))
+		}
+		if address == testAddress(t, "osmo", 2) {
+			coins = nil
+		}
+		fmt.Fprintf(w, `{"balances":[%v]}`, strings.Join(coins, ","))
+	}))
+	t.Cleanup(server.Close)
+
+	profile, err := ParseChainProfile(extra)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := NewCrossChainBridge()
+	b.SetChainConfig(&tokens.ChainConfig{ChainID: "1"})
+	b.SetGatewayConfig(&tokens.GatewayConfig{APIAddress: []string{server.URL}})
+	b.SetChainProfile(profile)
+	return b
+}
+
+func TestCalcFee(t *testing.T) {
+	payer := testAddress(t, "osmo", 1)
+	poorPayer := testAddress(t, "osmo", 2)
+	granter := testAddress(t, "osmo", 3)
+	balances := map[string]string{"uosmo": "1000", "uion": "100000"}
+	profile := `{"prefix":"osmo","feeDenoms":["uosmo","uion"],"gasPrices":{"uosmo":"0.01","uion":"0.0125"}%v}`
+
+	tests := []struct {
+		extra   string
+		payer   string
+		gas     uint64
+		spent   string
+		want    string
+		wantErr error
+	}{
+		// the first fee denom is affordable
+		{fmt.Sprintf(profile, ""), payer, 100000, "", "1000uosmo", nil},
+		// fall back to the next fee denom if balance is not enough for fee
+		{fmt.Sprintf(profile, ""), payer, 100001, "", "1251uion", nil},
+		// or is not enough for fee and spent value
+		{fmt.Sprintf(profile, ""), payer, 100, "1000uosmo", "2uion", nil},
+		{fmt.Sprintf(profile, ""), poorPayer, 100, "", "", tokens.ErrBalanceNotEnough},
+		// balance is not checked if fee is paid by fee granter
+		{fmt.Sprintf(profile, `,"feeGranter":"`+granter+`"`), poorPayer, 100001, "", "1001uosmo", nil},
+		// use the default fee if no gas price is configed
+		{`{"prefix":"osmo","feeDenoms":["uosmo","uion"]}`, poorPayer, 100000, "", DefaultFee + "uosmo", nil},
+		// gas price of the default denom is not configed
+		{`{"prefix":"osmo","feeDenoms":["uosmo","uion"],"gasPrices":{"uion":"0.01"}}`, payer, 100, "", "1uion", nil},
+	}
+	for i, test := range tests {
+		b := newFeeTestBridge(t, test.extra, balances)
+		spent, err := ParseCoinsFee(test.spent)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fee, err := b.calcFee(test.payer, test.gas, spent)
+		if !errors.Is(err, test.wantErr) {
+			t.Errorf("test %v: want error %v, but got %v", i, test.wantErr, err)
+			continue
+		}
+		if fee != test.want {
+			t.Errorf("test %v: want fee %v, but got %v", i, test.want, fee)
+		}
+	}
+}
@@ -133,7 +133,7 @@ func (b *Bridge) GRPCGetBaseAccount(address string) (res *QueryAccountResponse,
 		if err == nil {
 			return &QueryAccountResponse{
 				Account: &BaseAccount{
-					Address:       ret.GetAddress().String(),
+					Address:       address,
 					AccountNumber: fmt.Sprintf("%v", ret.GetAccountNumber()),
 					Sequence:      fmt.Sprintf("%v", ret.GetSequence()),
 				},
@@ -161,9 +161,13 @@ func (b *Bridge) GRPCGetDenomBalance(address, denom string) (res sdk.Int, err er
 }
 
 func (b *Bridge) GRPCSimulateTx(simulateReq *SimulateRequest) (res *sdktx.SimulateResponse, err error) {
+	txBytes, err := base64.StdEncoding.DecodeString(simulateReq.TxBytes)
+	if err != nil {
+		return nil, wrapRPCQueryError(err, "GRPCSimulateTx")
+	}
 	for _, rpcClient := range rpcClients {
 		clientCtx := b.ClientContext.WithClient(rpcClient)
-		res, err = grpc.SimulateTx(ctx, clientCtx, []byte(simulateReq.TxBytes))
+		res, err = grpc.SimulateTx(ctx, clientCtx, txBytes)
 		if err == nil {
 			return res, nil
 		}
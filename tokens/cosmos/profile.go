@@ -0,0 +1,106 @@
+package cosmos
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Router/v3/log"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ChainProfile chain profile of cosmos sdk chain, loaded from chain config extra
+type ChainProfile struct {
+	// bech32 prefix of account address
+	Prefix string `json:"prefix"`
+	// denoms which can be used to pay fee, the first one is the default
+	FeeDenoms []string `json:"feeDenoms"`
+	// gas price (decimal) of fee denoms, calc fee by `gas * gasPrice` if configed,
+	// otherwise use the default fee of router server config
+	GasPrices map[string]string `json:"gasPrices,omitempty"`
+	// simulate tx to get gas limit (`gasUsed * gasAdjustment`) if greater than 0,
+	// otherwise use the default gas limit
+	GasAdjustment float64 `json:"gasAdjustment,omitempty"`
+	// pay fee by the fee allowance granted by `feeGranter` (x/feegrant)
+	FeeGranter string `json:"feeGranter,omitempty"`
+	// send coins of `authzGranter` by `MsgExec` (x/authz), mpc is the grantee
+	AuthzGranter string `json:"authzGranter,omitempty"`
+
+	gasPrices map[string]sdk.Dec
+}
+
+// ParseChainProfile parse chain profile from chain config extra.
+// the extra is either json of `ChainProfile`, or of the legacy format `prefix:denom`
+func ParseChainProfile(extra string) (*ChainProfile, error) {
+	profile := &ChainProfile{}
+	extra = strings.TrimSpace(extra)
+	if strings.HasPrefix(extra, "{") {
+		if err := json.Unmarshal([]byte(extra), profile); err != nil {
+			return nil, fmt.Errorf("chainConfig extra error: %w", err)
+		}
+	} else {
+		parts := strings.Split(extra, ":")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("chainConfig extra error")
+		}
+		profile.Prefix = parts[0]
+		profile.FeeDenoms = []string{parts[1]}
+	}
+	if err := profile.check(); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+func (p *ChainProfile) check() error {
+	if p.Prefix == "" {
+		return fmt.Errorf("chain profile: empty prefix")
+	}
+	if len(p.FeeDenoms) == 0 {
+		return fmt.Errorf("chain profile: empty fee denoms")
+	}
+	for _, denom := range p.FeeDenoms {
+		if err := sdk.ValidateDenom(denom); err != nil {
+			return fmt.Errorf("chain profile: wrong fee denom %v: %w", denom, err)
+		}
+	}
+	p.gasPrices = make(map[string]sdk.Dec, len(p.GasPrices))
+	for denom, price := range p.GasPrices {
+		gasPrice, err := sdk.NewDecFromStr(price)
+		if err != nil || !gasPrice.IsPositive() {
+			return fmt.Errorf("chain profile: wrong gas price %v of %v", price, denom)
+		}
+		p.gasPrices[denom] = gasPrice
+	}
+	if p.GasAdjustment < 0 {
+		return fmt.Errorf("chain profile: negative gas adjustment %v", p.GasAdjustment)
+	}
+	for _, granter := range []string{p.FeeGranter, p.AuthzGranter} {
+		if granter != "" && !IsValidAddress(p.Prefix, granter) {
+			return fmt.Errorf("chain profile: wrong granter address %v", granter)
+		}
+	}
+	return nil
+}
+
+// DefaultFeeDenom default fee denom
+func (p *ChainProfile) DefaultFeeDenom() string {
+	return p.FeeDenoms[0]
+}
+
+// GetGasPrice get gas price of fee denom
+func (p *ChainProfile) GetGasPrice(denom string) (sdk.Dec, bool) {
+	gasPrice, exist := p.gasPrices[denom]
+	return gasPrice, exist
+}
+
+// SetChainProfile set chain profile.
+// addresses are encoded and checked with the bech32 prefix of each bridge,
+// the global config of cosmos sdk is not used, so cosmos chains of different prefixes can run in one process.
+func (b *Bridge) SetChainProfile(profile *ChainProfile) {
+	b.Profile = profile
+	b.Prefix = profile.Prefix
+	b.Denom = profile.DefaultFeeDenom()
+	log.Info("SetChainProfile finished", "prefix", profile.Prefix, "feeDenoms", profile.FeeDenoms, "gasPrices", profile.GasPrices,
+		"gasAdjustment", profile.GasAdjustment, "feeGranter", profile.FeeGranter, "authzGranter", profile.AuthzGranter)
+}
@@ -0,0 +1,71 @@
+package cosmos
+
+import (
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+)
+
+func testAddress(t *testing.T, prefix string, b byte) string {
+	addr := make([]byte, 20)
+	addr[19] = b
+	address, err := bech32.ConvertAndEncode(prefix, addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return address
+}
+
+func TestParseChainProfile(t *testing.T) {
+	osmoGranter := testAddress(t, "osmo", 1)
+	seiGranter := testAddress(t, "sei", 1)
+
+	tests := []struct {
+		extra     string
+		prefix    string
+		feeDenoms []string
+		wantErr   bool
+	}{
+		// legacy format `prefix:denom`
+		{"sei:usei", "sei", []string{"usei"}, false},
+		{" osmo:uosmo ", "osmo", []string{"uosmo"}, false},
+		{"sei", "", nil, true},
+		{"sei:usei:x", "", nil, true},
+		{":usei", "", nil, true},
+		{"sei:1usei", "", nil, true},
+		// json format
+		{`{"prefix":"osmo","feeDenoms":["uosmo","uion"],"gasPrices":{"uosmo":"0.025"},"gasAdjustment":1.3,"feeGranter":"` + osmoGranter + `","authzGranter":"` + osmoGranter + `"}`,
+			"osmo", []string{"uosmo", "uion"}, false},
+		{`{"prefix":"osmo","feeDenoms":["uosmo"]}`, "osmo", []string{"uosmo"}, false},
+		{`{"prefix":"osmo"}`, "", nil, true},
+		{`{"feeDenoms":["uosmo"]}`, "", nil, true},
+		{`{"prefix":"osmo","feeDenoms":["uosmo"]`, "", nil, true},
+		{`{"prefix":"osmo","feeDenoms":["uosmo"],"gasPrices":{"uosmo":"0"}}`, "", nil, true},
+		{`{"prefix":"osmo","feeDenoms":["uosmo"],"gasPrices":{"uosmo":"abc"}}`, "", nil, true},
+		{`{"prefix":"osmo","feeDenoms":["uosmo"],"gasAdjustment":-1}`, "", nil, true},
+		// granter of other prefix
+		{`{"prefix":"osmo","feeDenoms":["uosmo"],"feeGranter":"` + seiGranter + `"}`, "", nil, true},
+		{`{"prefix":"osmo","feeDenoms":["uosmo"],"authzGranter":"` + seiGranter + `"}`, "", nil, true},
+	}
+	for i, test := range tests {
+		profile, err := ParseChainProfile(test.extra)
+		if (err != nil) != test.wantErr {
+			t.Errorf("test %v: want error %v, but got %v", i, test.wantErr, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if profile.Prefix != test.prefix {
+			t.Errorf("test %v: want prefix %v, but got %v", i, test.prefix, profile.Prefix)
+		}
+		if len(profile.FeeDenoms) != len(test.feeDenoms) || profile.DefaultFeeDenom() != test.feeDenoms[0] {
+			t.Errorf("test %v: want fee denoms %v, but got %v", i, test.feeDenoms, profile.FeeDenoms)
+		}
+		for denom, price := range profile.GasPrices {
+			if gasPrice, exist := profile.GetGasPrice(denom); !exist || gasPrice.String() == "" {
+				t.Errorf("test %v: gas price %v of %v is not parsed", i, price, denom)
+			}
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package cosmos
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"strconv"
@@ -164,6 +165,36 @@ func (b *Bridge) SimulateTx(simulateReq *SimulateRequest) (string, error) {
 	}
 }
 
+// SimulateTxGas simulate tx and return the gas used
+func (b *Bridge) SimulateTxGas(txBytes []byte) (uint64, error) {
+	simulateReq := &SimulateRequest{TxBytes: base64.StdEncoding.EncodeToString(txBytes)}
+	if result, err := b.GRPCSimulateTx(simulateReq); err == nil {
+		return result.GasInfo.GasUsed, nil
+	} else if len(b.GatewayConfig.AllGatewayURLs) == 0 {
+		return 0, err
+	}
+	data, err := json.Marshal(simulateReq)
+	if err != nil {
+		return 0, err
+	}
+	var result *SimulateResponse
+	for _, url := range b.GatewayConfig.AllGatewayURLs {
+		restApi := joinURLPath(url, SimulateTx)
+		var res string
+		if res, err = client.RPCRawPostWithTimeout(restApi, string(data), 120); err != nil {
+			continue
+		}
+		if err = json.Unmarshal([]byte(res), &result); err == nil && result.GasInfo != nil {
+			return strconv.ParseUint(result.GasInfo.GasUsed, 10, 64)
+		}
+		log.Warn("SimulateTxGas failed", "url", restApi, "res", res, "err", err)
+	}
+	if err == nil {
+		err = tokens.ErrSimulateTx
+	}
+	return 0, wrapRPCQueryError(err, "SimulateTxGas")
+}
+
 func (b *Bridge) BroadcastTx(req *BroadcastTxRequest) (string, error) {
 	if result, err := b.GRPCBroadcastTx(req); err == nil {
 		data, _ := json.Marshal(BroadcastTxResponse{
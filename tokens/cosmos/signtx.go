@@ -119,7 +119,7 @@ func (b *Bridge) SignTransactionWithPrivateKey(buildRawTx *BuildRawTx, privKey s
 				if err := txBuilder.SetSignatures(sig); err != nil {
 					return nil, "", err
 				}
-				if err := txBuilder.GetTx().ValidateBasic(); err != nil {
+				if err := b.ValidateTx(txBuilder.GetTx()); err != nil {
 					return nil, "", err
 				}
 
@@ -10,13 +10,21 @@ import (
 	"github.com/anyswap/CrossChain-Router/v3/params"
 	"github.com/anyswap/CrossChain-Router/v3/tokens"
 	cosmosClient "github.com/cosmos/cosmos-sdk/client"
+	codecTypes "github.com/cosmos/cosmos-sdk/codec/types"
 	cryptoTypes "github.com/cosmos/cosmos-sdk/crypto/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdktx "github.com/cosmos/cosmos-sdk/types/tx"
 	signingTypes "github.com/cosmos/cosmos-sdk/types/tx/signing"
 	"github.com/cosmos/cosmos-sdk/x/auth/signing"
+	"github.com/cosmos/cosmos-sdk/x/authz"
 	bankTypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 )
 
+// protoTxProvider is implemented by the tx builder of `authTx.NewTxConfig`
+type protoTxProvider interface {
+	GetProtoTx() *sdktx.Tx
+}
+
 func (b *Bridge) NewSignModeHandler() signing.SignModeHandler {
 	return b.TxConfig.SignModeHandler()
 }
@@ -57,59 +65,198 @@ func (b *Bridge) BuildTx(
 ) (cosmosClient.TxBuilder, error) {
 	from := args.From
 	extra := args.Extra
-	log.Info("start to build tx", "swapID", args.SwapID, "from", from, "to", to, "denom", denom, "memo", memo, "amount", amount, "fee", *extra.Fee, "gas", *extra.Gas, "sequence", *extra.Sequence)
-	if balance, err := b.GetDenomBalance(from, denom); err != nil {
+	log.Info("start to build tx", "swapID", args.SwapID, "from", from, "to", to, "denom", denom, "memo", memo, "amount", amount, "sequence", *extra.Sequence)
+
+	profile := b.Profile
+	sender := from
+	if profile.AuthzGranter != "" {
+		sender = profile.AuthzGranter
+	}
+	msgs, spent, err := b.buildSwapMsgs(args, sender, to, denom, amount)
+	if err != nil {
 		return nil, err
-	} else {
-		var msgs []sdk.Msg
-		if balance.BigInt().Cmp(amount) >= 0 {
-			sendMsg := BuildSendMsg(from, to, denom, amount)
-			msgs = append(msgs, sendMsg)
-		} else {
-			log.Info("balance not enough", "denom", denom, "balance", balance, "amount", amount)
-			return nil, tokens.ErrBalanceNotEnough
+	}
+	if profile.AuthzGranter != "" {
+		if msgs, err = BuildExecMsgs(from, msgs); err != nil {
+			return nil, err
 		}
+		spent = nil // spent by granter
+	}
+
+	pubKey, err := PubKeyFromStr(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	if err = b.initGasAndFee(args, msgs, memo, pubKey, spent); err != nil {
+		return nil, err
+	}
+	fee, err := ParseCoinsFee(*extra.Fee)
+	if err != nil {
+		return nil, err
+	}
+	return b.newTxBuilder(msgs, memo, pubKey, fee, *extra.Gas, *extra.Sequence)
+}
 
-		// process charge fee on dest chain
-		tokenID := args.GetTokenID()
-		fromChainID := args.FromChainID
-		toChainID := args.ToChainID
-		if params.ChargeFeeOnDestChain(tokenID, fromChainID.String(), toChainID.String()) {
-			if extra.BridgeFee != nil && extra.BridgeFee.Sign() > 0 {
-				bridgeFeeReceiver := params.FeeReceiverOnDestChain(toChainID.String())
-				if bridgeFeeReceiver != "" {
-					sendMsg := BuildSendMsg(from, bridgeFeeReceiver, denom, extra.BridgeFee)
-					msgs = append(msgs, sendMsg)
-					log.Info("build charge fee on dest chain", "swapID", args.SwapID, "from", from, "receiver", bridgeFeeReceiver, "denom", denom, "amount", extra.BridgeFee)
-				}
+// buildSwapMsgs build send msgs of swap value (and bridge fee if charge fee on dest chain)
+func (b *Bridge) buildSwapMsgs(
+	args *tokens.BuildTxArgs,
+	sender, to, denom string,
+	amount *big.Int,
+) (msgs []sdk.Msg, spent sdk.Coins, err error) {
+	extra := args.Extra
+	balance, err := b.GetDenomBalance(sender, denom)
+	if err != nil {
+		return nil, nil, err
+	}
+	if balance.BigInt().Cmp(amount) < 0 {
+		log.Info("balance not enough", "sender", sender, "denom", denom, "balance", balance, "amount", amount)
+		return nil, nil, tokens.ErrBalanceNotEnough
+	}
+	msgs = append(msgs, BuildSendMsg(sender, to, denom, amount))
+	spent = spent.Add(sdk.NewCoin(denom, sdk.NewIntFromBigInt(amount)))
+
+	// process charge fee on dest chain
+	tokenID := args.GetTokenID()
+	fromChainID := args.FromChainID
+	toChainID := args.ToChainID
+	if params.ChargeFeeOnDestChain(tokenID, fromChainID.String(), toChainID.String()) {
+		if extra.BridgeFee != nil && extra.BridgeFee.Sign() > 0 {
+			bridgeFeeReceiver := params.FeeReceiverOnDestChain(toChainID.String())
+			if bridgeFeeReceiver != "" {
+				msgs = append(msgs, BuildSendMsg(sender, bridgeFeeReceiver, denom, extra.BridgeFee))
+				spent = spent.Add(sdk.NewCoin(denom, sdk.NewIntFromBigInt(extra.BridgeFee)))
+				log.Info("build charge fee on dest chain", "swapID", args.SwapID, "from", sender, "receiver", bridgeFeeReceiver, "denom", denom, "amount", extra.BridgeFee)
 			}
 		}
+	}
+	return msgs, spent, nil
+}
 
-		txBuilder := b.TxConfig.NewTxBuilder()
-		if err := txBuilder.SetMsgs(msgs...); err != nil {
+// BuildExecMsgs wrap msgs into authz `MsgExec` executed by grantee
+// (not by `authz.NewMsgExec` which encodes grantee with the global bech32 prefix)
+func BuildExecMsgs(grantee string, msgs []sdk.Msg) ([]sdk.Msg, error) {
+	msgsAny := make([]*codecTypes.Any, len(msgs))
+	for i, msg := range msgs {
+		msgAny, err := codecTypes.NewAnyWithValue(msg)
+		if err != nil {
 			return nil, err
 		}
-		txBuilder.SetMemo(memo)
-		if fee, err := ParseCoinsFee(*extra.Fee); err != nil {
-			return nil, err
-		} else {
-			txBuilder.SetFeeAmount(fee)
+		msgsAny[i] = msgAny
+	}
+	execMsg := &authz.MsgExec{
+		Grantee: grantee,
+		Msgs:    msgsAny,
+	}
+	return []sdk.Msg{execMsg}, nil
+}
+
+func (b *Bridge) newTxBuilder(
+	msgs []sdk.Msg,
+	memo string,
+	pubKey cryptoTypes.PubKey,
+	fee sdk.Coins,
+	gas, sequence uint64,
+) (cosmosClient.TxBuilder, error) {
+	txBuilder := b.TxConfig.NewTxBuilder()
+	if err := txBuilder.SetMsgs(msgs...); err != nil {
+		return nil, err
+	}
+	txBuilder.SetMemo(memo)
+	txBuilder.SetFeeAmount(fee)
+	txBuilder.SetGasLimit(gas)
+	if b.Profile.FeeGranter != "" {
+		// `SetFeeGranter` encodes granter with the global bech32 prefix, set it to proto tx instead.
+		// it must be set before `SetSignatures` which resets the cached auth info bytes
+		protoTx, ok := txBuilder.(protoTxProvider)
+		if !ok {
+			return nil, fmt.Errorf("unsupported tx builder %T", txBuilder)
+		}
+		protoTx.GetProtoTx().AuthInfo.Fee.Granter = b.Profile.FeeGranter
+	}
+	sig := BuildSignatures(pubKey, sequence, nil)
+	if err := txBuilder.SetSignatures(sig); err != nil {
+		return nil, err
+	}
+	if err := b.ValidateTx(txBuilder.GetTx()); err != nil {
+		return nil, err
+	}
+	return txBuilder, nil
+}
+
+// ValidateTx validate tx like `ValidateBasic` of cosmos sdk,
+// but check addresses with the bech32 prefix of this chain instead of the global one
+func (b *Bridge) ValidateTx(tx signing.Tx) error {
+	if tx.GetGas() > sdktx.MaxGasWanted {
+		return fmt.Errorf("invalid gas limit %v", tx.GetGas())
+	}
+	if fee := tx.GetFee(); fee.IsAnyNil() || fee.IsAnyNegative() {
+		return fmt.Errorf("invalid fee %v", fee)
+	}
+	if feeGranter := b.getFeeGranter(tx); feeGranter != "" && !b.IsValidAddress(feeGranter) {
+		return fmt.Errorf("invalid fee granter %v", feeGranter)
+	}
+	msgs := tx.GetMsgs()
+	if len(msgs) == 0 {
+		return fmt.Errorf("tx has no msgs")
+	}
+	for _, msg := range msgs {
+		if err := b.validateMsg(msg, true); err != nil {
+			return err
 		}
-		txBuilder.SetGasLimit(*extra.Gas)
-		pubKey, err := PubKeyFromStr(publicKey)
+	}
+	// all msgs are signed by mpc
+	sigs, err := tx.GetSignaturesV2()
+	if err != nil {
+		return err
+	}
+	if len(sigs) != 1 {
+		return fmt.Errorf("wrong number of signatures, have %v want 1", len(sigs))
+	}
+	return nil
+}
+
+func (b *Bridge) getFeeGranter(tx signing.Tx) string {
+	if protoTx, ok := tx.(protoTxProvider); ok && protoTx.GetProtoTx().AuthInfo.Fee != nil {
+		return protoTx.GetProtoTx().AuthInfo.Fee.Granter
+	}
+	return ""
+}
+
+func (b *Bridge) validateMsg(msg sdk.Msg, allowExec bool) error {
+	switch msg := msg.(type) {
+	case *bankTypes.MsgSend:
+		if !b.IsValidAddress(msg.FromAddress) {
+			return fmt.Errorf("invalid send from address %v", msg.FromAddress)
+		}
+		if !b.IsValidAddress(msg.ToAddress) {
+			return fmt.Errorf("invalid send to address %v", msg.ToAddress)
+		}
+		if !msg.Amount.IsValid() || msg.Amount.IsZero() {
+			return fmt.Errorf("invalid send amount %v", msg.Amount)
+		}
+	case *authz.MsgExec:
+		if !allowExec {
+			return fmt.Errorf("nested exec msg is not supported")
+		}
+		if !b.IsValidAddress(msg.Grantee) {
+			return fmt.Errorf("invalid exec grantee %v", msg.Grantee)
+		}
+		execMsgs, err := msg.GetMessages()
 		if err != nil {
-			return nil, err
+			return err
 		}
-		sig := BuildSignatures(pubKey, *extra.Sequence, nil)
-		if err := txBuilder.SetSignatures(sig); err != nil {
-			return nil, err
+		if len(execMsgs) == 0 {
+			return fmt.Errorf("exec msg has no msgs")
 		}
-		if err := txBuilder.GetTx().ValidateBasic(); err != nil {
-			return nil, err
+		for _, execMsg := range execMsgs {
+			if err := b.validateMsg(execMsg, false); err != nil {
+				return err
+			}
 		}
-
-		return txBuilder, nil
+	default:
+		return fmt.Errorf("unsupported msg %T", msg)
 	}
+	return nil
 }
 
 func (b *Bridge) GetSignBytes(tx *BuildRawTx) ([]byte, error) {
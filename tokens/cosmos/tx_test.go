@@ -0,0 +1,122 @@
+package cosmos
+
+import (
+	"math/big"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	bankTypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+)
+
+const testPublicKey = "0x0468438a94627b0de2b6a7c9af99136ef7e607f7944b749c3534bb27a89e742d583b1c8b3aecfae45dea2ac58730aa6ba654c73c435d44755e5cd1500c8f4d036b"
+
+// bridges of different bech32 prefixes build and validate txs in one process
+func TestBuildExecMsgsAndFeeGranter(t *testing.T) {
+	pubKey, err := PubKeyFromStr(testPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, prefix := range []string{"osmo", "sei"} {
+		mpc, _ := PublicKeyToAddress(prefix, testPublicKey)
+		receiver := testAddress(t, prefix, 1)
+		authzGranter := testAddress(t, prefix, 2)
+		feeGranter := testAddress(t, prefix, 3)
+		denom := "u" + prefix
+
+		for _, useFeeGranter := range []bool{false, true} {
+			granter := ""
+			if useFeeGranter {
+				granter = feeGranter
+			}
+			b := NewCrossChainBridge()
+			b.SetChainProfile(&ChainProfile{Prefix: prefix, FeeDenoms: []string{denom}, FeeGranter: granter})
+			sendMsg := BuildSendMsg(authzGranter, receiver, denom, big.NewInt(100))
+			msgs, err := BuildExecMsgs(mpc, []sdk.Msg{sendMsg})
+			if err != nil {
+				t.Fatalf("%v: build exec msgs failed: %v", prefix, err)
+			}
+			txBuilder, err := b.newTxBuilder(msgs, "memo", pubKey, sdk.NewCoins(sdk.NewInt64Coin(denom, 10)), DefaultGasLimit, 1)
+			if err != nil {
+				t.Fatalf("%v: build tx failed: %v", prefix, err)
+			}
+
+			// decode the encoded tx to check what is sent to chain
+			txBytes, err := b.TxConfig.TxEncoder()(txBuilder.GetTx())
+			if err != nil {
+				t.Fatal(err)
+			}
+			decoded, err := b.TxConfig.TxDecoder()(txBytes)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have := b.getFeeGranter(txBuilder.GetTx()); have != granter {
+				t.Errorf("%v: want fee granter %v, but got %v", prefix, granter, have)
+			}
+			decodedMsgs := decoded.GetMsgs()
+			execMsg, ok := decodedMsgs[0].(*authz.MsgExec)
+			if len(decodedMsgs) != 1 || !ok {
+				t.Errorf("%v: want one exec msg, but got %v", prefix, decodedMsgs)
+				continue
+			}
+			if execMsg.Grantee != mpc {
+				t.Errorf("%v: want grantee %v, but got %v", prefix, mpc, execMsg.Grantee)
+			}
+			execMsgs, err := execMsg.GetMessages()
+			if err != nil || len(execMsgs) != 1 {
+				t.Errorf("%v: wrong exec msgs %v, err %v", prefix, execMsgs, err)
+				continue
+			}
+			if send, ok := execMsgs[0].(*bankTypes.MsgSend); !ok || send.FromAddress != authzGranter || send.ToAddress != receiver {
+				t.Errorf("%v: wrong send msg %v", prefix, execMsgs[0])
+			}
+		}
+	}
+}
+
+func TestValidateTx(t *testing.T) {
+	pubKey, err := PubKeyFromStr(testPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mpc, _ := PublicKeyToAddress("osmo", testPublicKey)
+	receiver := testAddress(t, "osmo", 1)
+	fee := sdk.NewCoins(sdk.NewInt64Coin("uosmo", 10))
+	send := func(from, to string, amount int64) sdk.Msg {
+		return BuildSendMsg(from, to, "uosmo", big.NewInt(amount))
+	}
+	exec := func(grantee string, msgs ...sdk.Msg) sdk.Msg {
+		execMsgs, err := BuildExecMsgs(grantee, msgs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return execMsgs[0]
+	}
+
+	tests := []struct {
+		feeGranter string
+		msgs       []sdk.Msg
+		wantErr    bool
+	}{
+		{"", []sdk.Msg{send(mpc, receiver, 1)}, false},
+		{"", []sdk.Msg{send(mpc, receiver, 1), send(mpc, receiver, 2)}, false},
+		{"", []sdk.Msg{exec(mpc, send(receiver, mpc, 1))}, false},
+		{testAddress(t, "osmo", 3), []sdk.Msg{send(mpc, receiver, 1)}, false},
+		{"", []sdk.Msg{}, true},
+		{"", []sdk.Msg{send(mpc, receiver, 0)}, true},
+		{"", []sdk.Msg{send(mpc, testAddress(t, "sei", 1), 1)}, true},
+		{"", []sdk.Msg{exec(testAddress(t, "sei", 1), send(receiver, mpc, 1))}, true},
+		{"", []sdk.Msg{exec(mpc)}, true},
+		{"", []sdk.Msg{exec(mpc, exec(mpc, send(receiver, mpc, 1)))}, true},
+		{"", []sdk.Msg{&authz.MsgRevoke{Granter: mpc, Grantee: receiver}}, true},
+		{testAddress(t, "sei", 3), []sdk.Msg{send(mpc, receiver, 1)}, true},
+	}
+	for i, test := range tests {
+		b := NewCrossChainBridge()
+		b.SetChainProfile(&ChainProfile{Prefix: "osmo", FeeDenoms: []string{"uosmo"}, FeeGranter: test.feeGranter})
+		_, err := b.newTxBuilder(test.msgs, "", pubKey, fee, DefaultGasLimit, 1)
+		if (err != nil) != test.wantErr {
+			t.Errorf("test %v: want error %v, but got %v", i, test.wantErr, err)
+		}
+	}
+}
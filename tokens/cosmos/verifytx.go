@@ -40,17 +40,21 @@ func (b *Bridge) VerifyMsgHash(tx interface{}, msgHashes []string) (err error) {
 }
 
 // VerifyTransaction impl
-func (b *Bridge) VerifyTransaction(txHash string, args *tokens.VerifyArgs) (*tokens.SwapTxInfo, error) {
+func (b *Bridge) VerifyTransaction(txHash string, args *tokens.VerifyArgs) (swapInfo *tokens.SwapTxInfo, err error) {
 	swapType := args.SwapType
 	logIndex := args.LogIndex
 	allowUnstable := args.AllowUnstable
 
 	switch swapType {
 	case tokens.ERC20SwapType:
-		return b.verifySwapoutTx(txHash, logIndex, allowUnstable)
+		swapInfo, err = b.verifySwapoutTx(txHash, logIndex, allowUnstable)
 	default:
 		return nil, tokens.ErrSwapTypeNotSupported
 	}
+	if err == nil && !allowUnstable {
+		err = tokens.CheckSwapTxStable(b, swapInfo)
+	}
+	return swapInfo, err
 }
 
 func (b *Bridge) verifySwapoutTx(txHash string, logIndex int, allowUnstable bool) (*tokens.SwapTxInfo, error) {
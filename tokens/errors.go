@@ -47,6 +47,12 @@ var (
 	ErrEmptyTokenID           = errors.New("empty tokenID")
 	ErrNoEnoughReserveBudget  = errors.New("no enough reserve budget")
 	ErrTxWithNoPayment        = errors.New("tx with no payment")
+	ErrPartialPayment         = errors.New("tx is partial payment")
+	ErrMissDestinationTag     = errors.New("receiver requires destination tag")
+	ErrNoTrustLine            = errors.New("receiver has no trust line")
+	ErrTrustLineFrozen        = errors.New("receiver trust line is frozen")
+	ErrTrustLineNotAuthorized = errors.New("receiver trust line is not authorized")
+	ErrTrustLineLimitExceeded = errors.New("receiver trust line limit exceeded")
 	ErrTxIsNotValidated       = errors.New("tx is not validated")
 	ErrPauseSwapInto          = errors.New("maintain: pause swap into")
 	ErrBuildTxErrorAndDelay   = errors.New("[build tx error]")
@@ -58,6 +64,8 @@ var (
 	ErrFallbackNotSupport     = errors.New("app does not support fallback")
 	ErrQueryTokenBalance      = errors.New("query token balance error")
 	ErrTokenBalanceNotEnough  = errors.New("token balance not enough")
+	ErrInsufficientLiquidity  = errors.New("insufficient underlying liquidity")
+	ErrConfirmModeNotSupport  = errors.New("confirm mode not support")
 	ErrGetLatestBlockNumber   = errors.New("get latest block number error")
 	ErrGetAccountNonce        = errors.New("get account nonce error")
 	ErrGetUnderlying          = errors.New("get underlying address error")
@@ -96,6 +104,15 @@ var (
 	ErrGetBlockNumberByID     = errors.New("get block number by id error")
 	ErrSendTx                 = errors.New("send tx fails")
 	ErrGetAccount             = errors.New("get account fails")
+	ErrBatchSwapMismatch      = errors.New("batch swap mismatch")
+	ErrTooManyBatchSwaps      = errors.New("too many swaps in batch")
+	ErrAnyCallAppNotConfiged  = errors.New("anycall app is not configed on dest chain")
+	ErrAnyCallTargetMismatch  = errors.New("anycall target mismatch")
+	ErrAnyCallNoExecutor      = errors.New("anycall executor is not configed on dest chain")
+	ErrAnyCallNoSwapID        = errors.New("anycall target is not passed swapID")
+	ErrAnyCallSwapExecuted    = errors.New("anycall swap is already executed")
+	ErrNFTNativeIDMismatch    = errors.New("nft native id mismatch")
+	ErrNFTSwapNotSupported    = errors.New("nft swap is not supported by dest chain")
 )
 
 // errors should register in router swap
@@ -0,0 +1,109 @@
+package eth
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Router/v3/common"
+	"github.com/anyswap/CrossChain-Router/v3/common/hexutil"
+	"github.com/anyswap/CrossChain-Router/v3/log"
+	"github.com/anyswap/CrossChain-Router/v3/params"
+	"github.com/anyswap/CrossChain-Router/v3/router"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+	"github.com/anyswap/CrossChain-Router/v3/tokens/eth/abicoder"
+)
+
+var (
+	// multicall(bytes[] data)
+	MulticallFuncHash = common.FromHex("0xac9650d8")
+
+	errRouterNoMulticall = errors.New("router does not support multicall")
+)
+
+// IsBatchSwapSupported impl tokens.BatchSwapBuilder,
+// batch swap requires router supports multicall (configed in local chain config)
+func (b *Bridge) IsBatchSwapSupported() bool {
+	return params.GetLocalChainConfig(b.ChainConfig.ChainID).RouterSupportsMulticall
+}
+
+// BuildBatchRawTransaction build batch swapin tx.
+// The swapin calls are aggregated by calling `multicall(bytes[])` of the router contract,
+// so batching is only supported for chains whose router supports multicall.
+func (b *Bridge) BuildBatchRawTransaction(args *tokens.BuildTxArgs, swaps []*tokens.BuildTxArgs) (rawTx interface{}, err error) {
+	err = b.buildBatchSwapTxInput(args, swaps)
+	if err != nil {
+		return nil, err
+	}
+
+	err = b.setDefaults(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.buildTx(args)
+}
+
+// buildBatchSwapTxInput build the `multicall` input of swapin calls, and set to, value and batch swaps of args
+func (b *Bridge) buildBatchSwapTxInput(args *tokens.BuildTxArgs, swaps []*tokens.BuildTxArgs) (err error) {
+	if !params.IsTestMode && args.ToChainID.String() != b.ChainConfig.ChainID {
+		return tokens.ErrToChainIDMismatch
+	}
+	if !b.IsBatchSwapSupported() {
+		return errRouterNoMulticall
+	}
+	if args.Input != nil {
+		return fmt.Errorf("forbid build raw swap tx with input data")
+	}
+	if args.From == "" {
+		return fmt.Errorf("forbid empty sender")
+	}
+	err = tokens.CheckBatchSwaps(args, swaps)
+	if err != nil {
+		return err
+	}
+	routerMPC, err := router.GetRouterMPC(swaps[0].GetTokenID(), b.ChainConfig.ChainID)
+	if err != nil {
+		return err
+	}
+	if !common.IsEqualIgnoreCase(args.From, routerMPC) {
+		log.Error("build batch tx mpc mismatch", "have", args.From, "want", routerMPC)
+		return tokens.ErrSenderMismatch
+	}
+
+	calls := make([][]byte, len(swaps))
+	swapValue := big.NewInt(0)
+	bridgeFee := big.NewInt(0)
+	for i, swap := range swaps {
+		if swap.Extra == nil {
+			swap.Extra = &tokens.AllExtras{}
+		}
+		swap.Input = nil
+		err = b.BuildERC20SwapTxInput(swap)
+		if err != nil {
+			return err
+		}
+		if !strings.EqualFold(swap.To, swaps[0].To) {
+			return fmt.Errorf("%w: router contract %v != %v", tokens.ErrBatchSwapMismatch, swap.To, swaps[0].To)
+		}
+		calls[i] = *swap.Input
+		swapValue.Add(swapValue, swap.SwapValue)
+		if swap.Extra.BridgeFee != nil {
+			bridgeFee.Add(bridgeFee, swap.Extra.BridgeFee)
+		}
+	}
+
+	input := abicoder.PackDataWithFuncHash(MulticallFuncHash, calls)
+	args.Input = (*hexutil.Bytes)(&input) // input
+	args.To = swaps[0].To                 // to
+	args.SwapValue = swapValue            // total swapValue
+	if args.Extra == nil {
+		args.Extra = &tokens.AllExtras{}
+	}
+	args.Extra.BridgeFee = bridgeFee
+	args.SetBatchSwaps(swaps)
+
+	log.Info("build batch swapin tx input", "chainID", b.ChainConfig.ChainID, "tokenID", swaps[0].GetTokenID(), "count", len(swaps), "swapValue", swapValue)
+	return nil
+}
@@ -0,0 +1,187 @@
+package eth
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Router/v3/common"
+	"github.com/anyswap/CrossChain-Router/v3/params"
+	"github.com/anyswap/CrossChain-Router/v3/router"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+	"github.com/anyswap/CrossChain-Router/v3/tokens/eth/abicoder"
+)
+
+const (
+	batchFromChainID = "1001"
+	batchToChainID   = "1002"
+	batchTokenID     = "BATCHTOKEN"
+	batchFromToken   = "0x0000000000000000000000000000000000001001"
+	batchToToken     = "0x0000000000000000000000000000000000001002"
+	batchRouter      = "0x0000000000000000000000000000000000002002"
+	batchRouterMPC   = "0x0000000000000000000000000000000000003002"
+)
+
+// setupBatchSwapBridges register the source and dest bridges of batch swap to the router
+func setupBatchSwapBridges(t *testing.T, supportsMulticall bool) *Bridge {
+	prevExtra := params.GetExtraConfig()
+	extra := &params.ExtraConfig{
+		LocalChainConfig: map[string]*params.LocalChainConfig{
+			batchToChainID: {RouterSupportsMulticall: supportsMulticall},
+		},
+	}
+	if err := params.SetExtraConfig(extra); err != nil {
+		t.Fatalf("set extra config failed: %v", err)
+	}
+
+	fromBridge := NewCrossChainBridge()
+	fromBridge.SetChainConfig(&tokens.ChainConfig{ChainID: batchFromChainID})
+	fromBridge.SetTokenConfig(batchFromToken, &tokens.TokenConfig{TokenID: batchTokenID, Decimals: 18, ContractAddress: batchFromToken})
+
+	b := NewCrossChainBridge()
+	b.SetChainConfig(&tokens.ChainConfig{ChainID: batchToChainID, RouterContract: batchRouter})
+	b.SetTokenConfig(batchToToken, &tokens.TokenConfig{TokenID: batchTokenID, Decimals: 18, ContractAddress: batchToToken})
+
+	router.SetBridge(batchFromChainID, fromBridge)
+	router.SetBridge(batchToChainID, b)
+	router.SetMultichainToken(batchTokenID, batchFromChainID, batchFromToken)
+	router.SetMultichainToken(batchTokenID, batchToChainID, batchToToken)
+	router.SetRouterInfo(batchRouter, batchToChainID, &router.SwapRouterInfo{RouterMPC: batchRouterMPC})
+
+	t.Cleanup(func() {
+		router.SetBridge(batchFromChainID, nil)
+		router.SetBridge(batchToChainID, nil)
+		router.SetMultichainTokens(batchTokenID, nil)
+		router.RouterInfos.Delete(fmt.Sprintf("%s:%s", batchRouter, batchToChainID))
+		if prevExtra == nil {
+			prevExtra = &params.ExtraConfig{}
+		}
+		_ = params.SetExtraConfig(prevExtra)
+	})
+	return b
+}
+
+func newBatchSwapArgs(index int64) *tokens.BuildTxArgs {
+	return &tokens.BuildTxArgs{
+		SwapArgs: tokens.SwapArgs{
+			Identifier:  params.GetIdentifier(),
+			SwapID:      common.BigToHash(big.NewInt(index)).Hex(),
+			SwapType:    tokens.ERC20SwapType,
+			Bind:        common.BigToAddress(big.NewInt(0x4000 + index)).Hex(),
+			FromChainID: big.NewInt(1001),
+			ToChainID:   big.NewInt(1002),
+			SwapInfo: tokens.SwapInfo{ERC20SwapInfo: &tokens.ERC20SwapInfo{
+				Token:   batchFromToken,
+				TokenID: batchTokenID,
+			}},
+		},
+		From:        batchRouterMPC,
+		OriginValue: big.NewInt(1000 * index),
+	}
+}
+
+func TestBuildBatchSwapTxInput(t *testing.T) {
+	b := setupBatchSwapBridges(t, true)
+
+	swaps := []*tokens.BuildTxArgs{newBatchSwapArgs(1), newBatchSwapArgs(2), newBatchSwapArgs(3)}
+	args := &tokens.BuildTxArgs{
+		SwapArgs: tokens.SwapArgs{
+			SwapType:  tokens.ERC20SwapType,
+			ToChainID: big.NewInt(1002),
+		},
+		From: batchRouterMPC,
+	}
+	if err := b.buildBatchSwapTxInput(args, swaps); err != nil {
+		t.Fatalf("build batch swap tx input failed: %v", err)
+	}
+
+	if args.To != batchRouter {
+		t.Errorf("batch tx to: got %v, want %v", args.To, batchRouter)
+	}
+	if args.SwapValue.Cmp(big.NewInt(6000)) != 0 {
+		t.Errorf("batch tx swap value: got %v, want %v", args.SwapValue, 6000)
+	}
+	if len(args.Extra.BatchSwaps) != len(swaps) {
+		t.Fatalf("batch swaps: got %v, want %v", len(args.Extra.BatchSwaps), len(swaps))
+	}
+
+	input := *args.Input
+	if !bytes.Equal(input[:4], MulticallFuncHash) {
+		t.Fatalf("batch tx func hash: got %x, want %x", input[:4], MulticallFuncHash)
+	}
+	calls, err := abicoder.ParseBytesSliceInData(input[4:], 0)
+	if err != nil {
+		t.Fatalf("parse multicall input failed: %v", err)
+	}
+	if len(calls) != len(swaps) {
+		t.Fatalf("multicall calls: got %v, want %v", len(calls), len(swaps))
+	}
+	for i, swap := range swaps {
+		single := newBatchSwapArgs(int64(i + 1))
+		single.Extra = &tokens.AllExtras{}
+		if err := b.BuildERC20SwapTxInput(single); err != nil {
+			t.Fatalf("build swap %v input failed: %v", i, err)
+		}
+		if !bytes.Equal(calls[i], *single.Input) {
+			t.Errorf("multicall call %v: got %x, want %x", i, calls[i], *single.Input)
+		}
+		if !bytes.Equal(calls[i][:4], AnySwapInFuncHash) {
+			t.Errorf("multicall call %v func hash: got %x, want %x", i, calls[i][:4], AnySwapInFuncHash)
+		}
+		if args.Extra.BatchSwaps[i].SwapID != swap.SwapID {
+			t.Errorf("batch swap %v: got swap id %v, want %v", i, args.Extra.BatchSwaps[i].SwapID, swap.SwapID)
+		}
+	}
+}
+
+func TestBuildBatchSwapTxInputErrors(t *testing.T) {
+	b := setupBatchSwapBridges(t, true)
+
+	newArgs := func(from string) *tokens.BuildTxArgs {
+		return &tokens.BuildTxArgs{
+			SwapArgs: tokens.SwapArgs{
+				SwapType:  tokens.ERC20SwapType,
+				ToChainID: big.NewInt(1002),
+			},
+			From: from,
+		}
+	}
+	otherMPC := "0x0000000000000000000000000000000000003003"
+	otherFrom := newBatchSwapArgs(2)
+	otherFrom.From = otherMPC
+
+	tests := []struct {
+		args    *tokens.BuildTxArgs
+		swaps   []*tokens.BuildTxArgs
+		wantErr error
+	}{
+		// sender is not the router mpc
+		{newArgs(otherMPC), []*tokens.BuildTxArgs{newBatchSwapArgs(1), otherFrom}, tokens.ErrSenderMismatch},
+		{newArgs(batchRouterMPC), []*tokens.BuildTxArgs{newBatchSwapArgs(1), otherFrom}, tokens.ErrSenderMismatch},
+		{newArgs(batchRouterMPC), []*tokens.BuildTxArgs{newBatchSwapArgs(1), newBatchSwapArgs(1)}, tokens.ErrBatchSwapMismatch},
+	}
+	for i, tt := range tests {
+		err := b.buildBatchSwapTxInput(tt.args, tt.swaps)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("test %v: got err %v, want %v", i, err, tt.wantErr)
+		}
+	}
+
+	// the whole batch is from mpc other than the router mpc
+	swaps := []*tokens.BuildTxArgs{newBatchSwapArgs(1), newBatchSwapArgs(2)}
+	for _, swap := range swaps {
+		swap.From = otherMPC
+	}
+	if err := b.buildBatchSwapTxInput(newArgs(otherMPC), swaps); !errors.Is(err, tokens.ErrSenderMismatch) {
+		t.Errorf("batch of other mpc: got err %v, want %v", err, tokens.ErrSenderMismatch)
+	}
+
+	// router without multicall can not batch
+	_ = params.SetExtraConfig(&params.ExtraConfig{})
+	swaps = []*tokens.BuildTxArgs{newBatchSwapArgs(1), newBatchSwapArgs(2)}
+	if err := b.buildBatchSwapTxInput(newArgs(batchRouterMPC), swaps); !errors.Is(err, errRouterNoMulticall) {
+		t.Errorf("router without multicall: got err %v, want %v", err, errRouterNoMulticall)
+	}
+}
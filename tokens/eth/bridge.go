@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math/big"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/anyswap/CrossChain-Router/v3/common"
@@ -14,6 +15,7 @@ import (
 	"github.com/anyswap/CrossChain-Router/v3/router"
 	"github.com/anyswap/CrossChain-Router/v3/tokens"
 	"github.com/anyswap/CrossChain-Router/v3/tokens/base"
+	"github.com/anyswap/CrossChain-Router/v3/tokens/gasoracle"
 	"github.com/anyswap/CrossChain-Router/v3/types"
 )
 
@@ -41,6 +43,8 @@ type Bridge struct {
 	// internal usage
 	latestGasPrice  *big.Int
 	autoMaxGasPrice *big.Int
+	gasOracle       *gasoracle.Oracle
+	gasOracleOnce   sync.Once
 	EvmContractBridge
 }
 
@@ -76,6 +80,19 @@ func (b *Bridge) InitAfterConfig() {
 	if b.NeedsFinalizeAPIAddress() && len(b.GatewayConfig.FinalizeAPIAddress) == 0 {
 		logErrFunc("conflux has no 'FinalizeAPIAddress' gateway to get latest finalized block", "chainID", b.ChainConfig.ChainID)
 	}
+	b.getGasOracle()
+}
+
+// getGasOracle get gas oracle (init and register it at the first time)
+func (b *Bridge) getGasOracle() *gasoracle.Oracle {
+	b.gasOracleOnce.Do(func() {
+		b.gasOracle = gasoracle.NewOracle(b.ChainConfig.ChainID,
+			gasoracle.NewSource("eth_gasPrice", b.SuggestPrice),
+			gasoracle.NewSource("eth_feeHistory", b.getFeeHistoryGasPrice),
+		)
+		gasoracle.Register(b.gasOracle)
+	})
+	return b.gasOracle
 }
 
 func (b *Bridge) initSigner(chainID *big.Int) (err error) {
@@ -202,6 +219,13 @@ func (b *Bridge) GetTokenConfig(tokenAddr string) *tokens.TokenConfig {
 	return tokenCfg
 }
 
+// CheckTokenConfig check token config with token contract (decimals, minter, underlying)
+func (b *Bridge) CheckTokenConfig(tokenCfg *tokens.TokenConfig) error {
+	cfg := *tokenCfg
+	cfg.Checked = false
+	return b.checkTokenConfig(&cfg)
+}
+
 func (b *Bridge) checkTokenConfig(tokenCfg *tokens.TokenConfig) error {
 	if tokenCfg == nil || tokenCfg.Checked || !tokens.IsERC20Router() {
 		return nil
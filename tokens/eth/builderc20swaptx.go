@@ -1,7 +1,9 @@
 package eth
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/anyswap/CrossChain-Router/v3/common"
@@ -196,12 +198,34 @@ func (b *Bridge) buildERC20SwapinTxInput(args *tokens.BuildTxArgs, multichainTok
 	}
 
 	erc20SwapInfo := args.ERC20SwapInfo
+	routerVersion := b.GetRouterVersion(multichainToken)
 
 	var funcHash, input []byte
 
-	switch b.GetRouterVersion(multichainToken) {
+	switch routerVersion {
 	case "v7":
 		funcHash = GetSwapInFuncHashV7(toTokenCfg)
+	default:
+		funcHash = GetSwapInFuncHash1(toTokenCfg)
+	}
+
+	var payout *tokens.PayoutInfo
+	if bytes.Equal(funcHash, AnySwapInAutoFuncHash) || bytes.Equal(funcHash, AnySwapInAutoFuncHashV7) {
+		payout, err = b.getPayout(args, toTokenCfg, multichainToken, routerVersion, amount)
+		if err != nil {
+			return err
+		}
+	}
+	if payout != nil && payout.Type == tokens.PayoutAnyToken {
+		if routerVersion == "v7" {
+			funcHash = AnySwapInFuncHashV7
+		} else {
+			funcHash = AnySwapInFuncHash
+		}
+	}
+
+	switch routerVersion {
+	case "v7":
 		input = abicoder.PackDataWithFuncHash(funcHash,
 			args.GetUniqueSwapIdentifier(),
 			common.HexToHash(erc20SwapInfo.SwapoutID),
@@ -211,13 +235,33 @@ func (b *Bridge) buildERC20SwapinTxInput(args *tokens.BuildTxArgs, multichainTok
 			args.FromChainID,
 		)
 	default:
-		funcHash = GetSwapInFuncHash1(toTokenCfg)
 		var swapIDHash common.Hash
 		if common.IsHexHash(args.SwapID) {
 			swapIDHash = common.HexToHash(args.SwapID)
 		} else {
 			swapIDHash = common.BytesToHash([]byte(args.SwapID))
 		}
+		if payout != nil && payout.Type == tokens.PayoutSplit {
+			// pay underlying as much as possible, and anyToken of the rest
+			calls := [][]byte{
+				abicoder.PackDataWithFuncHash(AnySwapInUnderlyingFuncHash,
+					swapIDHash,
+					common.HexToAddress(multichainToken),
+					receiver,
+					payout.Underlying,
+					args.FromChainID,
+				),
+				abicoder.PackDataWithFuncHash(AnySwapInFuncHash,
+					GetSplitSwapIDHash(swapIDHash),
+					common.HexToAddress(multichainToken),
+					receiver,
+					new(big.Int).Sub(amount, payout.Underlying),
+					args.FromChainID,
+				),
+			}
+			input = abicoder.PackDataWithFuncHash(MulticallFuncHash, calls)
+			break
+		}
 		input = abicoder.PackDataWithFuncHash(funcHash,
 			swapIDHash,
 			common.HexToAddress(multichainToken),
@@ -230,10 +274,99 @@ func (b *Bridge) buildERC20SwapinTxInput(args *tokens.BuildTxArgs, multichainTok
 	args.Input = (*hexutil.Bytes)(&input)          // input
 	args.To = b.GetRouterContract(multichainToken) // to
 	args.SwapValue = amount                        // swapValue
+	args.Extra.Payout = payout                     // payout
 
 	return nil
 }
 
+// GetSplitSwapIDHash get swap id of the anyToken part of split payout,
+// which is distinct from the swap id of the underlying part
+func GetSplitSwapIDHash(swapIDHash common.Hash) common.Hash {
+	return common.Keccak256Hash(swapIDHash.Bytes(), []byte(tokens.PayoutAnyToken))
+}
+
+// isSplitPayoutSupported split payout is paid by `multicall` of router (not v7)
+func (b *Bridge) isSplitPayoutSupported(routerVersion string) bool {
+	return routerVersion != "v7" && b.IsBatchSwapSupported()
+}
+
+// getPayout decide payout according to router underlying liquidity.
+// a preset payout (eg. from the server when verifying by oracles, or when replacing)
+// is checked against the current liquidity, as oracles recompute it by themselves.
+// otherwise it is decided by the liquidity policy of token (nil if not configed).
+func (b *Bridge) getPayout(args *tokens.BuildTxArgs, tokenCfg *tokens.TokenConfig, multichainToken, routerVersion string, amount *big.Int) (*tokens.PayoutInfo, error) {
+	payout := args.Extra.Payout
+	if payout != nil {
+		if err := b.checkPresetPayout(payout, routerVersion, amount); err != nil {
+			return nil, err
+		}
+		if payout.Type == tokens.PayoutUnderlying {
+			return payout, nil
+		}
+	} else if params.GetLiquidityPolicy(args.GetTokenID()) == nil {
+		return nil, nil
+	}
+
+	balance, err := b.GetErc20Balance(tokenCfg.GetUnderlying(), multichainToken)
+	if err != nil {
+		log.Warn("get underlying liquidity failed", "chainID", b.ChainConfig.ChainID, "token", multichainToken, "underlying", tokenCfg.GetUnderlying(), "err", err)
+		return nil, fmt.Errorf("%w: %v", tokens.ErrQueryTokenBalance, err)
+	}
+
+	if payout != nil {
+		return recomputePayout(payout, balance, amount)
+	}
+
+	if balance.Cmp(amount) >= 0 {
+		return &tokens.PayoutInfo{Type: tokens.PayoutUnderlying}, nil
+	}
+
+	policy := params.GetLiquidityPolicy(args.GetTokenID())
+	log.Info("underlying liquidity is insufficient", "chainID", b.ChainConfig.ChainID, "tokenID", args.GetTokenID(), "swapID", args.SwapID, "policy", policy.Policy, "balance", balance, "amount", amount)
+
+	switch policy.Policy {
+	case params.LiquidityPolicyDelay:
+		return nil, fmt.Errorf("%w %v (balance %v, amount %v)", tokens.ErrBuildTxErrorAndDelay, tokens.ErrInsufficientLiquidity, balance, amount)
+	case params.LiquidityPolicySplit:
+		if balance.Sign() > 0 && b.isSplitPayoutSupported(routerVersion) {
+			return &tokens.PayoutInfo{Type: tokens.PayoutSplit, Underlying: balance}, nil
+		}
+	}
+	return &tokens.PayoutInfo{Type: tokens.PayoutAnyToken}, nil
+}
+
+func (b *Bridge) checkPresetPayout(payout *tokens.PayoutInfo, routerVersion string, amount *big.Int) error {
+	switch payout.Type {
+	case tokens.PayoutUnderlying, tokens.PayoutAnyToken:
+		if payout.Underlying != nil {
+			return fmt.Errorf("%w: %v payout with underlying amount", tokens.ErrTxWithWrongValue, payout.Type)
+		}
+	case tokens.PayoutSplit:
+		if !b.isSplitPayoutSupported(routerVersion) {
+			return fmt.Errorf("%w: split payout is not supported by router (version %v)", errRouterNoMulticall, routerVersion)
+		}
+		if payout.Underlying == nil || payout.Underlying.Sign() <= 0 || payout.Underlying.Cmp(amount) >= 0 {
+			return fmt.Errorf("%w: split payout underlying %v is not in range (0, %v)", tokens.ErrTxWithWrongValue, payout.Underlying, amount)
+		}
+	default:
+		return fmt.Errorf("unknown payout type '%v'", payout.Type)
+	}
+	return nil
+}
+
+// recomputePayout check the preset anyToken or split payout with the current liquidity.
+// pay underlying if the liquidity is sufficient now,
+// and the underlying part of split payout must not exceed the liquidity.
+func recomputePayout(payout *tokens.PayoutInfo, balance, amount *big.Int) (*tokens.PayoutInfo, error) {
+	if balance.Cmp(amount) >= 0 {
+		return &tokens.PayoutInfo{Type: tokens.PayoutUnderlying}, nil
+	}
+	if payout.Type == tokens.PayoutSplit && payout.Underlying.Cmp(balance) > 0 {
+		return nil, fmt.Errorf("%w %v: split payout underlying %v exceeds liquidity %v", tokens.ErrBuildTxErrorAndDelay, tokens.ErrInsufficientLiquidity, payout.Underlying, balance)
+	}
+	return payout, nil
+}
+
 func (b *Bridge) buildMixPoolSwapinTxInput(args *tokens.BuildTxArgs, multichainToken string) (err error) {
 	receiver, amount, err := b.getReceiverAndAmount(args, multichainToken)
 	if err != nil {
@@ -0,0 +1,103 @@
+package eth
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Router/v3/common"
+	"github.com/anyswap/CrossChain-Router/v3/params"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+)
+
+func TestCheckPresetPayout(t *testing.T) {
+	b := NewCrossChainBridge()
+	b.ChainConfig = &tokens.ChainConfig{ChainID: "4"}
+	extra := &params.ExtraConfig{
+		LocalChainConfig: map[string]*params.LocalChainConfig{
+			"4": {RouterSupportsMulticall: true},
+		},
+	}
+	if err := params.SetExtraConfig(extra); err != nil {
+		t.Fatalf("set extra config failed: %v", err)
+	}
+	defer func() { _ = params.SetExtraConfig(&params.ExtraConfig{}) }()
+
+	amount := big.NewInt(1000)
+	tests := []struct {
+		payout        *tokens.PayoutInfo
+		routerVersion string
+		wantErr       error
+	}{
+		{&tokens.PayoutInfo{Type: tokens.PayoutUnderlying}, "", nil},
+		{&tokens.PayoutInfo{Type: tokens.PayoutAnyToken}, "v7", nil},
+		{&tokens.PayoutInfo{Type: tokens.PayoutAnyToken, Underlying: big.NewInt(1)}, "", tokens.ErrTxWithWrongValue},
+		{&tokens.PayoutInfo{Type: tokens.PayoutSplit, Underlying: big.NewInt(400)}, "", nil},
+		{&tokens.PayoutInfo{Type: tokens.PayoutSplit, Underlying: big.NewInt(400)}, "v7", errRouterNoMulticall},
+		{&tokens.PayoutInfo{Type: tokens.PayoutSplit}, "", tokens.ErrTxWithWrongValue},
+		{&tokens.PayoutInfo{Type: tokens.PayoutSplit, Underlying: big.NewInt(1000)}, "", tokens.ErrTxWithWrongValue},
+	}
+	for i, tt := range tests {
+		err := b.checkPresetPayout(tt.payout, tt.routerVersion, amount)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("test %v: got err %v, want %v", i, err, tt.wantErr)
+		}
+	}
+
+	if err := b.checkPresetPayout(&tokens.PayoutInfo{Type: "unknown"}, "", amount); err == nil {
+		t.Errorf("unknown payout type should fail")
+	}
+
+	// router without multicall can not split
+	extra.LocalChainConfig["4"].RouterSupportsMulticall = false
+	split := &tokens.PayoutInfo{Type: tokens.PayoutSplit, Underlying: big.NewInt(400)}
+	if err := b.checkPresetPayout(split, "", amount); !errors.Is(err, errRouterNoMulticall) {
+		t.Errorf("split payout without multicall: got err %v, want %v", err, errRouterNoMulticall)
+	}
+}
+
+func TestRecomputePayout(t *testing.T) {
+	amount := big.NewInt(1000)
+	anyToken := &tokens.PayoutInfo{Type: tokens.PayoutAnyToken}
+	split := &tokens.PayoutInfo{Type: tokens.PayoutSplit, Underlying: big.NewInt(400)}
+	tests := []struct {
+		payout   *tokens.PayoutInfo
+		balance  int64
+		wantType string
+		wantErr  error
+	}{
+		{anyToken, 999, tokens.PayoutAnyToken, nil},
+		{anyToken, 1000, tokens.PayoutUnderlying, nil},
+		{split, 400, tokens.PayoutSplit, nil},
+		{split, 500, tokens.PayoutSplit, nil},
+		{split, 399, "", tokens.ErrInsufficientLiquidity},
+		{split, 2000, tokens.PayoutUnderlying, nil},
+	}
+	for i, tt := range tests {
+		payout, err := recomputePayout(tt.payout, big.NewInt(tt.balance), amount)
+		if tt.wantErr != nil {
+			if err == nil || !errors.Is(err, tokens.ErrBuildTxErrorAndDelay) {
+				t.Errorf("test %v: got err %v, want %v", i, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("test %v: unexpected err %v", i, err)
+			continue
+		}
+		if payout.Type != tt.wantType {
+			t.Errorf("test %v: got payout %v, want %v", i, payout.Type, tt.wantType)
+		}
+	}
+}
+
+func TestGetSplitSwapIDHash(t *testing.T) {
+	swapID := common.HexToHash("0x1111111111111111111111111111111111111111111111111111111111111111")
+	splitID := GetSplitSwapIDHash(swapID)
+	if splitID == swapID {
+		t.Errorf("split swap id should be distinct")
+	}
+	if splitID != GetSplitSwapIDHash(swapID) {
+		t.Errorf("split swap id should be deterministic")
+	}
+}
@@ -300,7 +300,7 @@ func (b *Bridge) getGasPrice(args *tokens.BuildTxArgs) (price *big.Int, err erro
 	fixedGasPrice := params.GetFixedGasPrice(b.ChainConfig.ChainID)
 	if fixedGasPrice != nil {
 		if params.IsDebugMode() { // debug the get gas price function
-			if price1, err1 := b.SuggestPrice(); err1 == nil {
+			if price1, err1 := b.getGasOracle().GasPrice(); err1 == nil {
 				max := params.GetMaxGasPrice(b.ChainConfig.ChainID)
 				if max != nil && price1.Cmp(max) > 0 {
 					log.Warnf("call eth_gasPrice got gas price %v exceeded maximum limit %v", price1, max)
@@ -317,7 +317,7 @@ func (b *Bridge) getGasPrice(args *tokens.BuildTxArgs) (price *big.Int, err erro
 		}
 	} else {
 		for i := 0; i < retryRPCCount; i++ {
-			price, err = b.SuggestPrice()
+			price, err = b.getGasOracle().GasPrice()
 			if err == nil {
 				break
 			}
@@ -484,7 +484,7 @@ func (b *Bridge) getGasTipCap(args *tokens.BuildTxArgs) (gasTipCap *big.Int, err
 	}
 
 	for i := 0; i < retryRPCCount; i++ {
-		gasTipCap, err = b.SuggestGasTipCap()
+		gasTipCap, err = b.suggestGasTipCapByOracle()
 		if err == nil {
 			break
 		}
@@ -543,5 +543,32 @@ func (b *Bridge) getGasFeeCap(_ *tokens.BuildTxArgs, gasTipCap *big.Int) (gasFee
 	if maxGasFeeCap != nil && newGasFeeCap.Cmp(maxGasFeeCap) > 0 {
 		newGasFeeCap = maxGasFeeCap
 	}
+	if oracleCfg := params.GetGasOracleConfig(b.ChainConfig.ChainID); oracleCfg != nil {
+		maxGasPrice := oracleCfg.GetMaxGasPrice()
+		if maxGasPrice != nil && newGasFeeCap.Cmp(maxGasPrice) > 0 {
+			log.Warn("gas fee cap exceeds gas oracle maximum bound", "chainID", b.ChainConfig.ChainID, "gasFeeCap", newGasFeeCap, "max", maxGasPrice)
+			newGasFeeCap = new(big.Int).Set(maxGasPrice)
+		}
+	}
+	if newGasFeeCap.Cmp(gasTipCap) < 0 {
+		return nil, fmt.Errorf("gas fee cap %v is lower than gas tip cap %v", newGasFeeCap, gasTipCap)
+	}
 	return newGasFeeCap, nil
 }
+
+// suggestGasTipCapByOracle get gas tip cap from the gas oracle estimate (which is base fee plus tip),
+// fallback to `eth_maxPriorityFeePerGas` if the estimate is not above the latest base fee
+func (b *Bridge) suggestGasTipCapByOracle() (*big.Int, error) {
+	gasPrice, err := b.getGasOracle().GasPrice()
+	if err == nil {
+		var baseFee *big.Int
+		baseFee, err = b.GetBaseFee(0)
+		if err == nil && gasPrice.Cmp(baseFee) > 0 {
+			return gasPrice.Sub(gasPrice, baseFee), nil
+		}
+	}
+	if err != nil {
+		log.Warn("get gas tip cap from gas oracle failed", "chainID", b.ChainConfig.ChainID, "err", err)
+	}
+	return b.SuggestGasTipCap()
+}
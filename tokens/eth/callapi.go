@@ -21,6 +21,7 @@ import (
 	"github.com/anyswap/CrossChain-Router/v3/rpc/client"
 	"github.com/anyswap/CrossChain-Router/v3/tokens"
 	"github.com/anyswap/CrossChain-Router/v3/tokens/eth/callapi"
+	"github.com/anyswap/CrossChain-Router/v3/tokens/gasoracle"
 	"github.com/anyswap/CrossChain-Router/v3/types"
 
 	ethereum "github.com/ethereum/go-ethereum"
@@ -126,6 +127,70 @@ func (b *Bridge) GetBlockByNumber(number *big.Int) (*types.RPCBlock, error) {
 	return nil, wrapRPCQueryError(err, "eth_getBlockByNumber", number)
 }
 
+const blockTagCacheSeconds = 3
+
+type cachedBlockNumber struct {
+	number    uint64
+	timestamp int64
+}
+
+// key is chainID:tag
+var blockTagCache sync.Map
+
+// GetBlockNumberByTag get block number of block tag (eg. finalized, safe),
+// use the minimum of all gateways to be conservative, and cache for a few seconds.
+func (b *Bridge) GetBlockNumberByTag(tag string) (uint64, error) {
+	key := b.ChainConfig.ChainID + ":" + tag
+	if cached, exist := blockTagCache.Load(key); exist {
+		if c := cached.(*cachedBlockNumber); c.timestamp+blockTagCacheSeconds > time.Now().Unix() {
+			return c.number, nil
+		}
+	}
+	var minHeight uint64
+	var err error
+	for _, url := range b.GatewayConfig.AllGatewayURLs {
+		var result *types.RPCBlock
+		err = client.RPCPostWithTimeout(b.RPCClientTimeout, &result, url, "eth_getBlockByNumber", tag, false)
+		if err != nil || result == nil || result.Number == nil {
+			continue
+		}
+		height := result.Number.ToInt().Uint64()
+		if minHeight == 0 || height < minHeight {
+			minHeight = height
+		}
+	}
+	if minHeight == 0 {
+		return 0, wrapRPCQueryError(err, "eth_getBlockByNumber", tag)
+	}
+	blockTagCache.Store(key, &cachedBlockNumber{number: minHeight, timestamp: time.Now().Unix()})
+	return minHeight, nil
+}
+
+// IsL1BatchFinalized is the block included in a finalized L1 batch.
+// zksync is checked by whether the block is executed on L1,
+// other rollups (eg. arbitrum, optimism) finalize L2 blocks with L1 batches.
+func (b *Bridge) IsL1BatchFinalized(blockHeight uint64) (bool, error) {
+	if !b.IsZKSync() {
+		finalized, err := b.GetBlockNumberByTag("finalized")
+		if err != nil {
+			return false, err
+		}
+		return blockHeight <= finalized, nil
+	}
+	var result *struct {
+		Status        string  `json:"status"`
+		ExecuteTxHash *string `json:"executeTxHash"`
+	}
+	var err error
+	for _, url := range b.GatewayConfig.AllGatewayURLs {
+		err = client.RPCPostWithTimeout(b.RPCClientTimeout, &result, url, "zks_getBlockDetails", blockHeight)
+		if err == nil && result != nil {
+			return result.ExecuteTxHash != nil && *result.ExecuteTxHash != "", nil
+		}
+	}
+	return false, wrapRPCQueryError(err, "zks_getBlockDetails", blockHeight)
+}
+
 // GetTransaction impl
 func (b *Bridge) GetTransaction(txHash string) (interface{}, error) {
 	return b.EvmContractBridge.GetTransactionByHash(txHash)
@@ -717,6 +782,36 @@ func (b *Bridge) FeeHistory(blockCount int, rewardPercentiles []float64) (*types
 	return nil, wrapRPCQueryError(err, "eth_feeHistory", blockCount)
 }
 
+// getFeeHistoryGasPrice get gas price (next base fee plus percentile of priority fees) from recent blocks.
+// return nil if it is not enabled in gas oracle config.
+func (b *Bridge) getFeeHistoryGasPrice() (*big.Int, error) {
+	cfg := params.GetGasOracleConfig(b.ChainConfig.ChainID)
+	if cfg == nil || cfg.BlockCount == 0 {
+		return nil, nil
+	}
+	percentile := gasoracle.GetPercentile(cfg)
+	feeHistory, err := b.FeeHistory(cfg.BlockCount, []float64{percentile})
+	if err != nil {
+		return nil, err
+	}
+	length := len(feeHistory.BaseFee)
+	if length == 0 {
+		return nil, errors.New("fee history without base fee")
+	}
+	baseFee := feeHistory.BaseFee[length-1].ToInt() // base fee of next block
+	rewards := make([]*big.Int, 0, len(feeHistory.Reward))
+	for _, reward := range feeHistory.Reward {
+		if len(reward) > 0 && reward[0] != nil {
+			rewards = append(rewards, reward[0].ToInt())
+		}
+	}
+	gasPrice := new(big.Int).Set(baseFee)
+	if len(rewards) > 0 {
+		gasPrice.Add(gasPrice, gasoracle.Median(rewards))
+	}
+	return gasPrice, nil
+}
+
 // GetBaseFee get base fee
 func (b *Bridge) GetBaseFee(blockCount int) (*big.Int, error) {
 	if blockCount == 0 { // from lastest block header
@@ -344,6 +344,12 @@ func (b *Bridge) checkNFTSwapInfo(swapInfo *tokens.SwapTxInfo) error {
 		log.Warn("wrong bind address in nft swap", "txid", swapInfo.Hash, "logIndex", swapInfo.LogIndex, "bind", swapInfo.Bind)
 		return tokens.ErrWrongBindAddress
 	}
+	// dest chain may support part of nft swaps
+	if checker, ok := dstBridge.(tokens.NFTSwapChecker); ok {
+		if err := checker.CheckNFTSwap(swapInfo.NFTSwapInfo); err != nil {
+			return err
+		}
+	}
 
 	fromTokenCfg := b.GetTokenConfig(nftSwapInfo.Token)
 	if fromTokenCfg == nil || nftSwapInfo.TokenID == "" {
@@ -83,6 +83,13 @@ func (b *Bridge) VerifyERC20SwapTx(txHash string, logIndex int, allowUnstable bo
 		return swapInfo, tokens.ErrSwapoutForbidden
 	}
 
+	if !allowUnstable {
+		err = b.checkValueTierStable(swapInfo)
+		if err != nil {
+			return swapInfo, err
+		}
+	}
+
 	if !allowUnstable {
 		ctx := []interface{}{
 			"identifier", params.GetIdentifier(),
@@ -161,6 +168,32 @@ func (b *Bridge) checkERC20SwapInfo(swapInfo *tokens.SwapTxInfo) error {
 	return nil
 }
 
+// checkValueTierStable check the confirm rule of value tier,
+// the base confirm rule is already checked when getting receipt.
+func (b *Bridge) checkValueTierStable(swapInfo *tokens.SwapTxInfo) error {
+	tokenCfg := b.GetTokenConfig(swapInfo.ERC20SwapInfo.Token)
+	if tokenCfg == nil {
+		return tokens.ErrMissTokenConfig
+	}
+	rule := tokens.GetValueTierConfirmRule(b.ChainConfig, swapInfo.ERC20SwapInfo.TokenID, swapInfo.Value, tokenCfg.Decimals)
+	if rule == nil {
+		return nil
+	}
+	txStatus, err := b.GetTransactionStatus(swapInfo.Hash)
+	if err != nil {
+		return err
+	}
+	stable, err := tokens.IsConfirmRuleSatisfied(b, rule, txStatus)
+	if err != nil {
+		return err
+	}
+	if !stable {
+		log.Info("swap value tier is not stable", "chainID", b.ChainConfig.ChainID, "txid", swapInfo.Hash, "logIndex", swapInfo.LogIndex, "tokenID", swapInfo.ERC20SwapInfo.TokenID, "value", swapInfo.Value, "mode", rule.Mode, "confirmations", txStatus.Confirmations)
+		return tokens.ErrTxNotStable
+	}
+	return nil
+}
+
 func (b *Bridge) getSwapTxReceipt(swapInfo *tokens.SwapTxInfo, allowUnstable bool) (receipt *types.RPCTxReceipt, err error) {
 	txStatus, err := b.GetTransactionStatus(swapInfo.Hash)
 	if err != nil {
@@ -181,8 +214,14 @@ func (b *Bridge) getSwapTxReceipt(swapInfo *tokens.SwapTxInfo, allowUnstable boo
 	swapInfo.Height = txStatus.BlockHeight  // Height
 	swapInfo.Timestamp = txStatus.BlockTime // Timestamp
 
-	if !allowUnstable && txStatus.Confirmations < b.ChainConfig.Confirmations {
-		return nil, tokens.ErrTxNotStable
+	if !allowUnstable {
+		stable, errt := tokens.IsConfirmRuleSatisfied(b, tokens.GetBaseConfirmRule(b.ChainConfig), txStatus)
+		if errt != nil {
+			return nil, errt
+		}
+		if !stable {
+			return nil, tokens.ErrTxNotStable
+		}
 	}
 
 	receipt, ok := txStatus.Receipt.(*types.RPCTxReceipt)
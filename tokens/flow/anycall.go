@@ -0,0 +1,156 @@
+package flow
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"strings"
+
+	"github.com/onflow/cadence"
+	sdk "github.com/onflow/flow-go-sdk"
+
+	"github.com/anyswap/CrossChain-Router/v3/log"
+	"github.com/anyswap/CrossChain-Router/v3/params"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+)
+
+var (
+	// ensure Bridge impl tokens.AnyCallAppChecker
+	_ tokens.AnyCallAppChecker = &Bridge{}
+
+	errAnyCallNoScript = errors.New("anycall script is not configed")
+)
+
+// anyCallTargetPlaceholder is replaced by the target address in the configed script
+const anyCallTargetPlaceholder = "{{Target}}"
+
+// anycall transaction generated if no script is configed,
+// the mpc account stores the app's `Executor` resource at `/storage/<Contract>Executor`.
+const anyCallScriptTemplate = `import %[1]s from %[2]s
+
+transaction(%[3]s) {
+    let executorRef: &%[1]s.Executor
+    prepare(acct: AuthAccount) {
+        self.executorRef = acct.borrow<&%[1]s.Executor>(from: /storage/%[1]sExecutor)
+            ?? panic("Could not borrow a reference to the anycall executor")
+    }
+
+    execute {
+        self.executorRef.%[4]s(%[5]s)
+    }
+}
+`
+
+// CheckAnyCallApp check anycall message can be delivered to cadence contract
+func (b *Bridge) CheckAnyCallApp(info *tokens.AnyCallSwapInfo) error {
+	cfg, err := tokens.GetAnyCallAppConfig(info, b.ChainConfig.ChainID)
+	if err != nil {
+		return err
+	}
+	if !b.IsValidAddress(cfg.Target) {
+		return fmt.Errorf("invalid anycall target '%v'", cfg.Target)
+	}
+	if cfg.Script == "" && len(strings.Split(cfg.Function, ".")) != 2 {
+		return fmt.Errorf("anycall function '%v' is not in format of 'Contract.method'", cfg.Function)
+	}
+	return tokens.CheckAnyCallSwapIDArg(cfg)
+}
+
+// buildAnyCallSwapTx deliver anycall message by calling the configed method of the app's executor,
+// or by the configed script (with `{{Target}}` replaced by the target address).
+// the arguments must contain swapID for the target to reject replayed messages.
+func (b *Bridge) buildAnyCallSwapTx(args *tokens.BuildTxArgs) (*sdk.Transaction, error) {
+	cfg, err := tokens.GetAnyCallAppConfig(args.AnyCallSwapInfo, b.ChainConfig.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	args.SwapValue = big.NewInt(0)
+
+	if args.Extra == nil {
+		args.Extra = &tokens.AllExtras{}
+	}
+	if args.Extra.Gas == nil && cfg.Gas > 0 {
+		gas := cfg.Gas
+		args.Extra.Gas = &gas
+	}
+	extra, err := b.initExtra(args)
+	if err != nil {
+		return nil, err
+	}
+
+	signerAddress := sdk.HexToAddress(args.From)
+	tx := sdk.NewTransaction().
+		SetReferenceBlockID(sdk.HexToID(*extra.BlockID)).
+		SetProposalKey(signerAddress, int(*extra.KeyIndex), *extra.Sequence).
+		SetPayer(signerAddress).
+		AddAuthorizer(signerAddress).
+		SetGasLimit(*extra.Gas)
+
+	if err = tokens.CheckAnyCallSwapIDArg(cfg); err != nil {
+		return nil, err
+	}
+	anycallArgs, err := tokens.GetAnyCallArgs(args, cfg)
+	if err != nil {
+		return nil, err
+	}
+	paramDecls := make([]string, len(anycallArgs))
+	labels := make([]string, len(anycallArgs))
+	for i, arg := range anycallArgs {
+		value, typ, errf := toCadenceValue(arg.Value)
+		if errf != nil {
+			return nil, errf
+		}
+		if err = tx.AddArgument(value); err != nil {
+			return nil, err
+		}
+		paramDecls[i] = arg.Name + ":" + typ
+		labels[i] = arg.Name + ":" + arg.Name
+	}
+	script := ""
+	if cfg.Script != "" {
+		script, err = loadAnyCallScript(cfg)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		parts := strings.Split(cfg.Function, ".")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("anycall function '%v' is not in format of 'Contract.method'", cfg.Function)
+		}
+		script = fmt.Sprintf(anyCallScriptTemplate, parts[0], cfg.Target, strings.Join(paramDecls, ","), parts[1], strings.Join(labels, ","))
+	}
+	tx.SetScript([]byte(script))
+	log.Info("build anycall tx", "swapID", args.SwapID, "target", cfg.Target, "function", cfg.Function, "script", cfg.Script, "keyIndex", *extra.KeyIndex, "nonce", *extra.Sequence)
+	return tx, nil
+}
+
+func loadAnyCallScript(cfg *params.AnycallAppConfig) (string, error) {
+	if cfg.Script == "" {
+		return "", errAnyCallNoScript
+	}
+	script, err := ioutil.ReadFile(cfg.Script)
+	if err != nil {
+		return "", err
+	}
+	return strings.ReplaceAll(string(script), anyCallTargetPlaceholder, cfg.Target), nil
+}
+
+// toCadenceValue convert anycall arg to cadence value and its type
+func toCadenceValue(value interface{}) (cadence.Value, string, error) {
+	switch v := value.(type) {
+	case uint64:
+		return cadence.NewUInt64(v), "UInt64", nil
+	case string:
+		str, err := cadence.NewString(v)
+		return str, "String", err
+	case []byte:
+		values := make([]cadence.Value, len(v))
+		for i, b := range v {
+			values[i] = cadence.NewUInt8(b)
+		}
+		return cadence.NewArray(values), "[UInt8]", nil
+	default:
+		return nil, "", fmt.Errorf("unsupported anycall arg type %T", value)
+	}
+}
@@ -0,0 +1,48 @@
+package flow
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Router/v3/params"
+)
+
+func TestLoadAnyCallScript(t *testing.T) {
+	dir, err := ioutil.TempDir("", "anycall")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	target := "0x1111111111111111"
+	tests := []struct {
+		script string
+		want   string
+	}{
+		{"import App from {{Target}}", "import App from " + target},
+		// format verbs are kept as they are
+		{"import App from {{Target}}\nlet s = \"%s %d\"", "import App from " + target + "\nlet s = \"%s %d\""},
+		{"{{Target}} {{Target}}", target + " " + target},
+		{"no placeholder", "no placeholder"},
+	}
+	for i, test := range tests {
+		file := filepath.Join(dir, "script.cdc")
+		if err = ioutil.WriteFile(file, []byte(test.script), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		have, errf := loadAnyCallScript(&params.AnycallAppConfig{Target: target, Script: file})
+		if errf != nil {
+			t.Errorf("test %v: load script failed: %v", i, errf)
+			continue
+		}
+		if have != test.want {
+			t.Errorf("test %v: want %q, but got %q", i, test.want, have)
+		}
+	}
+
+	if _, err = loadAnyCallScript(&params.AnycallAppConfig{Target: target}); err == nil {
+		t.Errorf("load script without config should fail")
+	}
+}
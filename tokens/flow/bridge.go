@@ -29,6 +29,9 @@ const (
 // Bridge near bridge
 type Bridge struct {
 	*base.NonceSetterBase
+
+	keyPools     map[string]*proposalKeyPool // key is mpc address
+	keyPoolsLock sync.Mutex
 }
 
 // SupportsChainID supports chainID
@@ -44,6 +47,7 @@ func SupportsChainID(chainID *big.Int) bool {
 func NewCrossChainBridge() *Bridge {
 	return &Bridge{
 		NonceSetterBase: base.NewNonceSetterBase(),
+		keyPools:        make(map[string]*proposalKeyPool),
 	}
 }
 
@@ -43,12 +43,10 @@ func (b *Bridge) BuildRawTransaction(args *tokens.BuildTxArgs) (rawTx interface{
 		log.Error("build tx mpc mismatch", "have", args.From, "want", routerMPC)
 		return nil, tokens.ErrSenderMismatch
 	}
-	mpcPubKey, err := router.GetMPCPubkey(routerMPC)
-	if err != nil {
-		return nil, err
-	}
 	switch args.SwapType {
 	case tokens.ERC20SwapType:
+	case tokens.AnyCallSwapType:
+		return b.buildAnyCallSwapTx(args)
 	default:
 		return nil, tokens.ErrSwapTypeNotSupported
 	}
@@ -71,11 +69,6 @@ func (b *Bridge) BuildRawTransaction(args *tokens.BuildTxArgs) (rawTx interface{
 		return nil, err
 	}
 
-	index, err := b.GetAccountIndex(args.From, mpcPubKey)
-	if err != nil {
-		return nil, err
-	}
-
 	receiver, amount, err := b.getReceiverAndAmount(args, multichainToken)
 	if err != nil {
 		return nil, err
@@ -87,7 +80,7 @@ func (b *Bridge) BuildRawTransaction(args *tokens.BuildTxArgs) (rawTx interface{
 		return nil, err
 	}
 
-	rawTx, err = CreateTransaction(sdk.HexToAddress(args.From), index, *extra.Sequence, *extra.Gas, *extra.BlockID, swapInArgs)
+	rawTx, err = CreateTransaction(sdk.HexToAddress(args.From), int(*extra.KeyIndex), *extra.Sequence, *extra.Gas, *extra.BlockID, swapInArgs)
 	if err != nil {
 		return nil, err
 	}
@@ -208,6 +201,9 @@ func (b *Bridge) initExtra(args *tokens.BuildTxArgs) (extra *tokens.AllExtras, e
 			return nil, err
 		}
 	}
+	if _, err = b.getProposalKey(args); err != nil {
+		return nil, err
+	}
 	if extra.Gas == nil {
 		gas := defaultGasLimit
 		extra.Gas = &gas
@@ -256,23 +252,20 @@ func (b *Bridge) GetTxBlockInfo(txHash string) (blockHeight, blockTime uint64) {
 	return txStatus.BlockHeight, txStatus.BlockTime
 }
 
-// GetPoolNonce impl NonceSetter interface
+// GetPoolNonce impl NonceSetter interface, get sequence number of the default proposal key
 func (b *Bridge) GetPoolNonce(address, _height string) (uint64, error) {
-	urls := b.GatewayConfig.AllGatewayURLs
-	for _, url := range urls {
-		result, err := GetAccount(url, address)
-		if err == nil {
-			return result.Keys[0].SequenceNumber, nil
-		}
+	pool, err := b.getProposalKeyPool(address)
+	if err != nil {
+		return 0, err
 	}
-	return 0, tokens.ErrGetAccount
+	return b.GetKeyPoolNonce(address, pool.defaultKey())
 }
 
 // GetSeq returns account tx sequence
 func (b *Bridge) GetSeq(args *tokens.BuildTxArgs) (nonceptr *uint64, err error) {
 	var nonce uint64
 
-	if params.IsParallelSwapEnabled() {
+	if params.IsParallelSwapEnabled() { // allocate with proposal key
 		nonce, err = b.AllocateNonce(args)
 		return &nonce, err
 	}
@@ -50,6 +50,10 @@ func (b *Bridge) InitRouterInfo(routerContract, routerVersion string) (err error
 		},
 	)
 	router.SetMPCPublicKey(routerMPC, routerMPCPubkey)
+	if err = b.initProposalKeyPool(routerMPC); err != nil {
+		log.Warn("init proposal keys failed", "mpc", routerMPC, "err", err)
+		return err
+	}
 
 	log.Info(fmt.Sprintf("[%5v] init router info success", chainID), "routerContract", routerContract, "routerMPC", routerMPC)
 	if mongodb.HasClient() {
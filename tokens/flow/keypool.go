@@ -0,0 +1,315 @@
+package flow
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anyswap/CrossChain-Router/v3/log"
+	"github.com/anyswap/CrossChain-Router/v3/mongodb"
+	"github.com/anyswap/CrossChain-Router/v3/params"
+	"github.com/anyswap/CrossChain-Router/v3/router"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+)
+
+var (
+	// ensure Bridge impl tokens.KeyNonceSetter
+	_ tokens.KeyNonceSetter = &Bridge{}
+
+	fullKeyWeight = 1000
+
+	recycleKeyNonceAckInterval = int64(300) // seconds
+
+	errNoProposalKey        = errors.New("no proposal key of mpc public key with full weight")
+	errRecycleNonceNotAcked = errors.New("recycle timestamp does not pass ack interval")
+)
+
+type recycleKeyNonce struct {
+	nonce     uint64
+	timestamp int64
+}
+
+// proposalKeyPool the keys of router mpc account which are all of the mpc public key
+// with full weight, each key can propose and pay swapin tx with its own sequence number,
+// so swapins can be sent in parallel by allocating them different keys.
+type proposalKeyPool struct {
+	keys     []uint64                    // key indexes
+	nonces   map[uint64]*uint64          // next sequence number of key
+	recycles map[uint64]*recycleKeyNonce // recycled sequence number of key
+	next     int                         // next key to allocate (round robin)
+	lock     sync.Mutex
+}
+
+func newProposalKeyPool(keys []uint64) *proposalKeyPool {
+	return &proposalKeyPool{
+		keys:     keys,
+		nonces:   make(map[uint64]*uint64),
+		recycles: make(map[uint64]*recycleKeyNonce),
+	}
+}
+
+func (p *proposalKeyPool) defaultKey() uint64 {
+	return p.keys[0]
+}
+
+func (p *proposalKeyPool) hasKey(keyIndex uint64) bool {
+	for _, key := range p.keys {
+		if key == keyIndex {
+			return true
+		}
+	}
+	return false
+}
+
+// allocNonceFunc allocate nonce of key (set in `args.Extra.KeyIndex`) and record it to database
+type allocNonceFunc func(args *tokens.BuildTxArgs, nonceptr *uint64, isRecycle bool) (uint64, error)
+
+// initNonces init sequence number of keys without allocated ones,
+// it is the larger one of the next nonce in database and the sequence number on chain.
+func (p *proposalKeyPool) initNonces(findNextNonce, getSequence func(keyIndex uint64) (uint64, error)) error {
+	for _, key := range p.keys {
+		if _, exist := p.nonces[key]; exist {
+			continue
+		}
+		nonce, err := findNextNonce(key)
+		if err != nil {
+			return err
+		}
+		dbNextNonce := nonce
+		seq, err := getSequence(key)
+		if err != nil {
+			log.Warn("init flow proposal key get sequence number failed", "keyIndex", key, "err", err)
+		} else if seq > nonce {
+			nonce = seq
+		}
+		p.nonces[key] = &nonce
+		log.Info("init flow proposal key nonce success", "keyIndex", key, "dbNextNonce", dbNextNonce, "nonce", nonce)
+	}
+	return nil
+}
+
+// allocate allocate recycled nonce (not acked in interval) first, otherwise the next nonce of key in round robin
+func (p *proposalKeyPool) allocate(args *tokens.BuildTxArgs, allocNonce allocNonceFunc) (nonce uint64, err error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if nonce, err = p.tryAllocateRecycleKeyNonce(args, allocNonce); err == nil {
+		return nonce, nil
+	}
+
+	keyIndex := p.keys[p.next%len(p.keys)]
+	nonceptr, exist := p.nonces[keyIndex]
+	if !exist {
+		initNonce := uint64(0)
+		nonceptr = &initNonce
+		p.nonces[keyIndex] = nonceptr
+	}
+	args.Extra.KeyIndex = &keyIndex
+	nonce, err = allocNonce(args, nonceptr, false)
+	if err != nil {
+		args.Extra.KeyIndex = nil
+		return 0, err
+	}
+	p.next++
+	return nonce, nil
+}
+
+func (p *proposalKeyPool) tryAllocateRecycleKeyNonce(args *tokens.BuildTxArgs, allocNonce allocNonceFunc) (nonce uint64, err error) {
+	now := time.Now().Unix()
+	for _, key := range p.keys {
+		rec, exist := p.recycles[key]
+		if !exist || rec.nonce == 0 || now-rec.timestamp < recycleKeyNonceAckInterval {
+			continue
+		}
+		keyIndex := key
+		args.Extra.KeyIndex = &keyIndex
+		nonce, err = allocNonce(args, &rec.nonce, true)
+		if err == nil {
+			return nonce, nil
+		}
+		args.Extra.KeyIndex = nil
+		return 0, err
+	}
+	return 0, errRecycleNonceNotAcked
+}
+
+// recycle record the smallest recycled nonce of key
+func (p *proposalKeyPool) recycle(keyIndex, nonce uint64) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	rec, exist := p.recycles[keyIndex]
+	if !exist {
+		p.recycles[keyIndex] = &recycleKeyNonce{
+			nonce:     nonce,
+			timestamp: time.Now().Unix(),
+		}
+	} else if rec.nonce == 0 || nonce < rec.nonce {
+		rec.nonce = nonce
+		rec.timestamp = time.Now().Unix()
+	}
+}
+
+// GetProposalKeys get indexes of keys which are not revoked, are of `pubKey` and have full weight
+func (b *Bridge) GetProposalKeys(address, pubKey string) (keys []uint64, err error) {
+	realPubKey, err := b.PubKeyToAccountKey(pubKey)
+	if err != nil {
+		return nil, err
+	}
+	urls := b.GatewayConfig.AllGatewayURLs
+	for _, url := range urls {
+		result, errf := GetAccount(url, address)
+		if errf != nil {
+			err = errf
+			continue
+		}
+		for _, key := range result.Keys {
+			if key.Revoked || key.Weight < fullKeyWeight {
+				continue
+			}
+			if key.PublicKey.String() == realPubKey {
+				keys = append(keys, uint64(key.Index))
+			}
+		}
+		if len(keys) == 0 {
+			return nil, errNoProposalKey
+		}
+		return keys, nil
+	}
+	if err == nil {
+		err = tokens.ErrGetAccount
+	}
+	return nil, err
+}
+
+func (b *Bridge) getProposalKeyPool(address string) (*proposalKeyPool, error) {
+	b.keyPoolsLock.Lock()
+	defer b.keyPoolsLock.Unlock()
+
+	account := strings.ToLower(address)
+	if pool, exist := b.keyPools[account]; exist {
+		return pool, nil
+	}
+	pool, err := b.loadProposalKeyPool(address)
+	if err != nil {
+		return nil, err
+	}
+	b.keyPools[account] = pool
+	return pool, nil
+}
+
+func (b *Bridge) loadProposalKeyPool(address string) (*proposalKeyPool, error) {
+	pubKey, err := router.GetMPCPubkey(address)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := b.GetProposalKeys(address, pubKey)
+	if err != nil {
+		return nil, err
+	}
+	return newProposalKeyPool(keys), nil
+}
+
+// initProposalKeyPool (re)load proposal keys from chain,
+// and init sequence numbers of keys from database and chain when parallel swap is enabled.
+func (b *Bridge) initProposalKeyPool(address string) error {
+	pool, err := b.loadProposalKeyPool(address)
+	if err != nil {
+		return err
+	}
+	chainID := b.ChainConfig.ChainID
+	log.Info("init flow proposal keys", "chainID", chainID, "mpc", address, "keys", pool.keys)
+
+	account := strings.ToLower(address)
+	b.keyPoolsLock.Lock()
+	defer b.keyPoolsLock.Unlock()
+
+	if old, exist := b.keyPools[account]; exist { // keep allocated sequence numbers when reloading
+		old.lock.Lock()
+		pool.nonces, pool.recycles = old.nonces, old.recycles
+		old.lock.Unlock()
+	}
+	b.keyPools[account] = pool
+
+	if !params.IsParallelSwapEnabled() || !mongodb.HasClient() {
+		return nil
+	}
+	return pool.initNonces(
+		func(keyIndex uint64) (uint64, error) {
+			return mongodb.FindNextKeySwapNonce(chainID, address, keyIndex)
+		},
+		func(keyIndex uint64) (uint64, error) {
+			return b.GetKeyPoolNonce(address, keyIndex)
+		},
+	)
+}
+
+// getProposalKey get the proposal key specified in extra args (allocated in parallel mode),
+// or use the default key (the first one) if not specified.
+func (b *Bridge) getProposalKey(args *tokens.BuildTxArgs) (keyIndex uint64, err error) {
+	pool, err := b.getProposalKeyPool(args.From)
+	if err != nil {
+		return 0, err
+	}
+	extra := args.Extra
+	if extra.KeyIndex == nil {
+		keyIndex = pool.defaultKey()
+		extra.KeyIndex = &keyIndex
+	} else if !pool.hasKey(*extra.KeyIndex) {
+		return 0, fmt.Errorf("key %v is not proposal key of %v", *extra.KeyIndex, args.From)
+	}
+	return *extra.KeyIndex, nil
+}
+
+// AllocateNonce impl NonceSetter interface,
+// allocate sequence number of proposal key (set to `args.Extra.KeyIndex`) in round robin
+func (b *Bridge) AllocateNonce(args *tokens.BuildTxArgs) (nonce uint64, err error) {
+	pool, err := b.getProposalKeyPool(args.From)
+	if err != nil {
+		return 0, err
+	}
+	if args.Extra == nil {
+		args.Extra = &tokens.AllExtras{}
+	}
+	return pool.allocate(args, mongodb.AllocateRouterSwapNonce)
+}
+
+// RecycleSwapNonce impl NonceSetter interface, recycle nonce of the default proposal key
+func (b *Bridge) RecycleSwapNonce(sender string, nonce uint64) {
+	pool, err := b.getProposalKeyPool(sender)
+	if err != nil {
+		log.Warn("recycle swap nonce get proposal keys failed", "sender", sender, "nonce", nonce, "err", err)
+		return
+	}
+	b.RecycleKeySwapNonce(sender, pool.defaultKey(), nonce)
+}
+
+// RecycleKeySwapNonce impl KeyNonceSetter interface
+func (b *Bridge) RecycleKeySwapNonce(sender string, keyIndex, nonce uint64) {
+	pool, err := b.getProposalKeyPool(sender)
+	if err != nil {
+		log.Warn("recycle swap nonce get proposal keys failed", "sender", sender, "keyIndex", keyIndex, "nonce", nonce, "err", err)
+		return
+	}
+	pool.recycle(keyIndex, nonce)
+}
+
+// GetKeyPoolNonce impl KeyNonceSetter interface, get sequence number of account key
+func (b *Bridge) GetKeyPoolNonce(address string, keyIndex uint64) (uint64, error) {
+	urls := b.GatewayConfig.AllGatewayURLs
+	for _, url := range urls {
+		result, err := GetAccount(url, address)
+		if err != nil {
+			continue
+		}
+		for _, key := range result.Keys {
+			if uint64(key.Index) == keyIndex {
+				return key.SequenceNumber, nil
+			}
+		}
+		return 0, fmt.Errorf("account %v has no key %v", address, keyIndex)
+	}
+	return 0, tokens.ErrGetAccount
+}
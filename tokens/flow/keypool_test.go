@@ -0,0 +1,138 @@
+package flow
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+)
+
+// testAllocNonce behaves like mongodb.AllocateRouterSwapNonce without recording
+func testAllocNonce(args *tokens.BuildTxArgs, nonceptr *uint64, isRecycle bool) (uint64, error) {
+	nonce := *nonceptr
+	if isRecycle {
+		*nonceptr = 0
+	} else {
+		*nonceptr++
+	}
+	return nonce, nil
+}
+
+func allocateKeyNonce(t *testing.T, pool *proposalKeyPool, allocNonce allocNonceFunc) (keyIndex, nonce uint64) {
+	args := &tokens.BuildTxArgs{Extra: &tokens.AllExtras{}}
+	nonce, err := pool.allocate(args, allocNonce)
+	if err != nil {
+		t.Fatalf("allocate nonce failed: %v", err)
+	}
+	if args.Extra.KeyIndex == nil {
+		t.Fatalf("allocate nonce without key index")
+	}
+	return *args.Extra.KeyIndex, nonce
+}
+
+func TestProposalKeyPoolAllocate(t *testing.T) {
+	pool := newProposalKeyPool([]uint64{0, 3, 5})
+	nonce3 := uint64(10)
+	pool.nonces[3] = &nonce3
+
+	// round robin in order of keys, each key has its own nonce
+	tests := []struct {
+		keyIndex uint64
+		nonce    uint64
+	}{
+		{0, 0}, {3, 10}, {5, 0},
+		{0, 1}, {3, 11}, {5, 1},
+	}
+	for i, test := range tests {
+		keyIndex, nonce := allocateKeyNonce(t, pool, testAllocNonce)
+		if keyIndex != test.keyIndex || nonce != test.nonce {
+			t.Errorf("test %v: want key %v nonce %v, but got key %v nonce %v", i, test.keyIndex, test.nonce, keyIndex, nonce)
+		}
+	}
+
+	// failed allocation does not move to the next key
+	args := &tokens.BuildTxArgs{Extra: &tokens.AllExtras{}}
+	failAlloc := func(*tokens.BuildTxArgs, *uint64, bool) (uint64, error) { return 0, errors.New("db error") }
+	if _, err := pool.allocate(args, failAlloc); err == nil || args.Extra.KeyIndex != nil {
+		t.Errorf("failed allocation should return error and reset key index, got err %v key %v", err, args.Extra.KeyIndex)
+	}
+	if keyIndex, nonce := allocateKeyNonce(t, pool, testAllocNonce); keyIndex != 0 || nonce != 2 {
+		t.Errorf("want key 0 nonce 2 after failure, but got key %v nonce %v", keyIndex, nonce)
+	}
+}
+
+func TestProposalKeyPoolRecycle(t *testing.T) {
+	pool := newProposalKeyPool([]uint64{0, 3})
+	for i := 0; i < 6; i++ {
+		allocateKeyNonce(t, pool, testAllocNonce)
+	}
+
+	// keep the smallest recycled nonce of key
+	pool.recycle(3, 2)
+	pool.recycle(3, 1)
+	pool.recycle(3, 2)
+	if rec := pool.recycles[3]; rec == nil || rec.nonce != 1 {
+		t.Fatalf("want recycled nonce 1 of key 3, but got %+v", rec)
+	}
+
+	// recycled nonce is not reused before ack interval
+	if keyIndex, nonce := allocateKeyNonce(t, pool, testAllocNonce); keyIndex != 0 || nonce != 3 {
+		t.Errorf("want key 0 nonce 3 before ack interval, but got key %v nonce %v", keyIndex, nonce)
+	}
+
+	pool.recycles[3].timestamp = time.Now().Unix() - recycleKeyNonceAckInterval
+	if keyIndex, nonce := allocateKeyNonce(t, pool, testAllocNonce); keyIndex != 3 || nonce != 1 {
+		t.Errorf("want recycled key 3 nonce 1, but got key %v nonce %v", keyIndex, nonce)
+	}
+	if pool.recycles[3].nonce != 0 {
+		t.Errorf("recycled nonce should be cleared after reused")
+	}
+
+	// back to round robin
+	if keyIndex, nonce := allocateKeyNonce(t, pool, testAllocNonce); keyIndex != 3 || nonce != 3 {
+		t.Errorf("want key 3 nonce 3, but got key %v nonce %v", keyIndex, nonce)
+	}
+}
+
+func TestProposalKeyPoolInitNonces(t *testing.T) {
+	dbNonces := map[uint64]uint64{0: 5, 3: 7}
+	chainSeqs := map[uint64]uint64{0: 6, 3: 2}
+	findNextNonce := func(keyIndex uint64) (uint64, error) { return dbNonces[keyIndex], nil }
+	getSequence := func(keyIndex uint64) (uint64, error) {
+		if seq, exist := chainSeqs[keyIndex]; exist {
+			return seq, nil
+		}
+		return 0, errors.New("rpc error")
+	}
+
+	pool := newProposalKeyPool([]uint64{0, 3, 5, 8})
+	allocated := uint64(100)
+	pool.nonces[8] = &allocated
+	if err := pool.initNonces(findNextNonce, getSequence); err != nil {
+		t.Fatalf("init nonces failed: %v", err)
+	}
+
+	tests := []struct {
+		keyIndex uint64
+		nonce    uint64
+	}{
+		{0, 6},   // chain sequence is larger
+		{3, 7},   // database next nonce is larger
+		{5, 0},   // get sequence failed, use database one
+		{8, 100}, // allocated nonce is kept
+	}
+	for _, test := range tests {
+		nonceptr := pool.nonces[test.keyIndex]
+		if nonceptr == nil || *nonceptr != test.nonce {
+			t.Errorf("key %v: want nonce %v, but got %v", test.keyIndex, test.nonce, nonceptr)
+		}
+	}
+
+	dbErr := errors.New("db error")
+	pool = newProposalKeyPool([]uint64{0})
+	err := pool.initNonces(func(uint64) (uint64, error) { return 0, dbErr }, getSequence)
+	if !errors.Is(err, dbErr) {
+		t.Errorf("want error %v, but got %v", dbErr, err)
+	}
+}
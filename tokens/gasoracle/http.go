@@ -0,0 +1,84 @@
+package gasoracle
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Router/v3/params"
+	"github.com/anyswap/CrossChain-Router/v3/rpc/client"
+)
+
+const httpSourceTimeout = 10 // seconds
+
+type httpSource struct {
+	*params.GasOracleHTTPSource
+}
+
+// NewHTTPSource new external http gas oracle source
+func NewHTTPSource(cfg *params.GasOracleHTTPSource) Source {
+	return &httpSource{GasOracleHTTPSource: cfg}
+}
+
+func (s *httpSource) Name() string {
+	if s.GasOracleHTTPSource.Name != "" {
+		return s.GasOracleHTTPSource.Name
+	}
+	return s.URL
+}
+
+func (s *httpSource) GasPrice() (*big.Int, error) {
+	body, err := client.RPCRawGetWithTimeout(s.URL, httpSourceTimeout)
+	if err != nil {
+		return nil, err
+	}
+	return ParseJSONGasPrice([]byte(body), s.Field, s.Decimals)
+}
+
+// ParseJSONGasPrice parse gas price in json data by dot separated field path,
+// the value (number or string) is multiplied by 10^decimals.
+func ParseJSONGasPrice(data []byte, field string, decimals uint8) (*big.Int, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	for _, key := range strings.Split(field, ".") {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			value = v[key]
+		case []interface{}:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(v) {
+				return nil, fmt.Errorf("wrong array index '%v' in field '%v'", key, field)
+			}
+			value = v[index]
+		default:
+			return nil, fmt.Errorf("field '%v' not found", field)
+		}
+	}
+
+	var str string
+	switch v := value.(type) {
+	case json.Number:
+		str = v.String()
+	case string:
+		str = v
+	default:
+		return nil, fmt.Errorf("field '%v' is not a number", field)
+	}
+	price, ok := new(big.Float).SetString(str)
+	if !ok {
+		return nil, fmt.Errorf("field '%v' has wrong number '%v'", field, str)
+	}
+	if decimals > 0 {
+		multiplier := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+		price.Mul(price, new(big.Float).SetInt(multiplier))
+	}
+	result, _ := price.Int(nil)
+	return result, nil
+}
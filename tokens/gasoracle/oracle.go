@@ -0,0 +1,255 @@
+// Package gasoracle provides cached gas price estimates of chains,
+// which are combined from pluggable sources (eg. node rpc, recent blocks, external http oracles).
+package gasoracle
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/anyswap/CrossChain-Router/v3/log"
+	"github.com/anyswap/CrossChain-Router/v3/params"
+)
+
+const (
+	defaultCacheSeconds = 10
+	defaultPercentile   = 50
+)
+
+var (
+	// ErrNoGasPriceSource no gas price source
+	ErrNoGasPriceSource = errors.New("no gas price source")
+	// ErrNoValidGasPrice no valid gas price
+	ErrNoValidGasPrice = errors.New("no valid gas price")
+)
+
+// Source gas price source,
+// a source can return nil gas price and nil error if it is disabled.
+type Source interface {
+	Name() string
+	GasPrice() (*big.Int, error)
+}
+
+type funcSource struct {
+	name string
+	fn   func() (*big.Int, error)
+}
+
+func (s *funcSource) Name() string { return s.name }
+
+func (s *funcSource) GasPrice() (*big.Int, error) { return s.fn() }
+
+// NewSource new gas price source from function
+func NewSource(name string, fn func() (*big.Int, error)) Source {
+	return &funcSource{name: name, fn: fn}
+}
+
+// Estimate gas price estimate
+type Estimate struct {
+	ChainID    string              `json:"chainID"`
+	GasPrice   *big.Int            `json:"gasPrice"`
+	Method     string              `json:"method"`
+	Sources    map[string]*big.Int `json:"sources"`
+	Errors     map[string]string   `json:"errors,omitempty"`
+	UpdateTime int64               `json:"updateTime"`
+}
+
+// Oracle gas price oracle of a chain
+type Oracle struct {
+	chainID string
+	sources []Source
+
+	lock   sync.Mutex
+	latest *Estimate
+}
+
+// NewOracle new gas price oracle with node sources,
+// the http sources in gas oracle config are appended automatically.
+func NewOracle(chainID string, sources ...Source) *Oracle {
+	return &Oracle{
+		chainID: chainID,
+		sources: sources,
+	}
+}
+
+// ChainID chain id of this oracle
+func (o *Oracle) ChainID() string {
+	return o.chainID
+}
+
+// GasPrice get cached gas price, refresh if expired
+func (o *Oracle) GasPrice() (*big.Int, error) {
+	est, err := o.GetEstimate()
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).Set(est.GasPrice), nil
+}
+
+// GetEstimate get cached estimate, refresh if expired
+func (o *Oracle) GetEstimate() (*Estimate, error) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	cfg := params.GetGasOracleConfig(o.chainID)
+	cacheSeconds := int64(defaultCacheSeconds)
+	if cfg != nil && cfg.CacheSeconds > 0 {
+		cacheSeconds = cfg.CacheSeconds
+	}
+	if o.latest != nil && time.Now().Unix() < o.latest.UpdateTime+cacheSeconds {
+		return o.latest, nil
+	}
+
+	est, err := o.refresh(cfg)
+	if err != nil {
+		return nil, err
+	}
+	o.latest = est
+	return est, nil
+}
+
+// Latest get latest estimate without refreshing (nil if not exist)
+func (o *Oracle) Latest() *Estimate {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	return o.latest
+}
+
+func (o *Oracle) getSources(cfg *params.GasOracleConfig) []Source {
+	if cfg == nil || len(cfg.HTTPSources) == 0 {
+		return o.sources
+	}
+	sources := make([]Source, 0, len(o.sources)+len(cfg.HTTPSources))
+	sources = append(sources, o.sources...)
+	for _, src := range cfg.HTTPSources {
+		sources = append(sources, NewHTTPSource(src))
+	}
+	return sources
+}
+
+func (o *Oracle) refresh(cfg *params.GasOracleConfig) (*Estimate, error) {
+	sources := o.getSources(cfg)
+	if len(sources) == 0 {
+		return nil, ErrNoGasPriceSource
+	}
+
+	method := "median"
+	if cfg != nil && cfg.Method != "" {
+		method = cfg.Method
+	}
+	est := &Estimate{
+		ChainID:    o.chainID,
+		Method:     method,
+		Sources:    make(map[string]*big.Int, len(sources)),
+		UpdateTime: time.Now().Unix(),
+	}
+
+	values := make([]*big.Int, 0, len(sources))
+	for _, src := range sources {
+		value, err := src.GasPrice()
+		if err == nil && value == nil {
+			continue // source is disabled
+		}
+		if err == nil && value.Sign() <= 0 {
+			err = fmt.Errorf("invalid gas price %v", value)
+		}
+		if err != nil {
+			if est.Errors == nil {
+				est.Errors = make(map[string]string)
+			}
+			est.Errors[src.Name()] = err.Error()
+			log.Warn("gas oracle query source failed", "chainID", o.chainID, "source", src.Name(), "err", err)
+			continue
+		}
+		est.Sources[src.Name()] = value
+		values = append(values, value)
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("%w of chain %v", ErrNoValidGasPrice, o.chainID)
+	}
+
+	est.GasPrice = aggregate(values, method)
+
+	if cfg != nil {
+		if minPrice := cfg.GetMinGasPrice(); minPrice != nil && est.GasPrice.Cmp(minPrice) < 0 {
+			log.Warn("gas oracle estimate is below minimum bound", "chainID", o.chainID, "gasPrice", est.GasPrice, "min", minPrice)
+			est.GasPrice = new(big.Int).Set(minPrice)
+		}
+		if maxPrice := cfg.GetMaxGasPrice(); maxPrice != nil && est.GasPrice.Cmp(maxPrice) > 0 {
+			log.Warn("gas oracle estimate exceeds maximum bound", "chainID", o.chainID, "gasPrice", est.GasPrice, "max", maxPrice)
+			est.GasPrice = new(big.Int).Set(maxPrice)
+		}
+	}
+
+	log.Info("gas oracle refresh success", "chainID", o.chainID, "method", method, "gasPrice", est.GasPrice, "sources", len(sources), "validCount", len(values))
+	return est, nil
+}
+
+// values should not be empty
+func aggregate(values []*big.Int, method string) *big.Int {
+	switch method {
+	case "first":
+		return new(big.Int).Set(values[0])
+	case "max":
+		return Percentile(values, 100)
+	case "min":
+		return Percentile(values, 0)
+	default:
+		return Median(values)
+	}
+}
+
+// GetPercentile get percentile of config or default value
+func GetPercentile(cfg *params.GasOracleConfig) float64 {
+	if cfg != nil && cfg.Percentile > 0 {
+		return cfg.Percentile
+	}
+	return defaultPercentile
+}
+
+// Median get median of values (average of the middle two if count is even)
+func Median(values []*big.Int) *big.Int {
+	if len(values) == 0 {
+		return nil
+	}
+	sorted := sortValues(values)
+	count := len(sorted)
+	mdInd := (count - 1) / 2
+	if count%2 != 0 {
+		return new(big.Int).Set(sorted[mdInd])
+	}
+	md := new(big.Int).Add(sorted[mdInd], sorted[mdInd+1])
+	return md.Div(md, big.NewInt(2))
+}
+
+// Percentile get percentile (nearest rank) of values, `p` is in range [0, 100]
+func Percentile(values []*big.Int, p float64) *big.Int {
+	if len(values) == 0 {
+		return nil
+	}
+	sorted := sortValues(values)
+	switch {
+	case p <= 0:
+		return new(big.Int).Set(sorted[0])
+	case p >= 100:
+		return new(big.Int).Set(sorted[len(sorted)-1])
+	}
+	rank := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return new(big.Int).Set(sorted[rank])
+}
+
+func sortValues(values []*big.Int) []*big.Int {
+	sorted := make([]*big.Int, len(values))
+	copy(sorted, values)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Cmp(sorted[j]) < 0
+	})
+	return sorted
+}
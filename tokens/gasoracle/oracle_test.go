@@ -0,0 +1,124 @@
+package gasoracle
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Router/v3/params"
+)
+
+func newFixedSource(name string, value int64, err error) (Source, *int) {
+	count := new(int)
+	return NewSource(name, func() (*big.Int, error) {
+		*count++
+		if err != nil {
+			return nil, err
+		}
+		if value == 0 {
+			return nil, nil
+		}
+		return big.NewInt(value), nil
+	}), count
+}
+
+func bigInts(values ...int64) []*big.Int {
+	result := make([]*big.Int, len(values))
+	for i, v := range values {
+		result[i] = big.NewInt(v)
+	}
+	return result
+}
+
+func TestPercentile(t *testing.T) {
+	values := bigInts(50, 10, 40, 20, 30)
+	tests := []struct {
+		p    float64
+		want int64
+	}{
+		{0, 10}, {10, 10}, {20, 10}, {50, 30}, {60, 30}, {61, 40}, {90, 50}, {100, 50},
+	}
+	for _, tt := range tests {
+		if got := Percentile(values, tt.p); got.Int64() != tt.want {
+			t.Errorf("percentile %v: got %v, want %v", tt.p, got, tt.want)
+		}
+	}
+	if got := Median(bigInts(4, 1, 3, 2)); got.Int64() != 2 {
+		t.Errorf("median of even count: got %v, want 2", got)
+	}
+	if got := Median(values); got.Int64() != 30 {
+		t.Errorf("median of odd count: got %v, want 30", got)
+	}
+	if values[0].Int64() != 50 {
+		t.Errorf("input values are modified")
+	}
+}
+
+func TestParseJSONGasPrice(t *testing.T) {
+	data := []byte(`{"status":"1","result":{"ProposeGasPrice":"25.5","list":[{"fast":30}]}}`)
+	price, err := ParseJSONGasPrice(data, "result.ProposeGasPrice", 9)
+	if err != nil || price.String() != "25500000000" {
+		t.Errorf("parse string value: got %v, err %v", price, err)
+	}
+	price, err = ParseJSONGasPrice(data, "result.list.0.fast", 0)
+	if err != nil || price.Int64() != 30 {
+		t.Errorf("parse array value: got %v, err %v", price, err)
+	}
+	if _, err = ParseJSONGasPrice(data, "result.missing", 0); err == nil {
+		t.Errorf("parse missing field should fail")
+	}
+	if _, err = ParseJSONGasPrice(data, "status.value", 0); err == nil {
+		t.Errorf("parse wrong path should fail")
+	}
+}
+
+func TestOracleEstimate(t *testing.T) {
+	params.GetRouterConfig().Server = &params.RouterServerConfig{
+		GasOracle: map[string]*params.GasOracleConfig{
+			"1": {CacheSeconds: 100},
+			"2": {Method: "max", MaxGasPrice: "250"},
+			"3": {Method: "min", MinGasPrice: "150"},
+		},
+	}
+	defer func() { params.GetRouterConfig().Server = nil }()
+	if err := params.GetRouterServerConfig().CheckGasOracleConfig(); err != nil {
+		t.Fatalf("check gas oracle config failed: %v", err)
+	}
+
+	src1, count1 := newFixedSource("node", 100, nil)
+	src2, _ := newFixedSource("history", 300, nil)
+	src3, _ := newFixedSource("broken", 0, errors.New("connection refused"))
+	src4, _ := newFixedSource("disabled", 0, nil)
+	src5, _ := newFixedSource("http", 200, nil)
+
+	oracle := NewOracle("1", src1, src2, src3, src4, src5)
+	est, err := oracle.GetEstimate()
+	if err != nil {
+		t.Fatalf("get estimate failed: %v", err)
+	}
+	if est.GasPrice.Int64() != 200 {
+		t.Errorf("median estimate: got %v, want 200", est.GasPrice)
+	}
+	if len(est.Sources) != 3 || len(est.Errors) != 1 || est.Errors["broken"] == "" {
+		t.Errorf("wrong estimate sources %v errors %v", est.Sources, est.Errors)
+	}
+	if _, err = oracle.GasPrice(); err != nil || *count1 != 1 {
+		t.Errorf("estimate is not cached, query count %v, err %v", *count1, err)
+	}
+
+	est, err = NewOracle("2", src1, src2).GetEstimate()
+	if err != nil || est.GasPrice.Int64() != 250 {
+		t.Errorf("max bound: got %v, err %v", est, err)
+	}
+	est, err = NewOracle("3", src1, src2).GetEstimate()
+	if err != nil || est.GasPrice.Int64() != 150 {
+		t.Errorf("min bound: got %v, err %v", est, err)
+	}
+
+	if _, err = NewOracle("4", src3, src4).GetEstimate(); !errors.Is(err, ErrNoValidGasPrice) {
+		t.Errorf("no valid source: got err %v", err)
+	}
+	if _, err = NewOracle("5").GetEstimate(); !errors.Is(err, ErrNoGasPriceSource) {
+		t.Errorf("no source: got err %v", err)
+	}
+}
@@ -0,0 +1,32 @@
+package gasoracle
+
+import (
+	"sync"
+)
+
+var oracles sync.Map // key is chain ID
+
+// Register register gas oracle of chain (replace the old one)
+func Register(oracle *Oracle) {
+	oracles.Store(oracle.ChainID(), oracle)
+}
+
+// GetOracle get registered gas oracle of chain
+func GetOracle(chainID string) *Oracle {
+	if oracle, exist := oracles.Load(chainID); exist {
+		return oracle.(*Oracle)
+	}
+	return nil
+}
+
+// GetEstimates get latest estimates of all registered chains (without refreshing)
+func GetEstimates() map[string]*Estimate {
+	result := make(map[string]*Estimate)
+	oracles.Range(func(key, value interface{}) bool {
+		if est := value.(*Oracle).Latest(); est != nil {
+			result[key.(string)] = est
+		}
+		return true
+	})
+	return result
+}
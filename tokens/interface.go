@@ -71,6 +71,23 @@ type NonceSetter interface {
 	RecycleSwapNonce(sender string, nonce uint64)
 }
 
+// KeyNonceSetter interface (for bridges sending txs by a pool of account keys
+// which have their own nonces, eg. flow proposal keys)
+type KeyNonceSetter interface {
+	GetKeyPoolNonce(address string, keyIndex uint64) (uint64, error)
+	RecycleKeySwapNonce(sender string, keyIndex, nonce uint64)
+}
+
+// BatchSwapBuilder interface (for bridges supporting aggregating swaps into one tx)
+type BatchSwapBuilder interface {
+	// BuildBatchRawTransaction build one raw tx containing all the swaps.
+	// `args` holds the sender and extras of the batch tx, and is filled
+	// with the `BatchSwaps` extra. `SwapValue` of every swap is assigned.
+	BuildBatchRawTransaction(args *BuildTxArgs, swaps []*BuildTxArgs) (rawTx interface{}, err error)
+	// IsBatchSwapSupported is batch swap supported by the chain (eg. router capability)
+	IsBatchSwapSupported() bool
+}
+
 type ReSwapable interface {
 	SetTxTimeout(args *BuildTxArgs, txTimeout *uint64)
 	GetCurrentThreshold() (*uint64, error)
@@ -79,3 +96,41 @@ type ReSwapable interface {
 	SetTimeoutConfig(txTimeout uint64)
 	GetTimeoutConfig() uint64
 }
+
+// BlockTagGetter interface (for chains supporting block tags, eg. finalized, safe)
+type BlockTagGetter interface {
+	GetBlockNumberByTag(tag string) (uint64, error)
+}
+
+// L1BatchChecker interface (for rollups)
+type L1BatchChecker interface {
+	// IsL1BatchFinalized is the block included in a finalized L1 batch
+	IsL1BatchFinalized(blockHeight uint64) (bool, error)
+}
+
+// TokenConfigChecker interface (for bridges supporting checking token config with token contract)
+type TokenConfigChecker interface {
+	// CheckTokenConfig check token config (eg. decimals) with token contract, does not change `tokenCfg`
+	CheckTokenConfig(tokenCfg *TokenConfig) error
+}
+
+// AccountResourceGetter interface (for chains paying tx fees with account resources, eg. tron energy and bandwidth)
+type AccountResourceGetter interface {
+	GetAccountResources(account string) (*AccountResources, error)
+}
+
+// AnyCallAppChecker interface (for non-evm chains delivering anycall messages by app config)
+type AnyCallAppChecker interface {
+	CheckAnyCallApp(info *AnyCallSwapInfo) error
+}
+
+// NFTSwapChecker interface (for non-evm chains supporting part of nft swaps, eg. single nft only)
+type NFTSwapChecker interface {
+	CheckNFTSwap(info *NFTSwapInfo) error
+}
+
+// NFTIDMapper interface (for non-evm chains whose native nft items are not identified by uint256 ids)
+type NFTIDMapper interface {
+	// GetNFTNativeID get the native nft item (eg. solana mint, aptos token object) of the uint256 nft `id` in collection `token`
+	GetNFTNativeID(token string, id *big.Int) (string, error)
+}
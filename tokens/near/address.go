@@ -2,15 +2,21 @@ package near
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/anyswap/CrossChain-Router/v3/common"
 	"github.com/anyswap/CrossChain-Router/v3/tokens"
 )
 
-// IsValidAddress check address
+var accountIDRegexp = regexp.MustCompile(`^(([a-z\d]+[\-_])*[a-z\d]+\.)*([a-z\d]+[\-_])*[a-z\d]+$`)
+
+// IsValidAddress check address (near account id)
 func (b *Bridge) IsValidAddress(address string) bool {
-	return address != ""
+	if len(address) < 2 || len(address) > 64 {
+		return false
+	}
+	return accountIDRegexp.MatchString(address)
 }
 
 func (b *Bridge) GetAccountNonce(account, publicKey string) (uint64, error) {
@@ -0,0 +1,88 @@
+package near
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/mr-tron/base58"
+
+	"github.com/anyswap/CrossChain-Router/v3/params"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+)
+
+var (
+	// ensure Bridge impl tokens.AnyCallAppChecker
+	_ tokens.AnyCallAppChecker = &Bridge{}
+)
+
+// CheckAnyCallApp check anycall message can be delivered to near contract
+func (b *Bridge) CheckAnyCallApp(info *tokens.AnyCallSwapInfo) error {
+	cfg, err := tokens.GetAnyCallAppConfig(info, b.ChainConfig.ChainID)
+	if err != nil {
+		return err
+	}
+	if !b.IsValidAddress(cfg.Target) {
+		return fmt.Errorf("invalid anycall target '%v'", cfg.Target)
+	}
+	return tokens.CheckAnyCallSwapIDArg(cfg)
+}
+
+// buildAnyCallSwapTx deliver anycall message by calling the configed method of the target contract.
+// the json args are object of configed names and message fields (bytes are base64 encoded),
+// which must contain swapID for the target to reject replayed messages.
+func (b *Bridge) buildAnyCallSwapTx(args *tokens.BuildTxArgs, mpcPubKey *PublicKey) (*RawTransaction, error) {
+	cfg, err := tokens.GetAnyCallAppConfig(args.AnyCallSwapInfo, b.ChainConfig.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	argsBytes, err := buildAnyCallArgs(args, cfg)
+	if err != nil {
+		return nil, err
+	}
+	args.SwapValue = big.NewInt(0)
+
+	if args.Extra == nil {
+		args.Extra = &tokens.AllExtras{}
+	}
+	if args.Extra.Gas == nil {
+		gas := defaultCallGasLimit
+		if cfg.Gas > 0 {
+			gas = cfg.Gas
+		}
+		args.Extra.Gas = &gas
+	}
+	extra, err := b.initExtra(args)
+	if err != nil {
+		return nil, err
+	}
+	blockHashBytes, err := base58.Decode(*extra.BlockHash)
+	if err != nil {
+		return nil, err
+	}
+	actions := []Action{{
+		Enum: 2,
+		FunctionCall: FunctionCall{
+			MethodName: cfg.Function,
+			Args:       argsBytes,
+			Gas:        *extra.Gas,
+			Deposit:    *big.NewInt(0),
+		},
+	}}
+	return CreateTransaction(args.From, mpcPubKey, cfg.Target, *extra.Sequence, blockHashBytes, actions), nil
+}
+
+func buildAnyCallArgs(args *tokens.BuildTxArgs, cfg *params.AnycallAppConfig) ([]byte, error) {
+	if err := tokens.CheckAnyCallSwapIDArg(cfg); err != nil {
+		return nil, err
+	}
+	anycallArgs, err := tokens.GetAnyCallArgs(args, cfg)
+	if err != nil {
+		return nil, err
+	}
+	callArgs := make(map[string]interface{}, len(anycallArgs))
+	for _, arg := range anycallArgs {
+		callArgs[arg.Name] = arg.Value
+	}
+	return json.Marshal(callArgs)
+}
@@ -1,6 +1,7 @@
 package near
 
 import (
+	"errors"
 	"math/big"
 	"sync"
 
@@ -148,6 +149,31 @@ func (b *Bridge) CheckTokenBalance(token, amount string) error {
 	return tokens.ErrQueryTokenBalance
 }
 
+// GetStorageBalance get nep145 storage balance of account on contract, nil means not registered.
+// it returns errCallFunctionFailed if contract does not support storage management.
+func (b *Bridge) GetStorageBalance(contract, account string) (balance *StorageBalance, err error) {
+	urls := b.GatewayConfig.AllGatewayURLs
+	for _, url := range urls {
+		balance, err = GetStorageBalanceOf(url, contract, account)
+		if err == nil || errors.Is(err, errCallFunctionFailed) {
+			return balance, err
+		}
+	}
+	return nil, tokens.ErrRPCQueryError
+}
+
+// GetStorageBalanceBounds get nep145 storage balance bounds of contract
+func (b *Bridge) GetStorageBalanceBounds(contract string) (bounds *StorageBalanceBounds, err error) {
+	urls := b.GatewayConfig.AllGatewayURLs
+	for _, url := range urls {
+		bounds, err = GetStorageBalanceBounds(url, contract)
+		if err == nil {
+			return bounds, nil
+		}
+	}
+	return nil, tokens.ErrRPCQueryError
+}
+
 // GetTransactionStatus impl
 func (b *Bridge) GetTransactionStatus(txHash string) (status *tokens.TxStatus, err error) {
 	status = new(tokens.TxStatus)
@@ -167,7 +193,8 @@ func (b *Bridge) GetTransactionStatus(txHash string) (status *tokens.TxStatus, e
 		return nil, tokens.ErrTxWithWrongStatus
 	}
 
-	status.Receipt = nil
+	// swap and call by ft_transfer_call is failed if token is refunded to mpc
+	status.Receipt = &swapTxStatus{refunded: hasFtRefund(txres)}
 	blockHeight, err := b.GetBlockNumberByHash(txres.TransactionOutcome.BlockHash)
 	if err != nil {
 		log.Warn("GetBlockNumberByHash", "error", err)
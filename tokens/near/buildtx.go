@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math/big"
 	"time"
+	"unicode/utf8"
 
 	"github.com/anyswap/CrossChain-Router/v3/common"
 	"github.com/anyswap/CrossChain-Router/v3/log"
@@ -16,7 +17,12 @@ import (
 )
 
 const (
-	defaultGasLimit uint64 = 70_000_000_000_000
+	defaultGasLimit     uint64 = 70_000_000_000_000
+	defaultCallGasLimit uint64 = 150_000_000_000_000 // ft_transfer_call and the receiver's ft_on_transfer
+	storageDepositGas   uint64 = 10_000_000_000_000
+
+	nativeContractVersion   uint64 = 999
+	anyTokenContractVersion uint64 = 666
 )
 
 var (
@@ -44,7 +50,7 @@ func (b *Bridge) BuildRawTransaction(args *tokens.BuildTxArgs) (rawTx interface{
 	}
 
 	switch args.SwapType {
-	case tokens.ERC20SwapType:
+	case tokens.ERC20SwapType, tokens.AnyCallSwapType:
 	default:
 		return nil, tokens.ErrSwapTypeNotSupported
 	}
@@ -59,6 +65,10 @@ func (b *Bridge) BuildRawTransaction(args *tokens.BuildTxArgs) (rawTx interface{
 		return nil, err
 	}
 
+	if args.SwapType == tokens.AnyCallSwapType {
+		return b.buildAnyCallSwapTx(args, nearPubKey)
+	}
+
 	erc20SwapInfo := args.ERC20SwapInfo
 	multichainToken := router.GetCachedMultichainToken(erc20SwapInfo.TokenID, args.ToChainID.String())
 	if multichainToken == "" {
@@ -76,19 +86,118 @@ func (b *Bridge) BuildRawTransaction(args *tokens.BuildTxArgs) (rawTx interface{
 		return nil, err
 	}
 	args.SwapValue = amount // SwapValue
-	if extra, err := b.initExtra(args); err != nil {
+	extra, err := b.initExtra(args)
+	if err != nil {
 		return nil, err
-	} else {
-		if blockHashBytes, err := base58.Decode(*extra.BlockHash); err != nil {
+	}
+	blockHashBytes, err := base58.Decode(*extra.BlockHash)
+	if err != nil {
+		return nil, err
+	}
+	to, actions, err := b.CreateFunctionCall(args.SwapID, multichainToken, receiver, amount.String(), args.FromChainID.String(), args.LogIndex, *extra.Gas, tokenCfg.ContractVersion, erc20SwapInfo)
+	if err != nil {
+		return nil, err
+	}
+	if tokenCfg.ContractVersion != nativeContractVersion {
+		// the account receiving token must be registered on the token contract
+		tokenReceiver := receiver
+		if erc20SwapInfo.CallProxy != "" {
+			tokenReceiver = erc20SwapInfo.CallProxy
+		}
+		deposit, errf := b.getStorageDeposit(args, multichainToken, tokenReceiver)
+		if errf != nil {
+			return nil, errf
+		}
+		if deposit.Sign() > 0 {
+			actions = append([]Action{buildStorageDepositAction(tokenReceiver, deposit)}, actions...)
+		}
+	}
+	return CreateTransaction(args.From, nearPubKey, to, *extra.Sequence, blockHashBytes, actions), nil
+}
+
+// getStorageDeposit decide nep145 storage deposit of registering account on token contract,
+// it is zero if the account is registered or the contract does not support storage management.
+// the decision is kept in `args.Extra.StorageDeposit` to be followed when rebuilding tx.
+func (b *Bridge) getStorageDeposit(args *tokens.BuildTxArgs, contract, account string) (*big.Int, error) {
+	if deposit := args.Extra.StorageDeposit; deposit != nil {
+		if deposit.Sign() == 0 {
+			return deposit, nil
+		}
+		// the preset deposit is paid by mpc, bound it when rebuilding tx
+		bounds, err := b.GetStorageBalanceBounds(contract)
+		if err != nil {
 			return nil, err
-		} else {
-			if to, actions, err := b.CreateFunctionCall(args.SwapID, multichainToken, receiver, amount.String(), args.FromChainID.String(), args.LogIndex, *extra.Gas, tokenCfg.ContractVersion); err != nil {
-				return nil, err
-			} else {
-				rawTx = CreateTransaction(args.From, nearPubKey, to, *extra.Sequence, blockHashBytes, actions)
+		}
+		if err = checkStorageDeposit(deposit, bounds); err != nil {
+			return nil, err
+		}
+		return deposit, nil
+	}
+	deposit := big.NewInt(0)
+	balance, err := b.GetStorageBalance(contract, account)
+	switch {
+	case errors.Is(err, errCallFunctionFailed):
+		log.Info("contract does not support storage management", "contract", contract, "err", err)
+	case err != nil:
+		return nil, err
+	case balance == nil:
+		bounds, errf := b.GetStorageBalanceBounds(contract)
+		if errf != nil {
+			return nil, errf
+		}
+		deposit, err = common.GetBigIntFromStr(bounds.Min)
+		if err != nil {
+			return nil, err
+		}
+		if err = b.CheckBalance(args.From, deposit.String()); err != nil {
+			if errors.Is(err, tokens.ErrTokenBalanceNotEnough) {
+				return nil, fmt.Errorf("%w %v", tokens.ErrBuildTxErrorAndDelay, "insufficient near balance for storage deposit")
 			}
-			return rawTx, nil
+			return nil, err
 		}
+		log.Info("receiver is not registered on token contract, add storage deposit", "contract", contract, "account", account, "deposit", deposit)
+	}
+	args.Extra.StorageDeposit = deposit
+	return deposit, nil
+}
+
+// checkStorageDeposit check deposit is in the storage balance bounds,
+// the upper bound is min if max is not set as registration only needs min.
+func checkStorageDeposit(deposit *big.Int, bounds *StorageBalanceBounds) error {
+	if deposit.Sign() < 0 {
+		return fmt.Errorf("negative storage deposit %v", deposit)
+	}
+	minDeposit, err := common.GetBigIntFromStr(bounds.Min)
+	if err != nil {
+		return err
+	}
+	maxDeposit := minDeposit
+	if bounds.Max != "" {
+		maxDeposit, err = common.GetBigIntFromStr(bounds.Max)
+		if err != nil {
+			return err
+		}
+	}
+	if deposit.Cmp(minDeposit) < 0 || deposit.Cmp(maxDeposit) > 0 {
+		return fmt.Errorf("storage deposit %v is out of bounds [%v, %v]", deposit, minDeposit, maxDeposit)
+	}
+	return nil
+}
+
+func buildStorageDepositAction(account string, deposit *big.Int) Action {
+	callArgs := &StorageDeposit{
+		AccountId:        account,
+		RegistrationOnly: true,
+	}
+	argsBytes, _ := json.Marshal(callArgs)
+	return Action{
+		Enum: 2,
+		FunctionCall: FunctionCall{
+			MethodName: "storage_deposit",
+			Args:       argsBytes,
+			Gas:        storageDepositGas,
+			Deposit:    *deposit,
+		},
 	}
 }
 
@@ -106,6 +215,9 @@ func (b *Bridge) initExtra(args *tokens.BuildTxArgs) (extra *tokens.AllExtras, e
 	}
 	if extra.Gas == nil {
 		gas := defaultGasLimit
+		if args.ERC20SwapInfo != nil && args.ERC20SwapInfo.CallProxy != "" {
+			gas = defaultCallGasLimit
+		}
 		extra.Gas = &gas
 	}
 	if extra.BlockHash == nil {
@@ -204,17 +316,27 @@ func CreateTransaction(
 	return &tx
 }
 
-func (b *Bridge) CreateFunctionCall(txHash, multichainToken, to, amount, fromChainID string, logIndex int, gas, contractVersion uint64) (string, []Action, error) {
-	log.Info("createFunctionCall", "txHash", txHash, "to", to, "amount", amount, "fromChainID", fromChainID)
+// CreateFunctionCall create actions of swapin, if swapInfo has call proxy
+// then swap and call by ft_transfer_call to the call proxy.
+func (b *Bridge) CreateFunctionCall(txHash, multichainToken, to, amount, fromChainID string, logIndex int, gas, contractVersion uint64, swapInfo *tokens.ERC20SwapInfo) (string, []Action, error) {
+	log.Info("createFunctionCall", "txHash", txHash, "to", to, "amount", amount, "fromChainID", fromChainID, "callProxy", swapInfo.CallProxy)
+	if swapInfo.CallProxy != "" {
+		if contractVersion == anyTokenContractVersion || contractVersion == nativeContractVersion {
+			return "", nil, fmt.Errorf("swap and call is not supported with contract version %v", contractVersion)
+		}
+		if !b.IsValidAddress(swapInfo.CallProxy) {
+			return "", nil, fmt.Errorf("swap and call to invalid call proxy %v", swapInfo.CallProxy)
+		}
+	}
 	var methodName string
 	var argsBytes []byte
 	var deposit *big.Int
 	switch contractVersion {
-	case 666:
+	case anyTokenContractVersion:
 		argsBytes = buildTokenSwapInArgs(txHash, to, amount, fromChainID)
 		methodName = "swap_in"
 		deposit = big.NewInt(0)
-	case 999:
+	case nativeContractVersion:
 		routerMPC := b.GetRouterContract("")
 		if err := b.CheckBalance(routerMPC, amount); err != nil {
 			return "", nil, err
@@ -233,8 +355,17 @@ func (b *Bridge) CreateFunctionCall(txHash, multichainToken, to, amount, fromCha
 		if err := b.CheckTokenBalance(multichainToken, amount); err != nil {
 			return "", nil, err
 		}
-		argsBytes = buildTokenTransferArgs(txHash, to, amount, fromChainID, logIndex)
-		methodName = "ft_transfer"
+		if swapInfo.CallProxy != "" {
+			var err error
+			argsBytes, err = buildTokenTransferCallArgs(txHash, to, amount, fromChainID, logIndex, swapInfo)
+			if err != nil {
+				return "", nil, err
+			}
+			methodName = "ft_transfer_call"
+		} else {
+			argsBytes = buildTokenTransferArgs(txHash, to, amount, fromChainID, logIndex)
+			methodName = "ft_transfer"
+		}
 		deposit = big.NewInt(1)
 	}
 	return multichainToken, []Action{{
@@ -259,6 +390,28 @@ func buildTokenTransferArgs(txHash, to, amount, fromChainID string, logIndex int
 	return argsBytes
 }
 
+// buildTokenTransferCallArgs build ft_transfer_call args, the call proxy receives the token,
+// and its ft_on_transfer gets the receiver and call data from msg.
+func buildTokenTransferCallArgs(txHash, to, amount, fromChainID string, logIndex int, swapInfo *tokens.ERC20SwapInfo) ([]byte, error) {
+	if !utf8.Valid(swapInfo.CallData) {
+		return nil, fmt.Errorf("call data is not valid utf8 string")
+	}
+	msg, err := json.Marshal(&FtTransferCallMsg{
+		ReceiverId: to,
+		Data:       string(swapInfo.CallData),
+	})
+	if err != nil {
+		return nil, err
+	}
+	callArgs := &FtTransferCall{
+		ReceiverId: swapInfo.CallProxy,
+		Amount:     amount,
+		Memo:       fmt.Sprintf("%s:%d:%s", txHash, logIndex, fromChainID),
+		Msg:        string(msg),
+	}
+	return json.Marshal(callArgs)
+}
+
 func buildTokenSwapInArgs(txHash, to, amount, fromChainID string) []byte {
 	callArgs := &FtSwapIn{
 		TxHash:      txHash,
@@ -0,0 +1,153 @@
+package near
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+)
+
+func TestIsValidAddress(t *testing.T) {
+	b := NewCrossChainBridge()
+	tests := []struct {
+		address string
+		valid   bool
+	}{
+		{address: "alice.near", valid: true},
+		{address: "proxy_1.multichain.testnet", valid: true},
+		{address: "98793cd91a3f870fb126f66285808c7e094afcfc4eda8a970f6648cdf0dbd6de", valid: true},
+		{address: "", valid: false},
+		{address: "a", valid: false},
+		{address: "Alice.near", valid: false},
+		{address: "alice..near", valid: false},
+		{address: "alice.near.", valid: false},
+		{address: "-alice.near", valid: false},
+		{address: "alice@near", valid: false},
+	}
+	for _, test := range tests {
+		if got := b.IsValidAddress(test.address); got != test.valid {
+			t.Errorf("test %v: got valid %v, want %v", test.address, got, test.valid)
+		}
+	}
+}
+
+func TestBuildTokenTransferCallArgs(t *testing.T) {
+	swapInfo := &tokens.ERC20SwapInfo{
+		CallProxy: "proxy.near",
+		CallData:  []byte(`{"action":"stake"}`),
+	}
+	data, err := buildTokenTransferCallArgs("0xabc", "alice.near", "1000", "1", 2, swapInfo)
+	if err != nil {
+		t.Fatalf("build ft_transfer_call args failed: %v", err)
+	}
+	var args FtTransferCall
+	if err = json.Unmarshal(data, &args); err != nil {
+		t.Fatalf("unmarshal ft_transfer_call args failed: %v", err)
+	}
+	if args.ReceiverId != "proxy.near" || args.Amount != "1000" || args.Memo != "0xabc:2:1" {
+		t.Errorf("wrong ft_transfer_call args: %+v", args)
+	}
+	var msg FtTransferCallMsg
+	if err = json.Unmarshal([]byte(args.Msg), &msg); err != nil {
+		t.Fatalf("unmarshal ft_transfer_call msg failed: %v", err)
+	}
+	if msg.ReceiverId != "alice.near" || msg.Data != `{"action":"stake"}` {
+		t.Errorf("wrong ft_transfer_call msg: %+v", msg)
+	}
+
+	swapInfo.CallData = []byte{0xff, 0xfe}
+	if _, err = buildTokenTransferCallArgs("0xabc", "alice.near", "1000", "1", 2, swapInfo); err == nil {
+		t.Errorf("build ft_transfer_call args with invalid utf8 call data should fail")
+	}
+}
+
+func TestCreateFunctionCallWithInvalidCallProxy(t *testing.T) {
+	b := NewCrossChainBridge()
+	tests := []struct {
+		callProxy       string
+		contractVersion uint64
+	}{
+		{callProxy: "Proxy.near", contractVersion: 1},
+		{callProxy: "proxy.near", contractVersion: anyTokenContractVersion},
+		{callProxy: "proxy.near", contractVersion: nativeContractVersion},
+	}
+	for _, test := range tests {
+		swapInfo := &tokens.ERC20SwapInfo{CallProxy: test.callProxy}
+		_, _, err := b.CreateFunctionCall("0xabc", "token.near", "alice.near", "1000", "1", 0, defaultCallGasLimit, test.contractVersion, swapInfo)
+		if err == nil {
+			t.Errorf("test %v/%v: create function call should fail", test.callProxy, test.contractVersion)
+		}
+	}
+}
+
+func TestCheckStorageDeposit(t *testing.T) {
+	tests := []struct {
+		deposit int64
+		bounds  *StorageBalanceBounds
+		ok      bool
+	}{
+		{deposit: 1250, bounds: &StorageBalanceBounds{Min: "1250"}, ok: true},
+		{deposit: 1250, bounds: &StorageBalanceBounds{Min: "1250", Max: "1250"}, ok: true},
+		{deposit: 2000, bounds: &StorageBalanceBounds{Min: "1250", Max: "3000"}, ok: true},
+		{deposit: 5000, bounds: &StorageBalanceBounds{Min: "1250"}, ok: false},
+		{deposit: 5000, bounds: &StorageBalanceBounds{Min: "1250", Max: "3000"}, ok: false},
+		{deposit: 1000, bounds: &StorageBalanceBounds{Min: "1250"}, ok: false},
+		{deposit: -1, bounds: &StorageBalanceBounds{Min: "0"}, ok: false},
+	}
+	for i, test := range tests {
+		err := checkStorageDeposit(big.NewInt(test.deposit), test.bounds)
+		if (err == nil) != test.ok {
+			t.Errorf("test %v: got err %v, want ok %v", i, err, test.ok)
+		}
+	}
+}
+
+func TestHasFtRefund(t *testing.T) {
+	newTxResult := func(executor string, logs ...string) *TransactionResult {
+		return &TransactionResult{
+			Transaction: Transaction{SignerID: "mpc.near", ReceiverID: "token.near"},
+			ReceiptsOutcome: []ReceiptsOutcome{
+				{Outcome: Outcome{ExecutorID: "proxy.near", Logs: []string{"Refund 100 from proxy.near to mpc.near"}}},
+				{Outcome: Outcome{ExecutorID: executor, Logs: logs}},
+			},
+		}
+	}
+	tests := []struct {
+		name     string
+		txres    *TransactionResult
+		refunded bool
+	}{
+		{
+			name:  "transfer without refund",
+			txres: newTxResult("token.near", `EVENT_JSON:{"standard":"nep141","version":"1.0.0","event":"ft_transfer","data":[{"old_owner_id":"mpc.near","new_owner_id":"proxy.near","amount":"100","memo":"0xabc:0:1"}]}`),
+		},
+		{
+			name:     "nep297 refund event",
+			txres:    newTxResult("token.near", `EVENT_JSON:{"standard":"nep141","version":"1.0.0","event":"ft_transfer","data":[{"old_owner_id":"proxy.near","new_owner_id":"mpc.near","amount":"100","memo":"refund"}]}`),
+			refunded: true,
+		},
+		{
+			name:     "legacy refund log",
+			txres:    newTxResult("token.near", "Refund 100 from proxy.near to mpc.near"),
+			refunded: true,
+		},
+		{
+			name:  "refund to others",
+			txres: newTxResult("token.near", "Refund 100 from proxy.near to bob.near"),
+		},
+		{
+			name:  "refund log not from token contract",
+			txres: newTxResult("other.near", "Refund 100 from proxy.near to mpc.near"),
+		},
+	}
+	for _, test := range tests {
+		if got := hasFtRefund(test.txres); got != test.refunded {
+			t.Errorf("test %v: got refunded %v, want %v", test.name, got, test.refunded)
+		}
+		status := &swapTxStatus{refunded: hasFtRefund(test.txres)}
+		if status.IsStatusOk() == test.refunded {
+			t.Errorf("test %v: wrong status ok", test.name)
+		}
+	}
+}
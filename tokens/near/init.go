@@ -13,6 +13,9 @@ var (
 	GetFtMetadata = "ft_metadata"
 	GetFtBalance  = "ft_balance_of"
 	EmptyArgs     = "e30="
+
+	GetStorageBalance = "storage_balance_of"
+	GetStorageBounds  = "storage_balance_bounds"
 )
 
 // InitAfterConfig init variables (ie. extra members) after loading config
@@ -2,6 +2,7 @@ package near
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
@@ -15,6 +16,8 @@ import (
 
 var (
 	rpcTimeout = 60
+
+	errCallFunctionFailed = errors.New("call function failed")
 )
 
 const (
@@ -136,7 +139,7 @@ func functionCall(url, accountID, methodName, args string) ([]byte, error) {
 		return nil, err
 	}
 	if result.Result == nil {
-		return nil, errors.New(result.Error)
+		return nil, fmt.Errorf("%w: %v", errCallFunctionFailed, result.Error)
 	}
 	return result.Result, nil
 }
@@ -189,3 +192,31 @@ func CheckBalance(url, account, amount string) error {
 	}
 	return err
 }
+
+// GetStorageBalanceOf get nep145 storage balance of account, returns nil if account is not registered
+func GetStorageBalanceOf(url, contract, account string) (*StorageBalance, error) {
+	argsStr := fmt.Sprintf("{\"account_id\":\"%s\"}", account)
+	argsBase64 := base64.StdEncoding.EncodeToString([]byte(argsStr))
+	result, err := functionCall(url, contract, GetStorageBalance, argsBase64)
+	if err != nil {
+		return nil, err
+	}
+	var balance *StorageBalance
+	if err = json.Unmarshal(result, &balance); err != nil {
+		return nil, err
+	}
+	return balance, nil
+}
+
+// GetStorageBalanceBounds get nep145 storage balance bounds
+func GetStorageBalanceBounds(url, contract string) (*StorageBalanceBounds, error) {
+	result, err := functionCall(url, contract, GetStorageBounds, EmptyArgs)
+	if err != nil {
+		return nil, err
+	}
+	var bounds StorageBalanceBounds
+	if err = json.Unmarshal(result, &bounds); err != nil {
+		return nil, err
+	}
+	return &bounds, nil
+}
@@ -172,6 +172,38 @@ type FtTransfer struct {
 	Memo       string `json:"memo"`
 }
 
+type FtTransferCall struct {
+	ReceiverId string `json:"receiver_id"`
+	Amount     string `json:"amount"`
+	Memo       string `json:"memo"`
+	Msg        string `json:"msg"`
+}
+
+// FtTransferCallMsg msg of ft_transfer_call in swap and call,
+// the call proxy receives the token and calls receiver with data
+type FtTransferCallMsg struct {
+	ReceiverId string `json:"receiver_id"`
+	Data       string `json:"data"`
+}
+
+// StorageDeposit nep145 storage_deposit args
+type StorageDeposit struct {
+	AccountId        string `json:"account_id"`
+	RegistrationOnly bool   `json:"registration_only"`
+}
+
+// StorageBalance nep145 storage balance
+type StorageBalance struct {
+	Total     string `json:"total"`
+	Available string `json:"available"`
+}
+
+// StorageBalanceBounds nep145 storage balance bounds
+type StorageBalanceBounds struct {
+	Min string `json:"min"`
+	Max string `json:"max"`
+}
+
 type FtSwapIn struct {
 	TxHash      string `json:"tx_hash"`
 	ReceiverId  string `json:"receiver_id"`
@@ -48,17 +48,21 @@ func (b *Bridge) VerifyMsgHash(rawTx interface{}, msgHashes []string) (err error
 }
 
 // VerifyTransaction impl
-func (b *Bridge) VerifyTransaction(txHash string, args *tokens.VerifyArgs) (*tokens.SwapTxInfo, error) {
+func (b *Bridge) VerifyTransaction(txHash string, args *tokens.VerifyArgs) (swapInfo *tokens.SwapTxInfo, err error) {
 	swapType := args.SwapType
 	logIndex := args.LogIndex
 	allowUnstable := args.AllowUnstable
 
 	switch swapType {
 	case tokens.ERC20SwapType:
-		return b.verifySwapoutTx(txHash, logIndex, allowUnstable)
+		swapInfo, err = b.verifySwapoutTx(txHash, logIndex, allowUnstable)
 	default:
 		return nil, tokens.ErrSwapTypeNotSupported
 	}
+	if err == nil && !allowUnstable {
+		err = tokens.CheckSwapTxStable(b, swapInfo)
+	}
+	return swapInfo, err
 }
 
 func (b *Bridge) verifySwapoutTx(txHash string, logIndex int, allowUnstable bool) (*tokens.SwapTxInfo, error) {
@@ -292,3 +296,66 @@ func (b *Bridge) fliterReceipts(swapInfo *tokens.SwapTxInfo, receipt *ReceiptsOu
 	}
 	return nil, tokens.ErrSwapoutLogNotFound
 }
+
+// swapTxStatus impl tokens.StatusInterface
+type swapTxStatus struct {
+	refunded bool
+}
+
+// IsStatusOk is swap tx status ok
+func (s *swapTxStatus) IsStatusOk() bool {
+	return !s.refunded
+}
+
+// ftEvent nep297 event of nep141 token
+type ftEvent struct {
+	Standard string `json:"standard"`
+	Event    string `json:"event"`
+	Data     []struct {
+		OldOwnerID string `json:"old_owner_id"`
+		NewOwnerID string `json:"new_owner_id"`
+		Amount     string `json:"amount"`
+		Memo       string `json:"memo"`
+	} `json:"data"`
+}
+
+// hasFtRefund check if ft_resolve_transfer of the token contract refunds token to the tx signer,
+// which happens when ft_on_transfer of the receiver fails or returns unused amount.
+func hasFtRefund(txres *TransactionResult) bool {
+	token := txres.Transaction.ReceiverID
+	sender := txres.Transaction.SignerID
+	for _, receipt := range txres.ReceiptsOutcome {
+		if receipt.Outcome.ExecutorID != token {
+			continue
+		}
+		for _, logStr := range receipt.Outcome.Logs {
+			if isFtRefundLog(logStr, sender) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isFtRefundLog(logStr, sender string) bool {
+	// legacy log format: Refund {amount} from {receiver} to {sender}
+	if strings.HasPrefix(logStr, "Refund ") {
+		return strings.HasSuffix(logStr, " to "+sender)
+	}
+	if !strings.HasPrefix(logStr, "EVENT_JSON:") {
+		return false
+	}
+	var event ftEvent
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(logStr, "EVENT_JSON:")), &event); err != nil {
+		return false
+	}
+	if event.Standard != "nep141" || event.Event != "ft_transfer" {
+		return false
+	}
+	for _, data := range event.Data {
+		if data.Memo == "refund" && data.NewOwnerID == sender {
+			return true
+		}
+	}
+	return false
+}
@@ -7,13 +7,19 @@ import (
 	"strings"
 
 	"github.com/anyswap/CrossChain-Router/v3/common"
+	"github.com/anyswap/CrossChain-Router/v3/tokens/ripple/rubblelabs/ripple/data"
 )
 
-var rAddressReg = regexp.MustCompile(`^r[1-9a-km-zA-HJ-NP-Z]{32,33}(?::[0-9]*)?$`)
+var rAddressReg = regexp.MustCompile(`^r[1-9a-km-zA-HJ-NP-Z]{32,33}(?::[0-9]{1,10})?$`)
 
-// IsValidAddress check address
+// IsValidAddress check address, address can have destination tag suffix (format: `address:tag`)
 func (b *Bridge) IsValidAddress(addr string) bool {
-	return rAddressReg.MatchString(addr)
+	address, _, err := GetAddressAndTag(addr)
+	if err != nil {
+		return false
+	}
+	_, err = data.NewAccountFromAddress(address)
+	return err == nil
 }
 
 // GetAddressAndTag get address and tag
@@ -198,8 +198,24 @@ func (b *Bridge) GetAccount(address string) (acctRes *websockets.AccountInfoResu
 	return nil, wrapRPCQueryError(err, "GetAccount")
 }
 
+// AccountLine is account line with the trust line flags,
+// which are not decoded by `data.AccountLine`
+type AccountLine struct {
+	data.AccountLine
+	Authorized     bool `json:"authorized"`
+	PeerAuthorized bool `json:"peer_authorized"`
+	Freeze         bool `json:"freeze"`
+	FreezePeer     bool `json:"freeze_peer"`
+}
+
+// accountLinesResult is `account_lines` result with lines of type `AccountLine`
+type accountLinesResult struct {
+	Marker *string        `json:"marker"`
+	Lines  []*AccountLine `json:"lines"`
+}
+
 // GetAccountLine get account line
-func (b *Bridge) GetAccountLine(currency, issuer, accountAddress string) (line *data.AccountLine, err error) {
+func (b *Bridge) GetAccountLine(currency, issuer, accountAddress string) (line *AccountLine, err error) {
 	rpcParams := map[string]interface{}{
 		"account":      accountAddress,
 		"peer":         issuer,
@@ -207,13 +223,13 @@ func (b *Bridge) GetAccountLine(currency, issuer, accountAddress string) (line *
 		"ledger_index": "current",
 	}
 	urls := b.GetGatewayConfig().AllGatewayURLs
-	var acclRes *websockets.AccountLinesResult
+	var acclRes *accountLinesResult
 PAGE_LOOP:
 	for {
 	RETRY_LOOP:
 		for i := 0; i < rpcRetryTimes; i++ {
 			for _, url := range urls {
-				var res *websockets.AccountLinesResult
+				var res *accountLinesResult
 				err = client.RPCPostWithTimeout(b.RPCClientTimeout, &res, url, "account_lines", rpcParams)
 				if err == nil && res != nil {
 					acclRes = res
@@ -226,8 +242,10 @@ PAGE_LOOP:
 			log.Error("GetAccountLine rpc error", "err", err)
 			return nil, err
 		}
-		for i := 0; i < len(acclRes.Lines); i++ {
-			accl := &acclRes.Lines[i]
+		for _, accl := range acclRes.Lines {
+			if accl == nil {
+				continue
+			}
 			asset := accl.Asset()
 			if asset.Currency == currency && asset.Issuer == issuer {
 				return accl, nil
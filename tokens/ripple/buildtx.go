@@ -78,6 +78,11 @@ func (b *Bridge) BuildRawTransaction(args *tokens.BuildTxArgs) (rawTx interface{
 		return nil, err
 	}
 
+	err = b.checkReceiverDestinationTag(receiver, toTag)
+	if err != nil {
+		return nil, err
+	}
+
 	if asset.IsNative() {
 		needAmount := new(big.Int).Add(amount, b.getMinReserveFee())
 		err = b.checkNativeBalance(args.From, needAmount, true)
@@ -254,15 +259,73 @@ func (b *Bridge) checkNativeBalance(account string, amount *big.Int, isPay bool)
 	return nil
 }
 
-func (b *Bridge) checkNonNativeBalance(currency, issuer, account, receiver string, amount *data.Amount) error {
-	if !params.IsSwapServer {
+// checkReceiverDestinationTag check receiver which requires destination tag has specified one
+func (b *Bridge) checkReceiverDestinationTag(receiver string, destTag *uint32) error {
+	if !params.IsSwapServer || destTag != nil {
 		return nil
 	}
-	_, err := b.GetAccountLine(currency, issuer, receiver)
+	acct, err := b.GetAccount(receiver)
+	if err != nil || acct == nil || acct.AccountData.Flags == nil {
+		return nil // receiver account may not exist, checked in balance checking
+	}
+	if *acct.AccountData.Flags&data.LsRequireDestTag != 0 {
+		return fmt.Errorf("%w %v, receiver: %v", tokens.ErrBuildTxErrorAndDelay, tokens.ErrMissDestinationTag, receiver)
+	}
+	return nil
+}
+
+// checkReceiverTrustLine check receiver can receive the payment by its trust line (account_lines)
+func (b *Bridge) checkReceiverTrustLine(currency, issuer, receiver string, amount *data.Amount) error {
+	if receiver == issuer {
+		return nil
+	}
+	line, err := b.GetAccountLine(currency, issuer, receiver)
 	if err != nil {
 		log.Error("get receiver account line failed", "currency", currency, "issuer", issuer, "receiver", receiver, "err", err)
+		if errors.Is(err, tokens.ErrNotFound) {
+			return fmt.Errorf("%w %v, currency: %v, issuer: %v, receiver: %v", tokens.ErrBuildTxErrorAndDelay, tokens.ErrNoTrustLine, currency, issuer, receiver)
+		}
 		return fmt.Errorf("%w %v", tokens.ErrBuildTxErrorAndDelay, "get receiver account line failed")
 	}
+	requireAuth := false
+	if !line.PeerAuthorized {
+		issuerAcct, errf := b.GetAccount(issuer)
+		requireAuth = errf == nil && issuerAcct != nil && issuerAcct.AccountData.Flags != nil &&
+			*issuerAcct.AccountData.Flags&data.LsRequireAuth != 0
+	}
+	err = checkTrustLine(line, amount, requireAuth)
+	if err != nil {
+		return fmt.Errorf("%w %v, currency: %v, issuer: %v, receiver: %v", tokens.ErrBuildTxErrorAndDelay, err, currency, issuer, receiver)
+	}
+	return nil
+}
+
+// checkTrustLine check trust line is not frozen, is authorized if issuer requires auth, and has enough limit for amount
+func checkTrustLine(line *AccountLine, amount *data.Amount, requireAuth bool) error {
+	if line.FreezePeer {
+		return tokens.ErrTrustLineFrozen
+	}
+	if requireAuth && !line.PeerAuthorized {
+		return tokens.ErrTrustLineNotAuthorized
+	}
+	newBalance, err := line.Balance.Add(*amount.Value)
+	if err != nil {
+		return err
+	}
+	if newBalance.Compare(line.Limit.Value) > 0 {
+		return fmt.Errorf("%w, limit: %v, balance: %v, amount: %v", tokens.ErrTrustLineLimitExceeded, line.Limit.String(), line.Balance.String(), amount.Value.String())
+	}
+	return nil
+}
+
+func (b *Bridge) checkNonNativeBalance(currency, issuer, account, receiver string, amount *data.Amount) error {
+	if !params.IsSwapServer {
+		return nil
+	}
+	err := b.checkReceiverTrustLine(currency, issuer, receiver, amount)
+	if err != nil {
+		return err
+	}
 
 	if issuer == account {
 		return nil
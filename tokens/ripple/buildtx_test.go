@@ -0,0 +1,85 @@
+package ripple
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+)
+
+const testAccountLinesResult = `{
+	"account": "rLHzPsX6oXkzU2qL12kHCH8G8cnZv1rBJh",
+	"lines": [
+		{"account": "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh", "balance": "10", "currency": "USD", "limit": "100", "limit_peer": "0", "quality_in": 0, "quality_out": 0},
+		{"account": "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh", "balance": "0", "currency": "EUR", "limit": "100", "limit_peer": "0", "quality_in": 0, "quality_out": 0, "peer_authorized": true, "freeze_peer": true}
+	],
+	"marker": "next"
+}`
+
+func newTestAccountLine(t *testing.T, balance, limit string) *AccountLine {
+	line := &AccountLine{}
+	line.Currency = mustNewAmount(t, "0/USD/"+testIssuer).Currency
+	line.Balance.Value = *mustNewAmount(t, balance+"/USD/"+testIssuer).Value
+	line.Limit.Value = *mustNewAmount(t, limit+"/USD/"+testIssuer).Value
+	return line
+}
+
+func TestDecodeAccountLines(t *testing.T) {
+	var res *accountLinesResult
+	if err := json.Unmarshal([]byte(testAccountLinesResult), &res); err != nil {
+		t.Fatalf("decode account lines failed: %v", err)
+	}
+	if res.Marker == nil || *res.Marker != "next" || len(res.Lines) != 2 {
+		t.Fatalf("decode account lines: got marker %v lines %v", res.Marker, len(res.Lines))
+	}
+	usd, eur := res.Lines[0], res.Lines[1]
+	if asset := usd.Asset(); asset.Currency != "USD" || asset.Issuer != testIssuer {
+		t.Errorf("line asset: got %v", asset)
+	}
+	if usd.Balance.String() != "10" || usd.Limit.String() != "100" {
+		t.Errorf("line balance and limit: got %v %v", usd.Balance.String(), usd.Limit.String())
+	}
+	if usd.PeerAuthorized || usd.FreezePeer {
+		t.Errorf("line without flags: got peer authorized %v freeze peer %v", usd.PeerAuthorized, usd.FreezePeer)
+	}
+	if !eur.PeerAuthorized || !eur.FreezePeer || eur.Authorized || eur.Freeze {
+		t.Errorf("line with flags: got %+v", eur)
+	}
+}
+
+func TestCheckTrustLine(t *testing.T) {
+	tests := []struct {
+		balance        string
+		limit          string
+		amount         string
+		peerAuthorized bool
+		freezePeer     bool
+		requireAuth    bool
+		wantErr        error
+	}{
+		{"0", "100", "100", false, false, false, nil},
+		{"40", "100", "60", false, false, false, nil},
+		{"40", "100", "60.000001", false, false, false, tokens.ErrTrustLineLimitExceeded},
+		{"0", "100", "101", false, false, false, tokens.ErrTrustLineLimitExceeded},
+		{"0", "0", "1", false, false, false, tokens.ErrTrustLineLimitExceeded},
+		// balance above limit (eg. limit is lowered)
+		{"150", "100", "1", false, false, false, tokens.ErrTrustLineLimitExceeded},
+		// frozen by issuer
+		{"0", "100", "1", true, true, false, tokens.ErrTrustLineFrozen},
+		// issuer requires auth
+		{"0", "100", "1", false, false, true, tokens.ErrTrustLineNotAuthorized},
+		{"0", "100", "1", true, false, true, nil},
+		{"0", "100", "101", true, false, true, tokens.ErrTrustLineLimitExceeded},
+	}
+	for i, tt := range tests {
+		line := newTestAccountLine(t, tt.balance, tt.limit)
+		line.PeerAuthorized = tt.peerAuthorized
+		line.FreezePeer = tt.freezePeer
+		amount := mustNewAmount(t, tt.amount+"/USD/"+testIssuer)
+		err := checkTrustLine(line, amount, tt.requireAuth)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("test %v: got err %v, want %v", i, err, tt.wantErr)
+		}
+	}
+}
@@ -47,17 +47,21 @@ func (b *Bridge) VerifyMsgHash(rawTx interface{}, msgHashes []string) (err error
 }
 
 // VerifyTransaction impl
-func (b *Bridge) VerifyTransaction(txHash string, args *tokens.VerifyArgs) (*tokens.SwapTxInfo, error) {
+func (b *Bridge) VerifyTransaction(txHash string, args *tokens.VerifyArgs) (swapInfo *tokens.SwapTxInfo, err error) {
 	swapType := args.SwapType
 	logIndex := args.LogIndex
 	allowUnstable := args.AllowUnstable
 
 	switch swapType {
 	case tokens.ERC20SwapType:
-		return b.verifySwapoutTx(txHash, logIndex, allowUnstable)
+		swapInfo, err = b.verifySwapoutTx(txHash, logIndex, allowUnstable)
 	default:
 		return nil, tokens.ErrSwapTypeNotSupported
 	}
+	if err == nil && !allowUnstable {
+		err = tokens.CheckSwapTxStable(b, swapInfo)
+	}
+	return swapInfo, err
 }
 
 //nolint:gocyclo,funlen // ok
@@ -104,18 +108,24 @@ func (b *Bridge) verifySwapoutTx(txHash string, _ int, allowUnstable bool) (*tok
 		return swapInfo, tokens.ErrTxWithWrongStatus
 	}
 
-	asset := txres.TransactionWithMetaData.MetaData.DeliveredAmount.Asset().String()
-	token := b.GetTokenConfig(asset)
-	if token == nil {
-		return swapInfo, tokens.ErrMissTokenConfig
-	}
-
 	payment, ok := txres.TransactionWithMetaData.Transaction.(*data.Payment)
 	if !ok || payment.GetTransactionType() != data.PAYMENT {
 		log.Printf("Not a payment transaction")
 		return swapInfo, fmt.Errorf("not a payment transaction")
 	}
 
+	deliveredAmount, err := checkDeliveredAmount(payment, txres.TransactionWithMetaData.MetaData.DeliveredAmount)
+	if err != nil {
+		log.Warn("check delivered amount failed", "txid", txHash, "amount", payment.Amount.String(), "err", err)
+		return swapInfo, err
+	}
+
+	asset := deliveredAmount.Asset().String()
+	token := b.GetTokenConfig(asset)
+	if token == nil {
+		return swapInfo, tokens.ErrMissTokenConfig
+	}
+
 	txRecipient := payment.Destination.String()
 	// special usage, ripple has no router contract, and use deposit methods
 	depositAddress := b.GetRouterContract(asset)
@@ -138,10 +148,7 @@ func (b *Bridge) verifySwapoutTx(txHash string, _ int, allowUnstable bool) (*tok
 		return swapInfo, tokens.ErrWrongBindAddress
 	}
 
-	if !txres.TransactionWithMetaData.MetaData.DeliveredAmount.IsPositive() {
-		return swapInfo, tokens.ErrTxWithNoPayment
-	}
-	amt := tokens.ToBits(txres.TransactionWithMetaData.MetaData.DeliveredAmount.Value.String(), token.Decimals)
+	amt := tokens.ToBits(deliveredAmount.Value.String(), token.Decimals)
 
 	swapInfo.To = depositAddress             // To
 	swapInfo.From = payment.Account.String() // From
@@ -161,6 +168,25 @@ func (b *Bridge) verifySwapoutTx(txHash string, _ int, allowUnstable bool) (*tok
 	return swapInfo, nil
 }
 
+// checkDeliveredAmount use `delivered_amount` in tx meta data, as `Amount` is the upper limit of partial payment
+func checkDeliveredAmount(payment *data.Payment, deliveredAmount *data.Amount) (*data.Amount, error) {
+	if deliveredAmount == nil || deliveredAmount.IsZero() || !deliveredAmount.IsPositive() {
+		return nil, tokens.ErrTxWithNoPayment
+	}
+	if isPartialPayment(payment, deliveredAmount) {
+		return nil, tokens.ErrPartialPayment
+	}
+	return deliveredAmount, nil
+}
+
+// isPartialPayment is payment with partial payment flag and delivered less than `Amount`
+func isPartialPayment(payment *data.Payment, deliveredAmount *data.Amount) bool {
+	if payment.Flags == nil || *payment.Flags&data.TxPartialPayment == 0 {
+		return false
+	}
+	return !deliveredAmount.Equals(payment.Amount)
+}
+
 func (b *Bridge) checkToken(token *tokens.TokenConfig, txmeta *data.TransactionWithMetaData) error {
 	assetI, exist := assetMap.Load(token.ContractAddress)
 	if !exist {
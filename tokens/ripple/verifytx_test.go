@@ -0,0 +1,85 @@
+package ripple
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+	"github.com/anyswap/CrossChain-Router/v3/tokens/ripple/rubblelabs/ripple/data"
+)
+
+const testIssuer = "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh"
+
+func mustNewAmount(t *testing.T, value string) *data.Amount {
+	t.Helper()
+	amount, err := data.NewAmount(value)
+	if err != nil {
+		t.Fatalf("new amount %v failed: %v", value, err)
+	}
+	return amount
+}
+
+func newTestPayment(t *testing.T, amount string, flags data.TransactionFlag) *data.Payment {
+	payment := &data.Payment{Amount: *mustNewAmount(t, amount)}
+	if flags != 0 {
+		payment.Flags = &flags
+	}
+	return payment
+}
+
+func TestIsPartialPayment(t *testing.T) {
+	tests := []struct {
+		amount    string
+		flags     data.TransactionFlag
+		delivered string
+		want      bool
+	}{
+		{"100/USD/" + testIssuer, 0, "100/USD/" + testIssuer, false},
+		// delivered less without partial payment flag is not possible, and is decided by `delivered_amount`
+		{"100/USD/" + testIssuer, 0, "1/USD/" + testIssuer, false},
+		{"100/USD/" + testIssuer, data.TxPartialPayment, "100/USD/" + testIssuer, false},
+		{"100/USD/" + testIssuer, data.TxPartialPayment, "99.9/USD/" + testIssuer, true},
+		{"100/USD/" + testIssuer, data.TxPartialPayment | data.TxNoDirectRipple, "1/USD/" + testIssuer, true},
+		{"1000000", data.TxPartialPayment, "1000000", false},
+		{"1000000", data.TxPartialPayment, "1", true},
+	}
+	for i, tt := range tests {
+		payment := newTestPayment(t, tt.amount, tt.flags)
+		if got := isPartialPayment(payment, mustNewAmount(t, tt.delivered)); got != tt.want {
+			t.Errorf("test %v: got %v, want %v", i, got, tt.want)
+		}
+	}
+}
+
+func TestCheckDeliveredAmount(t *testing.T) {
+	tests := []struct {
+		amount    string
+		flags     data.TransactionFlag
+		delivered string // empty means no `delivered_amount` in meta data
+		wantErr   error
+	}{
+		{"100/USD/" + testIssuer, 0, "100/USD/" + testIssuer, nil},
+		{"100/USD/" + testIssuer, data.TxPartialPayment, "100/USD/" + testIssuer, nil},
+		{"100/USD/" + testIssuer, data.TxPartialPayment, "50/USD/" + testIssuer, tokens.ErrPartialPayment},
+		{"1000000", 0, "1000000", nil},
+		{"1000000", data.TxPartialPayment, "999999", tokens.ErrPartialPayment},
+		{"100/USD/" + testIssuer, 0, "", tokens.ErrTxWithNoPayment},
+		{"100/USD/" + testIssuer, 0, "0/USD/" + testIssuer, tokens.ErrTxWithNoPayment},
+		{"0", 0, "0", tokens.ErrTxWithNoPayment},
+	}
+	for i, tt := range tests {
+		payment := newTestPayment(t, tt.amount, tt.flags)
+		var delivered *data.Amount
+		if tt.delivered != "" {
+			delivered = mustNewAmount(t, tt.delivered)
+		}
+		got, err := checkDeliveredAmount(payment, delivered)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("test %v: got err %v, want %v", i, err, tt.wantErr)
+			continue
+		}
+		if err == nil && got != delivered {
+			t.Errorf("test %v: got delivered amount %v, want %v", i, got, delivered)
+		}
+	}
+}
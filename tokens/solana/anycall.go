@@ -0,0 +1,190 @@
+package solana
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Router/v3/common"
+	"github.com/anyswap/CrossChain-Router/v3/log"
+	"github.com/anyswap/CrossChain-Router/v3/params"
+	"github.com/anyswap/CrossChain-Router/v3/router"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+	"github.com/anyswap/CrossChain-Router/v3/tokens/solana/programs/anycall"
+	"github.com/anyswap/CrossChain-Router/v3/tokens/solana/types"
+)
+
+var (
+	// ensure Bridge impl tokens.AnyCallAppChecker
+	_ tokens.AnyCallAppChecker = &Bridge{}
+)
+
+// CheckAnyCallApp check anycall message can be delivered to solana program
+func (b *Bridge) CheckAnyCallApp(info *tokens.AnyCallSwapInfo) error {
+	cfg, err := tokens.GetAnyCallAppConfig(info, b.ChainConfig.ChainID)
+	if err != nil {
+		return err
+	}
+	if _, err = types.PublicKeyFromBase58(cfg.Target); err != nil {
+		return fmt.Errorf("invalid anycall target '%v'", cfg.Target)
+	}
+	if _, err = getAnyCallExecutor(b.ChainConfig.ChainID); err != nil {
+		return err
+	}
+	_, err = parseAnyCallAccounts(cfg.Accounts)
+	return err
+}
+
+// buildAnyCallSwapTx deliver anycall message by the configed anchor instruction of the target program,
+// which is called by the executor program with its authority and the configed accounts in order.
+func (b *Bridge) buildAnyCallSwapTx(args *tokens.BuildTxArgs) (*types.Transaction, error) {
+	routerMPC, err := router.GetRouterMPC("", b.ChainConfig.ChainID)
+	if err != nil {
+		return nil, err
+	}
+	if !common.IsEqualIgnoreCase(args.From, routerMPC) {
+		log.Error("build tx mpc mismatch", "have", args.From, "want", routerMPC)
+		return nil, tokens.ErrSenderMismatch
+	}
+	mpc, err := types.PublicKeyFromBase58(routerMPC)
+	if err != nil {
+		return nil, err
+	}
+	instruction, err := buildAnyCallInstruction(args, mpc)
+	if err != nil {
+		return nil, err
+	}
+	if err = b.checkAnyCallSwapNotExecuted(instruction, args.SwapID); err != nil {
+		return nil, err
+	}
+	args.SwapValue = big.NewInt(0)
+	log.Info("build anycall instruction", "swapID", args.SwapID, "executor", instruction.Program.String(), "accounts", len(instruction.AccountMetas), "payload", common.ToHex(instruction.Payload))
+	return b.newSwapinTransaction(args, mpc, instruction)
+}
+
+func buildAnyCallInstruction(args *tokens.BuildTxArgs, mpc types.PublicKey) (*anycall.Instruction, error) {
+	chainID := args.ToChainID.String()
+	cfg, err := tokens.GetAnyCallAppConfig(args.AnyCallSwapInfo, chainID)
+	if err != nil {
+		return nil, err
+	}
+	executor, err := getAnyCallExecutor(chainID)
+	if err != nil {
+		return nil, err
+	}
+	program, err := types.PublicKeyFromBase58(cfg.Target)
+	if err != nil {
+		return nil, err
+	}
+	accounts, err := parseAnyCallAccounts(cfg.Accounts)
+	if err != nil {
+		return nil, err
+	}
+	for _, account := range accounts {
+		if account.PublicKey.Equals(mpc) {
+			return nil, fmt.Errorf("mpc can not be passed to anycall target")
+		}
+	}
+	payload, err := buildAnyCallPayload(args, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return anycall.NewExecuteInstruction(executor, mpc, program, args.SwapID, payload, accounts)
+}
+
+func getAnyCallExecutor(chainID string) (types.PublicKey, error) {
+	executor, err := tokens.GetAnyCallExecutor(chainID)
+	if err != nil {
+		return types.PublicKey{}, err
+	}
+	pubkey, err := types.PublicKeyFromBase58(executor)
+	if err != nil {
+		return types.PublicKey{}, fmt.Errorf("invalid anycall executor '%v'", executor)
+	}
+	return pubkey, nil
+}
+
+// checkAnyCallSwapNotExecuted the executor creates swap record when executing,
+// do not build tx of executed swap (the executor rejects it too).
+func (b *Bridge) checkAnyCallSwapNotExecuted(instruction *anycall.Instruction, swapID string) error {
+	record := instruction.AccountMetas[2].PublicKey.String()
+	_, err := b.GetAccountInfo(record, "")
+	switch {
+	case err == nil:
+		return fmt.Errorf("%w: swapID %v record %v", tokens.ErrAnyCallSwapExecuted, swapID, record)
+	case errors.Is(err, tokens.ErrNotFound):
+		return nil
+	default:
+		return err
+	}
+}
+
+// buildAnyCallPayload build anchor instruction data,
+// it is the call data itself after the discriminator in raw encoding.
+func buildAnyCallPayload(args *tokens.BuildTxArgs, cfg *params.AnycallAppConfig) ([]byte, error) {
+	if cfg.IsRawEncoding() {
+		payload := anycall.CalcSighash(cfg.Function)
+		return append(payload, args.AnyCallSwapInfo.CallData...), nil
+	}
+	anycallArgs, err := tokens.GetAnyCallArgs(args, cfg)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]interface{}, len(anycallArgs))
+	for i, arg := range anycallArgs {
+		values[i] = arg.Value
+	}
+	return anycall.EncodePayload(cfg.Function, values)
+}
+
+// parseAnyCallAccounts parse accounts in format of `pubkey` or `pubkey:w` (writable)
+func parseAnyCallAccounts(accounts []string) ([]*types.AccountMeta, error) {
+	result := make([]*types.AccountMeta, 0, len(accounts))
+	for _, account := range accounts {
+		parts := strings.Split(account, ":")
+		pubkey, err := types.PublicKeyFromBase58(parts[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid anycall account '%v'", account)
+		}
+		result = append(result, &types.AccountMeta{
+			PublicKey:  pubkey,
+			IsWritable: len(parts) == 2 && parts[1] == "w",
+		})
+	}
+	return result, nil
+}
+
+func verifyAnyCallInstruction(tx *types.Transaction, instruction types.CompiledInstruction, args *tokens.BuildTxArgs) error {
+	mpc, err := types.PublicKeyFromBase58(args.From)
+	if err != nil {
+		return err
+	}
+	want, err := buildAnyCallInstruction(args, mpc)
+	if err != nil {
+		return err
+	}
+	accountKeys := tx.Message.AccountKeys
+	programIndex := int(instruction.ProgramIDIndex)
+	if programIndex >= len(accountKeys) || !accountKeys[programIndex].Equals(want.Program) {
+		return fmt.Errorf("[sign] verify anycall program failed")
+	}
+	if len(instruction.Accounts) != len(want.AccountMetas) {
+		return fmt.Errorf("[sign] verify anycall accounts count failed")
+	}
+	for i, accountIndex := range instruction.Accounts {
+		wantAccount := want.AccountMetas[i]
+		index := int(accountIndex)
+		if index >= len(accountKeys) || !accountKeys[index].Equals(wantAccount.PublicKey) {
+			return fmt.Errorf("[sign] verify anycall account %v failed", i)
+		}
+		if tx.IsSigner(accountKeys[index]) != wantAccount.IsSigner {
+			return fmt.Errorf("[sign] verify anycall account %v signer failed", i)
+		}
+	}
+	if !bytes.Equal(instruction.Data, want.Payload) {
+		return fmt.Errorf("[sign] verify anycall payload failed")
+	}
+	return nil
+}
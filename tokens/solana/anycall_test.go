@@ -0,0 +1,184 @@
+package solana
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Router/v3/params"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+	"github.com/anyswap/CrossChain-Router/v3/tokens/solana/programs/anycall"
+	"github.com/anyswap/CrossChain-Router/v3/tokens/solana/programs/system"
+	"github.com/anyswap/CrossChain-Router/v3/tokens/solana/types"
+)
+
+const (
+	testAnyCallChainID  = "245022934"
+	testAnyCallExecutor = "metaqbxxUerdq28cj1RbAWkYQm3ybzjb6a8bt518x1s"
+	testAnyCallTarget   = "TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"
+	testAnyCallAccount  = "ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL"
+	testAnyCallMPC      = "SysvarRent111111111111111111111111111111111"
+)
+
+// setAnyCallTestConfig set anycall extra config, and restore it after test
+func setAnyCallTestConfig(t *testing.T, accounts ...string) {
+	prevExtra := params.GetExtraConfig()
+	t.Cleanup(func() {
+		if prevExtra == nil {
+			prevExtra = &params.ExtraConfig{}
+		}
+		_ = params.SetExtraConfig(prevExtra)
+	})
+	err := params.SetExtraConfig(&params.ExtraConfig{
+		LocalChainConfig: map[string]*params.LocalChainConfig{
+			testAnyCallChainID: {AnyCallExecutor: testAnyCallExecutor},
+		},
+		AnycallApps: map[string]map[string]*params.AnycallAppConfig{
+			"app": {testAnyCallChainID: {
+				Target:   testAnyCallTarget,
+				Function: "anycall_exec",
+				Accounts: accounts,
+			}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("set extra config failed: %v", err)
+	}
+}
+
+func newAnyCallTestArgs(swapID string) *tokens.BuildTxArgs {
+	return &tokens.BuildTxArgs{
+		SwapArgs: tokens.SwapArgs{
+			Identifier:  "test",
+			SwapID:      swapID,
+			SwapType:    tokens.AnyCallSwapType,
+			FromChainID: big.NewInt(1),
+			ToChainID:   big.NewInt(245022934),
+			SwapInfo: tokens.SwapInfo{AnyCallSwapInfo: &tokens.AnyCallSwapInfo{
+				CallFrom: "0x1111111111111111111111111111111111111111",
+				CallTo:   testAnyCallTarget,
+				CallData: []byte{1, 2, 3},
+				AppID:    "app",
+			}},
+		},
+		From: testAnyCallMPC,
+	}
+}
+
+func TestBuildAnyCallInstruction(t *testing.T) {
+	setAnyCallTestConfig(t, testAnyCallAccount+":w")
+
+	mpc := types.MustPublicKeyFromBase58(testAnyCallMPC)
+	executor := types.MustPublicKeyFromBase58(testAnyCallExecutor)
+	args := newAnyCallTestArgs("0xswap")
+	instruction, err := buildAnyCallInstruction(args, mpc)
+	if err != nil {
+		t.Fatalf("build anycall instruction failed: %v", err)
+	}
+	if !instruction.Program.Equals(executor) {
+		t.Errorf("want program executor %v, but got %v", executor, instruction.Program)
+	}
+
+	authority, err := anycall.FindExecutorAuthority(executor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	record, err := anycall.FindSwapRecordAddress(executor, args.SwapID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantAccounts := []*types.AccountMeta{
+		{PublicKey: mpc, IsSigner: true, IsWritable: true},
+		{PublicKey: authority},
+		{PublicKey: record, IsWritable: true},
+		{PublicKey: system.SystemProgramID},
+		{PublicKey: types.MustPublicKeyFromBase58(testAnyCallTarget)},
+		{PublicKey: types.MustPublicKeyFromBase58(testAnyCallAccount), IsWritable: true},
+	}
+	if len(instruction.AccountMetas) != len(wantAccounts) {
+		t.Fatalf("want %v accounts, but got %v", len(wantAccounts), len(instruction.AccountMetas))
+	}
+	for i, want := range wantAccounts {
+		have := instruction.AccountMetas[i]
+		if !have.PublicKey.Equals(want.PublicKey) || have.IsSigner != want.IsSigner || have.IsWritable != want.IsWritable {
+			t.Errorf("account %v: want %+v, but got %+v", i, want, have)
+		}
+	}
+	// only the mpc signs the tx, the target is not passed the mpc
+	for i, account := range instruction.AccountMetas[1:] {
+		if account.PublicKey.Equals(mpc) || account.IsSigner {
+			t.Errorf("account %v: mpc signer is passed to the target", i+1)
+		}
+	}
+
+	targetPayload, err := anycall.EncodePayload("anycall_exec", []interface{}{
+		args.AnyCallSwapInfo.CallFrom, uint64(1), args.SwapID, []byte(args.AnyCallSwapInfo.CallData),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantPayload, _ := anycall.EncodePayload(anycall.ExecuteFunction, []interface{}{args.SwapID, targetPayload})
+	if !bytes.Equal(instruction.Payload, wantPayload) {
+		t.Errorf("want payload %x, but got %x", wantPayload, instruction.Payload)
+	}
+
+	// pda is determined by swapID
+	otherRecord, _ := anycall.FindSwapRecordAddress(executor, "0xother")
+	if otherRecord.Equals(record) {
+		t.Errorf("swap record of different swapIDs should not equal")
+	}
+}
+
+func TestBuildAnyCallInstructionErrors(t *testing.T) {
+	mpc := types.MustPublicKeyFromBase58(testAnyCallMPC)
+
+	setAnyCallTestConfig(t, testAnyCallMPC+":w")
+	if _, err := buildAnyCallInstruction(newAnyCallTestArgs("0xswap"), mpc); err == nil {
+		t.Errorf("mpc in app accounts should be rejected")
+	}
+
+	setAnyCallTestConfig(t)
+	params.GetExtraConfig().LocalChainConfig = nil
+	if _, err := buildAnyCallInstruction(newAnyCallTestArgs("0xswap"), mpc); err == nil {
+		t.Errorf("anycall without executor should be rejected")
+	}
+}
+
+func TestVerifyAnyCallInstruction(t *testing.T) {
+	setAnyCallTestConfig(t, testAnyCallAccount+":w")
+
+	mpc := types.MustPublicKeyFromBase58(testAnyCallMPC)
+	buildTx := func(swapID string) *types.Transaction {
+		instruction, err := buildAnyCallInstruction(newAnyCallTestArgs(swapID), mpc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tx, err := types.NewTransaction([]types.TransactionInstruction{instruction}, types.Hash{1}, types.TransactionPayer(mpc))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tx
+	}
+
+	tx := buildTx("0xswap")
+	instruction := tx.Message.Instructions[0]
+	if err := verifyAnyCallInstruction(tx, instruction, newAnyCallTestArgs("0xswap")); err != nil {
+		t.Errorf("verify anycall instruction failed: %v", err)
+	}
+	if err := verifyAnyCallInstruction(tx, instruction, newAnyCallTestArgs("0xother")); err == nil {
+		t.Errorf("verify anycall instruction of other swap should fail")
+	}
+
+	tampered := buildTx("0xswap")
+	tampered.Message.Instructions[0].Data = append(tampered.Message.Instructions[0].Data, 0)
+	if err := verifyAnyCallInstruction(tampered, tampered.Message.Instructions[0], newAnyCallTestArgs("0xswap")); err == nil {
+		t.Errorf("verify anycall instruction with tampered data should fail")
+	}
+
+	tampered = buildTx("0xswap")
+	accounts := tampered.Message.Instructions[0].Accounts
+	accounts[1], accounts[2] = accounts[2], accounts[1]
+	if err := verifyAnyCallInstruction(tampered, tampered.Message.Instructions[0], newAnyCallTestArgs("0xswap")); err == nil {
+		t.Errorf("verify anycall instruction with swapped accounts should fail")
+	}
+}
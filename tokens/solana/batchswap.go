@@ -0,0 +1,81 @@
+package solana
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/anyswap/CrossChain-Router/v3/log"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+	routerprog "github.com/anyswap/CrossChain-Router/v3/tokens/solana/programs/router"
+	"github.com/anyswap/CrossChain-Router/v3/tokens/solana/types"
+)
+
+// maxTransactionSize max size of a serialized tx (packet data size)
+const maxTransactionSize = 1232
+
+// IsBatchSwapSupported impl tokens.BatchSwapBuilder
+func (b *Bridge) IsBatchSwapSupported() bool {
+	return true
+}
+
+// BuildBatchRawTransaction build batch swapin tx (one swapin instruction per swap)
+func (b *Bridge) BuildBatchRawTransaction(args *tokens.BuildTxArgs, swaps []*tokens.BuildTxArgs) (rawTx interface{}, err error) {
+	if args.ToChainID.String() != b.ChainConfig.ChainID {
+		return nil, tokens.ErrToChainIDMismatch
+	}
+	err = tokens.CheckBatchSwaps(args, swaps)
+	if err != nil {
+		return nil, err
+	}
+	multichainToken, tokenCfg, err := b.getSwapinTokenConfig(swaps[0])
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		mpc          types.PublicKey
+		instruction  *routerprog.Instruction
+		instructions = make([]types.TransactionInstruction, len(swaps))
+		swapValue    = big.NewInt(0)
+	)
+	for i, swap := range swaps {
+		switch {
+		case tokens.IsNativeCoin(multichainToken):
+			instruction, mpc, err = b.buildSwapinNativeInstruction(swap, tokenCfg)
+		case tokenCfg.ContractVersion == 0:
+			instruction, mpc, err = b.buildSwapinTransferInstruction(swap, tokenCfg)
+		default:
+			instruction, mpc, err = b.buildSwapinMintInstruction(swap, tokenCfg)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if mpc.String() != args.From {
+			return nil, tokens.ErrSenderMismatch
+		}
+		instructions[i] = instruction
+		swapValue.Add(swapValue, swap.SwapValue)
+	}
+	args.SwapValue = swapValue
+	args.SetBatchSwaps(swaps)
+
+	tx, err := b.newSwapinTransaction(args, mpc, instructions...)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := tx.Message.Serialize()
+	if err != nil {
+		return nil, err
+	}
+	if txSize := 1 + 64*len(tx.Message.SignerKeys()) + len(msg); txSize > maxTransactionSize {
+		return nil, fmt.Errorf("%w: tx size %v exceeds %v", tokens.ErrTooManyBatchSwaps, txSize, maxTransactionSize)
+	}
+	log.Info("build batch swapin tx", "chainID", b.ChainConfig.ChainID, "tokenID", swaps[0].GetTokenID(), "count", len(swaps), "swapValue", swapValue)
+
+	_, err = b.SimulateTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
@@ -3,6 +3,7 @@ package solana
 import (
 	"fmt"
 	"math/big"
+	"sync"
 
 	"github.com/anyswap/CrossChain-Router/v3/common"
 	"github.com/anyswap/CrossChain-Router/v3/log"
@@ -10,6 +11,7 @@ import (
 	"github.com/anyswap/CrossChain-Router/v3/router"
 	"github.com/anyswap/CrossChain-Router/v3/tokens"
 	"github.com/anyswap/CrossChain-Router/v3/tokens/base"
+	"github.com/anyswap/CrossChain-Router/v3/tokens/gasoracle"
 	routerprog "github.com/anyswap/CrossChain-Router/v3/tokens/solana/programs/router"
 	"github.com/anyswap/CrossChain-Router/v3/tokens/solana/types"
 )
@@ -27,6 +29,9 @@ var (
 type Bridge struct {
 	*tokens.CrossChainBridgeBase
 	*base.ReSwapableBridgeBase
+
+	gasOracle     *gasoracle.Oracle
+	gasOracleOnce sync.Once
 }
 
 // NewCrossChainBridge new bridge
@@ -72,6 +77,18 @@ func (b *Bridge) InitAfterConfig() {
 			b.ReSwapableBridgeBase.SetReswapMaxValueRate(reswapMaxAmountRate)
 		}
 	}
+	b.getGasOracle()
+}
+
+// getGasOracle get priority fee oracle (init and register it at the first time)
+func (b *Bridge) getGasOracle() *gasoracle.Oracle {
+	b.gasOracleOnce.Do(func() {
+		b.gasOracle = gasoracle.NewOracle(b.ChainConfig.ChainID,
+			gasoracle.NewSource("getRecentPrioritizationFees", b.getPriorityFee),
+		)
+		gasoracle.Register(b.gasOracle)
+	})
+	return b.gasOracle
 }
 
 // SetGatewayConfig set gateway config
@@ -1,43 +1,56 @@
 package solana
 
 import (
+	"fmt"
+	"math/big"
+
 	"github.com/anyswap/CrossChain-Router/v3/log"
+	"github.com/anyswap/CrossChain-Router/v3/params"
 	"github.com/anyswap/CrossChain-Router/v3/router"
 	"github.com/anyswap/CrossChain-Router/v3/tokens"
+	"github.com/anyswap/CrossChain-Router/v3/tokens/solana/programs/computebudget"
 	routerprog "github.com/anyswap/CrossChain-Router/v3/tokens/solana/programs/router"
 	"github.com/anyswap/CrossChain-Router/v3/tokens/solana/programs/system"
 	"github.com/anyswap/CrossChain-Router/v3/tokens/solana/programs/token"
 	"github.com/anyswap/CrossChain-Router/v3/tokens/solana/types"
 )
 
+// default max priority fee (micro-lamports per compute unit)
+const defaultMaxPriorityFee = 1000000
+
 // BuildRawTransaction impl
 func (b *Bridge) BuildRawTransaction(args *tokens.BuildTxArgs) (rawTx interface{}, err error) {
 	if args.ToChainID.String() != b.ChainConfig.ChainID {
 		return nil, tokens.ErrToChainIDMismatch
 	}
-	if args.SwapType != tokens.ERC20SwapType {
+	var tx *types.Transaction
+	switch args.SwapType {
+	case tokens.ERC20SwapType:
+		tx, err = b.buildSwapinTransaction(args)
+	case tokens.NFTSwapType:
+		tx, err = b.buildNFTSwapinTransaction(args)
+	case tokens.AnyCallSwapType:
+		tx, err = b.buildAnyCallSwapTx(args)
+	default:
 		return nil, tokens.ErrSwapTypeNotSupported
 	}
-	if args.ERC20SwapInfo == nil || args.ERC20SwapInfo.TokenID == "" {
-		return nil, tokens.ErrEmptyTokenID
+	if err != nil {
+		return nil, err
 	}
 
-	erc20SwapInfo := args.ERC20SwapInfo
-	tokenID := erc20SwapInfo.TokenID
-	chainID := b.ChainConfig.ChainID
-
-	multichainToken := router.GetCachedMultichainToken(tokenID, chainID)
-	if multichainToken == "" {
-		log.Warn("get multichain token failed", "tokenID", tokenID, "chainID", chainID)
-		return nil, tokens.ErrMissTokenConfig
+	_, err = b.SimulateTransaction(tx)
+	if err != nil {
+		return nil, err
 	}
+	return tx, nil
+}
 
-	tokenCfg := b.GetTokenConfig(multichainToken)
-	if tokenCfg == nil {
-		return nil, tokens.ErrMissTokenConfig
+func (b *Bridge) buildSwapinTransaction(args *tokens.BuildTxArgs) (tx *types.Transaction, err error) {
+	multichainToken, tokenCfg, err := b.getSwapinTokenConfig(args)
+	if err != nil {
+		return nil, err
 	}
 
-	var tx *types.Transaction
 	if tokens.IsNativeCoin(multichainToken) {
 		tx, err = b.BuildSwapinNativeTransaction(args, tokenCfg)
 	} else if tokenCfg.ContractVersion == 0 {
@@ -45,15 +58,28 @@ func (b *Bridge) BuildRawTransaction(args *tokens.BuildTxArgs) (rawTx interface{
 	} else {
 		tx, err = b.BuildSwapinMintTransaction(args, tokenCfg)
 	}
-	if err != nil {
-		return nil, err
+	return tx, err
+}
+
+func (b *Bridge) getSwapinTokenConfig(args *tokens.BuildTxArgs) (multichainToken string, tokenCfg *tokens.TokenConfig, err error) {
+	if args.ERC20SwapInfo == nil || args.ERC20SwapInfo.TokenID == "" {
+		return "", nil, tokens.ErrEmptyTokenID
 	}
 
-	_, err = b.SimulateTransaction(tx)
-	if err != nil {
-		return nil, err
+	tokenID := args.ERC20SwapInfo.TokenID
+	chainID := b.ChainConfig.ChainID
+
+	multichainToken = router.GetCachedMultichainToken(tokenID, chainID)
+	if multichainToken == "" {
+		log.Warn("get multichain token failed", "tokenID", tokenID, "chainID", chainID)
+		return "", nil, tokens.ErrMissTokenConfig
 	}
-	return tx, nil
+
+	tokenCfg = b.GetTokenConfig(multichainToken)
+	if tokenCfg == nil {
+		return "", nil, tokens.ErrMissTokenConfig
+	}
+	return multichainToken, tokenCfg, nil
 }
 
 func (b *Bridge) getReceiverAndAmount(args *tokens.BuildTxArgs, multichainToken string) (receiver types.PublicKey, amount uint64, err error) {
@@ -86,30 +112,39 @@ func (b *Bridge) getReceiverAndAmount(args *tokens.BuildTxArgs, multichainToken
 
 // BuildSwapinMintTransaction build swapin mint tx
 func (b *Bridge) BuildSwapinMintTransaction(args *tokens.BuildTxArgs, tokenCfg *tokens.TokenConfig) (*types.Transaction, error) {
-	receiver, amount, err := b.getReceiverAndAmount(args, tokenCfg.ContractAddress)
+	instruction, mpc, err := b.buildSwapinMintInstruction(args, tokenCfg)
 	if err != nil {
 		return nil, err
 	}
+	return b.newSwapinTransaction(args, mpc, instruction)
+}
+
+func (b *Bridge) buildSwapinMintInstruction(args *tokens.BuildTxArgs, tokenCfg *tokens.TokenConfig) (*routerprog.Instruction, types.PublicKey, error) {
+	var mpc types.PublicKey
+	receiver, amount, err := b.getReceiverAndAmount(args, tokenCfg.ContractAddress)
+	if err != nil {
+		return nil, mpc, err
+	}
 	routerInfo, err := router.GetTokenRouterInfo(tokenCfg.TokenID, b.ChainConfig.ChainID)
 	if err != nil {
-		return nil, err
+		return nil, mpc, err
 	}
-	mpc, err := types.PublicKeyFromBase58(routerInfo.RouterMPC)
+	mpc, err = types.PublicKeyFromBase58(routerInfo.RouterMPC)
 	if err != nil {
-		return nil, err
+		return nil, mpc, err
 	}
 	routerAccount, err := types.PublicKeyFromBase58(routerInfo.RouterPDA)
 	if err != nil {
-		return nil, err
+		return nil, mpc, err
 	}
 	tokenMint, err := types.PublicKeyFromBase58(tokenCfg.ContractAddress)
 	if err != nil {
-		return nil, err
+		return nil, mpc, err
 	}
 	routerContract := b.GetRouterContract(tokenCfg.ContractAddress)
 	routerContractPubkey, err := types.PublicKeyFromBase58(routerContract)
 	if err != nil {
-		return nil, err
+		return nil, mpc, err
 	}
 
 	instruction := routerprog.NewSwapinMintInstruction(
@@ -118,49 +153,48 @@ func (b *Bridge) BuildSwapinMintTransaction(args *tokens.BuildTxArgs, tokenCfg *
 	)
 	log.Info("BuildSwapinMintTransaction", "mpc", mpc.String(), "routerAccount", routerAccount.String(), "receiver", receiver.String(), "tokenMint", tokenMint.String())
 	instruction.RouterProgramID = routerContractPubkey
-	instructions := []types.TransactionInstruction{instruction}
+	return instruction, mpc, nil
+}
 
-	err = b.setExtraArgs(args)
-	if err != nil {
-		return nil, err
-	}
-	recentBlockHash, err := types.PublicKeyFromBase58(*args.Extra.BlockHash)
+// BuildSwapinTransferTransaction build swapin transfer tx
+func (b *Bridge) BuildSwapinTransferTransaction(args *tokens.BuildTxArgs, tokenCfg *tokens.TokenConfig) (*types.Transaction, error) {
+	instruction, mpc, err := b.buildSwapinTransferInstruction(args, tokenCfg)
 	if err != nil {
 		return nil, err
 	}
-	return types.NewTransaction(instructions, recentBlockHash, types.TransactionPayer(mpc))
+	return b.newSwapinTransaction(args, mpc, instruction)
 }
 
-// BuildSwapinTransferTransaction build swapin transfer tx
-func (b *Bridge) BuildSwapinTransferTransaction(args *tokens.BuildTxArgs, tokenCfg *tokens.TokenConfig) (*types.Transaction, error) {
+func (b *Bridge) buildSwapinTransferInstruction(args *tokens.BuildTxArgs, tokenCfg *tokens.TokenConfig) (*routerprog.Instruction, types.PublicKey, error) {
+	var mpc types.PublicKey
 	receiver, amount, err := b.getReceiverAndAmount(args, tokenCfg.ContractAddress)
 	if err != nil {
-		return nil, err
+		return nil, mpc, err
 	}
 	routerInfo, err := router.GetTokenRouterInfo(tokenCfg.TokenID, b.ChainConfig.ChainID)
 	if err != nil {
-		return nil, err
+		return nil, mpc, err
 	}
-	mpc, err := types.PublicKeyFromBase58(routerInfo.RouterMPC)
+	mpc, err = types.PublicKeyFromBase58(routerInfo.RouterMPC)
 	if err != nil {
-		return nil, err
+		return nil, mpc, err
 	}
 	routerAccount, err := types.PublicKeyFromBase58(routerInfo.RouterPDA)
 	if err != nil {
-		return nil, err
+		return nil, mpc, err
 	}
 	tokenMint, err := types.PublicKeyFromBase58(tokenCfg.ContractAddress)
 	if err != nil {
-		return nil, err
+		return nil, mpc, err
 	}
 	ata, err := types.FindAssociatedTokenAddress(routerAccount, tokenMint)
 	if err != nil {
-		return nil, err
+		return nil, mpc, err
 	}
 	routerContract := b.GetRouterContract(tokenCfg.ContractAddress)
 	routerContractPubkey, err := types.PublicKeyFromBase58(routerContract)
 	if err != nil {
-		return nil, err
+		return nil, mpc, err
 	}
 
 	instruction := routerprog.NewSwapinTransferInstruction(
@@ -170,41 +204,40 @@ func (b *Bridge) BuildSwapinTransferTransaction(args *tokens.BuildTxArgs, tokenC
 
 	log.Info("BuildSwapinTransferTransaction", "mpc", mpc.String(), "routerAccount", routerAccount.String(), "ata", ata.String(), "receiver", receiver.String(), "tokenMint", tokenMint.String())
 	instruction.RouterProgramID = routerContractPubkey
-	instructions := []types.TransactionInstruction{instruction}
+	return instruction, mpc, nil
+}
 
-	err = b.setExtraArgs(args)
-	if err != nil {
-		return nil, err
-	}
-	recentBlockHash, err := types.PublicKeyFromBase58(*args.Extra.BlockHash)
+// BuildSwapinNativeTransaction build swapin native tx
+func (b *Bridge) BuildSwapinNativeTransaction(args *tokens.BuildTxArgs, tokenCfg *tokens.TokenConfig) (*types.Transaction, error) {
+	instruction, mpc, err := b.buildSwapinNativeInstruction(args, tokenCfg)
 	if err != nil {
 		return nil, err
 	}
-	return types.NewTransaction(instructions, recentBlockHash, types.TransactionPayer(mpc))
+	return b.newSwapinTransaction(args, mpc, instruction)
 }
 
-// BuildSwapinNativeTransaction build swapin native tx
-func (b *Bridge) BuildSwapinNativeTransaction(args *tokens.BuildTxArgs, tokenCfg *tokens.TokenConfig) (*types.Transaction, error) {
+func (b *Bridge) buildSwapinNativeInstruction(args *tokens.BuildTxArgs, tokenCfg *tokens.TokenConfig) (*routerprog.Instruction, types.PublicKey, error) {
+	var mpc types.PublicKey
 	receiver, amount, err := b.getReceiverAndAmount(args, tokenCfg.ContractAddress)
 	if err != nil {
-		return nil, err
+		return nil, mpc, err
 	}
 	routerInfo, err := router.GetTokenRouterInfo(tokenCfg.TokenID, b.ChainConfig.ChainID)
 	if err != nil {
-		return nil, err
+		return nil, mpc, err
 	}
-	mpc, err := types.PublicKeyFromBase58(routerInfo.RouterMPC)
+	mpc, err = types.PublicKeyFromBase58(routerInfo.RouterMPC)
 	if err != nil {
-		return nil, err
+		return nil, mpc, err
 	}
 	routerAccount, err := types.PublicKeyFromBase58(routerInfo.RouterPDA)
 	if err != nil {
-		return nil, err
+		return nil, mpc, err
 	}
 	routerContract := b.GetRouterContract(tokenCfg.ContractAddress)
 	routerContractPubkey, err := types.PublicKeyFromBase58(routerContract)
 	if err != nil {
-		return nil, err
+		return nil, mpc, err
 	}
 
 	instruction := routerprog.NewSwapinNativeInstruction(
@@ -213,17 +246,22 @@ func (b *Bridge) BuildSwapinNativeTransaction(args *tokens.BuildTxArgs, tokenCfg
 	)
 	log.Info("BuildSwapinNativeTransaction", "mpc", mpc.String(), "routerAccount", routerAccount.String(), "receiver", receiver.String())
 	instruction.RouterProgramID = routerContractPubkey
-	instructions := []types.TransactionInstruction{instruction}
+	return instruction, mpc, nil
+}
 
-	err = b.setExtraArgs(args)
+func (b *Bridge) newSwapinTransaction(args *tokens.BuildTxArgs, mpc types.PublicKey, instructions ...types.TransactionInstruction) (*types.Transaction, error) {
+	err := b.setExtraArgs(args)
 	if err != nil {
 		return nil, err
 	}
-	blockHash, err := types.PublicKeyFromBase58(*args.Extra.BlockHash)
+	recentBlockHash, err := types.PublicKeyFromBase58(*args.Extra.BlockHash)
 	if err != nil {
 		return nil, err
 	}
-	return types.NewTransaction(instructions, blockHash, types.TransactionPayer(mpc))
+	if priorityFee := args.Extra.GasPrice; priorityFee != nil && priorityFee.Sign() > 0 {
+		instructions = append(instructions, computebudget.NewSetComputeUnitPriceInstruction(priorityFee.Uint64()))
+	}
+	return types.NewTransaction(instructions, recentBlockHash, types.TransactionPayer(mpc))
 }
 
 func (b *Bridge) setExtraArgs(args *tokens.BuildTxArgs) error {
@@ -241,10 +279,48 @@ func (b *Bridge) setExtraArgs(args *tokens.BuildTxArgs) error {
 		extra.BlockHash = &blockhash
 		b.ReSwapableBridgeBase.SetTxTimeout(args, &blockHeight)
 	}
-	log.Info("BuildSwapin", "BlockHash", extra.BlockHash, "blockHeight", extra.Sequence)
+	if err := b.setPriorityFee(args); err != nil {
+		return err
+	}
+	log.Info("BuildSwapin", "BlockHash", extra.BlockHash, "blockHeight", extra.Sequence, "priorityFee", extra.GasPrice)
 	return nil
 }
 
+// setPriorityFee set priority fee (micro-lamports per compute unit) in `Extra.GasPrice`
+func (b *Bridge) setPriorityFee(args *tokens.BuildTxArgs) error {
+	maxPriorityFee := b.getMaxPriorityFee()
+	extra := args.Extra
+	if extra.GasPrice != nil {
+		// the priority fee is set by server, check it as oracles have no gas oracle config
+		if !extra.GasPrice.IsUint64() || extra.GasPrice.Uint64() > maxPriorityFee {
+			return fmt.Errorf("priority fee %v exceeded maximum limit %v", extra.GasPrice, maxPriorityFee)
+		}
+		return nil
+	}
+	if params.GetGasOracleConfig(b.ChainConfig.ChainID) == nil {
+		return nil
+	}
+	priorityFee, err := b.getGasOracle().GasPrice()
+	if err != nil {
+		log.Warn("get priority fee failed", "chainID", b.ChainConfig.ChainID, "err", err)
+		return nil
+	}
+	if !priorityFee.IsUint64() || priorityFee.Uint64() > maxPriorityFee {
+		log.Warn("priority fee exceeded maximum limit", "chainID", b.ChainConfig.ChainID, "priorityFee", priorityFee, "max", maxPriorityFee)
+		priorityFee = new(big.Int).SetUint64(maxPriorityFee)
+	}
+	extra.GasPrice = priorityFee
+	return nil
+}
+
+// getMaxPriorityFee get max priority fee from local chain config (shared by server and oracles)
+func (b *Bridge) getMaxPriorityFee() uint64 {
+	if maxPriorityFee := params.GetLocalChainConfig(b.ChainConfig.ChainID).MaxPriorityFee; maxPriorityFee > 0 {
+		return maxPriorityFee
+	}
+	return defaultMaxPriorityFee
+}
+
 // BuildMintSPLTransaction build mint spl token tx
 func (b *Bridge) BuildMintSPLTransaction(amount uint64, mintAddr, toAddr, minterAddr string) (*types.Transaction, error) {
 	mint, err := types.PublicKeyFromBase58(mintAddr)
@@ -0,0 +1,32 @@
+package solana
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+)
+
+func TestSetPriorityFee(t *testing.T) {
+	b := NewCrossChainBridge()
+	b.SetChainConfig(&tokens.ChainConfig{ChainID: "245022934"})
+
+	cases := []struct {
+		PriorityFee *big.Int
+		WantErr     bool
+	}{
+		{nil, false}, // gas oracle is not configed
+		{big.NewInt(0), false},
+		{big.NewInt(defaultMaxPriorityFee), false},
+		{big.NewInt(defaultMaxPriorityFee + 1), true},
+		{new(big.Int).Lsh(big.NewInt(1), 64), true},
+	}
+
+	for i, c := range cases {
+		args := &tokens.BuildTxArgs{Extra: &tokens.AllExtras{GasPrice: c.PriorityFee}}
+		err := b.setPriorityFee(args)
+		if (err != nil) != c.WantErr {
+			t.Errorf("case %v priority fee %v: want error %v, but got %v", i, c.PriorityFee, c.WantErr, err)
+		}
+	}
+}
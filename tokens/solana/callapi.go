@@ -3,8 +3,10 @@ package solana
 import (
 	"math/big"
 
+	"github.com/anyswap/CrossChain-Router/v3/params"
 	"github.com/anyswap/CrossChain-Router/v3/rpc/client"
 	"github.com/anyswap/CrossChain-Router/v3/tokens"
+	"github.com/anyswap/CrossChain-Router/v3/tokens/gasoracle"
 	"github.com/anyswap/CrossChain-Router/v3/tokens/solana/types"
 )
 
@@ -248,3 +250,35 @@ func (b *Bridge) GetBlocks(start, end uint64) (*[]uint64, error) {
 	}
 	return result, nil
 }
+
+// GetRecentPrioritizationFees get prioritization fees (in micro-lamports per compute unit) of recent slots
+func (b *Bridge) GetRecentPrioritizationFees(accounts []string) (result types.GetRecentPrioritizationFeesResult, err error) {
+	callMethod := "getRecentPrioritizationFees"
+	err = RPCCall(&result, b.GatewayConfig.AllGatewayURLs, callMethod, accounts)
+	return result, err
+}
+
+// getPriorityFee get percentile of recent prioritization fees.
+// return nil if gas oracle is not configed (priority fee is disabled).
+func (b *Bridge) getPriorityFee() (*big.Int, error) {
+	cfg := params.GetGasOracleConfig(b.ChainConfig.ChainID)
+	if cfg == nil {
+		return nil, nil
+	}
+	fees, err := b.GetRecentPrioritizationFees([]string{})
+	if err != nil {
+		return nil, err
+	}
+	if len(fees) == 0 {
+		return nil, wrapRPCQueryError(nil, "getRecentPrioritizationFees")
+	}
+	values := make([]*big.Int, len(fees))
+	for i, fee := range fees {
+		values[i] = new(big.Int).SetUint64(fee.PrioritizationFee)
+	}
+	fee := gasoracle.Percentile(values, gasoracle.GetPercentile(cfg))
+	if fee.Sign() == 0 {
+		fee.SetUint64(1) // at least 1 micro-lamport to be prioritized
+	}
+	return fee, nil
+}
@@ -0,0 +1,372 @@
+package solana
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+
+	"github.com/anyswap/CrossChain-Router/v3/common"
+	"github.com/anyswap/CrossChain-Router/v3/log"
+	"github.com/anyswap/CrossChain-Router/v3/params"
+	"github.com/anyswap/CrossChain-Router/v3/router"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+	routerprog "github.com/anyswap/CrossChain-Router/v3/tokens/solana/programs/router"
+	"github.com/anyswap/CrossChain-Router/v3/tokens/solana/types"
+)
+
+var (
+	// ensure Bridge impl tokens.NFTIDMapper
+	_ tokens.NFTIDMapper = &Bridge{}
+	// ensure Bridge impl tokens.NFTSwapChecker
+	_ tokens.NFTSwapChecker = &Bridge{}
+
+	// Program log: NFTSwapout 0xdce8e16a5b685b7713436b4adf4ffd66bd0387d8 <collection> <mint> <id> 666
+	nftSwapoutLogPattern        = regexp.MustCompile(`^Program log: NFTSwapout (\S+) (\w+) (\w+) (\d+) (\d+)$`)
+	nftSwapoutInstructionPrefix = "Program log: Instruction: NftSwapout"
+	nftSwapoutLogPrefix         = "Program log: NFTSwapout "
+
+	errWrongNFTIDs = fmt.Errorf("%w: solana only supports swap of single nft", tokens.ErrNFTSwapNotSupported)
+)
+
+// CheckNFTSwap impl tokens.NFTSwapChecker, only swap of single nft is supported
+func (b *Bridge) CheckNFTSwap(info *tokens.NFTSwapInfo) error {
+	if info == nil || !info.IsSingleNFT() {
+		return errWrongNFTIDs
+	}
+	return nil
+}
+
+// GetNFTNativeID impl tokens.NFTIDMapper, the native id is the nft mint.
+// nft of native collection (contract version is 0) is identified by its mint address as uint256,
+// nft minted by router program is at the pda of collection and id.
+func (b *Bridge) GetNFTNativeID(token string, id *big.Int) (string, error) {
+	mint, err := b.getNFTMint(token, id)
+	if err != nil {
+		return "", err
+	}
+	return mint.String(), nil
+}
+
+func (b *Bridge) getNFTMint(token string, id *big.Int) (mint types.PublicKey, err error) {
+	tokenCfg := b.GetTokenConfig(token)
+	if tokenCfg == nil {
+		return mint, tokens.ErrMissTokenConfig
+	}
+	if id == nil || id.Sign() < 0 || id.BitLen() > 256 {
+		return mint, fmt.Errorf("invalid nft id %v", id)
+	}
+	var id32 [32]byte
+	copy(id32[:], common.LeftPadBytes(id.Bytes(), 32))
+	if tokenCfg.ContractVersion == 0 {
+		return types.PublicKey(id32), nil
+	}
+	collection, err := types.PublicKeyFromBase58(tokenCfg.ContractAddress)
+	if err != nil {
+		return mint, err
+	}
+	routerProgram, err := types.PublicKeyFromBase58(b.GetRouterContract(token))
+	if err != nil {
+		return mint, err
+	}
+	return routerprog.FindNFTMintAddress(routerProgram, collection, id32)
+}
+
+func (b *Bridge) registerNFTSwapTx(txHash string, logIndex int) ([]*tokens.SwapTxInfo, []error) {
+	commonInfo := &tokens.SwapTxInfo{SwapInfo: tokens.SwapInfo{NFTSwapInfo: &tokens.NFTSwapInfo{}}}
+	commonInfo.SwapType = tokens.NFTSwapType // SwapType
+	commonInfo.Hash = txHash                 // Hash
+	commonInfo.LogIndex = logIndex           // LogIndex
+	isSpecifiedLogIndex := logIndex > 0
+
+	txm, err := b.getTransactionMeta(commonInfo, true)
+	if err != nil {
+		return []*tokens.SwapTxInfo{commonInfo}, []error{err}
+	}
+	logMessages := txm.LogMessages
+
+	if logIndex >= len(logMessages) || logIndex < 0 {
+		return []*tokens.SwapTxInfo{commonInfo}, []error{tokens.ErrLogIndexOutOfRange}
+	}
+
+	routerProgramID := b.ChainConfig.RouterContract
+	invokeStart := fmt.Sprintf("Program %s invoke [", routerProgramID)
+
+	swapInfos := make([]*tokens.SwapTxInfo, 0)
+	errs := make([]error, 0)
+
+	for i, msg := range logMessages[logIndex:] {
+		if !strings.HasPrefix(msg, invokeStart) {
+			continue
+		}
+		swapInfo := &tokens.SwapTxInfo{}
+		*swapInfo = *commonInfo
+		swapInfo.NFTSwapInfo = &tokens.NFTSwapInfo{}
+		swapInfo.LogIndex = logIndex + i // LogIndex
+		err = b.verifyNFTSwapoutLogs(swapInfo, logMessages)
+		if err == nil {
+			err = b.checkNFTSwapInfo(swapInfo)
+		}
+		if err != nil {
+			log.Debug(b.ChainConfig.BlockChain+" register nft swap error", "txHash", txHash, "logIndex", swapInfo.LogIndex, "err", err)
+		}
+		swapInfos = append(swapInfos, swapInfo)
+		errs = append(errs, err)
+		if isSpecifiedLogIndex {
+			break
+		}
+	}
+
+	if len(swapInfos) == 0 {
+		return []*tokens.SwapTxInfo{commonInfo}, []error{tokens.ErrSwapoutLogNotFound}
+	}
+
+	return swapInfos, errs
+}
+
+func (b *Bridge) verifyNFTSwapTx(txHash string, args *tokens.VerifyArgs) (*tokens.SwapTxInfo, error) {
+	swapInfo := &tokens.SwapTxInfo{SwapInfo: tokens.SwapInfo{NFTSwapInfo: &tokens.NFTSwapInfo{}}}
+	swapInfo.SwapType = tokens.NFTSwapType // SwapType
+	swapInfo.Hash = txHash                 // Hash
+	swapInfo.LogIndex = args.LogIndex      // LogIndex
+
+	allowUnstable := args.AllowUnstable
+	txm, err := b.getTransactionMeta(swapInfo, allowUnstable)
+	if err != nil {
+		return swapInfo, err
+	}
+
+	err = b.verifyNFTSwapoutLogs(swapInfo, txm.LogMessages)
+	if err != nil {
+		return swapInfo, err
+	}
+
+	err = b.checkNFTSwapInfo(swapInfo)
+	if err != nil {
+		return swapInfo, err
+	}
+
+	if params.IsSwapoutForbidden(b.ChainConfig.ChainID, swapInfo.NFTSwapInfo.TokenID) {
+		return swapInfo, tokens.ErrSwapoutForbidden
+	}
+
+	if !allowUnstable {
+		log.Info("verify nft swap tx stable pass", "identifier", params.GetIdentifier(),
+			"from", swapInfo.From, "to", swapInfo.To, "bind", swapInfo.Bind,
+			"txid", txHash, "logIndex", swapInfo.LogIndex, "height", swapInfo.Height, "timestamp", swapInfo.Timestamp,
+			"fromChainID", swapInfo.FromChainID, "toChainID", swapInfo.ToChainID,
+			"token", swapInfo.NFTSwapInfo.Token, "tokenID", swapInfo.NFTSwapInfo.TokenID,
+			"ids", swapInfo.NFTSwapInfo.IDs)
+	}
+
+	return swapInfo, nil
+}
+
+func (b *Bridge) verifyNFTSwapoutLogs(swapInfo *tokens.SwapTxInfo, logMessages []string) error {
+	swapoutLog, err := b.findSwapoutLog(swapInfo, logMessages, nftSwapoutInstructionPrefix, nftSwapoutLogPrefix)
+	if err != nil {
+		return err
+	}
+
+	matches := nftSwapoutLogPattern.FindStringSubmatch(swapoutLog)
+	if len(matches) != 6 {
+		return tokens.ErrSwapoutLogNotFound
+	}
+
+	// matches parts is (to, collection, mint, id, to_chainid)
+	nftSwapInfo := swapInfo.NFTSwapInfo
+	swapInfo.Bind = matches[1]
+	nftSwapInfo.Token = matches[2]
+	tokenCfg := b.GetTokenConfig(nftSwapInfo.Token)
+	if tokenCfg == nil {
+		return tokens.ErrMissTokenConfig
+	}
+	nftSwapInfo.TokenID = tokenCfg.TokenID
+	id, err := common.GetBigIntFromStr(matches[4])
+	if err != nil {
+		return err
+	}
+	mint, err := b.getNFTMint(nftSwapInfo.Token, id)
+	if err != nil {
+		return err
+	}
+	if mint.String() != matches[3] {
+		log.Warn("nft swapout mint mismatch", "txid", swapInfo.Hash, "logIndex", swapInfo.LogIndex, "id", id, "have", matches[3], "want", mint.String())
+		return tokens.ErrNFTNativeIDMismatch
+	}
+	nftSwapInfo.IDs = []*big.Int{id}
+	swapInfo.Value = big.NewInt(0)
+	swapInfo.FromChainID = b.ChainConfig.GetChainID()
+	toChainID, err := common.GetUint64FromStr(matches[5])
+	if err != nil {
+		return err
+	}
+	swapInfo.ToChainID = new(big.Int).SetUint64(toChainID)
+	return nil
+}
+
+func (b *Bridge) checkNFTSwapInfo(swapInfo *tokens.SwapTxInfo) error {
+	if swapInfo.FromChainID.String() != b.ChainConfig.ChainID {
+		log.Error("nft swap tx with mismatched fromChainID", "txid", swapInfo.Hash, "logIndex", swapInfo.LogIndex, "fromChainID", swapInfo.FromChainID, "toChainID", swapInfo.ToChainID, "chainID", b.ChainConfig.ChainID)
+		return tokens.ErrFromChainIDMismatch
+	}
+	if swapInfo.FromChainID.Cmp(swapInfo.ToChainID) == 0 {
+		return tokens.ErrSameFromAndToChainID
+	}
+	nftSwapInfo := swapInfo.NFTSwapInfo
+	if nftSwapInfo.TokenID == "" {
+		return tokens.ErrMissTokenConfig
+	}
+	multichainToken := router.GetCachedMultichainToken(nftSwapInfo.TokenID, swapInfo.ToChainID.String())
+	if multichainToken == "" {
+		log.Warn("get multichain token failed", "tokenID", nftSwapInfo.TokenID, "chainID", swapInfo.ToChainID)
+		return tokens.ErrMissTokenConfig
+	}
+	dstBridge := router.GetBridgeByChainID(swapInfo.ToChainID.String())
+	if dstBridge == nil {
+		return tokens.ErrNoBridgeForChainID
+	}
+	if !dstBridge.IsValidAddress(swapInfo.Bind) {
+		log.Warn("wrong bind address in nft swap", "txid", swapInfo.Hash, "logIndex", swapInfo.LogIndex, "bind", swapInfo.Bind, "toChainID", swapInfo.ToChainID)
+		return tokens.ErrWrongBindAddress
+	}
+	// dest chain may support part of nft swaps
+	if checker, ok := dstBridge.(tokens.NFTSwapChecker); ok {
+		if err := checker.CheckNFTSwap(swapInfo.NFTSwapInfo); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildNFTSwapinTransaction build nft swapin tx.
+// nft of native collection (contract version is 0) is transferred from the vault of router account,
+// otherwise the nft is minted (or released from vault if swapped out before) by router program.
+func (b *Bridge) buildNFTSwapinTransaction(args *tokens.BuildTxArgs) (*types.Transaction, error) {
+	nftSwapInfo := args.NFTSwapInfo
+	if nftSwapInfo == nil || nftSwapInfo.TokenID == "" {
+		return nil, tokens.ErrEmptyTokenID
+	}
+	if err := b.CheckNFTSwap(nftSwapInfo); err != nil {
+		return nil, err
+	}
+
+	tokenID := nftSwapInfo.TokenID
+	chainID := b.ChainConfig.ChainID
+	multichainToken := router.GetCachedMultichainToken(tokenID, chainID)
+	if multichainToken == "" {
+		log.Warn("get multichain token failed", "tokenID", tokenID, "chainID", chainID)
+		return nil, tokens.ErrMissTokenConfig
+	}
+	tokenCfg := b.GetTokenConfig(multichainToken)
+	if tokenCfg == nil {
+		return nil, tokens.ErrMissTokenConfig
+	}
+
+	receiver, err := types.PublicKeyFromBase58(args.Bind)
+	if err != nil {
+		log.Warn("nft swapout to wrong receiver", "receiver", args.Bind, "err", err)
+		return nil, tokens.ErrWrongBindAddress
+	}
+	routerInfo, err := router.GetTokenRouterInfo(tokenID, chainID)
+	if err != nil {
+		return nil, err
+	}
+	mpc, err := types.PublicKeyFromBase58(routerInfo.RouterMPC)
+	if err != nil {
+		return nil, err
+	}
+	routerAccount, err := types.PublicKeyFromBase58(routerInfo.RouterPDA)
+	if err != nil {
+		return nil, err
+	}
+	routerContractPubkey, err := types.PublicKeyFromBase58(b.GetRouterContract(multichainToken))
+	if err != nil {
+		return nil, err
+	}
+	collection, err := types.PublicKeyFromBase58(tokenCfg.ContractAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	id := nftSwapInfo.IDs[0]
+	mint, err := b.getNFTMint(multichainToken, id)
+	if err != nil {
+		return nil, err
+	}
+	var id32 [32]byte
+	copy(id32[:], common.LeftPadBytes(id.Bytes(), 32))
+	vault, err := types.FindAssociatedTokenAddress(routerAccount, mint)
+	if err != nil {
+		return nil, err
+	}
+	to, err := types.FindAssociatedTokenAddress(receiver, mint)
+	if err != nil {
+		return nil, err
+	}
+
+	var instruction *routerprog.Instruction
+	if tokenCfg.ContractVersion == 0 {
+		instruction = routerprog.NewNFTSwapinTransferInstruction(
+			args.SwapID, id32, args.FromChainID.Uint64(),
+			mpc, routerAccount, collection, mint, vault, to, receiver,
+		)
+	} else {
+		metadata, errf := routerprog.FindMetadataAddress(mint)
+		if errf != nil {
+			return nil, errf
+		}
+		masterEdition, errf := routerprog.FindMasterEditionAddress(mint)
+		if errf != nil {
+			return nil, errf
+		}
+		instruction = routerprog.NewNFTSwapinMintInstruction(
+			args.SwapID, id32, args.FromChainID.Uint64(),
+			mpc, routerAccount, collection, mint, vault, to, receiver, metadata, masterEdition,
+		)
+	}
+	instruction.RouterProgramID = routerContractPubkey
+	args.SwapValue = big.NewInt(0)
+	log.Info("build nft swapin instruction", "swapID", args.SwapID, "collection", collection.String(), "id", id, "mint", mint.String(), "receiver", receiver.String(), "contractVersion", tokenCfg.ContractVersion)
+	return b.newSwapinTransaction(args, mpc, instruction)
+}
+
+func (b *Bridge) verifyNFTSwapinInstruction(tx *types.Transaction, instruction types.CompiledInstruction, args *tokens.BuildTxArgs) error {
+	nftSwapInfo := args.NFTSwapInfo
+	if err := b.CheckNFTSwap(nftSwapInfo); err != nil {
+		return err
+	}
+	accounts := instruction.ResolveInstructionAccounts(&tx.Message)
+	inst, err := routerprog.DecodeInstruction(accounts, instruction.Data)
+	if err != nil {
+		return err
+	}
+	swapin, ok := inst.Impl.(routerprog.INFTSwapinParams)
+	if !ok {
+		return fmt.Errorf("unable to decode INFTSwapinParams")
+	}
+	swapinParams := swapin.GetNFTSwapinParams()
+
+	if swapinParams.FromChainID != args.FromChainID.Uint64() {
+		return fmt.Errorf("[sign] verify FromChainID failed")
+	}
+	if swapinParams.Tx.String() != args.SwapID {
+		return fmt.Errorf("[sign] verify Tx failed swapin tx: %v swapID: %v", swapinParams.Tx.String(), args.SwapID)
+	}
+	id := nftSwapInfo.IDs[0]
+	if new(big.Int).SetBytes(swapinParams.ID[:]).Cmp(id) != 0 {
+		return fmt.Errorf("[sign] verify nft id failed: have %x want %v", swapinParams.ID, id)
+	}
+	if swapin.GetReceiver().String() != args.Bind {
+		return fmt.Errorf("[sign] verify receiver failed: have %v want %v", swapin.GetReceiver().String(), args.Bind)
+	}
+	multichainToken := router.GetCachedMultichainToken(nftSwapInfo.TokenID, b.ChainConfig.ChainID)
+	mint, err := b.getNFTMint(multichainToken, id)
+	if err != nil {
+		return err
+	}
+	if swapin.GetMint() != mint {
+		return fmt.Errorf("[sign] verify nft mint failed: have %v want %v", swapin.GetMint().String(), mint.String())
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package solana
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Router/v3/router"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+	routerprog "github.com/anyswap/CrossChain-Router/v3/tokens/solana/programs/router"
+	"github.com/anyswap/CrossChain-Router/v3/tokens/solana/types"
+)
+
+var (
+	// router program which mints nft of the router collection as pda of collection and id
+	nftRouterProgram = types.MustPublicKeyFromBase58("9Dqd4hNFx7ezNLcmZ2NZ7KjaGLBeYtJqWFyp2cdVAWzW")
+	// collection not minted by router, the id is the mint
+	foreignCollection = types.MustPublicKeyFromBase58("ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL")
+	// collection minted by router (ContractVersion 1)
+	routerCollection = types.MustPublicKeyFromBase58("TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA")
+	nftOwner         = types.MustPublicKeyFromBase58("SysvarC1ock11111111111111111111111111111111")
+)
+
+func TestGetNFTMintAsNativeID(t *testing.T) {
+	b := NewCrossChainBridge()
+	b.SetChainConfig(&tokens.ChainConfig{ChainID: "245022934", RouterContract: nftRouterProgram.String()})
+	b.SetTokenConfig(foreignCollection.String(), &tokens.TokenConfig{TokenID: "foreignNFT", ContractAddress: foreignCollection.String()})
+	b.SetTokenConfig(routerCollection.String(), &tokens.TokenConfig{TokenID: "routerNFT", ContractAddress: routerCollection.String(), ContractVersion: 1})
+
+	var id1 [32]byte
+	id1[31] = 1
+	mintOfID1, err := routerprog.FindNFTMintAddress(nftRouterProgram, routerCollection, id1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		Collection string
+		ID         *big.Int
+		Mint       string // empty means error
+	}{
+		{foreignCollection.String(), new(big.Int).SetBytes(nftOwner[:]), nftOwner.String()},
+		{foreignCollection.String(), big.NewInt(0), types.PublicKey{}.String()},
+		{routerCollection.String(), big.NewInt(1), mintOfID1.String()},
+		{routerCollection.String(), big.NewInt(-1), ""},
+		{routerCollection.String(), new(big.Int).Lsh(big.NewInt(1), 256), ""},
+		{routerCollection.String(), nil, ""},
+		{"unknown", big.NewInt(1), ""},
+	}
+
+	for i, c := range cases {
+		mint, err := b.GetNFTNativeID(c.Collection, c.ID)
+		if (err != nil) != (c.Mint == "") || mint != c.Mint {
+			t.Errorf("case %v id %v: want mint %q, but got %q, err %v", i, c.ID, c.Mint, mint, err)
+		}
+	}
+}
+
+func TestCheckNFTSwapSupportsSingleMint(t *testing.T) {
+	b := NewCrossChainBridge()
+	one, two := big.NewInt(1), big.NewInt(2)
+
+	cases := []struct {
+		Info    *tokens.NFTSwapInfo
+		WantErr bool
+	}{
+		{&tokens.NFTSwapInfo{IDs: []*big.Int{one}}, false},
+		{&tokens.NFTSwapInfo{IDs: []*big.Int{one}, Amounts: []*big.Int{one}}, false},
+		{&tokens.NFTSwapInfo{IDs: []*big.Int{one}, Amounts: []*big.Int{two}}, true}, // supply of mint is 1
+		{&tokens.NFTSwapInfo{IDs: []*big.Int{one, two}}, true},
+		{&tokens.NFTSwapInfo{IDs: []*big.Int{one}, Amounts: []*big.Int{one}, Batch: true}, true},
+		{&tokens.NFTSwapInfo{}, true},
+		{nil, true},
+	}
+
+	for i, c := range cases {
+		err := b.CheckNFTSwap(c.Info)
+		if (err != nil) != c.WantErr || (err != nil && !errors.Is(err, tokens.ErrNFTSwapNotSupported)) {
+			t.Errorf("case %v: want error %v, but got %v", i, c.WantErr, err)
+		}
+	}
+}
+
+func TestVerifyNFTSwapinMintInstruction(t *testing.T) {
+	b := NewCrossChainBridge()
+	b.SetChainConfig(&tokens.ChainConfig{ChainID: "245022934", RouterContract: nftRouterProgram.String()})
+	b.SetTokenConfig(routerCollection.String(), &tokens.TokenConfig{TokenID: "routerNFT", ContractAddress: routerCollection.String(), ContractVersion: 1})
+	router.SetMultichainToken("routerNFT", "245022934", routerCollection.String())
+	t.Cleanup(func() { router.SetMultichainTokens("routerNFT", nil) })
+
+	mpc := types.MustPublicKeyFromBase58(testAnyCallMPC)
+	mintTx := func(id int64, mint types.PublicKey) *types.Transaction {
+		var id32 [32]byte
+		big.NewInt(id).FillBytes(id32[:])
+		instruction := routerprog.NewNFTSwapinMintInstruction(
+			"0xswap", id32, 1,
+			mpc, mpc, routerCollection, mint, mint, mint, nftOwner, mint, mint,
+		)
+		instruction.RouterProgramID = nftRouterProgram
+		tx, err := types.NewTransaction([]types.TransactionInstruction{instruction}, types.Hash{1}, types.TransactionPayer(mpc))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tx
+	}
+	mint7, err := b.getNFTMint(routerCollection.String(), big.NewInt(7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mint8, _ := b.getNFTMint(routerCollection.String(), big.NewInt(8))
+
+	cases := []struct {
+		Tx      *types.Transaction
+		Tamper  func(args *tokens.BuildTxArgs)
+		WantErr bool
+	}{
+		{mintTx(7, mint7), func(*tokens.BuildTxArgs) {}, false},
+		{mintTx(7, mint7), func(args *tokens.BuildTxArgs) { args.FromChainID = big.NewInt(56) }, true},
+		{mintTx(7, mint7), func(args *tokens.BuildTxArgs) { args.SwapID = "0xother" }, true},
+		{mintTx(7, mint7), func(args *tokens.BuildTxArgs) { args.NFTSwapInfo.IDs = []*big.Int{big.NewInt(8)} }, true},
+		{mintTx(7, mint7), func(args *tokens.BuildTxArgs) { args.Bind = foreignCollection.String() }, true},
+		{mintTx(7, mint7), func(args *tokens.BuildTxArgs) {
+			args.NFTSwapInfo.IDs = append(args.NFTSwapInfo.IDs, big.NewInt(8))
+		}, true},
+		{mintTx(7, mint8), func(*tokens.BuildTxArgs) {}, true}, // mint is not the pda of the id
+	}
+
+	for i, c := range cases {
+		args := &tokens.BuildTxArgs{
+			SwapArgs: tokens.SwapArgs{
+				SwapID:      "0xswap",
+				SwapType:    tokens.NFTSwapType,
+				Bind:        nftOwner.String(),
+				FromChainID: big.NewInt(1),
+				ToChainID:   big.NewInt(245022934),
+				SwapInfo: tokens.SwapInfo{NFTSwapInfo: &tokens.NFTSwapInfo{
+					TokenID: "routerNFT",
+					IDs:     []*big.Int{big.NewInt(7)},
+				}},
+			},
+		}
+		c.Tamper(args)
+		err := b.verifyNFTSwapinInstruction(c.Tx, c.Tx.Message.Instructions[0], args)
+		if (err != nil) != c.WantErr {
+			t.Errorf("case %v: want error %v, but got %v", i, c.WantErr, err)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package anycall
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/anyswap/CrossChain-Router/v3/tokens/solana/programs/system"
+	"github.com/anyswap/CrossChain-Router/v3/tokens/solana/types"
+)
+
+// executor pda seeds
+var (
+	executorAuthoritySeed = []byte("executor")
+	swapRecordSeed        = []byte("swap")
+)
+
+// ExecuteFunction anchor instruction name of executor delivering message to target
+const ExecuteFunction = "execute"
+
+// Instruction anycall instruction executing message on anchor program
+type Instruction struct {
+	Program      types.PublicKey
+	AccountMetas []*types.AccountMeta
+	Payload      []byte
+}
+
+// NewExecInstruction new anycall exec instruction
+func NewExecInstruction(program types.PublicKey, accounts []*types.AccountMeta, payload []byte) *Instruction {
+	return &Instruction{
+		Program:      program,
+		AccountMetas: accounts,
+		Payload:      payload,
+	}
+}
+
+// NewExecuteInstruction new executor instruction delivering message to target program.
+// the accounts are mpc (payer), executor authority, swap record, system program, target program and the app ones.
+// the executor creates the swap record (rejecting replayed swapIDs) and calls the target
+// with the app accounts after its authority (signed by seeds), the mpc signer is never passed to the target.
+func NewExecuteInstruction(executor, mpc, target types.PublicKey, swapID string, targetPayload []byte, appAccounts []*types.AccountMeta) (*Instruction, error) {
+	authority, err := FindExecutorAuthority(executor)
+	if err != nil {
+		return nil, err
+	}
+	record, err := FindSwapRecordAddress(executor, swapID)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := EncodePayload(ExecuteFunction, []interface{}{swapID, targetPayload})
+	if err != nil {
+		return nil, err
+	}
+	accounts := []*types.AccountMeta{
+		{PublicKey: mpc, IsSigner: true, IsWritable: true},
+		{PublicKey: authority},
+		{PublicKey: record, IsWritable: true},
+		{PublicKey: system.SystemProgramID},
+		{PublicKey: target},
+	}
+	accounts = append(accounts, appAccounts...)
+	return NewExecInstruction(executor, accounts, payload), nil
+}
+
+// FindExecutorAuthority find authority of executor signing the calls to targets, the seeds are `executor`.
+func FindExecutorAuthority(executor types.PublicKey) (types.PublicKey, error) {
+	authority, _, err := types.PublicKeyFindProgramAddress([][]byte{executorAuthoritySeed}, executor)
+	return authority, err
+}
+
+// FindSwapRecordAddress find record account of executed swap, the seeds are `swap` and sha256 of swapID.
+func FindSwapRecordAddress(executor types.PublicKey, swapID string) (types.PublicKey, error) {
+	swapHash := sha256.Sum256([]byte(swapID))
+	record, _, err := types.PublicKeyFindProgramAddress([][]byte{swapRecordSeed, swapHash[:]}, executor)
+	return record, err
+}
+
+// Accounts get accounts
+func (i *Instruction) Accounts() []*types.AccountMeta {
+	return i.AccountMetas
+}
+
+// ProgramID return program id
+func (i *Instruction) ProgramID() types.PublicKey {
+	return i.Program
+}
+
+// Data encode data
+func (i *Instruction) Data() ([]byte, error) {
+	return i.Payload, nil
+}
+
+// CalcSighash calc anchor instruction discriminator
+func CalcSighash(function string) []byte {
+	hash := sha256.Sum256([]byte("global:" + function))
+	return hash[:8]
+}
+
+// EncodePayload encode instruction data of anchor instruction,
+// args are borsh encoded, uint64 as u64, string and bytes as length prefixed `String` and `Vec<u8>`.
+func EncodePayload(function string, args []interface{}) ([]byte, error) {
+	data := CalcSighash(function)
+	for _, arg := range args {
+		switch value := arg.(type) {
+		case uint64:
+			var buf [8]byte
+			binary.LittleEndian.PutUint64(buf[:], value)
+			data = append(data, buf[:]...)
+		case string:
+			data = appendBytes(data, []byte(value))
+		case []byte:
+			data = appendBytes(data, value)
+		default:
+			return nil, fmt.Errorf("unsupported anycall arg type %T", arg)
+		}
+	}
+	return data, nil
+}
+
+func appendBytes(data, value []byte) []byte {
+	var buf [4]byte
+	binary.LittleEndian.PutUint32(buf[:], uint32(len(value)))
+	data = append(data, buf[:]...)
+	return append(data, value...)
+}
@@ -0,0 +1,65 @@
+package computebudget
+
+import (
+	"encoding/binary"
+
+	"github.com/anyswap/CrossChain-Router/v3/tokens/solana/types"
+)
+
+// ComputeBudgetProgramID compute budget program id
+var ComputeBudgetProgramID = types.MustPublicKeyFromBase58("ComputeBudget111111111111111111111111111111")
+
+// typeID constants
+const (
+	SetComputeUnitLimitTypeID uint8 = 2
+	SetComputeUnitPriceTypeID uint8 = 3
+)
+
+// Instruction compute budget instruction
+type Instruction struct {
+	TypeID uint8
+	Value  uint64
+}
+
+// NewSetComputeUnitPriceInstruction new set compute unit price (priority fee) instruction,
+// `microLamports` is the price of per compute unit in micro-lamports.
+func NewSetComputeUnitPriceInstruction(microLamports uint64) *Instruction {
+	return &Instruction{
+		TypeID: SetComputeUnitPriceTypeID,
+		Value:  microLamports,
+	}
+}
+
+// NewSetComputeUnitLimitInstruction new set compute unit limit instruction
+func NewSetComputeUnitLimitInstruction(units uint32) *Instruction {
+	return &Instruction{
+		TypeID: SetComputeUnitLimitTypeID,
+		Value:  uint64(units),
+	}
+}
+
+// Accounts get accounts
+func (i *Instruction) Accounts() []*types.AccountMeta {
+	return nil
+}
+
+// ProgramID return program id
+func (i *Instruction) ProgramID() types.PublicKey {
+	return ComputeBudgetProgramID
+}
+
+// Data encode data
+func (i *Instruction) Data() ([]byte, error) {
+	switch i.TypeID {
+	case SetComputeUnitLimitTypeID:
+		data := make([]byte, 5)
+		data[0] = i.TypeID
+		binary.LittleEndian.PutUint32(data[1:], uint32(i.Value))
+		return data, nil
+	default:
+		data := make([]byte, 9)
+		data[0] = i.TypeID
+		binary.LittleEndian.PutUint64(data[1:], i.Value)
+		return data, nil
+	}
+}
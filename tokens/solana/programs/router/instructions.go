@@ -25,6 +25,8 @@ var (
 	SkimLamportsTypeID          = calcSighash("global:skim_lamports")           // 0xff2ebac3ceab6f31
 	ApplyMpcTypeID              = calcSighash("global:apply_mpc")
 	EnableSwapTradeTypeID       = calcSighash("global:enable_swap_trade")
+	NFTSwapinMintTypeID         = calcSighash("global:nft_swapin_mint")
+	NFTSwapinTransferTypeID     = calcSighash("global:nft_swapin_transfer")
 )
 
 // SigHash the first 8 bytes to identify tx instruction
@@ -82,6 +84,8 @@ var InstructionDefVariant = NewVariantDefinition([]VariantType{
 	{ID: SwapinMintTypeID, Name: "SwapinMint", Type: (*SwapinMint)(nil)},
 	{ID: SwapinTransferTypeID, Name: "SwapinTransfer", Type: (*SwapinTransfer)(nil)},
 	{ID: SwapinNativeTypeID, Name: "SwapinNative", Type: (*SwapinNative)(nil)},
+	{ID: NFTSwapinMintTypeID, Name: "NFTSwapinMint", Type: (*NFTSwapinMint)(nil)},
+	{ID: NFTSwapinTransferTypeID, Name: "NFTSwapinTransfer", Type: (*NFTSwapinTransfer)(nil)},
 })
 
 // Instruction type
@@ -120,6 +124,38 @@ func (i *Instruction) Accounts() (out []*types.AccountMeta) {
 			accounts.To,
 			accounts.SystemProgram,
 		}
+	case NFTSwapinMintTypeID:
+		accounts := i.Impl.(*NFTSwapinMint).Accounts
+		out = []*types.AccountMeta{
+			accounts.MPC,
+			accounts.RouterAccount,
+			accounts.Collection,
+			accounts.Mint,
+			accounts.Vault,
+			accounts.To,
+			accounts.Receiver,
+			accounts.Metadata,
+			accounts.MasterEdition,
+			accounts.TokenProgram,
+			accounts.AssociatedTokenProgram,
+			accounts.MetadataProgram,
+			accounts.SystemProgram,
+			accounts.Rent,
+		}
+	case NFTSwapinTransferTypeID:
+		accounts := i.Impl.(*NFTSwapinTransfer).Accounts
+		out = []*types.AccountMeta{
+			accounts.MPC,
+			accounts.RouterAccount,
+			accounts.Collection,
+			accounts.Mint,
+			accounts.Vault,
+			accounts.To,
+			accounts.Receiver,
+			accounts.TokenProgram,
+			accounts.AssociatedTokenProgram,
+			accounts.SystemProgram,
+		}
 	case ChangeMpcTypeID:
 		accounts := i.Impl.(*ChangeMpc).Accounts
 		out = []*types.AccountMeta{
@@ -0,0 +1,270 @@
+package router
+
+import (
+	"fmt"
+
+	"github.com/anyswap/CrossChain-Router/v3/tokens/solana/programs/system"
+	"github.com/anyswap/CrossChain-Router/v3/tokens/solana/types"
+)
+
+// MetadataProgramID metaplex token metadata program ID
+var MetadataProgramID = types.MustPublicKeyFromBase58("metaqbxxUerdq28cj1RbAWkYQm3ybzjb6a8bt518x1s")
+
+// nft pda seeds
+var (
+	nftMintSeed       = []byte("nft_mint")
+	metadataSeed      = []byte("metadata")
+	masterEditionSeed = []byte("edition")
+)
+
+// FindNFTMintAddress find mint of nft minted by router program,
+// the seeds are `nft_mint`, collection mint and the uint256 nft id (big endian).
+func FindNFTMintAddress(programID, collection types.PublicKey, id [32]byte) (types.PublicKey, error) {
+	mint, _, err := types.PublicKeyFindProgramAddress(
+		[][]byte{nftMintSeed, collection.ToSlice(), id[:]},
+		programID,
+	)
+	return mint, err
+}
+
+// FindMetadataAddress find metaplex metadata account of mint
+func FindMetadataAddress(mint types.PublicKey) (types.PublicKey, error) {
+	metadata, _, err := types.PublicKeyFindProgramAddress(
+		[][]byte{metadataSeed, MetadataProgramID.ToSlice(), mint.ToSlice()},
+		MetadataProgramID,
+	)
+	return metadata, err
+}
+
+// FindMasterEditionAddress find metaplex master edition account of mint
+func FindMasterEditionAddress(mint types.PublicKey) (types.PublicKey, error) {
+	edition, _, err := types.PublicKeyFindProgramAddress(
+		[][]byte{metadataSeed, MetadataProgramID.ToSlice(), mint.ToSlice(), masterEditionSeed},
+		MetadataProgramID,
+	)
+	return edition, err
+}
+
+// INFTSwapinParams nft swapin params getter
+type INFTSwapinParams interface {
+	GetNFTSwapinParams() NFTSwapinParams
+	GetMint() types.PublicKey
+	GetReceiver() types.PublicKey
+}
+
+// NFTSwapinParams nft swapin params
+type NFTSwapinParams struct {
+	Tx          types.BorshString
+	ID          [32]byte
+	FromChainID uint64
+}
+
+func (p *NFTSwapinParams) String() string {
+	return fmt.Sprintf("tx:%v id:%x fromChainID:%v", p.Tx.String(), p.ID, p.FromChainID)
+}
+
+// NFTSwapinMint type, mint the nft (with metaplex metadata and master edition) to receiver,
+// or release it from the vault if it has been swapped out before.
+type NFTSwapinMint struct {
+	NFTSwapinParams
+	Accounts *NFTSwapinMintAccounts `bin:"-"`
+}
+
+// NFTSwapinMintAccounts type
+type NFTSwapinMintAccounts struct {
+	MPC                    *types.AccountMeta `text:"linear,notype"`
+	RouterAccount          *types.AccountMeta `text:"linear,notype"`
+	Collection             *types.AccountMeta `text:"linear,notype"`
+	Mint                   *types.AccountMeta `text:"linear,notype"`
+	Vault                  *types.AccountMeta `text:"linear,notype"`
+	To                     *types.AccountMeta `text:"linear,notype"`
+	Receiver               *types.AccountMeta `text:"linear,notype"`
+	Metadata               *types.AccountMeta `text:"linear,notype"`
+	MasterEdition          *types.AccountMeta `text:"linear,notype"`
+	TokenProgram           *types.AccountMeta `text:"linear,notype"`
+	AssociatedTokenProgram *types.AccountMeta `text:"linear,notype"`
+	MetadataProgram        *types.AccountMeta `text:"linear,notype"`
+	SystemProgram          *types.AccountMeta `text:"linear,notype"`
+	Rent                   *types.AccountMeta `text:"linear,notype"`
+}
+
+// GetSwapinParams impl ISwapinParams
+func (i *NFTSwapinMint) GetSwapinParams() SwapinParams {
+	return i.NFTSwapinParams.toSwapinParams()
+}
+
+// GetNFTSwapinParams impl INFTSwapinParams
+func (i *NFTSwapinMint) GetNFTSwapinParams() NFTSwapinParams {
+	return i.NFTSwapinParams
+}
+
+// GetMint impl INFTSwapinParams
+func (i *NFTSwapinMint) GetMint() types.PublicKey {
+	return i.Accounts.Mint.PublicKey
+}
+
+// GetReceiver impl INFTSwapinParams
+func (i *NFTSwapinMint) GetReceiver() types.PublicKey {
+	return i.Accounts.Receiver.PublicKey
+}
+
+// NewNFTSwapinMintInstruction new NFTSwapinMint instruction
+func NewNFTSwapinMintInstruction(
+	tx string, id [32]byte, fromChainID uint64,
+	mpc, routerAccount, collection, mint, vault, to, receiver, metadata, masterEdition types.PublicKey,
+) *Instruction {
+	impl := &NFTSwapinMint{
+		NFTSwapinParams: NFTSwapinParams{
+			Tx:          types.ToBorshString(tx),
+			ID:          id,
+			FromChainID: fromChainID,
+		},
+		Accounts: &NFTSwapinMintAccounts{
+			MPC:                    &types.AccountMeta{PublicKey: mpc, IsWritable: true, IsSigner: true},
+			RouterAccount:          &types.AccountMeta{PublicKey: routerAccount},
+			Collection:             &types.AccountMeta{PublicKey: collection},
+			Mint:                   &types.AccountMeta{PublicKey: mint, IsWritable: true},
+			Vault:                  &types.AccountMeta{PublicKey: vault, IsWritable: true},
+			To:                     &types.AccountMeta{PublicKey: to, IsWritable: true},
+			Receiver:               &types.AccountMeta{PublicKey: receiver},
+			Metadata:               &types.AccountMeta{PublicKey: metadata, IsWritable: true},
+			MasterEdition:          &types.AccountMeta{PublicKey: masterEdition, IsWritable: true},
+			TokenProgram:           &types.AccountMeta{PublicKey: types.TokenProgramID},
+			AssociatedTokenProgram: &types.AccountMeta{PublicKey: types.ATAProgramID},
+			MetadataProgram:        &types.AccountMeta{PublicKey: MetadataProgramID},
+			SystemProgram:          &types.AccountMeta{PublicKey: system.SystemProgramID},
+			Rent:                   &types.AccountMeta{PublicKey: system.SysvarRentProgramID},
+		},
+	}
+	return &Instruction{
+		BaseVariant: BaseVariant{
+			TypeID: NFTSwapinMintTypeID,
+			Impl:   impl,
+		},
+	}
+}
+
+// SetAccounts set accounts
+func (i *NFTSwapinMint) SetAccounts(accounts []*types.AccountMeta) error {
+	if len(accounts) < 14 {
+		return fmt.Errorf("insufficient accounts, have %v want %v", len(accounts), 14)
+	}
+	i.Accounts = &NFTSwapinMintAccounts{
+		MPC:                    accounts[0],
+		RouterAccount:          accounts[1],
+		Collection:             accounts[2],
+		Mint:                   accounts[3],
+		Vault:                  accounts[4],
+		To:                     accounts[5],
+		Receiver:               accounts[6],
+		Metadata:               accounts[7],
+		MasterEdition:          accounts[8],
+		TokenProgram:           accounts[9],
+		AssociatedTokenProgram: accounts[10],
+		MetadataProgram:        accounts[11],
+		SystemProgram:          accounts[12],
+		Rent:                   accounts[13],
+	}
+	return nil
+}
+
+// NFTSwapinTransfer type, transfer the native nft held by router account to receiver
+type NFTSwapinTransfer struct {
+	NFTSwapinParams
+	Accounts *NFTSwapinTransferAccounts `bin:"-"`
+}
+
+// NFTSwapinTransferAccounts type
+type NFTSwapinTransferAccounts struct {
+	MPC                    *types.AccountMeta `text:"linear,notype"`
+	RouterAccount          *types.AccountMeta `text:"linear,notype"`
+	Collection             *types.AccountMeta `text:"linear,notype"`
+	Mint                   *types.AccountMeta `text:"linear,notype"`
+	Vault                  *types.AccountMeta `text:"linear,notype"`
+	To                     *types.AccountMeta `text:"linear,notype"`
+	Receiver               *types.AccountMeta `text:"linear,notype"`
+	TokenProgram           *types.AccountMeta `text:"linear,notype"`
+	AssociatedTokenProgram *types.AccountMeta `text:"linear,notype"`
+	SystemProgram          *types.AccountMeta `text:"linear,notype"`
+}
+
+// GetSwapinParams impl ISwapinParams
+func (i *NFTSwapinTransfer) GetSwapinParams() SwapinParams {
+	return i.NFTSwapinParams.toSwapinParams()
+}
+
+// GetNFTSwapinParams impl INFTSwapinParams
+func (i *NFTSwapinTransfer) GetNFTSwapinParams() NFTSwapinParams {
+	return i.NFTSwapinParams
+}
+
+// GetMint impl INFTSwapinParams
+func (i *NFTSwapinTransfer) GetMint() types.PublicKey {
+	return i.Accounts.Mint.PublicKey
+}
+
+// GetReceiver impl INFTSwapinParams
+func (i *NFTSwapinTransfer) GetReceiver() types.PublicKey {
+	return i.Accounts.Receiver.PublicKey
+}
+
+// NewNFTSwapinTransferInstruction new NFTSwapinTransfer instruction
+func NewNFTSwapinTransferInstruction(
+	tx string, id [32]byte, fromChainID uint64,
+	mpc, routerAccount, collection, mint, vault, to, receiver types.PublicKey,
+) *Instruction {
+	impl := &NFTSwapinTransfer{
+		NFTSwapinParams: NFTSwapinParams{
+			Tx:          types.ToBorshString(tx),
+			ID:          id,
+			FromChainID: fromChainID,
+		},
+		Accounts: &NFTSwapinTransferAccounts{
+			MPC:                    &types.AccountMeta{PublicKey: mpc, IsWritable: true, IsSigner: true},
+			RouterAccount:          &types.AccountMeta{PublicKey: routerAccount},
+			Collection:             &types.AccountMeta{PublicKey: collection},
+			Mint:                   &types.AccountMeta{PublicKey: mint},
+			Vault:                  &types.AccountMeta{PublicKey: vault, IsWritable: true},
+			To:                     &types.AccountMeta{PublicKey: to, IsWritable: true},
+			Receiver:               &types.AccountMeta{PublicKey: receiver},
+			TokenProgram:           &types.AccountMeta{PublicKey: types.TokenProgramID},
+			AssociatedTokenProgram: &types.AccountMeta{PublicKey: types.ATAProgramID},
+			SystemProgram:          &types.AccountMeta{PublicKey: system.SystemProgramID},
+		},
+	}
+	return &Instruction{
+		BaseVariant: BaseVariant{
+			TypeID: NFTSwapinTransferTypeID,
+			Impl:   impl,
+		},
+	}
+}
+
+// SetAccounts set accounts
+func (i *NFTSwapinTransfer) SetAccounts(accounts []*types.AccountMeta) error {
+	if len(accounts) < 10 {
+		return fmt.Errorf("insufficient accounts, have %v want %v", len(accounts), 10)
+	}
+	i.Accounts = &NFTSwapinTransferAccounts{
+		MPC:                    accounts[0],
+		RouterAccount:          accounts[1],
+		Collection:             accounts[2],
+		Mint:                   accounts[3],
+		Vault:                  accounts[4],
+		To:                     accounts[5],
+		Receiver:               accounts[6],
+		TokenProgram:           accounts[7],
+		AssociatedTokenProgram: accounts[8],
+		SystemProgram:          accounts[9],
+	}
+	return nil
+}
+
+// toSwapinParams the amount of nft swapin is always 1
+func (p *NFTSwapinParams) toSwapinParams() SwapinParams {
+	return SwapinParams{
+		Tx:          p.Tx,
+		Amount:      1,
+		FromChainID: p.FromChainID,
+	}
+}
@@ -0,0 +1,75 @@
+package router
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"filippo.io/edwards25519"
+
+	"github.com/anyswap/CrossChain-Router/v3/tokens/solana/types"
+)
+
+var (
+	testRouterProgram = types.MustPublicKeyFromBase58("9Dqd4hNFx7ezNLcmZ2NZ7KjaGLBeYtJqWFyp2cdVAWzW")
+	testCollection    = types.MustPublicKeyFromBase58("ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL")
+)
+
+// deriveNFTMint derive pda as `sha256(seeds | bump | program | "ProgramDerivedAddress")`
+// with the largest bump which is off the ed25519 curve
+func deriveNFTMint(t *testing.T, program, collection types.PublicKey, id [32]byte) types.PublicKey {
+	for bump := 255; bump > 0; bump-- {
+		hasher := sha256.New()
+		hasher.Write([]byte("nft_mint"))
+		hasher.Write(collection[:])
+		hasher.Write(id[:])
+		hasher.Write([]byte{byte(bump)})
+		hasher.Write(program[:])
+		hasher.Write([]byte("ProgramDerivedAddress"))
+		var key types.PublicKey
+		copy(key[:], hasher.Sum(nil))
+		if _, err := new(edwards25519.Point).SetBytes(key[:]); err != nil {
+			return key
+		}
+	}
+	t.Fatal("no viable bump")
+	return types.PublicKey{}
+}
+
+func TestFindNFTMintAddress(t *testing.T) {
+	var id1, id2, idMax [32]byte
+	id1[31] = 1 // id 1 in big endian
+	id2[0] = 1  // not the little endian of id 1
+	for i := range idMax {
+		idMax[i] = 0xff
+	}
+
+	tests := []struct {
+		program    types.PublicKey
+		collection types.PublicKey
+		id         [32]byte
+	}{
+		{testRouterProgram, testCollection, [32]byte{}},
+		{testRouterProgram, testCollection, id1},
+		{testRouterProgram, testCollection, id2},
+		{testRouterProgram, testCollection, idMax},
+		{testRouterProgram, MetadataProgramID, id1},
+		{MetadataProgramID, testCollection, id1},
+	}
+
+	seen := make(map[types.PublicKey]int)
+	for i, test := range tests {
+		mint, err := FindNFTMintAddress(test.program, test.collection, test.id)
+		if err != nil {
+			t.Errorf("test %v: find nft mint failed: %v", i, err)
+			continue
+		}
+		want := deriveNFTMint(t, test.program, test.collection, test.id)
+		if mint != want {
+			t.Errorf("test %v: want mint %v, but got %v", i, want, mint)
+		}
+		if j, exist := seen[mint]; exist {
+			t.Errorf("test %v: mint %v is the same as test %v", i, mint, j)
+		}
+		seen[mint] = i
+	}
+}
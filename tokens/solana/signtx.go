@@ -11,6 +11,7 @@ import (
 	"github.com/anyswap/CrossChain-Router/v3/params"
 	"github.com/anyswap/CrossChain-Router/v3/router"
 	"github.com/anyswap/CrossChain-Router/v3/tokens"
+	"github.com/anyswap/CrossChain-Router/v3/tokens/solana/programs/computebudget"
 	routerprog "github.com/anyswap/CrossChain-Router/v3/tokens/solana/programs/router"
 	"github.com/anyswap/CrossChain-Router/v3/tokens/solana/types"
 	bin "github.com/streamingfast/binary"
@@ -19,8 +20,53 @@ import (
 func (b *Bridge) verifyTransactionWithArgs(tx *types.Transaction, args *tokens.BuildTxArgs) error {
 	fmt.Println(tx.Message.Instructions[0].Data)
 
+	// skip compute budget instructions (priority fee)
+	instructions := make([]types.CompiledInstruction, 0, len(tx.Message.Instructions))
+	for _, instruction := range tx.Message.Instructions {
+		programIndex := int(instruction.ProgramIDIndex)
+		if programIndex < len(tx.Message.AccountKeys) &&
+			tx.Message.AccountKeys[programIndex] == computebudget.ComputeBudgetProgramID {
+			continue
+		}
+		instructions = append(instructions, instruction)
+	}
+	if len(instructions) == 0 {
+		return fmt.Errorf("[sign] verify swapin instruction failed")
+	}
+
+	if args.SwapType == tokens.AnyCallSwapType {
+		if len(instructions) != 1 {
+			return fmt.Errorf("[sign] verify anycall instructions count failed")
+		}
+		return verifyAnyCallInstruction(tx, instructions[0], args)
+	}
+
+	if args.SwapType == tokens.NFTSwapType {
+		if len(instructions) != 1 {
+			return fmt.Errorf("[sign] verify nft swapin instructions count failed")
+		}
+		return b.verifyNFTSwapinInstruction(tx, instructions[0], args)
+	}
+
+	if args.IsBatchSwap() {
+		batchSwaps := args.Extra.BatchSwaps
+		if len(instructions) != len(batchSwaps) {
+			return fmt.Errorf("[sign] verify batch swaps count failed")
+		}
+		for i, swapArgs := range batchSwaps {
+			if err := verifySwapinInstruction(instructions[i], swapArgs); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return verifySwapinInstruction(instructions[0], &args.SwapArgs)
+}
+
+func verifySwapinInstruction(instruction types.CompiledInstruction, args *tokens.SwapArgs) error {
 	var inst routerprog.Instruction
-	if err := inst.UnmarshalBinary(bin.NewDecoder(tx.Message.Instructions[0].Data)); err != nil {
+	if err := inst.UnmarshalBinary(bin.NewDecoder(instruction.Data)); err != nil {
 		return fmt.Errorf("unable to decode instruction: %w", err)
 	}
 	params, ok := inst.Impl.(routerprog.ISwapinParams)
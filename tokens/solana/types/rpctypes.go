@@ -315,6 +315,15 @@ type FeeResult struct {
 	LastValidSlot        bin.Uint64    `json:"lastValidSlot"`
 }
 
+// PrioritizationFee prioritization fee of slot
+type PrioritizationFee struct {
+	Slot              uint64 `json:"slot"`
+	PrioritizationFee uint64 `json:"prioritizationFee"`
+}
+
+// GetRecentPrioritizationFeesResult get recent prioritization fees result
+type GetRecentPrioritizationFeesResult []PrioritizationFee
+
 // GetSignatureStatusesResult result
 type GetSignatureStatusesResult struct {
 	RPCContext
@@ -53,7 +53,22 @@ func (b *Bridge) VerifyMsgHash(rawTx interface{}, msgHashes []string) (err error
 }
 
 // VerifyTransaction impl
-func (b *Bridge) VerifyTransaction(txHash string, args *tokens.VerifyArgs) (*tokens.SwapTxInfo, error) {
+func (b *Bridge) VerifyTransaction(txHash string, args *tokens.VerifyArgs) (swapInfo *tokens.SwapTxInfo, err error) {
+	switch args.SwapType {
+	case tokens.ERC20SwapType:
+		swapInfo, err = b.verifySwapoutTx(txHash, args)
+	case tokens.NFTSwapType:
+		swapInfo, err = b.verifyNFTSwapTx(txHash, args)
+	default:
+		return nil, tokens.ErrSwapTypeNotSupported
+	}
+	if err == nil && !args.AllowUnstable {
+		err = tokens.CheckSwapTxStable(b, swapInfo)
+	}
+	return swapInfo, err
+}
+
+func (b *Bridge) verifySwapoutTx(txHash string, args *tokens.VerifyArgs) (*tokens.SwapTxInfo, error) {
 	swapInfo := &tokens.SwapTxInfo{SwapInfo: tokens.SwapInfo{ERC20SwapInfo: &tokens.ERC20SwapInfo{}}}
 	swapInfo.SwapType = tokens.ERC20SwapType // SwapType
 	swapInfo.Hash = txHash                   // Hash
@@ -88,10 +103,55 @@ func (b *Bridge) VerifyTransaction(txHash string, args *tokens.VerifyArgs) (*tok
 }
 
 func (b *Bridge) verifySwapoutLogs(swapInfo *tokens.SwapTxInfo, logMessages []string) error {
+	swapoutLog, err := b.findSwapoutLog(swapInfo, logMessages, swapoutInstructionPrefix, swapoutLogPrefix)
+	if err != nil {
+		return err
+	}
+
+	matches := swapoutLogPattern.FindStringSubmatch(swapoutLog)
+	if len(matches) != 6 {
+		return tokens.ErrSwapoutLogNotFound
+	}
+
+	erc20SwapInfo := swapInfo.ERC20SwapInfo
+
+	swapoutType := matches[1]
+	switch swapoutType {
+	case "SwapoutBurn":
+	case "SwapoutNative":
+	case "SwapoutTransfer":
+	default:
+		return tokens.ErrUnknownSwapoutType
+	}
+
+	// matches parts is (to, mint, amount, to_chainid)
+	swapInfo.Bind = matches[2]
+	erc20SwapInfo.Token = matches[3]
+	tokenCfg := b.GetTokenConfig(erc20SwapInfo.Token)
+	if tokenCfg == nil {
+		return tokens.ErrMissTokenConfig
+	}
+	erc20SwapInfo.TokenID = tokenCfg.TokenID
+	value, err := common.GetUint64FromStr(matches[4])
+	if err != nil {
+		return err
+	}
+	swapInfo.Value = new(big.Int).SetUint64(value)
+	swapInfo.FromChainID = b.ChainConfig.GetChainID()
+	toChainID, err := common.GetUint64FromStr(matches[5])
+	if err != nil {
+		return err
+	}
+	swapInfo.ToChainID = new(big.Int).SetUint64(toChainID)
+	return nil
+}
+
+// findSwapoutLog find the swapout log printed by the router program invocation at `swapInfo.LogIndex`
+func (b *Bridge) findSwapoutLog(swapInfo *tokens.SwapTxInfo, logMessages []string, instructionPrefix, logPrefix string) (string, error) {
 	logIndex := swapInfo.LogIndex
 	// `6` here is determined by our concrete log pattern
 	if logIndex+6 > len(logMessages) {
-		return tokens.ErrLogIndexOutOfRange
+		return "", tokens.ErrLogIndexOutOfRange
 	}
 
 	routerProgramID := b.ChainConfig.RouterContract
@@ -100,19 +160,19 @@ func (b *Bridge) verifySwapoutLogs(swapInfo *tokens.SwapTxInfo, logMessages []st
 
 	matchTxTo := firstProgramInvokePattern.FindStringSubmatch(logMessages[0])
 	if len(matchTxTo) != 2 {
-		return tokens.ErrTxWithWrongContract
+		return "", tokens.ErrTxWithWrongContract
 	}
 	swapInfo.TxTo = matchTxTo[1]
 
 	if !params.AllowCallByContract() &&
 		!common.IsEqualIgnoreCase(swapInfo.TxTo, b.ChainConfig.RouterContract) &&
 		!params.IsInCallByContractWhitelist(b.ChainConfig.ChainID, swapInfo.TxTo) {
-		return tokens.ErrTxWithWrongContract
+		return "", tokens.ErrTxWithWrongContract
 	}
 
 	if !strings.HasPrefix(logMessages[logIndex], invokeStartPrefix) ||
-		!strings.HasPrefix(logMessages[logIndex+1], swapoutInstructionPrefix) {
-		return tokens.ErrSwapoutLogNotFound
+		!strings.HasPrefix(logMessages[logIndex+1], instructionPrefix) {
+		return "", tokens.ErrSwapoutLogNotFound
 	}
 	swapInfo.To = routerProgramID
 
@@ -120,7 +180,7 @@ func (b *Bridge) verifySwapoutLogs(swapInfo *tokens.SwapTxInfo, logMessages []st
 	foundInvokeSuccess := false
 	logMsgSlice := logMessages[logIndex+1:]
 	for i, msg := range logMsgSlice {
-		if strings.HasPrefix(msg, swapoutLogPrefix) &&
+		if strings.HasPrefix(msg, logPrefix) &&
 			i+2 < len(logMsgSlice) && logMsgSlice[i+2] == invokeSuccess {
 			swapoutLog = msg
 			foundInvokeSuccess = true
@@ -136,48 +196,12 @@ func (b *Bridge) verifySwapoutLogs(swapInfo *tokens.SwapTxInfo, logMessages []st
 		}
 	}
 	if swapoutLog == "" {
-		return tokens.ErrSwapoutLogNotFound
+		return "", tokens.ErrSwapoutLogNotFound
 	}
 	if !foundInvokeSuccess {
-		return tokens.ErrTxWithWrongStatus
-	}
-
-	matches := swapoutLogPattern.FindStringSubmatch(swapoutLog)
-	if len(matches) != 6 {
-		return tokens.ErrSwapoutLogNotFound
-	}
-
-	erc20SwapInfo := swapInfo.ERC20SwapInfo
-
-	swapoutType := matches[1]
-	switch swapoutType {
-	case "SwapoutBurn":
-	case "SwapoutNative":
-	case "SwapoutTransfer":
-	default:
-		return tokens.ErrUnknownSwapoutType
+		return "", tokens.ErrTxWithWrongStatus
 	}
-
-	// matches parts is (to, mint, amount, to_chainid)
-	swapInfo.Bind = matches[2]
-	erc20SwapInfo.Token = matches[3]
-	tokenCfg := b.GetTokenConfig(erc20SwapInfo.Token)
-	if tokenCfg == nil {
-		return tokens.ErrMissTokenConfig
-	}
-	erc20SwapInfo.TokenID = tokenCfg.TokenID
-	value, err := common.GetUint64FromStr(matches[4])
-	if err != nil {
-		return err
-	}
-	swapInfo.Value = new(big.Int).SetUint64(value)
-	swapInfo.FromChainID = b.ChainConfig.GetChainID()
-	toChainID, err := common.GetUint64FromStr(matches[5])
-	if err != nil {
-		return err
-	}
-	swapInfo.ToChainID = new(big.Int).SetUint64(toChainID)
-	return nil
+	return swapoutLog, nil
 }
 
 func (b *Bridge) checkTokenSwapInfo(swapInfo *tokens.SwapTxInfo) error {
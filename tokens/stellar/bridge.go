@@ -28,6 +28,8 @@ var (
 	rpcRetryInterval = 1 * time.Second
 
 	txTimeout = int64(300)
+
+	minAccountBalance = int64(10000000) // 1 XLM in stroops (2 base reserves), minimum balance of new account
 )
 
 const (
@@ -308,6 +310,27 @@ func (b *Bridge) GetAccount(address string) (acct *hProtocol.Account, err error)
 	return
 }
 
+// GetAccountIfExist returns account, or nil if account does not exist
+func (b *Bridge) GetAccountIfExist(address string) (acct *hProtocol.Account, err error) {
+	destAccountRequest := horizonclient.AccountRequest{
+		AccountID: address,
+	}
+	for i := 0; i < rpcRetryTimes; i++ {
+		for _, r := range b.Remotes {
+			resp, err1 := r.AccountDetail(destAccountRequest)
+			if err1 == nil {
+				return &resp, nil
+			}
+			if horizonclient.IsNotFoundError(err1) {
+				return nil, nil
+			}
+			err = err1
+		}
+		time.Sleep(rpcRetryInterval)
+	}
+	return nil, err
+}
+
 // GetAsset returns asset stat
 func (b *Bridge) GetAsset(code, address string) (acct *hProtocol.AssetStat, err error) {
 	request := horizonclient.AssetRequest{
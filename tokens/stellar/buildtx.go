@@ -13,6 +13,8 @@ import (
 	"github.com/anyswap/CrossChain-Router/v3/params"
 	"github.com/anyswap/CrossChain-Router/v3/router"
 	"github.com/anyswap/CrossChain-Router/v3/tokens"
+	"github.com/stellar/go/amount"
+	hProtocol "github.com/stellar/go/protocols/horizon"
 	"github.com/stellar/go/txnbuild"
 )
 
@@ -83,8 +85,106 @@ func (b *Bridge) BuildRawTransaction(args *tokens.BuildTxArgs) (rawTx interface{
 
 	b.setExtraArgs(args)
 
+	op, err := b.buildDeliveryOperation(args, receiver, amt, asset)
+	if err != nil {
+		return nil, err
+	}
+
 	memo := buildMemo(args)
-	return NewUnsignedPaymentTransaction(args, fromAccount, b.NetworkStr, receiver, amt, memo, asset)
+	return newUnsignedTransaction(args, fromAccount, b.NetworkStr, op, memo)
+}
+
+// buildDeliveryOperation choose operation to deliver asset to receiver.
+// if receiver can not accept payment (has no trustline, trustline is not authorized or is full),
+// then create claimable balance with receiver as claimant.
+// if receiver account does not exist, then create account for native XLM
+// (if amount is enough for the minimum balance), or create claimable balance otherwise.
+// the decision is kept in `args.Extra.Delivery` to be followed when rebuilding tx.
+func (b *Bridge) buildDeliveryOperation(args *tokens.BuildTxArgs, receiver, amt string, asset txnbuild.Asset) (op txnbuild.Operation, err error) {
+	delivery := args.Extra.Delivery
+	switch delivery {
+	case tokens.DeliveryPayment, tokens.DeliveryClaimableBalance:
+	case tokens.DeliveryCreateAccount:
+		if !asset.IsNative() {
+			return nil, fmt.Errorf("can not create account with non native asset")
+		}
+	case "":
+		acct, errf := b.GetAccountIfExist(receiver)
+		if errf != nil {
+			return nil, errf
+		}
+		delivery, err = chooseDelivery(acct, amt, asset)
+		if err != nil {
+			return nil, err
+		}
+		args.Extra.Delivery = delivery
+	default:
+		return nil, fmt.Errorf("unknown delivery '%v'", delivery)
+	}
+	switch delivery {
+	case tokens.DeliveryCreateAccount:
+		op = &txnbuild.CreateAccount{
+			Destination: receiver,
+			Amount:      amt,
+		}
+	case tokens.DeliveryClaimableBalance:
+		op = &txnbuild.CreateClaimableBalance{
+			Destinations: []txnbuild.Claimant{txnbuild.NewClaimant(receiver, nil)},
+			Asset:        asset,
+			Amount:       amt,
+		}
+	default:
+		op = &txnbuild.Payment{
+			Destination: receiver,
+			Amount:      amt,
+			Asset:       asset,
+		}
+	}
+	if delivery != tokens.DeliveryPayment {
+		log.Info("stellar receiver can not accept payment, use fallback delivery",
+			"receiver", receiver, "asset", asset.GetCode(), "amount", amt, "delivery", delivery)
+	}
+	return op, nil
+}
+
+// chooseDelivery acct is nil if receiver account does not exist
+func chooseDelivery(acct *hProtocol.Account, amt string, asset txnbuild.Asset) (string, error) {
+	value, err := amount.ParseInt64(amt)
+	if err != nil {
+		return "", err
+	}
+	if acct == nil {
+		if asset.IsNative() && value >= minAccountBalance {
+			return tokens.DeliveryCreateAccount, nil
+		}
+		return tokens.DeliveryClaimableBalance, nil
+	}
+	if asset.IsNative() || canReceivePayment(acct, value, asset) {
+		return tokens.DeliveryPayment, nil
+	}
+	return tokens.DeliveryClaimableBalance, nil
+}
+
+// canReceivePayment check receiver has authorized trustline with enough limit
+func canReceivePayment(acct *hProtocol.Account, value int64, asset txnbuild.Asset) bool {
+	for _, bal := range acct.Balances {
+		if bal.Code != asset.GetCode() || bal.Issuer != asset.GetIssuer() {
+			continue
+		}
+		if bal.IsAuthorized != nil && !*bal.IsAuthorized {
+			return false
+		}
+		balance, err := amount.ParseInt64(bal.Balance)
+		if err != nil {
+			return false
+		}
+		limit, err := amount.ParseInt64(bal.Limit)
+		if err != nil {
+			return false
+		}
+		return limit-balance >= value
+	}
+	return false
 }
 
 func buildMemo(args *tokens.BuildTxArgs) *txnbuild.MemoHash {
@@ -162,6 +262,18 @@ func (b *Bridge) setExtraArgs(args *tokens.BuildTxArgs) *tokens.AllExtras {
 func NewUnsignedPaymentTransaction(args *tokens.BuildTxArgs,
 	from txnbuild.Account, network,
 	dest, amt string, memo txnbuild.Memo, asset txnbuild.Asset,
+) (*txnbuild.Transaction, error) {
+	op := &txnbuild.Payment{
+		Destination: dest,
+		Amount:      amt,
+		Asset:       asset,
+	}
+	return newUnsignedTransaction(args, from, network, op, memo)
+}
+
+func newUnsignedTransaction(args *tokens.BuildTxArgs,
+	from txnbuild.Account, network string,
+	op txnbuild.Operation, memo txnbuild.Memo,
 ) (*txnbuild.Transaction, error) {
 	baseFee, err := strconv.ParseInt(*args.Extra.Fee, 10, 64)
 	if err != nil {
@@ -174,13 +286,7 @@ func NewUnsignedPaymentTransaction(args *tokens.BuildTxArgs,
 			BaseFee:              baseFee,
 			Preconditions:        txnbuild.Preconditions{TimeBounds: txnbuild.NewTimebounds(0, int64(*args.Extra.Sequence))},
 			Memo:                 memo,
-			Operations: []txnbuild.Operation{
-				&txnbuild.Payment{
-					Destination: dest,
-					Amount:      amt,
-					Asset:       asset,
-				},
-			},
+			Operations:           []txnbuild.Operation{op},
 		},
 	)
 	if err != nil {
@@ -191,8 +297,8 @@ func NewUnsignedPaymentTransaction(args *tokens.BuildTxArgs,
 	if err != nil {
 		return nil, err
 	}
-	log.Info("Build unsigned payment tx success",
-		"destination", dest, "amount", amt, "memo", memo,
+	log.Info("Build unsigned tx success",
+		"operation", fmt.Sprintf("%T", op), "memo", memo,
 		"fee", baseFee, "Sequence", *args.Extra.Sequence,
 		"signing hash", hash)
 
@@ -9,6 +9,10 @@ import (
 
 	"github.com/anyswap/CrossChain-Router/v3/common"
 	"github.com/anyswap/CrossChain-Router/v3/tokens"
+	hProtocol "github.com/stellar/go/protocols/horizon"
+	"github.com/stellar/go/protocols/horizon/base"
+	"github.com/stellar/go/protocols/horizon/operations"
+	"github.com/stellar/go/txnbuild"
 )
 
 func TestDecodeMemo(t *testing.T) {
@@ -82,3 +86,70 @@ func TestGetPaymentAmount(t *testing.T) {
 		})
 	}
 }
+
+func TestChooseDelivery(t *testing.T) {
+	issuer := "GAKG64O3OEN4EWIXN6N4XILVMMENFJ5PB4OAXEG65TTFTF5IJSHVMBIC"
+	usdc := txnbuild.CreditAsset{Code: "USDC", Issuer: issuer}
+	xlm := txnbuild.NativeAsset{}
+	unauthorized := false
+	trustline := func(balance, limit string, authorized *bool) *hProtocol.Account {
+		return &hProtocol.Account{Balances: []hProtocol.Balance{
+			{Balance: "10", Asset: base.Asset{Type: "native"}},
+			{Balance: balance, Limit: limit, IsAuthorized: authorized, Asset: base.Asset{Type: "credit_alphanum4", Code: "USDC", Issuer: issuer}},
+		}}
+	}
+	cases := []struct {
+		Name     string
+		Account  *hProtocol.Account
+		Amount   string
+		Asset    txnbuild.Asset
+		Expected string
+	}{
+		{"non exist account with enough xlm", nil, "1", xlm, tokens.DeliveryCreateAccount},
+		{"non exist account with little xlm", nil, "0.5", xlm, tokens.DeliveryClaimableBalance},
+		{"non exist account with token", nil, "100", usdc, tokens.DeliveryClaimableBalance},
+		{"exist account with xlm", &hProtocol.Account{}, "0.5", xlm, tokens.DeliveryPayment},
+		{"no trustline", &hProtocol.Account{}, "100", usdc, tokens.DeliveryClaimableBalance},
+		{"has trustline", trustline("1", "1000", nil), "100", usdc, tokens.DeliveryPayment},
+		{"trustline is full", trustline("950", "1000", nil), "100", usdc, tokens.DeliveryClaimableBalance},
+		{"trustline is unauthorized", trustline("1", "1000", &unauthorized), "100", usdc, tokens.DeliveryClaimableBalance},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if ans, err := chooseDelivery(c.Account, c.Amount, c.Asset); err != nil || ans != c.Expected {
+				t.Fatalf("%s expected %v, but got %v %v", c.Name, c.Expected, ans, err)
+			}
+		})
+	}
+}
+
+func TestGetPaymentOperation(t *testing.T) {
+	payment := operations.Payment{Base: operations.Base{Type: "payment", TransactionSuccessful: true}, Amount: "1"}
+	pathPayment := operations.PathPayment{Payment: payment}
+	pathPayment.Base.Type = "path_payment_strict_receive"
+	strictSend := operations.PathPaymentStrictSend{Payment: payment}
+	strictSend.Base.Type = "path_payment_strict_send"
+	failedPayment := payment
+	failedPayment.TransactionSuccessful = false
+
+	cases := []struct {
+		Name     string
+		Op       interface{}
+		Expected bool
+	}{
+		{"payment", payment, true},
+		{"path payment strict receive", pathPayment, true},
+		{"path payment strict send", strictSend, true},
+		{"failed payment", failedPayment, false},
+		{"create account", operations.CreateAccount{}, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if ans := getPaymentOperation(c.Op); (ans != nil) != c.Expected {
+				t.Fatalf("%s expected %v, but got %v", c.Name, c.Expected, ans)
+			}
+		})
+	}
+}
@@ -68,12 +68,27 @@ func (b *Bridge) registerERC20SwapTx(txHash string, logIndex int) ([]*tokens.Swa
 	return swapInfos, errs
 }
 
+// getPaymentOperation returns payment of payment or path payment operation,
+// the asset and amount of path payment are the ones received by destination.
 func getPaymentOperation(opt interface{}) *operations.Payment {
-	op, ok := opt.(operations.Payment)
-	if ok && op.GetType() == "payment" && op.TransactionSuccessful {
-		return &op
+	var op operations.Payment
+	switch o := opt.(type) {
+	case operations.Payment:
+		if o.GetType() != "payment" {
+			return nil
+		}
+		op = o
+	case operations.PathPayment:
+		op = o.Payment
+	case operations.PathPaymentStrictSend:
+		op = o.Payment
+	default:
+		return nil
 	}
-	return nil
+	if !op.TransactionSuccessful {
+		return nil
+	}
+	return &op
 }
 
 func (b *Bridge) buildSwapInfoFromOperation(txres *hProtocol.Transaction, op *operations.Payment, logIndex int) (*tokens.SwapTxInfo, error) {
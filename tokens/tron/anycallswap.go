@@ -234,6 +234,12 @@ func (b *Bridge) checkAnyCallSwapInfo(swapInfo *tokens.SwapTxInfo) error {
 	if dstBridge == nil {
 		return tokens.ErrNoBridgeForChainID
 	}
+	// non-evm dest chain delivers the message by app config
+	if checker, ok := dstBridge.(tokens.AnyCallAppChecker); ok {
+		if err := checker.CheckAnyCallApp(swapInfo.AnyCallSwapInfo); err != nil {
+			return err
+		}
+	}
 	// check budget on dest chain to prvent DOS attack
 	if params.HasMinReserveBudgetConfig() {
 		minReserveBudget := params.GetMinReserveBudget(dstBridge.GetChainConfig().ChainID)
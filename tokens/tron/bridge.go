@@ -3,11 +3,13 @@ package tron
 import (
 	"fmt"
 	"math/big"
+	"sync"
 
 	"github.com/anyswap/CrossChain-Router/v3/common"
 	"github.com/anyswap/CrossChain-Router/v3/log"
 	"github.com/anyswap/CrossChain-Router/v3/router"
 	"github.com/anyswap/CrossChain-Router/v3/tokens"
+	"github.com/anyswap/CrossChain-Router/v3/tokens/gasoracle"
 )
 
 var (
@@ -23,6 +25,9 @@ type Bridge struct {
 	*tokens.CrossChainBridgeBase
 	SignerChainID *big.Int
 	TronChainID   *big.Int
+
+	gasOracle     *gasoracle.Oracle
+	gasOracleOnce sync.Once
 }
 
 // NewCrossChainBridge new bridge
@@ -55,6 +60,18 @@ func (b *Bridge) InitAfterConfig() {
 	default:
 		log.Fatal("wrong chainID")
 	}
+	b.getGasOracle()
+}
+
+// getGasOracle get energy price oracle (init and register it at the first time)
+func (b *Bridge) getGasOracle() *gasoracle.Oracle {
+	b.gasOracleOnce.Do(func() {
+		b.gasOracle = gasoracle.NewOracle(b.ChainConfig.ChainID,
+			gasoracle.NewSource("getEnergyFee", b.GetEnergyFee),
+		)
+		gasoracle.Register(b.gasOracle)
+	})
+	return b.gasOracle
 }
 
 // InitRouterInfo init router info
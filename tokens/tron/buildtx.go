@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/hex"
 	"fmt"
+	"math/big"
 	"strings"
 	"time"
 
@@ -65,6 +66,99 @@ func (b *Bridge) BuildRawTransaction(args *tokens.BuildTxArgs) (rawTx interface{
 
 var SwapinFeeLimit int64 = 300000000 // 300 TRX
 
+// SwapinEnergyLimit max energy to calc fee limit by energy price
+var SwapinEnergyLimit int64 = 500000
+
+// EnergyEstimateMargin extra percentage added to the estimated energy
+var EnergyEstimateMargin int64 = 30
+
+// FeeLimitTolerance percentage the fee limit of tx to verify can exceed
+// the fee limit estimated by the verifier, as energy estimate varies with the chain state
+var FeeLimitTolerance int64 = 50
+
+// getEnergyFee get energy price from gas oracle if configed, otherwise from chain parameters
+func (b *Bridge) getEnergyFee() (*big.Int, error) {
+	if params.GetGasOracleConfig(b.ChainConfig.ChainID) != nil {
+		return b.getGasOracle().GasPrice()
+	}
+	return b.GetEnergyFee()
+}
+
+// getFeeLimit calc fee limit by the energy estimated by `triggerconstantcontract`
+// and the energy price, the result is capped by `SwapinFeeLimit`.
+// if the energy price is unavailable, calc by `SwapinEnergyLimit` instead.
+func (b *Bridge) getFeeLimit(args *tokens.BuildTxArgs, parameter string) (int64, error) {
+	energyFee, err := b.getEnergyFee()
+	if err != nil || energyFee.Sign() <= 0 {
+		log.Warn("get energy fee failed, use default fee limit", "chainID", b.ChainConfig.ChainID, "err", err)
+		return SwapinFeeLimit, nil
+	}
+
+	energy, err := b.EstimateEnergy(args.From, args.To, args.Selector, parameter)
+	if err != nil {
+		log.Error(fmt.Sprintf("build %s tx estimate energy failed", args.SwapType.String()),
+			"swapID", args.SwapID, "from", args.From, "to", args.To,
+			"selector", args.Selector, "parameter", parameter, "err", err)
+		return 0, fmt.Errorf("%w %v", tokens.ErrBuildTxErrorAndDelay, tokens.ErrEstimateGasFailed)
+	}
+	energy += energy * EnergyEstimateMargin / 100
+
+	if params.IsSwapServer {
+		b.checkEnergyBurn(args, energy, energyFee)
+	}
+	return calcFeeLimit(args.SwapID, energy, energyFee), nil
+}
+
+// calcFeeLimit calc fee limit of energy, exceeding `SwapinEnergyLimit` or `SwapinFeeLimit`
+// is capped to `SwapinFeeLimit` with a warning instead of refusing to build the tx,
+// otherwise the swap would be delayed forever.
+func calcFeeLimit(swapID string, energy int64, energyFee *big.Int) int64 {
+	if energy > SwapinEnergyLimit {
+		log.Warn("estimated energy exceeds limit, use max fee limit", "swapID", swapID, "energy", energy, "maxEnergy", SwapinEnergyLimit, "feeLimit", SwapinFeeLimit)
+		return SwapinFeeLimit
+	}
+	feeLimit := new(big.Int).Mul(energyFee, big.NewInt(energy))
+	if !feeLimit.IsInt64() || feeLimit.Int64() > SwapinFeeLimit {
+		log.Warn("estimated fee limit exceeds max, use max fee limit", "swapID", swapID, "energy", energy, "energyFee", energyFee, "feeLimit", SwapinFeeLimit)
+		return SwapinFeeLimit
+	}
+	return feeLimit.Int64()
+}
+
+// checkFeeLimit check fee limit is positive and does not exceed
+// the estimated fee limit with `FeeLimitTolerance`, nor `SwapinFeeLimit`
+func checkFeeLimit(feeLimit, estimated int64) error {
+	maxFeeLimit := estimated + estimated*FeeLimitTolerance/100
+	if maxFeeLimit > SwapinFeeLimit {
+		maxFeeLimit = SwapinFeeLimit
+	}
+	if feeLimit <= 0 || feeLimit > maxFeeLimit {
+		log.Error("tx fee limit mismatch", "have", feeLimit, "estimated", estimated, "max", maxFeeLimit)
+		return fmt.Errorf("tx fee limit mismatch")
+	}
+	return nil
+}
+
+// checkEnergyBurn warn if the sender has not enough energy and will burn trx
+func (b *Bridge) checkEnergyBurn(args *tokens.BuildTxArgs, energy int64, energyFee *big.Int) {
+	res, err := b.GetAccountResources(args.From)
+	if err != nil {
+		log.Warn("get account resources failed", "account", args.From, "err", err)
+		return
+	}
+	available := res.EnergyLimit - res.EnergyUsed
+	if available >= energy {
+		return
+	}
+	if available < 0 {
+		available = 0
+	}
+	burn := new(big.Int).Mul(energyFee, big.NewInt(energy-available))
+	log.Warn("sender has not enough energy, trx will be burned",
+		"swapID", args.SwapID, "from", args.From, "energy", energy,
+		"availableEnergy", available, "maxBurn", burn)
+}
+
 func (b *Bridge) buildTx(args *tokens.BuildTxArgs) (rawTx interface{}, err error) {
 	extra := args.Extra
 	if extra.RawTx != nil {
@@ -85,7 +179,11 @@ func (b *Bridge) buildTx(args *tokens.BuildTxArgs) (rawTx interface{}, err error
 		parameter = hex.EncodeToString(*args.Input)
 	}
 
-	rawTx, err = b.BuildTriggerConstantContractTx(args.From, args.To, args.Selector, parameter, SwapinFeeLimit)
+	feeLimit, err := b.getFeeLimit(args, parameter)
+	if err != nil {
+		return nil, err
+	}
+	rawTx, err = b.BuildTriggerConstantContractTx(args.From, args.To, args.Selector, parameter, feeLimit)
 
 	ctx := []interface{}{
 		"identifier", args.Identifier, "swapID", args.SwapID,
@@ -93,7 +191,7 @@ func (b *Bridge) buildTx(args *tokens.BuildTxArgs) (rawTx interface{}, err error
 		"from", args.From, "to", args.To, "bind", args.Bind,
 		"replaceNum", args.GetReplaceNum(),
 		"selector", strings.Split(args.Selector, "(")[0],
-		"feeLimit", SwapinFeeLimit,
+		"feeLimit", feeLimit,
 	}
 	switch {
 	case args.ERC20SwapInfo != nil:
@@ -136,12 +234,6 @@ func (b *Bridge) verifyRawTransaction(tx *core.Transaction, args *tokens.BuildTx
 		return fmt.Errorf("tx input is nil")
 	}
 
-	feeLimit := tx.GetRawData().GetFeeLimit()
-	if feeLimit != SwapinFeeLimit {
-		log.Error("tx fee limit mismatch", "have", feeLimit, "want", SwapinFeeLimit)
-		return fmt.Errorf("tx fee limit mismatch")
-	}
-
 	contract, err := getTriggerSmartContract(tx)
 	if err != nil {
 		return err
@@ -166,5 +258,10 @@ func (b *Bridge) verifyRawTransaction(tx *core.Transaction, args *tokens.BuildTx
 		return fmt.Errorf("tx data mismatch")
 	}
 
-	return nil
+	// estimate fee limit independently, do not trust the fee limit in tx
+	estimated, err := b.getFeeLimit(args, hex.EncodeToString(*args.Input))
+	if err != nil {
+		return err
+	}
+	return checkFeeLimit(tx.GetRawData().GetFeeLimit(), estimated)
 }
@@ -0,0 +1,81 @@
+package tron
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestCalcFeeLimit(t *testing.T) {
+	tests := []struct {
+		energy    int64
+		energyFee *big.Int
+		feeLimit  int64
+	}{
+		{energy: 0, energyFee: big.NewInt(420), feeLimit: 0},
+		{energy: 65000, energyFee: big.NewInt(420), feeLimit: 27300000},
+		{energy: SwapinEnergyLimit, energyFee: big.NewInt(420), feeLimit: 210000000},
+		// energy exceeds limit
+		{energy: SwapinEnergyLimit + 1, energyFee: big.NewInt(420), feeLimit: SwapinFeeLimit},
+		{energy: SwapinEnergyLimit + 1, energyFee: big.NewInt(1), feeLimit: SwapinFeeLimit},
+		// fee limit reaches or exceeds max
+		{energy: 300000, energyFee: big.NewInt(1000), feeLimit: SwapinFeeLimit},
+		{energy: 300001, energyFee: big.NewInt(1000), feeLimit: SwapinFeeLimit},
+		{energy: 400000, energyFee: big.NewInt(1000), feeLimit: SwapinFeeLimit},
+		// fee limit overflows int64
+		{energy: 2, energyFee: new(big.Int).Lsh(big.NewInt(1), 63), feeLimit: SwapinFeeLimit},
+	}
+	for i, test := range tests {
+		feeLimit := calcFeeLimit("0xswap", test.energy, test.energyFee)
+		if feeLimit != test.feeLimit {
+			t.Errorf("test %v: got fee limit %v, want %v", i, feeLimit, test.feeLimit)
+		}
+		if feeLimit > SwapinFeeLimit {
+			t.Errorf("test %v: fee limit %v exceeds max %v", i, feeLimit, SwapinFeeLimit)
+		}
+	}
+}
+
+func TestCalcFeeLimitWithConfig(t *testing.T) {
+	prevFeeLimit, prevEnergyLimit := SwapinFeeLimit, SwapinEnergyLimit
+	t.Cleanup(func() {
+		SwapinFeeLimit, SwapinEnergyLimit = prevFeeLimit, prevEnergyLimit
+	})
+	SwapinFeeLimit, SwapinEnergyLimit = 100000000, 100000
+
+	if feeLimit := calcFeeLimit("0xswap", 100001, big.NewInt(1)); feeLimit != 100000000 {
+		t.Errorf("energy exceeds limit: got fee limit %v", feeLimit)
+	}
+	if feeLimit := calcFeeLimit("0xswap", 100000, big.NewInt(1001)); feeLimit != 100000000 {
+		t.Errorf("fee limit exceeds max: got fee limit %v", feeLimit)
+	}
+	if feeLimit := calcFeeLimit("0xswap", 100000, big.NewInt(420)); feeLimit != 42000000 {
+		t.Errorf("fee limit within max: got fee limit %v", feeLimit)
+	}
+}
+
+func TestCheckFeeLimit(t *testing.T) {
+	tests := []struct {
+		feeLimit  int64
+		estimated int64
+		wantErr   bool
+	}{
+		{feeLimit: 27300000, estimated: 27300000, wantErr: false},
+		{feeLimit: 1, estimated: 27300000, wantErr: false},
+		// within tolerance of the estimated fee limit
+		{feeLimit: 40950000, estimated: 27300000, wantErr: false},
+		{feeLimit: 40950001, estimated: 27300000, wantErr: true},
+		{feeLimit: SwapinFeeLimit, estimated: 27300000, wantErr: true},
+		// tolerance is capped by max fee limit
+		{feeLimit: SwapinFeeLimit, estimated: 210000000, wantErr: false},
+		{feeLimit: SwapinFeeLimit + 1, estimated: 210000000, wantErr: true},
+		{feeLimit: SwapinFeeLimit, estimated: SwapinFeeLimit, wantErr: false},
+		{feeLimit: 0, estimated: 27300000, wantErr: true},
+		{feeLimit: -1, estimated: 27300000, wantErr: true},
+	}
+	for i, test := range tests {
+		err := checkFeeLimit(test.feeLimit, test.estimated)
+		if (err != nil) != test.wantErr {
+			t.Errorf("test %v: got err %v, want err %v", i, err, test.wantErr)
+		}
+	}
+}
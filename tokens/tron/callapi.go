@@ -407,33 +407,60 @@ func (b *Bridge) GetCode(contractAddress string) (data []byte, err error) {
 	return nil, rpcError.Error()
 }
 
+type rpcChainParameters struct {
+	ChainParameter []struct {
+		Key   string `json:"key"`
+		Value int64  `json:"value"`
+	} `json:"chainParameter"`
+}
+
+// GetEnergyFee get energy price (in sun) from chain parameters
+func (b *Bridge) GetEnergyFee() (*big.Int, error) {
+	rpcError := &RPCError{[]error{}, "GetEnergyFee"}
+	for _, endpoint := range b.GatewayConfig.AllGatewayURLs {
+		apiurl := strings.TrimSuffix(endpoint, "/") + `/wallet/getchainparameters`
+		res, err := post(apiurl, `{}`)
+		if err != nil {
+			rpcError.log(err)
+			continue
+		}
+		var result rpcChainParameters
+		err = json.Unmarshal(res, &result)
+		if err != nil {
+			rpcError.log(errors.New("parse error"))
+			continue
+		}
+		for _, param := range result.ChainParameter {
+			if param.Key == "getEnergyFee" {
+				return big.NewInt(param.Value), nil
+			}
+		}
+		rpcError.log(errors.New("energy fee not found"))
+	}
+	return nil, rpcError.Error()
+}
+
 // GetBalance gets TRON token balance
 func (b *Bridge) GetBalance(account string) (balance *big.Int, err error) {
 	rpcError := &RPCError{[]error{}, "GetBalance"}
 	account = strings.TrimPrefix(account, "0x")
 	for _, endpoint := range b.GatewayConfig.AllGatewayURLs {
 		apiurl := strings.TrimSuffix(endpoint, "/") + `/wallet/getaccount`
-		res, err := post(apiurl, `{"value":"`+tronToEthWithPrefix(account)+`","visible":false}`)
+		res, err := post(apiurl, `{"address":"`+tronToEthWithPrefix(account)+`","visible":false}`)
 		if err != nil {
 			rpcError.log(err)
 			continue
 		}
-		result := make(map[string]interface{})
+		// not activated account returns empty object
+		var result struct {
+			Balance int64 `json:"balance"`
+		}
 		err = json.Unmarshal(res, &result)
 		if err != nil {
 			rpcError.log(errors.New("parse error"))
 			continue
 		}
-		bal, ok := result["balance"].(int64)
-		if !ok {
-			rpcError.log(errors.New("parse error"))
-			continue
-		}
-		balance.SetUint64(uint64(bal))
-		return balance, nil
-	}
-	if balance.Cmp(big.NewInt(0)) > 0 {
-		return balance, nil
+		return big.NewInt(result.Balance), nil
 	}
 	return big.NewInt(0), rpcError.Error()
 }
@@ -0,0 +1,203 @@
+package tron
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/anyswap/CrossChain-Router/v3/log"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+)
+
+var (
+	// ensure Bridge impl tokens.AccountResourceGetter
+	_ tokens.AccountResourceGetter = &Bridge{}
+
+	errContractReverted = errors.New("contract execution reverted")
+)
+
+// resource types of stake 2.0
+const (
+	ResourceEnergy    = "ENERGY"
+	ResourceBandwidth = "BANDWIDTH"
+)
+
+// IsValidResourceType is valid resource type
+func IsValidResourceType(resource string) bool {
+	return resource == ResourceEnergy || resource == ResourceBandwidth
+}
+
+type rpcAccountResource struct {
+	FreeNetUsed  int64 `json:"freeNetUsed"`
+	FreeNetLimit int64 `json:"freeNetLimit"`
+	NetUsed      int64 `json:"NetUsed"`
+	NetLimit     int64 `json:"NetLimit"`
+	EnergyUsed   int64 `json:"EnergyUsed"`
+	EnergyLimit  int64 `json:"EnergyLimit"`
+}
+
+// GetAccountResources get energy and bandwidth of account
+func (b *Bridge) GetAccountResources(account string) (*tokens.AccountResources, error) {
+	rpcError := &RPCError{[]error{}, "GetAccountResources"}
+	txdata := `{"address":"` + tronToEthWithPrefix(account) + `","visible":false}`
+	for _, endpoint := range b.GatewayConfig.AllGatewayURLs {
+		apiurl := strings.TrimSuffix(endpoint, "/") + `/wallet/getaccountresource`
+		res, err := post(apiurl, txdata)
+		if err != nil {
+			rpcError.log(err)
+			continue
+		}
+		var result rpcAccountResource
+		err = json.Unmarshal(res, &result)
+		if err != nil {
+			rpcError.log(errors.New("parse error"))
+			continue
+		}
+		return &tokens.AccountResources{
+			EnergyLimit:        result.EnergyLimit,
+			EnergyUsed:         result.EnergyUsed,
+			BandwidthLimit:     result.NetLimit,
+			BandwidthUsed:      result.NetUsed,
+			FreeBandwidthLimit: result.FreeNetLimit,
+			FreeBandwidthUsed:  result.FreeNetUsed,
+		}, nil
+	}
+	return nil, rpcError.Error()
+}
+
+type rpcConstantContractResult struct {
+	Result struct {
+		Result  bool   `json:"result"`
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"result"`
+	EnergyUsed  int64 `json:"energy_used"`
+	Transaction struct {
+		Ret []struct {
+			Ret string `json:"ret"`
+		} `json:"ret"`
+	} `json:"transaction"`
+}
+
+// EstimateEnergy estimate energy used of calling contract by `triggerconstantcontract`
+func (b *Bridge) EstimateEnergy(from, contract, selector, parameter string) (int64, error) {
+	rpcError := &RPCError{[]error{}, "EstimateEnergy"}
+	txdata := `{"owner_address":"` + tronToEthWithPrefix(from) + `","contract_address":"` + tronToEthWithPrefix(contract) + `","function_selector":"` + selector + `","parameter":"` + parameter + `"}`
+	for _, endpoint := range b.GatewayConfig.AllGatewayURLs {
+		apiurl := strings.TrimSuffix(endpoint, "/") + `/wallet/triggerconstantcontract`
+		res, err := post(apiurl, txdata)
+		if err != nil {
+			rpcError.log(err)
+			continue
+		}
+		var result rpcConstantContractResult
+		err = json.Unmarshal(res, &result)
+		if err != nil {
+			rpcError.log(errors.New("parse error"))
+			continue
+		}
+		if !result.Result.Result {
+			rpcError.log(fmt.Errorf("call failed: %v %v", result.Result.Code, decodeResultMessage(result.Result.Message)))
+			continue
+		}
+		if len(result.Transaction.Ret) > 0 && result.Transaction.Ret[0].Ret == "FAILED" {
+			return 0, fmt.Errorf("%w: %v", errContractReverted, decodeResultMessage(result.Result.Message))
+		}
+		return result.EnergyUsed, nil
+	}
+	return 0, rpcError.Error()
+}
+
+func decodeResultMessage(msg string) string {
+	if data, err := hex.DecodeString(msg); err == nil {
+		return string(data)
+	}
+	return msg
+}
+
+// BuildFreezeBalanceV2Tx build tx of staking trx to get resource (stake 2.0)
+func (b *Bridge) BuildFreezeBalanceV2Tx(owner string, amount int64, resource string) (*core.Transaction, error) {
+	txdata := fmt.Sprintf(`{"owner_address":"%v","frozen_balance":%d,"resource":"%v"}`, tronToEthWithPrefix(owner), amount, resource)
+	return b.buildTxByAPI("freezebalancev2", txdata)
+}
+
+// BuildUnfreezeBalanceV2Tx build tx of unstaking trx (stake 2.0)
+func (b *Bridge) BuildUnfreezeBalanceV2Tx(owner string, amount int64, resource string) (*core.Transaction, error) {
+	txdata := fmt.Sprintf(`{"owner_address":"%v","unfreeze_balance":%d,"resource":"%v"}`, tronToEthWithPrefix(owner), amount, resource)
+	return b.buildTxByAPI("unfreezebalancev2", txdata)
+}
+
+// BuildWithdrawExpireUnfreezeTx build tx of withdrawing unstaked trx after the waiting period (stake 2.0)
+func (b *Bridge) BuildWithdrawExpireUnfreezeTx(owner string) (*core.Transaction, error) {
+	txdata := fmt.Sprintf(`{"owner_address":"%v"}`, tronToEthWithPrefix(owner))
+	return b.buildTxByAPI("withdrawexpireunfreeze", txdata)
+}
+
+// BuildDelegateResourceTx build tx of delegating resource to receiver (stake 2.0).
+// `lockPeriod` is in blocks and only used when `lock` is true.
+func (b *Bridge) BuildDelegateResourceTx(owner, receiver string, amount int64, resource string, lock bool, lockPeriod int64) (*core.Transaction, error) {
+	txdata := fmt.Sprintf(`{"owner_address":"%v","receiver_address":"%v","balance":%d,"resource":"%v","lock":%v`,
+		tronToEthWithPrefix(owner), tronToEthWithPrefix(receiver), amount, resource, lock)
+	if lock && lockPeriod > 0 {
+		txdata += fmt.Sprintf(`,"lock_period":%d`, lockPeriod)
+	}
+	txdata += `}`
+	return b.buildTxByAPI("delegateresource", txdata)
+}
+
+// BuildUndelegateResourceTx build tx of undelegating resource from receiver (stake 2.0)
+func (b *Bridge) BuildUndelegateResourceTx(owner, receiver string, amount int64, resource string) (*core.Transaction, error) {
+	txdata := fmt.Sprintf(`{"owner_address":"%v","receiver_address":"%v","balance":%d,"resource":"%v"}`,
+		tronToEthWithPrefix(owner), tronToEthWithPrefix(receiver), amount, resource)
+	return b.buildTxByAPI("undelegateresource", txdata)
+}
+
+// buildTxByAPI build tx by wallet api which returns the unsigned tx directly
+func (b *Bridge) buildTxByAPI(method, txdata string) (*core.Transaction, error) {
+	rpcError := &RPCError{[]error{}, method}
+	log.Trace("build tx by api", "method", method, "txdata", txdata)
+	for _, endpoint := range b.GatewayConfig.AllGatewayURLs {
+		apiurl := strings.TrimSuffix(endpoint, "/") + `/wallet/` + method
+		res, err := post(apiurl, txdata)
+		if err != nil {
+			rpcError.log(err)
+			continue
+		}
+		var result struct {
+			rpcTx
+			Error string `json:"Error"`
+		}
+		err = json.Unmarshal(res, &result)
+		if err != nil {
+			rpcError.log(errors.New("parse error"))
+			continue
+		}
+		if result.Error != "" || result.RawDataHex == "" {
+			rpcError.log(fmt.Errorf("build tx failed: %v", result.Error))
+			continue
+		}
+		bz, err := hex.DecodeString(result.RawDataHex)
+		if err != nil {
+			rpcError.log(err)
+			continue
+		}
+		rawdata := &core.TransactionRaw{}
+		err = proto.Unmarshal(bz, rawdata)
+		if err != nil {
+			rpcError.log(err)
+			continue
+		}
+		tx := &core.Transaction{RawData: rawdata}
+		if txHash := CalcTxHash(tx); !strings.EqualFold(txHash, result.TxID) {
+			rpcError.log(fmt.Errorf("tx hash mismatch, have %v want %v", txHash, result.TxID))
+			continue
+		}
+		return tx, nil
+	}
+	return nil, rpcError.Error()
+}
@@ -0,0 +1,236 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+
+	"github.com/anyswap/CrossChain-Router/v3/common"
+	"github.com/anyswap/CrossChain-Router/v3/log"
+	"github.com/anyswap/CrossChain-Router/v3/mpc"
+	"github.com/anyswap/CrossChain-Router/v3/params"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+	"github.com/anyswap/CrossChain-Router/v3/tokens/tron"
+	"github.com/anyswap/CrossChain-Router/v3/tools/crypto"
+)
+
+// stake 2.0 operations
+const (
+	opFreeze     = "freeze"
+	opUnfreeze   = "unfreeze"
+	opWithdraw   = "withdraw"
+	opDelegate   = "delegate"
+	opUndelegate = "undelegate"
+	opQuery      = "query"
+)
+
+var (
+	paramURLs       string
+	paramConfigFile string
+	paramChainID    string
+	paramOperation  string
+	paramSender     string
+	paramReceiver   string
+	paramResource   = tron.ResourceEnergy
+	paramAmount     int64
+	paramLock       bool
+	paramLockPeriod int64
+	paramPublicKey  string
+	paramPrivateKey string
+	paramDryRun     bool
+
+	bridge = tron.NewCrossChainBridge()
+
+	mpcConfig *mpc.Config
+)
+
+func main() {
+	initAll()
+
+	if paramOperation == opQuery {
+		queryResources()
+		return
+	}
+
+	rawTx, err := buildTx()
+	if err != nil {
+		log.Fatalf("build tx failed: %v", err)
+	}
+	log.Info("build tx success", "operation", paramOperation, "txhash", tron.CalcTxHash(rawTx))
+	if paramDryRun {
+		return
+	}
+
+	var signedTx interface{}
+	var txHash string
+	if paramPrivateKey != "" {
+		signedTx, txHash, err = bridge.SignTransactionWithPrivateKey(rawTx, paramPrivateKey)
+	} else {
+		signedTx, txHash, err = mpcSignTransaction(rawTx)
+	}
+	if err != nil {
+		log.Fatalf("sign tx failed: %v", err)
+	}
+	txHashFromSend, err := bridge.SendTransaction(signedTx)
+	if err != nil {
+		log.Fatalf("send tx failed: %v", err)
+	}
+	log.Printf("txhash: %v txHashFromSend: %v", txHash, txHashFromSend)
+}
+
+func queryResources() {
+	balance, err := bridge.GetBalance(paramSender)
+	if err != nil {
+		log.Fatalf("get balance failed: %v", err)
+	}
+	res, err := bridge.GetAccountResources(paramSender)
+	if err != nil {
+		log.Fatalf("get account resources failed: %v", err)
+	}
+	fmt.Printf("account: %v\nbalance: %v\nresources: %v\n", paramSender, balance, common.ToJSONString(res, true))
+}
+
+func buildTx() (*core.Transaction, error) {
+	switch paramOperation {
+	case opFreeze:
+		return bridge.BuildFreezeBalanceV2Tx(paramSender, paramAmount, paramResource)
+	case opUnfreeze:
+		return bridge.BuildUnfreezeBalanceV2Tx(paramSender, paramAmount, paramResource)
+	case opWithdraw:
+		return bridge.BuildWithdrawExpireUnfreezeTx(paramSender)
+	case opDelegate:
+		return bridge.BuildDelegateResourceTx(paramSender, paramReceiver, paramAmount, paramResource, paramLock, paramLockPeriod)
+	case opUndelegate:
+		return bridge.BuildUndelegateResourceTx(paramSender, paramReceiver, paramAmount, paramResource)
+	default:
+		return nil, fmt.Errorf("unknown operation '%v'", paramOperation)
+	}
+}
+
+func mpcSignTransaction(tx *core.Transaction) (signedTx interface{}, txHash string, err error) {
+	if err = tron.VerifyMPCPubKey(paramSender, paramPublicKey); err != nil {
+		return nil, "", err
+	}
+	txHash = tron.CalcTxHash(tx)
+	msgContext := fmt.Sprintf("tron stake 2.0 %v %v", paramOperation, paramResource)
+	keyID, rsvs, err := mpcConfig.DoSignOneEC(paramPublicKey, txHash, msgContext)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(rsvs) != 1 {
+		log.Warn("get sign status require one rsv but return many", "rsvs", len(rsvs), "keyID", keyID)
+		return nil, "", errors.New("get sign status require one rsv but return many")
+	}
+	signature := common.FromHex(rsvs[0])
+	if len(signature) != crypto.SignatureLength {
+		log.Error("wrong signature length", "keyID", keyID, "have", len(signature), "want", crypto.SignatureLength)
+		return nil, "", errors.New("wrong signature length")
+	}
+	tx.Signature = append(tx.Signature, signature)
+	return tx, txHash, nil
+}
+
+func initAll() {
+	initFlags()
+	initConfig()
+	initBridge()
+}
+
+func initFlags() {
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(),
+			`Usage:
+this command manages tron stake 2.0 resources of the router mpc
+operations: freeze, unfreeze, withdraw, delegate, undelegate, query
+this command support two ways to sign tx
+1. use private key
+	use paramters: privateKey, url
+2. use mpc
+	use parameters: config, publicKey
+
+Parameters:`)
+		flag.PrintDefaults()
+	}
+
+	flag.StringVar(&paramURLs, "url", "", "urls (comma separated)")
+	flag.StringVar(&paramConfigFile, "config", "", "config file to init mpc and gateway")
+	flag.StringVar(&paramChainID, "chainID", "", "chain id (used with config file)")
+	flag.StringVar(&paramOperation, "op", "", "operation: freeze, unfreeze, withdraw, delegate, undelegate, query")
+	flag.StringVar(&paramSender, "sender", "", "sender (owner) address")
+	flag.StringVar(&paramReceiver, "receiver", "", "receiver address of delegate and undelegate")
+	flag.StringVar(&paramResource, "resource", paramResource, "resource type: ENERGY or BANDWIDTH")
+	flag.Int64Var(&paramAmount, "amount", paramAmount, "trx amount (in sun)")
+	flag.BoolVar(&paramLock, "lock", paramLock, "lock delegated resource")
+	flag.Int64Var(&paramLockPeriod, "lockPeriod", paramLockPeriod, "lock period (in blocks) of delegated resource")
+	flag.StringVar(&paramPublicKey, "publicKey", "", "mpc public key")
+	flag.StringVar(&paramPrivateKey, "privateKey", "", "private key")
+	flag.BoolVar(&paramDryRun, "dryrun", paramDryRun, "build tx only, do not sign and send")
+
+	flag.Parse()
+
+	if paramSender == "" {
+		log.Fatal("must config -sender")
+	}
+	if paramConfigFile != "" && paramChainID == "" {
+		log.Fatal("must config -chainID if use config file")
+	}
+	if paramConfigFile == "" && paramURLs == "" {
+		log.Fatal("must config -config or -url")
+	}
+	switch paramOperation {
+	case opQuery, opWithdraw:
+	case opFreeze, opUnfreeze, opDelegate, opUndelegate:
+		if !tron.IsValidResourceType(paramResource) {
+			log.Fatal("wrong resource type", "resource", paramResource)
+		}
+		if paramAmount <= 0 {
+			log.Fatal("must config positive -amount")
+		}
+		if (paramOperation == opDelegate || paramOperation == opUndelegate) && paramReceiver == "" {
+			log.Fatal("must config -receiver")
+		}
+	default:
+		log.Fatal("wrong operation", "op", paramOperation)
+	}
+	if paramOperation != opQuery && !paramDryRun {
+		if paramPrivateKey == "" && (paramConfigFile == "" || paramPublicKey == "") {
+			log.Fatal("must config -privateKey or -config and -publicKey")
+		}
+	}
+
+	log.Info("init flags finished")
+}
+
+func initConfig() {
+	if paramConfigFile == "" {
+		return
+	}
+
+	config := params.LoadRouterConfig(paramConfigFile, true, false)
+	if config.FastMPC != nil {
+		mpcConfig = mpc.InitConfig(config.FastMPC, true)
+	} else {
+		mpcConfig = mpc.InitConfig(config.MPC, true)
+	}
+	log.Info("init config finished", "IsFastMPC", mpcConfig.IsFastMPC)
+}
+
+func initBridge() {
+	if paramConfigFile != "" {
+		cfg := params.GetRouterConfig()
+		bridge.SetGatewayConfig(&tokens.GatewayConfig{
+			APIAddress:    cfg.Gateways[paramChainID],
+			APIAddressExt: cfg.GatewaysExt[paramChainID],
+		})
+		log.Info("use config file", "config", paramConfigFile)
+	} else {
+		bridge.SetGatewayConfig(&tokens.GatewayConfig{
+			APIAddress: strings.Split(paramURLs, ","),
+		})
+		log.Info("use direct urls", "paramURLs", paramURLs)
+	}
+	log.Infof("gateway config is %v", common.ToJSONString(bridge.GetGatewayConfig(), false))
+}
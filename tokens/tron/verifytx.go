@@ -52,21 +52,25 @@ func (b *Bridge) VerifyMsgHash(rawTx interface{}, msgHashes []string) error {
 }
 
 // VerifyTransaction api
-func (b *Bridge) VerifyTransaction(txHash string, args *tokens.VerifyArgs) (*tokens.SwapTxInfo, error) {
+func (b *Bridge) VerifyTransaction(txHash string, args *tokens.VerifyArgs) (swapInfo *tokens.SwapTxInfo, err error) {
 	swapType := args.SwapType
 	logIndex := args.LogIndex
 	allowUnstable := args.AllowUnstable
 
 	switch swapType {
 	case tokens.ERC20SwapType:
-		return b.verifyERC20SwapTx(txHash, logIndex, allowUnstable)
+		swapInfo, err = b.verifyERC20SwapTx(txHash, logIndex, allowUnstable)
 	case tokens.NFTSwapType:
-		return b.verifyNFTSwapTx(txHash, logIndex, allowUnstable)
+		swapInfo, err = b.verifyNFTSwapTx(txHash, logIndex, allowUnstable)
 	case tokens.AnyCallSwapType:
-		return b.verifyAnyCallSwapTx(txHash, logIndex, allowUnstable)
+		swapInfo, err = b.verifyAnyCallSwapTx(txHash, logIndex, allowUnstable)
 	default:
 		return nil, tokens.ErrSwapTypeNotSupported
 	}
+	if err == nil && !allowUnstable {
+		err = tokens.CheckSwapTxStable(b, swapInfo)
+	}
+	return swapInfo, err
 }
 
 func CalcTxHash(tx *core.Transaction) string {
@@ -85,6 +85,12 @@ type NFTSwapInfo struct {
 	Data    hexutil.Bytes `json:"data,omitempty"`
 }
 
+// IsSingleNFT is swap of single nft (one id with amount 1)
+func (s *NFTSwapInfo) IsSingleNFT() bool {
+	return !s.Batch && len(s.IDs) == 1 && (len(s.Amounts) == 0 ||
+		(len(s.Amounts) == 1 && s.Amounts[0].Cmp(big.NewInt(1)) == 0))
+}
+
 // AnyCallSwapInfo struct
 type AnyCallSwapInfo struct {
 	CallFrom string        `json:"callFrom"`
@@ -235,6 +241,43 @@ type AllExtras struct {
 	BlockNumber *uint64       `json:"blockNumber,omitempty"`
 	TTL         *uint64       `json:"ttl,omitempty"`
 	BridgeFee   *big.Int      `json:"bridgeFee,omitempty"`
+	BatchSwaps  []*SwapArgs   `json:"batchSwaps,omitempty"`
+	Payout      *PayoutInfo   `json:"payout,omitempty"`
+	Delivery    string        `json:"delivery,omitempty"`
+
+	StorageDeposit *big.Int `json:"storageDeposit,omitempty"` // deposit of registering receiver storage (eg. near nep145)
+	KeyIndex       *uint64  `json:"keyIndex,omitempty"`       // index of the account key whose sequence is used (eg. flow proposal key)
+}
+
+// payout types
+const (
+	PayoutUnderlying = "underlying"
+	PayoutAnyToken   = "anyToken"
+	PayoutSplit      = "split"
+)
+
+// delivery methods of swap value on dest chain
+const (
+	DeliveryPayment          = "payment"
+	DeliveryClaimableBalance = "claimableBalance" // receiver claims it later, eg. stellar receiver has no trustline
+	DeliveryCreateAccount    = "createAccount"    // create receiver account with native value
+	DeliveryRegisterDeposit  = "registerDeposit"  // register receiver store and deposit, eg. aptos coin store
+)
+
+// PayoutInfo payout decision according to router underlying liquidity
+type PayoutInfo struct {
+	Type       string   `json:"type"`
+	Underlying *big.Int `json:"underlying,omitempty"` // underlying amount of split payout
+}
+
+// AccountResources resources of account which pay tx fees instead of burning native token
+type AccountResources struct {
+	EnergyLimit        int64 `json:"energyLimit"`
+	EnergyUsed         int64 `json:"energyUsed"`
+	BandwidthLimit     int64 `json:"bandwidthLimit"` // staked bandwidth
+	BandwidthUsed      int64 `json:"bandwidthUsed"`
+	FreeBandwidthLimit int64 `json:"freeBandwidthLimit"`
+	FreeBandwidthUsed  int64 `json:"freeBandwidthUsed"`
 }
 
 // GetReplaceNum get rplace swap count
@@ -261,6 +304,11 @@ func (args *BuildTxArgs) GetExtraArgs() *BuildTxArgs {
 	}
 }
 
+// IsBatchSwap is swaps aggregated into one tx
+func (args *BuildTxArgs) IsBatchSwap() bool {
+	return args.Extra != nil && len(args.Extra.BatchSwaps) > 0
+}
+
 // GetTxNonce get tx nonce
 func (args *BuildTxArgs) GetTxNonce() uint64 {
 	if args.Extra != nil && args.Extra.Sequence != nil {
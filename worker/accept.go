@@ -309,6 +309,10 @@ func verifySignInfo(mpcConfig *mpc.Config, signInfo *mpc.SignInfoData) (*tokens.
 		}
 		return args, nil
 	}
+	if args.IsBatchSwap() {
+		err = rebuildAndVerifyBatchMsgHash(signInfo.Key, signInfo.MsgHash, args)
+		return args, err
+	}
 	if lvldbHandle != nil && args.GetTxNonce() > 0 { // only for eth like chain
 		err = CheckAcceptRecord(args)
 		if err != nil {
@@ -328,28 +332,64 @@ func getBridges(fromChainID, toChainID string) (srcBridge, dstBridge tokens.IBri
 	return
 }
 
+func getAcceptContext(keyID string, args *tokens.BuildTxArgs) []interface{} {
+	return []interface{}{
+		"keyID", keyID,
+		"identifier", args.Identifier,
+		"swapType", args.SwapType.String(),
+		"fromChainID", args.FromChainID,
+		"toChainID", args.ToChainID,
+		"swapID", args.SwapID,
+		"logIndex", args.LogIndex,
+		"tokenID", args.GetTokenID(),
+	}
+}
+
 func rebuildAndVerifyMsgHash(keyID string, msgHash []string, args *tokens.BuildTxArgs) (err error) {
 	if !args.SwapType.IsValidType() {
 		return fmt.Errorf("unknown router swap type %d", args.SwapType)
 	}
-	srcBridge, dstBridge, err := getBridges(args.FromChainID.String(), args.ToChainID.String())
+	_, dstBridge, err := getBridges(args.FromChainID.String(), args.ToChainID.String())
+	if err != nil {
+		return err
+	}
+
+	ctx := getAcceptContext(keyID, args)
+
+	buildTxArgs, err := verifySwapAndGetBuildTxArgs(keyID, args)
 	if err != nil {
 		return err
 	}
 
 	start := time.Now()
+	rawTx, err := dstBridge.BuildRawTransaction(buildTxArgs)
+	if err != nil {
+		logWorkerError("accept", fmt.Sprintf("build raw tx failed (timespent %v)", time.Since(start).String()), err, ctx...)
+		return err
+	}
+	err = dstBridge.VerifyMsgHash(rawTx, msgHash)
+	if err != nil {
+		logWorkerError("accept", fmt.Sprintf("verify message hash failed (timespent %v)", time.Since(start).String()), err, ctx...)
+		return err
+	}
+	logWorker("accept", fmt.Sprintf("build raw tx and verify message hash success (timespent %v)", time.Since(start).String()), ctx...)
+	if lvldbHandle != nil && args.GetTxNonce() > 0 { // only for eth like chain
+		go saveAcceptRecord(dstBridge, keyID, buildTxArgs, rawTx, ctx)
+	}
+	return nil
+}
 
-	ctx := []interface{}{
-		"keyID", keyID,
-		"identifier", args.Identifier,
-		"swapType", args.SwapType.String(),
-		"fromChainID", args.FromChainID,
-		"toChainID", args.ToChainID,
-		"swapID", args.SwapID,
-		"logIndex", args.LogIndex,
-		"tokenID", args.GetTokenID(),
+// verifySwapAndGetBuildTxArgs verify the swap on source chain, and
+// return the build tx args constructed from the verified swap info
+func verifySwapAndGetBuildTxArgs(keyID string, args *tokens.BuildTxArgs) (*tokens.BuildTxArgs, error) {
+	srcBridge, _, err := getBridges(args.FromChainID.String(), args.ToChainID.String())
+	if err != nil {
+		return nil, err
 	}
 
+	start := time.Now()
+	ctx := getAcceptContext(keyID, args)
+
 	txid := args.SwapID
 	logIndex := args.LogIndex
 	verifyArgs := &tokens.VerifyArgs{
@@ -360,14 +400,14 @@ func rebuildAndVerifyMsgHash(keyID string, msgHash []string, args *tokens.BuildT
 	swapInfo, err := srcBridge.VerifyTransaction(txid, verifyArgs)
 	if err != nil {
 		logWorkerError("accept", "verifySignInfo failed", err, ctx...)
-		return err
+		return nil, err
 	}
 	logWorker("accept", fmt.Sprintf("verifySignInfo success (timespent %v)", time.Since(start).String()), ctx...)
 	if !strings.EqualFold(args.Bind, swapInfo.Bind) {
-		return fmt.Errorf("bind mismatch: '%v' != '%v'", args.Bind, swapInfo.Bind)
+		return nil, fmt.Errorf("bind mismatch: '%v' != '%v'", args.Bind, swapInfo.Bind)
 	}
 	if args.ToChainID.Cmp(swapInfo.ToChainID) != 0 {
-		return fmt.Errorf("toChainID mismatch: '%v' != '%v'", args.ToChainID, swapInfo.ToChainID)
+		return nil, fmt.Errorf("toChainID mismatch: '%v' != '%v'", args.ToChainID, swapInfo.ToChainID)
 	}
 
 	verifySwapInfo := swapInfo.SwapInfo
@@ -378,8 +418,7 @@ func rebuildAndVerifyMsgHash(keyID string, msgHash []string, args *tokens.BuildT
 		verifySwapInfo.AnyCallSwapInfo.Attestation = argsSwapInfo.AnyCallSwapInfo.Attestation
 	}
 
-	start = time.Now()
-	buildTxArgs := &tokens.BuildTxArgs{
+	return &tokens.BuildTxArgs{
 		SwapArgs: tokens.SwapArgs{
 			SwapInfo:    verifySwapInfo,
 			Identifier:  params.GetIdentifier(),
@@ -396,18 +435,72 @@ func rebuildAndVerifyMsgHash(keyID string, msgHash []string, args *tokens.BuildT
 		OriginTxTo:  swapInfo.TxTo,
 		OriginValue: swapInfo.Value,
 		Extra:       args.Extra,
+	}, nil
+}
+
+// rebuildAndVerifyBatchMsgHash verify every swap in the batch,
+// then rebuild the batch tx and verify its message hash
+func rebuildAndVerifyBatchMsgHash(keyID string, msgHash []string, args *tokens.BuildTxArgs) (err error) {
+	batchSwaps := args.Extra.BatchSwaps
+	if len(batchSwaps) > params.MaxBatchSwapSize {
+		return tokens.ErrTooManyBatchSwaps
 	}
-	rawTx, err := dstBridge.BuildRawTransaction(buildTxArgs)
+	dstBridge := router.GetBridgeByChainID(args.ToChainID.String())
+	if dstBridge == nil {
+		return tokens.ErrNoBridgeForChainID
+	}
+	builder, ok := dstBridge.(tokens.BatchSwapBuilder)
+	if !ok {
+		return fmt.Errorf("%w: batch swap on chain %v", tokens.ErrNotImplemented, args.ToChainID)
+	}
+
+	ctx := getAcceptContext(keyID, args)
+	ctx = append(ctx, "batchSize", len(batchSwaps))
+
+	swaps := make([]*tokens.BuildTxArgs, len(batchSwaps))
+	for i, swapArgs := range batchSwaps {
+		if !swapArgs.SwapType.IsValidType() {
+			return fmt.Errorf("unknown router swap type %d", swapArgs.SwapType)
+		}
+		if swapArgs.FromChainID == nil || swapArgs.ToChainID == nil ||
+			swapArgs.ToChainID.Cmp(args.ToChainID) != 0 {
+			return tokens.ErrToChainIDMismatch
+		}
+		swap := &tokens.BuildTxArgs{
+			SwapArgs: *swapArgs,
+			From:     args.From,
+			Extra:    args.Extra,
+		}
+		if lvldbHandle != nil && args.GetTxNonce() > 0 { // only for eth like chain
+			err = CheckAcceptRecord(swap)
+			if err != nil {
+				return err
+			}
+		}
+		swap.Extra = &tokens.AllExtras{}
+		swaps[i], err = verifySwapAndGetBuildTxArgs(keyID, swap)
+		if err != nil {
+			return err
+		}
+	}
+
+	start := time.Now()
+	buildTxArgs := &tokens.BuildTxArgs{
+		SwapArgs: swaps[0].SwapArgs,
+		From:     args.From,
+		Extra:    args.Extra,
+	}
+	rawTx, err := builder.BuildBatchRawTransaction(buildTxArgs, swaps)
 	if err != nil {
-		logWorkerError("accept", fmt.Sprintf("build raw tx failed (timespent %v)", time.Since(start).String()), err, ctx...)
+		logWorkerError("accept", fmt.Sprintf("build batch raw tx failed (timespent %v)", time.Since(start).String()), err, ctx...)
 		return err
 	}
 	err = dstBridge.VerifyMsgHash(rawTx, msgHash)
 	if err != nil {
-		logWorkerError("accept", fmt.Sprintf("verify message hash failed (timespent %v)", time.Since(start).String()), err, ctx...)
+		logWorkerError("accept", fmt.Sprintf("verify batch message hash failed (timespent %v)", time.Since(start).String()), err, ctx...)
 		return err
 	}
-	logWorker("accept", fmt.Sprintf("build raw tx and verify message hash success (timespent %v)", time.Since(start).String()), ctx...)
+	logWorker("accept", fmt.Sprintf("build batch raw tx and verify message hash success (timespent %v)", time.Since(start).String()), ctx...)
 	if lvldbHandle != nil && args.GetTxNonce() > 0 { // only for eth like chain
 		go saveAcceptRecord(dstBridge, keyID, buildTxArgs, rawTx, ctx)
 	}
@@ -429,10 +522,19 @@ func saveAcceptRecord(bridge tokens.IBridge, keyID string, args *tokens.BuildTxA
 	}
 	ctx = append(ctx, "swaptx", swapTx)
 
-	err = AddAcceptRecord(args, swapTx)
-	if err != nil {
-		logWorkerError("accept", "save accept record to db failed", err, ctx...)
-		return
+	records := []*tokens.BuildTxArgs{args}
+	if args.IsBatchSwap() {
+		records = make([]*tokens.BuildTxArgs, len(args.Extra.BatchSwaps))
+		for i, swapArgs := range args.Extra.BatchSwaps {
+			records[i] = &tokens.BuildTxArgs{SwapArgs: *swapArgs}
+		}
+	}
+	for _, record := range records {
+		err = AddAcceptRecord(record, swapTx)
+		if err != nil {
+			logWorkerError("accept", "save accept record to db failed", err, ctx...)
+			return
+		}
 	}
 	logWorker("accept", "save accept record to db success", ctx...)
 }
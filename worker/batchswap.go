@@ -0,0 +1,377 @@
+package worker
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/anyswap/CrossChain-Router/v3/common"
+	"github.com/anyswap/CrossChain-Router/v3/mongodb"
+	"github.com/anyswap/CrossChain-Router/v3/mpc"
+	"github.com/anyswap/CrossChain-Router/v3/params"
+	"github.com/anyswap/CrossChain-Router/v3/router"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+)
+
+// batch swap steps, replaced in tests
+var (
+	doBatchSwapFunc     = doBatchSwap
+	processSwapTaskFunc = processSwapTask
+)
+
+// swapBatcher aggregates pending swap tasks of the same token to one chain,
+// it is only accessed by the swap consumer routine of this chain.
+type swapBatcher struct {
+	chainID string
+	batches map[string]*swapBatch // key is tokenID
+}
+
+type swapBatch struct {
+	swaps     []*tokens.BuildTxArgs
+	startTime int64
+}
+
+func newSwapBatcher(chainID string) *swapBatcher {
+	return &swapBatcher{
+		chainID: chainID,
+		batches: make(map[string]*swapBatch),
+	}
+}
+
+func isBatchSwapEnabled(chainID string) bool {
+	if params.GetBatchSwapConfig(chainID) == nil || params.IsParallelSwapEnabled() {
+		return false
+	}
+	builder, ok := router.GetBridgeByChainID(chainID).(tokens.BatchSwapBuilder)
+	return ok && builder.IsBatchSwapSupported()
+}
+
+// add add swap task to batch, return false if it can not be batched
+func (sb *swapBatcher) add(args *tokens.BuildTxArgs) bool {
+	if !isBatchSwapEnabled(sb.chainID) ||
+		args.Reswapping ||
+		args.SwapType != tokens.ERC20SwapType ||
+		args.ERC20SwapInfo == nil ||
+		args.ERC20SwapInfo.CallProxy != "" ||
+		params.GetLiquidityPolicy(args.GetTokenID()) != nil {
+		// payout decision of liquidity policy is per swap
+		return false
+	}
+	tokenID := args.GetTokenID()
+	batch, exist := sb.batches[tokenID]
+	if !exist {
+		batch = &swapBatch{startTime: now()}
+		sb.batches[tokenID] = batch
+	}
+	batch.swaps = append(batch.swaps, args)
+	logWorker("doSwap", "add swap task to batch", "fromChainID", args.FromChainID, "toChainID", args.ToChainID, "txid", args.SwapID, "logIndex", args.LogIndex, "tokenID", tokenID, "count", len(batch.swaps))
+	return true
+}
+
+// flush process the batches which are full or out of window, or all batches if `force`
+func (sb *swapBatcher) flush(force bool) {
+	if len(sb.batches) == 0 {
+		return
+	}
+	batchCfg := params.GetBatchSwapConfig(sb.chainID)
+	if batchCfg == nil || params.IsParallelSwapEnabled() {
+		force = true // batching is disabled by reloading config
+	}
+	for tokenID, batch := range sb.batches {
+		if !force &&
+			len(batch.swaps) < batchCfg.MaxSize &&
+			now() < batch.startTime+batchCfg.Window {
+			continue
+		}
+		delete(sb.batches, tokenID)
+
+		swaps := batch.swaps
+		for len(swaps) > 0 {
+			size := len(swaps)
+			if batchCfg != nil && size > batchCfg.MaxSize {
+				size = batchCfg.MaxSize
+			}
+			processSwapBatch(swaps[:size])
+			swaps = swaps[size:]
+		}
+	}
+}
+
+func processSwapBatch(swaps []*tokens.BuildTxArgs) {
+	if len(swaps) == 1 || !isBatchSwapEnabled(swaps[0].ToChainID.String()) {
+		for _, args := range swaps {
+			processSwapTaskFunc(args)
+		}
+		return
+	}
+
+	ctx := []interface{}{"toChainID", swaps[0].ToChainID, "tokenID", swaps[0].GetTokenID(), "count", len(swaps)}
+	logWorker("doSwap", "process batch swap start", ctx...)
+	isBuilt, err := doBatchSwapFunc(swaps)
+	switch {
+	case !isBuilt:
+		// a failed sub-call fails the whole batch tx (eg. estimate gas reverts),
+		// split the batch in halves to isolate it and keep batching the others
+		ctx = append(ctx, "err", err)
+		logWorkerWarn("doSwap", "process batch swap by splitting", ctx...)
+		half := len(swaps) / 2
+		processSwapBatch(swaps[:half])
+		processSwapBatch(swaps[half:])
+		return
+	case err == nil:
+		logWorker("doSwap", "process batch swap success", ctx...)
+	default:
+		logWorkerError("doSwap", "process batch swap failed", err, ctx...)
+	}
+
+	for _, args := range swaps {
+		cacheKey := mongodb.GetRouterSwapKey(args.FromChainID.String(), args.SwapID, args.LogIndex)
+		swapTasksInQueue.Remove(cacheKey)
+	}
+}
+
+// doBatchSwap aggregate swaps into one tx, sign and send it.
+// `isBuilt` is false if the batch tx is not built (the swaps can be processed one by one).
+//
+//nolint:funlen,gocyclo // ok
+func doBatchSwap(swaps []*tokens.BuildTxArgs) (isBuilt bool, err error) {
+	toChainID := swaps[0].ToChainID.String()
+	resBridge := router.GetBridgeByChainID(toChainID)
+	if resBridge == nil {
+		return false, tokens.ErrNoBridgeForChainID
+	}
+	builder, ok := resBridge.(tokens.BatchSwapBuilder)
+	if !ok {
+		return false, tokens.ErrNotImplemented
+	}
+
+	batchSwaps := make([]*tokens.BuildTxArgs, 0, len(swaps))
+	cacheKeys := make([]string, 0, len(swaps))
+	for _, args := range swaps {
+		cacheKey := mongodb.GetRouterSwapKey(args.FromChainID.String(), args.SwapID, args.LogIndex)
+		if checkAndUpdateProcessSwapTaskCache(cacheKey) != nil {
+			logWorkerTrace("doSwap", "ignore swap in cache", "key", cacheKey)
+			continue
+		}
+		batchSwaps = append(batchSwaps, args)
+		cacheKeys = append(cacheKeys, cacheKey)
+	}
+	isCachedSwapProcessed := false
+	defer func() {
+		if !isCachedSwapProcessed {
+			for _, cacheKey := range cacheKeys {
+				cachedSwapTasks.Remove(cacheKey)
+			}
+		}
+	}()
+	if len(batchSwaps) < 2 {
+		return false, nil
+	}
+
+	args := &tokens.BuildTxArgs{
+		SwapArgs: batchSwaps[0].SwapArgs,
+		From:     batchSwaps[0].From,
+		Extra:    &tokens.AllExtras{},
+	}
+	batchSize := len(batchSwaps)
+	ctx := []interface{}{"toChainID", toChainID, "tokenID", args.GetTokenID(), "count", batchSize}
+
+	start := time.Now()
+	rawTx, err := builder.BuildBatchRawTransaction(args, batchSwaps)
+	if err != nil {
+		logWorkerError("doSwap", "build batch tx failed", err, append(ctx, "timespent", time.Since(start).String())...)
+		return false, err
+	}
+	for _, swap := range batchSwaps {
+		if swap.SwapValue == nil {
+			return false, tokens.ErrNilSwapValue
+		}
+	}
+	swapTxNonce := args.GetTxNonce() // assign after build tx
+	ctx = append(ctx, "swapNonce", swapTxNonce)
+	logWorker("doSwap", "build batch tx success", append(ctx, "timespent", time.Since(start).String())...)
+
+	if err = checkLeadership(); err != nil {
+		return true, err
+	}
+	start = time.Now()
+	signedTx, txHash, err := resBridge.MPCSignTransaction(rawTx, args)
+	if err != nil {
+		logWorkerError("doSwap", "sign batch tx failed", err, append(ctx, "timespent", time.Since(start).String())...)
+		if errors.Is(err, mpc.ErrGetSignStatusHasDisagree) {
+			for _, swap := range batchSwaps {
+				reverifySwap(swap)
+			}
+		}
+		return true, err
+	}
+	ctx = append(ctx, "txHash", txHash)
+	logWorker("doSwap", "sign batch tx success", append(ctx, "timespent", time.Since(start).String())...)
+
+	// recheck reswap before update db
+	for _, swap := range batchSwaps {
+		fromChainID := swap.FromChainID.String()
+		_ = mongodb.ResetRouterSwapDisagreeCount(fromChainID, swap.SwapID, swap.LogIndex)
+		res, errf := mongodb.FindRouterSwapResult(fromChainID, swap.SwapID, swap.LogIndex)
+		if errf != nil {
+			return true, errf
+		}
+		if errf = preventReswap(res); errf != nil {
+			return true, errf
+		}
+	}
+
+	// update database before sending transaction
+	for i, swap := range batchSwaps {
+		fromChainID := swap.FromChainID.String()
+		addSwapHistory(fromChainID, swap.SwapID, swap.LogIndex, txHash)
+		matchTx := &MatchTx{
+			SwapTx:     txHash,
+			SwapNonce:  swapTxNonce,
+			SwapValue:  swap.SwapValue.String(),
+			MPC:        args.From,
+			BatchSize:  batchSize,
+			BatchIndex: i,
+		}
+		if args.Extra.TTL != nil {
+			matchTx.TTL = *args.Extra.TTL
+		}
+		err = updateRouterSwapResult(fromChainID, swap.SwapID, swap.LogIndex, matchTx)
+		if err != nil {
+			logWorkerError("doSwap", "update router swap result failed", err, "fromChainID", fromChainID, "toChainID", toChainID, "txid", swap.SwapID, "logIndex", swap.LogIndex, "swapNonce", swapTxNonce)
+			return true, err
+		}
+	}
+	isCachedSwapProcessed = true
+
+	for _, swap := range batchSwaps {
+		fromChainID := swap.FromChainID.String()
+		err = mongodb.UpdateRouterSwapStatus(fromChainID, swap.SwapID, swap.LogIndex, mongodb.TxProcessed, now(), "")
+		if err != nil {
+			logWorkerError("doSwap", "update router swap status failed", err, "fromChainID", fromChainID, "toChainID", toChainID, "txid", swap.SwapID, "logIndex", swap.LogIndex)
+			return true, err
+		}
+	}
+
+	start = time.Now()
+	sentTxHash, err := sendSignedTransaction(resBridge, signedTx, args)
+	if err == nil && txHash != sentTxHash {
+		logWorkerError("doSwap", "send batch tx success but with different hash", errSendTxWithDiffHash,
+			append(ctx, "sentTxHash", sentTxHash, "timespent", time.Since(start).String())...)
+		for _, swap := range batchSwaps {
+			_ = mongodb.UpdateRouterOldSwapTxs(swap.FromChainID.String(), swap.SwapID, swap.LogIndex, sentTxHash)
+		}
+	} else if err == nil {
+		logWorker("doSwap", "send batch tx success", append(ctx, "timespent", time.Since(start).String())...)
+	}
+	return true, err
+}
+
+// replaceBatchRouterSwap rebuild the batch swap tx with the same nonce to replace it
+func replaceBatchRouterSwap(res *mongodb.MgoSwapResult, gasPrice *big.Int, isManual bool) error {
+	resBridge := router.GetBridgeByChainID(res.ToChainID)
+	if resBridge == nil {
+		return tokens.ErrNoBridgeForChainID
+	}
+	builder, ok := resBridge.(tokens.BatchSwapBuilder)
+	if !ok {
+		return fmt.Errorf("%w: batch swap on chain %v", tokens.ErrNotImplemented, res.ToChainID)
+	}
+	if res.SwapNonce == 0 && !isManual {
+		return errors.New("swap nonce is zero")
+	}
+	results, err := mongodb.FindRouterSwapResultsOfBatch(res.ToChainID, res.MPC, res.SwapNonce)
+	if err != nil {
+		return err
+	}
+	if len(results) != res.BatchSize {
+		return fmt.Errorf("batch swap results count mismatch, have %v want %v", len(results), res.BatchSize)
+	}
+
+	swaps := make([]*tokens.BuildTxArgs, len(results))
+	for i, item := range results {
+		if item.BatchIndex != i || item.BatchSize != res.BatchSize {
+			return fmt.Errorf("batch swap result %v mismatch, batch index %v size %v", item.Key, item.BatchIndex, item.BatchSize)
+		}
+		swap, errf := verifyReplaceSwap(item, isManual)
+		if errf != nil {
+			return errf
+		}
+		swaps[i], errf = getReplaceSwapArgs(item, swap, &tokens.AllExtras{})
+		if errf != nil {
+			return errf
+		}
+	}
+
+	routerMPC, err := router.GetRouterMPC(swaps[0].GetTokenID(), res.ToChainID)
+	if err != nil {
+		return err
+	}
+	if !common.IsEqualIgnoreCase(res.MPC, routerMPC) {
+		return tokens.ErrSenderMismatch
+	}
+
+	logWorker("replaceSwap", "process batch task", "toChainID", res.ToChainID, "swapNonce", res.SwapNonce, "count", len(results))
+	for _, item := range results {
+		_ = updateSwapTimestamp(item.FromChainID, item.TxID, item.LogIndex)
+	}
+
+	nonce := res.SwapNonce
+	replaceNum := uint64(len(res.OldSwapTxs))
+	if replaceNum == 0 {
+		replaceNum++
+	}
+	args := &tokens.BuildTxArgs{
+		SwapArgs: swaps[0].SwapArgs,
+		From:     res.MPC,
+		Extra: &tokens.AllExtras{
+			GasPrice:   gasPrice,
+			Sequence:   &nonce,
+			ReplaceNum: replaceNum,
+		},
+	}
+	rawTx, err := builder.BuildBatchRawTransaction(args, swaps)
+	if err != nil {
+		logWorkerError("replaceSwap", "build batch tx failed", err, "chainID", res.ToChainID, "swapNonce", nonce, "count", len(results))
+		return err
+	}
+	go signAndSendReplaceBatchTx(resBridge, rawTx, args, results)
+	return nil
+}
+
+func signAndSendReplaceBatchTx(resBridge tokens.IBridge, rawTx interface{}, args *tokens.BuildTxArgs, results []*mongodb.MgoSwapResult) {
+	ctx := []interface{}{"toChainID", args.ToChainID, "swapNonce", args.GetTxNonce(), "count", len(results)}
+	if err := checkLeadership(); err != nil {
+		logWorkerError("replaceSwap", "mpc sign batch tx failed", err, ctx...)
+		return
+	}
+	signedTx, txHash, err := resBridge.MPCSignTransaction(rawTx, args)
+	if err != nil {
+		logWorkerError("replaceSwap", "mpc sign batch tx failed", err, ctx...)
+		if errors.Is(err, mpc.ErrGetSignStatusHasDisagree) {
+			for _, swap := range args.Extra.BatchSwaps {
+				reverifySwap(&tokens.BuildTxArgs{SwapArgs: *swap})
+			}
+		}
+		return
+	}
+	ctx = append(ctx, "txHash", txHash)
+
+	for _, res := range results {
+		_ = mongodb.ResetRouterSwapDisagreeCount(res.FromChainID, res.TxID, res.LogIndex)
+		err = mongodb.UpdateRouterOldSwapTxs(res.FromChainID, res.TxID, res.LogIndex, txHash)
+		if err != nil {
+			logWorkerError("replaceSwap", "update old swap txs failed", err, append(ctx, "fromChainID", res.FromChainID, "txid", res.TxID, "logIndex", res.LogIndex)...)
+			return
+		}
+	}
+
+	sentTxHash, err := sendSignedTransaction(resBridge, signedTx, args)
+	if err == nil && txHash != sentTxHash {
+		logWorkerError("replaceSwap", "send batch tx success but with different hash", errSendTxWithDiffHash, append(ctx, "sentTxHash", sentTxHash)...)
+		for _, res := range results {
+			_ = mongodb.UpdateRouterOldSwapTxs(res.FromChainID, res.TxID, res.LogIndex, sentTxHash)
+		}
+	}
+}
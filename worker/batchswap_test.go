@@ -0,0 +1,344 @@
+package worker
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Router/v3/mongodb"
+	"github.com/anyswap/CrossChain-Router/v3/params"
+	"github.com/anyswap/CrossChain-Router/v3/router"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+)
+
+const (
+	batchFromChainID = "2001"
+	batchToChainID   = "2002"
+	batchTokenID     = "BATCHTOKEN"
+	batchMPC         = "0x0000000000000000000000000000000000003002"
+)
+
+var errBatchTestNotFound = errors.New("tx not found")
+
+// batchTestBridge verifies the swaps of `txs` as source chain,
+// and builds batch tx as `swapID:value` list as dest chain.
+type batchTestBridge struct {
+	tokens.IBridge
+	txs map[string]*tokens.SwapTxInfo
+}
+
+func (b *batchTestBridge) IsBatchSwapSupported() bool { return true }
+
+func (b *batchTestBridge) VerifyTransaction(txHash string, args *tokens.VerifyArgs) (*tokens.SwapTxInfo, error) {
+	swapInfo, exist := b.txs[txHash]
+	if !exist {
+		return nil, errBatchTestNotFound
+	}
+	return swapInfo, nil
+}
+
+func (b *batchTestBridge) BuildBatchRawTransaction(args *tokens.BuildTxArgs, swaps []*tokens.BuildTxArgs) (interface{}, error) {
+	if err := tokens.CheckBatchSwaps(args, swaps); err != nil {
+		return nil, err
+	}
+	calls := make([]string, len(swaps))
+	for i, swap := range swaps {
+		swap.SwapValue = swap.OriginValue
+		calls[i] = fmt.Sprintf("%v:%v:%v", swap.SwapID, swap.Bind, swap.SwapValue)
+	}
+	args.SetBatchSwaps(swaps)
+	return strings.Join(calls, ","), nil
+}
+
+func (b *batchTestBridge) VerifyMsgHash(rawTx interface{}, msgHash []string) error {
+	if len(msgHash) != 1 || rawTx.(string) != msgHash[0] {
+		return tokens.ErrMsgHashMismatch
+	}
+	return nil
+}
+
+func setupBatchSwapTest(t *testing.T, maxSize int, window int64) *batchTestBridge {
+	routerCfg := params.GetRouterConfig()
+	prevServer := routerCfg.Server
+	routerCfg.Server = &params.RouterServerConfig{
+		BatchSwap: map[string]*params.BatchSwapConfig{
+			batchToChainID: {MaxSize: maxSize, Window: window},
+		},
+	}
+
+	bridge := &batchTestBridge{txs: make(map[string]*tokens.SwapTxInfo)}
+	router.SetBridge(batchFromChainID, bridge)
+	router.SetBridge(batchToChainID, bridge)
+
+	prevDoBatchSwap := doBatchSwapFunc
+	prevProcessSwapTask := processSwapTaskFunc
+	t.Cleanup(func() {
+		doBatchSwapFunc = prevDoBatchSwap
+		processSwapTaskFunc = prevProcessSwapTask
+		router.SetBridge(batchFromChainID, nil)
+		router.SetBridge(batchToChainID, nil)
+		routerCfg.Server = prevServer
+	})
+	return bridge
+}
+
+func newBatchTestSwap(index int) *tokens.BuildTxArgs {
+	return &tokens.BuildTxArgs{
+		SwapArgs: tokens.SwapArgs{
+			SwapID:      fmt.Sprintf("0x%064x", index),
+			SwapType:    tokens.ERC20SwapType,
+			Bind:        fmt.Sprintf("0x%040x", 0x4000+index),
+			FromChainID: big.NewInt(2001),
+			ToChainID:   big.NewInt(2002),
+			SwapInfo:    tokens.SwapInfo{ERC20SwapInfo: &tokens.ERC20SwapInfo{TokenID: batchTokenID}},
+		},
+		From:        batchMPC,
+		OriginValue: big.NewInt(int64(1000 * index)),
+	}
+}
+
+// batchTestRecorder records the processing of swaps
+type batchTestRecorder struct {
+	batches [][]string // swap ids of built batches
+	singles []string   // swap ids processed one by one
+}
+
+func (r *batchTestRecorder) hook(isBuilt func([]*tokens.BuildTxArgs) bool) {
+	doBatchSwapFunc = func(swaps []*tokens.BuildTxArgs) (bool, error) {
+		if !isBuilt(swaps) {
+			return false, tokens.ErrBuildTxErrorAndDelay
+		}
+		ids := make([]string, len(swaps))
+		for i, swap := range swaps {
+			ids[i] = swap.SwapID
+		}
+		r.batches = append(r.batches, ids)
+		return true, nil
+	}
+	processSwapTaskFunc = func(args *tokens.BuildTxArgs) {
+		r.singles = append(r.singles, args.SwapID)
+	}
+}
+
+func swapIDsOf(indexes ...int) []string {
+	ids := make([]string, len(indexes))
+	for i, index := range indexes {
+		ids[i] = newBatchTestSwap(index).SwapID
+	}
+	return ids
+}
+
+func isSameSwapIDs(have, want []string) bool {
+	return strings.Join(have, ",") == strings.Join(want, ",")
+}
+
+func TestSwapBatcherAdd(t *testing.T) {
+	setupBatchSwapTest(t, 3, 10)
+	sb := newSwapBatcher(batchToChainID)
+
+	tests := []struct {
+		modify func(*tokens.BuildTxArgs)
+		want   bool
+	}{
+		{nil, true},
+		{func(args *tokens.BuildTxArgs) { args.Reswapping = true }, false},
+		{func(args *tokens.BuildTxArgs) { args.SwapType = tokens.ERC20SwapTypeMixPool }, false},
+		{func(args *tokens.BuildTxArgs) { args.ERC20SwapInfo.CallProxy = "0x1" }, false},
+		{func(args *tokens.BuildTxArgs) { args.ERC20SwapInfo = nil }, false},
+	}
+	for i, tt := range tests {
+		args := newBatchTestSwap(i + 1)
+		if tt.modify != nil {
+			tt.modify(args)
+		}
+		if got := sb.add(args); got != tt.want {
+			t.Errorf("test %v: got %v, want %v", i, got, tt.want)
+		}
+	}
+
+	// batching is disabled if not configed
+	other := newSwapBatcher(batchFromChainID)
+	if other.add(newBatchTestSwap(1)) {
+		t.Errorf("add swap to batcher of chain without batch swap config")
+	}
+}
+
+func TestSwapBatcherFlushOnSize(t *testing.T) {
+	setupBatchSwapTest(t, 3, 1000)
+	recorder := &batchTestRecorder{}
+	recorder.hook(func([]*tokens.BuildTxArgs) bool { return true })
+
+	sb := newSwapBatcher(batchToChainID)
+	sb.add(newBatchTestSwap(1))
+	sb.add(newBatchTestSwap(2))
+	sb.flush(false)
+	if len(recorder.batches) != 0 || len(sb.batches) != 1 {
+		t.Fatalf("flush batch which is neither full nor out of window")
+	}
+
+	sb.add(newBatchTestSwap(3))
+	sb.flush(false)
+	if len(recorder.batches) != 1 || !isSameSwapIDs(recorder.batches[0], swapIDsOf(1, 2, 3)) {
+		t.Fatalf("flush full batch: got %v", recorder.batches)
+	}
+	if len(sb.batches) != 0 {
+		t.Errorf("flushed batch is not removed")
+	}
+
+	// forced flush is chunked by max size, the rest single one is processed alone
+	for i := 4; i <= 10; i++ {
+		sb.add(newBatchTestSwap(i))
+	}
+	sb.flush(true)
+	wantBatches := [][]string{swapIDsOf(1, 2, 3), swapIDsOf(4, 5, 6), swapIDsOf(7, 8, 9)}
+	if len(recorder.batches) != len(wantBatches) {
+		t.Fatalf("forced flush: got batches %v", recorder.batches)
+	}
+	for i, want := range wantBatches {
+		if !isSameSwapIDs(recorder.batches[i], want) {
+			t.Errorf("batch %v: got %v, want %v", i, recorder.batches[i], want)
+		}
+	}
+	if !isSameSwapIDs(recorder.singles, swapIDsOf(10)) {
+		t.Errorf("forced flush: got singles %v, want %v", recorder.singles, swapIDsOf(10))
+	}
+}
+
+func TestSwapBatcherFlushOnWindow(t *testing.T) {
+	setupBatchSwapTest(t, 10, 60)
+	recorder := &batchTestRecorder{}
+	recorder.hook(func([]*tokens.BuildTxArgs) bool { return true })
+
+	sb := newSwapBatcher(batchToChainID)
+	sb.add(newBatchTestSwap(1))
+	sb.add(newBatchTestSwap(2))
+	sb.flush(false)
+	if len(recorder.batches) != 0 {
+		t.Fatalf("flush batch in window")
+	}
+
+	sb.batches[batchTokenID].startTime -= 60
+	sb.flush(false)
+	if len(recorder.batches) != 1 || !isSameSwapIDs(recorder.batches[0], swapIDsOf(1, 2)) {
+		t.Fatalf("flush batch out of window: got %v", recorder.batches)
+	}
+
+	// swaps are processed one by one if batching is disabled by reloading config
+	sb.add(newBatchTestSwap(3))
+	sb.add(newBatchTestSwap(4))
+	params.GetRouterConfig().Server.BatchSwap = nil
+	sb.flush(false)
+	if len(recorder.batches) != 1 || !isSameSwapIDs(recorder.singles, swapIDsOf(3, 4)) {
+		t.Errorf("flush after disabling batching: got batches %v singles %v", recorder.batches, recorder.singles)
+	}
+}
+
+func TestProcessSwapBatchHalving(t *testing.T) {
+	setupBatchSwapTest(t, 10, 60)
+	recorder := &batchTestRecorder{}
+	badSwapID := newBatchTestSwap(2).SwapID
+	// the batch fails to build if it contains the bad swap
+	recorder.hook(func(swaps []*tokens.BuildTxArgs) bool {
+		for _, swap := range swaps {
+			if swap.SwapID == badSwapID {
+				return false
+			}
+		}
+		return true
+	})
+
+	swaps := make([]*tokens.BuildTxArgs, 6)
+	for i := range swaps {
+		swaps[i] = newBatchTestSwap(i + 1)
+		swapTasksInQueue.Add(mongodb.GetRouterSwapKey(batchFromChainID, swaps[i].SwapID, 0))
+	}
+	defer swapTasksInQueue.Clear()
+
+	// [1..6] -> [1,2,3] [4,5,6] -> [1] [2,3] -> [2] [3]
+	processSwapBatch(swaps)
+	wantBatches := [][]string{swapIDsOf(4, 5, 6)}
+	if len(recorder.batches) != len(wantBatches) || !isSameSwapIDs(recorder.batches[0], wantBatches[0]) {
+		t.Errorf("halving: got batches %v, want %v", recorder.batches, wantBatches)
+	}
+	if !isSameSwapIDs(recorder.singles, swapIDsOf(1, 2, 3)) {
+		t.Errorf("halving: got singles %v, want %v", recorder.singles, swapIDsOf(1, 2, 3))
+	}
+	// only swaps of built batches are removed from queue
+	for i, swap := range swaps {
+		inQueue := swapTasksInQueue.Contains(mongodb.GetRouterSwapKey(batchFromChainID, swap.SwapID, 0))
+		if inQueue != (i < 3) {
+			t.Errorf("swap %v: in queue %v", i+1, inQueue)
+		}
+	}
+}
+
+func TestRebuildAndVerifyBatchMsgHash(t *testing.T) {
+	bridge := setupBatchSwapTest(t, 10, 60)
+	swaps := make([]*tokens.BuildTxArgs, 3)
+	for i := range swaps {
+		swaps[i] = newBatchTestSwap(i + 1)
+		bridge.txs[swaps[i].SwapID] = &tokens.SwapTxInfo{
+			SwapInfo:    swaps[i].SwapInfo,
+			SwapType:    swaps[i].SwapType,
+			Hash:        swaps[i].SwapID,
+			Bind:        swaps[i].Bind,
+			Value:       swaps[i].OriginValue,
+			FromChainID: swaps[i].FromChainID,
+			ToChainID:   swaps[i].ToChainID,
+		}
+	}
+
+	// the server built batch tx
+	serverArgs := &tokens.BuildTxArgs{SwapArgs: swaps[0].SwapArgs, From: batchMPC, Extra: &tokens.AllExtras{}}
+	rawTx, err := bridge.BuildBatchRawTransaction(serverArgs, swaps)
+	if err != nil {
+		t.Fatalf("build batch tx failed: %v", err)
+	}
+	msgHash := []string{rawTx.(string)}
+
+	newArgs := func(modify func(batchSwaps []*tokens.SwapArgs)) *tokens.BuildTxArgs {
+		batchSwaps := make([]*tokens.SwapArgs, len(serverArgs.Extra.BatchSwaps))
+		for i, swap := range serverArgs.Extra.BatchSwaps {
+			swapArgs := *swap
+			batchSwaps[i] = &swapArgs
+		}
+		if modify != nil {
+			modify(batchSwaps)
+		}
+		return &tokens.BuildTxArgs{
+			SwapArgs: swaps[0].SwapArgs,
+			From:     batchMPC,
+			Extra:    &tokens.AllExtras{BatchSwaps: batchSwaps},
+		}
+	}
+	tooMany := make([]*tokens.SwapArgs, params.MaxBatchSwapSize+1)
+
+	tests := []struct {
+		args    *tokens.BuildTxArgs
+		msgHash []string
+		wantErr error
+	}{
+		{newArgs(nil), msgHash, nil},
+		// msg hash is not built from the batch swaps
+		{newArgs(nil), []string{"0x1"}, tokens.ErrMsgHashMismatch},
+		{newArgs(func(s []*tokens.SwapArgs) { s[0], s[1] = s[1], s[0] }), msgHash, tokens.ErrMsgHashMismatch},
+		// batch swap is not verified
+		{newArgs(func(s []*tokens.SwapArgs) { s[1].SwapID = newBatchTestSwap(9).SwapID }), msgHash, errBatchTestNotFound},
+		{newArgs(func(s []*tokens.SwapArgs) { s[2].ToChainID = big.NewInt(2003) }), msgHash, tokens.ErrToChainIDMismatch},
+		{&tokens.BuildTxArgs{SwapArgs: swaps[0].SwapArgs, From: batchMPC, Extra: &tokens.AllExtras{BatchSwaps: tooMany}}, msgHash, tokens.ErrTooManyBatchSwaps},
+	}
+	for i, tt := range tests {
+		err := rebuildAndVerifyBatchMsgHash("keyID", tt.msgHash, tt.args)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("test %v: got err %v, want %v", i, err, tt.wantErr)
+		}
+	}
+
+	// bind is checked against the verified swap
+	args := newArgs(func(s []*tokens.SwapArgs) { s[1].Bind = newBatchTestSwap(9).Bind })
+	if err := rebuildAndVerifyBatchMsgHash("keyID", msgHash, args); err == nil || !strings.Contains(err.Error(), "bind mismatch") {
+		t.Errorf("batch swap of other bind: got err %v", err)
+	}
+}
@@ -21,6 +21,9 @@ func StartCheckFailedSwapJob() {
 func doCheckFailedSwapJob() {
 	defer mongodb.MgoWaitGroup.Done()
 	for {
+		if !waitForLeadership("checkfailedswap") {
+			return
+		}
 		septime := getSepTimeInFind(maxCheckFailedSwapLifetime)
 		res, err := mongodb.FindRouterSwapResultsWithStatus(mongodb.MatchTxFailed, septime)
 		if err != nil {
@@ -80,13 +83,17 @@ func checkFailedRouterSwap(swap *mongodb.MgoSwapResult) error {
 			"txid", swap.TxID, "logIndex", swap.LogIndex,
 			"swaptx", swap.SwapTx, "swapnonce", swap.SwapNonce,
 			"swapheight", txStatus.BlockHeight, "confirmations", txStatus.Confirmations)
-		if txStatus.Confirmations < resBridge.GetChainConfig().Confirmations {
+		stable, err := isSwapTxStable(resBridge, swap, txStatus)
+		if err != nil {
+			return err
+		}
+		if !stable {
 			return markSwapResultUnstable(swap.FromChainID, swap.TxID, swap.LogIndex)
 		}
 		return markSwapResultStable(swap.FromChainID, swap.TxID, swap.LogIndex)
 	}
 
-	nonce, err := nonceSetter.GetPoolNonce(swap.MPC, "latest")
+	nonce, err := getLatestSwapNonce(nonceSetter, swap)
 	if err != nil {
 		return fmt.Errorf("get router mpc nonce failed, %w", err)
 	}
@@ -1,8 +1,10 @@
 package worker
 
 import (
+	"fmt"
 	"strings"
 
+	"github.com/anyswap/CrossChain-Router/v3/common"
 	"github.com/anyswap/CrossChain-Router/v3/mongodb"
 	"github.com/anyswap/CrossChain-Router/v3/params"
 	"github.com/anyswap/CrossChain-Router/v3/tokens"
@@ -17,6 +19,10 @@ type MatchTx struct {
 	SwapValue  string
 	SwapNonce  uint64
 	TTL        uint64
+	BatchSize  int
+	BatchIndex int
+	Payout     *tokens.PayoutInfo
+	Delivery   string
 }
 
 // AddInitialSwapResult add initial result
@@ -83,6 +89,14 @@ func updateRouterSwapResult(fromChainID, txid string, logIndex int, mtx *MatchTx
 	if mtx.TTL > 0 {
 		updates.TTL = mtx.TTL
 	}
+	if mtx.BatchSize > 0 {
+		updates.BatchSize = mtx.BatchSize
+		updates.BatchIndex = mtx.BatchIndex
+	}
+	if mtx.Payout != nil {
+		updates.Payout, updates.PayoutUnderlying = convertPayoutInfo(mtx.Payout)
+	}
+	updates.Delivery = mtx.Delivery
 	err = mongodb.UpdateRouterSwapResult(fromChainID, txid, logIndex, updates)
 	if err != nil {
 		logWorkerError("update", "updateSwapResult failed", err,
@@ -100,11 +114,23 @@ func updateRouterSwapResult(fromChainID, txid string, logIndex int, mtx *MatchTx
 	return err
 }
 
+// getLatestSwapNonce get latest nonce of the sender (or its account key) of swap tx
+func getLatestSwapNonce(nonceSetter tokens.NonceSetter, res *mongodb.MgoSwapResult) (uint64, error) {
+	if res.KeyIndex != nil {
+		if keyNonceSetter, ok := nonceSetter.(tokens.KeyNonceSetter); ok {
+			return keyNonceSetter.GetKeyPoolNonce(res.MPC, *res.KeyIndex)
+		}
+	}
+	return nonceSetter.GetPoolNonce(res.MPC, "latest")
+}
+
+// updateSwapMemo update memo of delayed swap, and record the time of entering the delayed state
 func updateSwapMemo(fromChainID, txid string, logIndex int, memo string) (err error) {
 	updates := &mongodb.SwapResultUpdateItems{
 		Status:    mongodb.KeepStatus,
 		Memo:      memo,
 		Timestamp: now(),
+		DelayTime: now(),
 	}
 	err = mongodb.UpdateRouterSwapResult(fromChainID, txid, logIndex, updates)
 	if err != nil {
@@ -144,6 +170,58 @@ func updateSwapTx(fromChainID, txid string, logIndex int, swapTx string) (err er
 	return err
 }
 
+func convertPayoutInfo(payout *tokens.PayoutInfo) (payoutType, underlying string) {
+	if payout.Underlying != nil {
+		underlying = payout.Underlying.String()
+	}
+	return payout.Type, underlying
+}
+
+func getPayoutFromSwapResult(res *mongodb.MgoSwapResult) (*tokens.PayoutInfo, error) {
+	if res.Payout == "" {
+		return nil, nil
+	}
+	payout := &tokens.PayoutInfo{Type: res.Payout}
+	if res.PayoutUnderlying != "" {
+		underlying, err := common.GetBigIntFromStr(res.PayoutUnderlying)
+		if err != nil {
+			return nil, fmt.Errorf("wrong payout underlying %v", res.PayoutUnderlying)
+		}
+		payout.Underlying = underlying
+	}
+	return payout, nil
+}
+
+func updateSwapPayout(fromChainID, txid string, logIndex int, payout *tokens.PayoutInfo) (err error) {
+	updates := &mongodb.SwapResultUpdateItems{
+		Status:    mongodb.KeepStatus,
+		Timestamp: now(),
+	}
+	updates.Payout, updates.PayoutUnderlying = convertPayoutInfo(payout)
+	err = mongodb.UpdateRouterSwapResult(fromChainID, txid, logIndex, updates)
+	if err != nil {
+		logWorkerError("update", "updateSwapPayout failed", err, "chainid", fromChainID, "txid", txid, "logIndex", logIndex, "payout", updates.Payout, "underlying", updates.PayoutUnderlying)
+	} else {
+		logWorker("update", "updateSwapPayout success", "chainid", fromChainID, "txid", txid, "logIndex", logIndex, "payout", updates.Payout, "underlying", updates.PayoutUnderlying)
+	}
+	return err
+}
+
+func updateSwapDelivery(fromChainID, txid string, logIndex int, delivery string) (err error) {
+	updates := &mongodb.SwapResultUpdateItems{
+		Status:    mongodb.KeepStatus,
+		Delivery:  delivery,
+		Timestamp: now(),
+	}
+	err = mongodb.UpdateRouterSwapResult(fromChainID, txid, logIndex, updates)
+	if err != nil {
+		logWorkerError("update", "updateSwapDelivery failed", err, "chainid", fromChainID, "txid", txid, "logIndex", logIndex, "delivery", delivery)
+	} else {
+		logWorker("update", "updateSwapDelivery success", "chainid", fromChainID, "txid", txid, "logIndex", logIndex, "delivery", delivery)
+	}
+	return err
+}
+
 func markSwapResultUnstable(fromChainID, txid string, logIndex int) (err error) {
 	status := mongodb.MatchTxNotStable
 	timestamp := now()
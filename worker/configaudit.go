@@ -0,0 +1,30 @@
+package worker
+
+import (
+	"os"
+
+	"github.com/anyswap/CrossChain-Router/v3/mongodb"
+	"github.com/anyswap/CrossChain-Router/v3/params"
+	"github.com/anyswap/CrossChain-Router/v3/router/bridge"
+)
+
+// startConfigAuditRecorder record changes of reloading router config into mongodb (server only)
+func startConfigAuditRecorder() {
+	bridge.OnRouterConfigChanged = recordConfigAudit
+}
+
+func recordConfigAudit(source string, result *params.ConfigReloadResult) {
+	hostname, _ := os.Hostname()
+	audit := &mongodb.MgoConfigAudit{
+		Source:  source,
+		Status:  result.Status,
+		Changes: result.Changes,
+		Message: result.Message,
+		Server:  hostname,
+	}
+	if err := mongodb.AddConfigAudit(audit); err != nil {
+		logWorkerError("config", "record config audit failed", err, "source", source, "status", result.Status)
+		return
+	}
+	logWorker("config", "record config audit success", "version", audit.Key, "source", source, "status", result.Status, "changes", len(result.Changes))
+}
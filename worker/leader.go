@@ -0,0 +1,180 @@
+package worker
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/anyswap/CrossChain-Router/v3/cmd/utils"
+	"github.com/anyswap/CrossChain-Router/v3/common"
+	"github.com/anyswap/CrossChain-Router/v3/mongodb"
+	"github.com/anyswap/CrossChain-Router/v3/params"
+	"github.com/anyswap/CrossChain-Router/v3/router/bridge"
+)
+
+var (
+	leaderElectionStarter sync.Once
+	leaderHolderID        string
+
+	isLeaderFlag        int32
+	leaderLeaseDeadline int64 // unix milliseconds, leadership is valid before it
+
+	leaderWaitInterval = 3 * time.Second
+
+	errNotLeader = errors.New("server is not the leader")
+
+	// lease steps, replaced in tests
+	acquireLeaseFunc   = mongodb.AcquireLease
+	initSwapNoncesFunc = bridge.InitSwapNonces
+)
+
+// LeaderInfo leader election info
+type LeaderInfo struct {
+	Enabled    bool   `json:"enabled"`
+	IsLeader   bool   `json:"isLeader"`
+	HolderID   string `json:"holderID,omitempty"`
+	Leader     string `json:"leader,omitempty"`
+	ExpireTime int64  `json:"expireTime,omitempty"` // unix milliseconds
+}
+
+func getLeaderLeaseKey() string {
+	return "leader:" + params.GetIdentifier()
+}
+
+func getLeaderHolderID() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%v:%v:%v", hostname, os.Getpid(), common.NowMilli())
+}
+
+// IsLeader is this server the leader which runs the swap jobs.
+// it is always true if leader election is not enabled.
+func IsLeader() bool {
+	if params.GetLeaderElectionConfig() == nil {
+		return true
+	}
+	return atomic.LoadInt32(&isLeaderFlag) == 1 &&
+		common.NowMilli() < atomic.LoadInt64(&leaderLeaseDeadline)
+}
+
+// GetLeaderInfo get leader election info
+func GetLeaderInfo() *LeaderInfo {
+	info := &LeaderInfo{
+		Enabled:  params.GetLeaderElectionConfig() != nil,
+		IsLeader: IsLeader(),
+	}
+	if !info.Enabled {
+		return info
+	}
+	info.HolderID = leaderHolderID
+	if lease, err := mongodb.FindLease(getLeaderLeaseKey()); err == nil {
+		info.Leader = lease.Holder
+		info.ExpireTime = lease.ExpireTime
+	}
+	return info
+}
+
+// checkLeadership fence before signing, prevent double signing after losing leadership.
+// the swap result writes are also fenced by the lease epoch (see `mongodb.SetFenceToken`).
+func checkLeadership() error {
+	if !IsLeader() {
+		return errNotLeader
+	}
+	return nil
+}
+
+// waitForLeadership block job until this server becomes the leader.
+// returns false if the program is cleanuping.
+func waitForLeadership(job string) bool {
+	if IsLeader() {
+		return true
+	}
+	logWorker(job, "wait for leadership to run job", "holder", leaderHolderID)
+	for !IsLeader() {
+		if utils.IsCleanuping() {
+			logWorker(job, "stop job as cleanuping")
+			return false
+		}
+		restInJob(leaderWaitInterval)
+	}
+	logWorker(job, "run job as become leader", "holder", leaderHolderID)
+	return true
+}
+
+// StartLeaderElectionJob start leader election job if enabled.
+// the leader holds and renews a lease in mongodb, standby servers take over after the lease expired.
+func StartLeaderElectionJob() {
+	cfg := params.GetLeaderElectionConfig()
+	if cfg == nil {
+		return
+	}
+	leaderElectionStarter.Do(func() {
+		leaderHolderID = getLeaderHolderID()
+		renewInterval := time.Duration(cfg.GetRenewInterval()) * time.Second
+		logWorker("leader", "start leader election job", "holder", leaderHolderID, "leaseSeconds", cfg.GetLeaseSeconds(), "renewInterval", renewInterval)
+
+		tryAcquireLeadership(cfg)
+
+		mongodb.MgoWaitGroup.Add(1)
+		go func() {
+			defer mongodb.MgoWaitGroup.Done()
+			for {
+				restInJob(renewInterval)
+				if utils.IsCleanuping() {
+					releaseLeadership()
+					return
+				}
+				tryAcquireLeadership(cfg)
+			}
+		}()
+	})
+}
+
+func tryAcquireLeadership(cfg *params.LeaderElectionConfig) {
+	wasLeader := IsLeader()
+	lease := time.Duration(cfg.GetLeaseSeconds()) * time.Second
+	start := common.NowMilli()
+	epoch, acquired, err := acquireLeaseFunc(getLeaderLeaseKey(), leaderHolderID, lease)
+	if err != nil {
+		// keep the local deadline, leadership will be lost after it
+		logWorkerWarn("leader", "acquire leader lease failed", "holder", leaderHolderID, "isLeader", wasLeader, "err", err)
+		return
+	}
+	if !acquired {
+		if atomic.SwapInt32(&isLeaderFlag, 0) == 1 {
+			logWorkerWarn("leader", "lost leadership", "holder", leaderHolderID)
+		}
+		return
+	}
+	// keep a margin of one renew interval for clock drift and database latency
+	margin := time.Duration(cfg.GetRenewInterval()) * time.Second
+	atomic.StoreInt64(&leaderLeaseDeadline, start+(lease-margin).Milliseconds())
+	if mongodb.GetFenceToken() != epoch {
+		// writes of swap results carry the epoch as fencing token,
+		// so a stale leader can not overwrite the swap results written by a newer leader
+		mongodb.SetFenceToken(epoch)
+		logWorker("leader", "update fencing token", "holder", leaderHolderID, "epoch", epoch)
+	}
+	if !wasLeader {
+		// other servers may have allocated nonces when we are not the leader
+		initSwapNoncesFunc()
+		atomic.StoreInt32(&isLeaderFlag, 1)
+		logWorker("leader", "become leader", "holder", leaderHolderID)
+	}
+}
+
+func releaseLeadership() {
+	if atomic.SwapInt32(&isLeaderFlag, 0) == 0 {
+		return
+	}
+	if err := mongodb.ReleaseLease(getLeaderLeaseKey(), leaderHolderID); err != nil {
+		logWorkerWarn("leader", "release leader lease failed", "holder", leaderHolderID, "err", err)
+		return
+	}
+	logWorker("leader", "release leader lease success", "holder", leaderHolderID)
+}
@@ -0,0 +1,149 @@
+package worker
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/anyswap/CrossChain-Router/v3/common"
+	"github.com/anyswap/CrossChain-Router/v3/mongodb"
+	"github.com/anyswap/CrossChain-Router/v3/params"
+)
+
+var errLeaseTestDB = errors.New("database unavailable")
+
+// leaseTestStore keeps the lease in memory as `mongodb.AcquireLease` does
+type leaseTestStore struct {
+	holder     string
+	epoch      uint64
+	expireTime int64
+	fail       bool
+	initNonces int
+}
+
+func (s *leaseTestStore) acquire(key, holder string, ttl time.Duration) (uint64, bool, error) {
+	if s.fail {
+		return 0, false, errLeaseTestDB
+	}
+	now := common.NowMilli()
+	if s.holder != holder && s.expireTime >= now {
+		return 0, false, nil // held by others
+	}
+	if s.holder != holder {
+		s.epoch++
+	}
+	s.holder = holder
+	s.expireTime = now + ttl.Milliseconds()
+	return s.epoch, true, nil
+}
+
+// expire let the lease expire, so it can be taken over
+func (s *leaseTestStore) expire() {
+	s.expireTime = common.NowMilli() - 1
+}
+
+func setupLeaderTest(t *testing.T) (*leaseTestStore, *params.LeaderElectionConfig) {
+	routerCfg := params.GetRouterConfig()
+	prevServer := routerCfg.Server
+	cfg := &params.LeaderElectionConfig{Enable: true, LeaseSeconds: 30}
+	routerCfg.Server = &params.RouterServerConfig{LeaderElection: cfg}
+
+	store := &leaseTestStore{}
+	prevAcquire, prevInitNonces := acquireLeaseFunc, initSwapNoncesFunc
+	prevHolder, prevFenceToken := leaderHolderID, mongodb.GetFenceToken()
+	acquireLeaseFunc = store.acquire
+	initSwapNoncesFunc = func() { store.initNonces++ }
+	leaderHolderID = "server1"
+	atomic.StoreInt32(&isLeaderFlag, 0)
+	atomic.StoreInt64(&leaderLeaseDeadline, 0)
+
+	t.Cleanup(func() {
+		acquireLeaseFunc, initSwapNoncesFunc = prevAcquire, prevInitNonces
+		leaderHolderID = prevHolder
+		mongodb.SetFenceToken(prevFenceToken)
+		atomic.StoreInt32(&isLeaderFlag, 0)
+		atomic.StoreInt64(&leaderLeaseDeadline, 0)
+		routerCfg.Server = prevServer
+	})
+	return store, cfg
+}
+
+func checkLeaderState(t *testing.T, stage string, isLeader bool, epoch uint64) {
+	t.Helper()
+	if IsLeader() != isLeader {
+		t.Errorf("%v: is leader: got %v, want %v", stage, IsLeader(), isLeader)
+	}
+	if err := checkLeadership(); (err == nil) != isLeader {
+		t.Errorf("%v: check leadership: got err %v", stage, err)
+	}
+	if token := mongodb.GetFenceToken(); token != epoch {
+		t.Errorf("%v: fence token: got %v, want %v", stage, token, epoch)
+	}
+}
+
+func TestLeaderLeaseTakeover(t *testing.T) {
+	store, cfg := setupLeaderTest(t)
+
+	tryAcquireLeadership(cfg)
+	checkLeaderState(t, "acquire", true, 1)
+	if store.initNonces != 1 {
+		t.Errorf("swap nonces are not inited when becoming leader")
+	}
+
+	// renew keeps the epoch
+	tryAcquireLeadership(cfg)
+	checkLeaderState(t, "renew", true, 1)
+	if store.initNonces != 1 {
+		t.Errorf("swap nonces are inited when renewing lease")
+	}
+
+	// the lease can not be taken over before expired
+	if _, acquired, _ := store.acquire("", "server2", 30*time.Second); acquired {
+		t.Fatalf("lease is taken over before expired")
+	}
+
+	// taken over by other server after expired
+	store.expire()
+	if epoch, acquired, _ := store.acquire("", "server2", 30*time.Second); !acquired || epoch != 2 {
+		t.Fatalf("take over expired lease: got acquired %v epoch %v", acquired, epoch)
+	}
+	tryAcquireLeadership(cfg)
+	checkLeaderState(t, "taken over", false, 1)
+
+	// take over again after the other server's lease expired
+	store.expire()
+	tryAcquireLeadership(cfg)
+	checkLeaderState(t, "take over", true, 3)
+	if store.initNonces != 2 {
+		t.Errorf("swap nonces are not inited when becoming leader again")
+	}
+}
+
+func TestLeaderLeaseDeadline(t *testing.T) {
+	store, cfg := setupLeaderTest(t)
+
+	tryAcquireLeadership(cfg)
+	checkLeaderState(t, "acquire", true, 1)
+
+	// keep leadership before the local deadline if database is unavailable
+	store.fail = true
+	tryAcquireLeadership(cfg)
+	checkLeaderState(t, "renew failed", true, 1)
+
+	// the local deadline keeps a margin of one renew interval
+	deadline := atomic.LoadInt64(&leaderLeaseDeadline)
+	if lease := store.expireTime; deadline > lease-cfg.GetRenewInterval()*1000 {
+		t.Errorf("leader deadline %v is not before lease expire time %v with margin", deadline, lease)
+	}
+
+	// lose leadership after the deadline, even if the lease is not renewed
+	atomic.StoreInt64(&leaderLeaseDeadline, common.NowMilli()-1)
+	checkLeaderState(t, "deadline passed", false, 1)
+
+	// not fenced if leader election is disabled
+	params.GetRouterConfig().Server.LeaderElection = nil
+	if !IsLeader() {
+		t.Errorf("server is not leader with leader election disabled")
+	}
+}
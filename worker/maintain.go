@@ -0,0 +1,263 @@
+package worker
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anyswap/CrossChain-Router/v3/mongodb"
+	"github.com/anyswap/CrossChain-Router/v3/params"
+	"github.com/anyswap/CrossChain-Router/v3/router"
+	"github.com/anyswap/CrossChain-Router/v3/router/bridge"
+	"github.com/anyswap/CrossChain-Router/v3/rpc/client"
+)
+
+// maintain categories
+const (
+	MaintainPause     = "pause"
+	MaintainBlacklist = "blacklist"
+	MaintainWhitelist = "whitelist"
+)
+
+var (
+	maintainLock         sync.Mutex
+	maintainVersion      uint64
+	maintainItems        []*mongodb.MgoMaintainItem
+	maintainSyncStarter  sync.Once
+	maintainSyncInterval = 60 * time.Second
+)
+
+// MaintenanceState effective maintenance state
+type MaintenanceState struct {
+	Version uint64 `json:"version"`
+
+	PausedChainIDs   []string `json:"pausedChainIDs"`
+	ChainIDBlackList []string `json:"chainIDBlackList"`
+	TokenIDBlackList []string `json:"tokenIDBlackList"`
+	AccountBlackList []string `json:"accountBlackList"`
+
+	CallByContractWhitelist         map[string][]string `json:"callByContractWhitelist,omitempty"`
+	CallByContractCodeHashWhitelist map[string][]string `json:"callByContractCodeHashWhitelist,omitempty"`
+	BigValueWhitelist               map[string][]string `json:"bigValueWhitelist,omitempty"`
+
+	// persisted changes with caller and time
+	Items []*mongodb.MgoMaintainItem `json:"items"`
+}
+
+// NewMaintainItems new maintain items of the same category, type and scope
+func NewMaintainItems(category, itemType, scope string, values []string, enabled bool, caller string) []*mongodb.MgoMaintainItem {
+	items := make([]*mongodb.MgoMaintainItem, 0, len(values))
+	for _, value := range values {
+		if value == "" {
+			continue
+		}
+		items = append(items, &mongodb.MgoMaintainItem{
+			Category: category,
+			Type:     strings.ToLower(itemType),
+			Scope:    scope,
+			Value:    value,
+			Enabled:  enabled,
+			Caller:   caller,
+		})
+	}
+	return items
+}
+
+// CheckMaintainItem check maintain item
+func CheckMaintainItem(item *mongodb.MgoMaintainItem) error {
+	switch item.Category {
+	case MaintainPause:
+		return nil
+	case MaintainBlacklist:
+		switch item.Type {
+		case "chainid", "tokenid", "account":
+			return nil
+		}
+		return fmt.Errorf("unknown blacklist type '%v'", item.Type)
+	case MaintainWhitelist:
+		switch item.Type {
+		case "callbycontract", "callbycontractcodehash", "bigvalue":
+			return nil
+		}
+		return fmt.Errorf("unknown whitelist type '%v'", item.Type)
+	default:
+		return fmt.Errorf("unknown maintain category '%v'", item.Category)
+	}
+}
+
+func applyMaintainItem(item *mongodb.MgoMaintainItem) {
+	values := []string{item.Value}
+	switch item.Category {
+	case MaintainPause:
+		if item.Enabled {
+			router.AddPausedChainIDs(values)
+		} else {
+			router.RemovePausedChainIDs(values)
+		}
+	case MaintainBlacklist:
+		switch item.Type {
+		case "chainid":
+			params.AddOrRemoveChainIDBlackList(values, item.Enabled)
+		case "tokenid":
+			params.AddOrRemoveTokenIDBlackList(values, item.Enabled)
+		case "account":
+			params.AddOrRemoveAccountBlackList(values, item.Enabled)
+		}
+	case MaintainWhitelist:
+		switch item.Type {
+		case "callbycontract":
+			params.AddOrRemoveCallByContractWhitelist(item.Scope, values, item.Enabled)
+		case "callbycontractcodehash":
+			params.AddOrRemoveCallByContractCodeHashWhitelist(item.Scope, values, item.Enabled)
+		case "bigvalue":
+			params.AddOrRemoveBigValueWhitelist(item.Scope, values, item.Enabled)
+		}
+	}
+}
+
+// SaveMaintainItems persist and apply maintain items (only for server)
+func SaveMaintainItems(items []*mongodb.MgoMaintainItem) error {
+	for _, item := range items {
+		if err := CheckMaintainItem(item); err != nil {
+			return err
+		}
+	}
+	maintainLock.Lock()
+	defer maintainLock.Unlock()
+
+	version, err := mongodb.UpdateMaintainItems(items)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		applyMaintainItem(item)
+	}
+	// reload all items if other server nodes have changed them
+	if version == maintainVersion+1 {
+		maintainItems = mergeMaintainItems(maintainItems, items)
+		maintainVersion = version
+	} else if err = loadMaintainItemsFromDB(); err != nil {
+		logWorkerError("maintain", "reload maintain items failed", err)
+	}
+	logWorker("maintain", "save maintain items success", "count", len(items), "version", version)
+	return nil
+}
+
+func mergeMaintainItems(olds, news []*mongodb.MgoMaintainItem) []*mongodb.MgoMaintainItem {
+	result := make([]*mongodb.MgoMaintainItem, 0, len(olds)+len(news))
+	exist := make(map[string]struct{}, len(news))
+	for _, item := range news {
+		exist[item.Key] = struct{}{}
+	}
+	for _, item := range olds {
+		if _, ok := exist[item.Key]; !ok {
+			result = append(result, item)
+		}
+	}
+	return append(result, news...)
+}
+
+// GetMaintenanceState get effective maintenance state
+func GetMaintenanceState() *MaintenanceState {
+	maintainLock.Lock()
+	defer maintainLock.Unlock()
+
+	state := &MaintenanceState{
+		Version: maintainVersion,
+		Items:   maintainItems,
+	}
+	for _, chainID := range router.GetPausedChainIDs() {
+		state.PausedChainIDs = append(state.PausedChainIDs, chainID.String())
+	}
+	state.ChainIDBlackList = params.GetRouterConfig().ChainIDBlackList
+	state.TokenIDBlackList = params.GetRouterConfig().TokenIDBlackList
+	state.AccountBlackList = params.GetRouterConfig().AccountBlackList
+	if extra := params.GetExtraConfig(); extra != nil {
+		state.CallByContractWhitelist = extra.CallByContractWhitelist
+		state.CallByContractCodeHashWhitelist = extra.CallByContractCodeHashWhitelist
+		state.BigValueWhitelist = extra.BigValueWhitelist
+	}
+	return state
+}
+
+// loadMaintainItemsFromDB load and apply all persisted items, should hold `maintainLock`
+func loadMaintainItemsFromDB() error {
+	version, err := mongodb.GetMaintainVersion()
+	if err != nil {
+		return err
+	}
+	if version == maintainVersion && maintainItems != nil {
+		return nil
+	}
+	items, err := mongodb.FindMaintainItems()
+	if err != nil {
+		return err
+	}
+	setMaintainItems(version, items)
+	return nil
+}
+
+// loadMaintainItemsFromServer sync and apply all persisted items from server (only for oracle)
+func loadMaintainItemsFromServer() error {
+	url := params.GetRouterOracleConfig().ServerAPIAddress
+	var state MaintenanceState
+	err := client.RPCPostWithTimeout(20, &state, url, "swap.GetMaintenanceState")
+	if err != nil {
+		return err
+	}
+	if state.Version == maintainVersion && maintainItems != nil {
+		return nil
+	}
+	setMaintainItems(state.Version, state.Items)
+	return nil
+}
+
+func setMaintainItems(version uint64, items []*mongodb.MgoMaintainItem) {
+	for _, item := range items {
+		applyMaintainItem(item)
+	}
+	if version != maintainVersion {
+		logWorker("maintain", "apply maintain state", "oldVersion", maintainVersion, "newVersion", version, "count", len(items))
+	}
+	maintainVersion = version
+	maintainItems = items
+}
+
+// LoadMaintainState load and apply persisted maintenance state
+func LoadMaintainState(isServer bool) error {
+	maintainLock.Lock()
+	defer maintainLock.Unlock()
+
+	if isServer {
+		return loadMaintainItemsFromDB()
+	}
+	return loadMaintainItemsFromServer()
+}
+
+// reapplyMaintainState reapply cached maintenance state (eg. after reloading config)
+func reapplyMaintainState() {
+	maintainLock.Lock()
+	defer maintainLock.Unlock()
+
+	for _, item := range maintainItems {
+		applyMaintainItem(item)
+	}
+	logWorker("maintain", "reapply maintain state", "version", maintainVersion, "count", len(maintainItems))
+}
+
+// StartMaintainSyncJob sync maintenance state changed by other nodes
+func StartMaintainSyncJob(isServer bool) {
+	maintainSyncStarter.Do(func() {
+		logWorker("maintain", "start maintain state sync job")
+		bridge.AfterReloadRouterConfig = reapplyMaintainState
+		go func() {
+			for {
+				restInJob(maintainSyncInterval)
+				if err := LoadMaintainState(isServer); err != nil {
+					logWorkerWarn("maintain", "sync maintain state failed", "err", err)
+				}
+			}
+		}()
+	})
+}
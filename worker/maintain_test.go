@@ -0,0 +1,196 @@
+package worker
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/anyswap/CrossChain-Router/v3/mongodb"
+	"github.com/anyswap/CrossChain-Router/v3/params"
+)
+
+func newTestMaintainItem(itemType, value string, enabled bool) *mongodb.MgoMaintainItem {
+	item := NewMaintainItems(MaintainBlacklist, itemType, "", []string{value}, enabled, "admin")[0]
+	item.Key = strings.Join([]string{item.Category, item.Type, item.Scope, strings.ToLower(item.Value)}, ":")
+	return item
+}
+
+// saveMaintainState save maintain and blacklist state, and restore them after test
+func saveMaintainState(t *testing.T) {
+	routerCfg := params.GetRouterConfig()
+	prevVersion, prevItems := maintainVersion, maintainItems
+	prevOracle := routerCfg.Oracle
+	prevChainIDBlackList := routerCfg.ChainIDBlackList
+	prevTokenIDBlackList := routerCfg.TokenIDBlackList
+	prevAccountBlackList := routerCfg.AccountBlackList
+	t.Cleanup(func() {
+		maintainVersion, maintainItems = prevVersion, prevItems
+		routerCfg.Oracle = prevOracle
+		routerCfg.ChainIDBlackList = prevChainIDBlackList
+		routerCfg.TokenIDBlackList = prevTokenIDBlackList
+		routerCfg.AccountBlackList = prevAccountBlackList
+		_ = routerCfg.CheckBlacklistConfig()
+	})
+}
+
+// reloadTestBlacklistConfig reset blacklist to the local config as reloading config
+func reloadTestBlacklistConfig(t *testing.T, tokenIDs ...string) {
+	routerCfg := params.GetRouterConfig()
+	routerCfg.TokenIDBlackList = tokenIDs
+	if err := routerCfg.CheckBlacklistConfig(); err != nil {
+		t.Fatalf("check blacklist config failed: %v", err)
+	}
+}
+
+func TestMergeMaintainItems(t *testing.T) {
+	olds := []*mongodb.MgoMaintainItem{
+		newTestMaintainItem("tokenid", "tokenA", true),
+		newTestMaintainItem("tokenid", "tokenB", true),
+		newTestMaintainItem("chainid", "1", true),
+	}
+	news := []*mongodb.MgoMaintainItem{
+		newTestMaintainItem("tokenid", "tokenB", false),
+		newTestMaintainItem("account", "0x1", true),
+	}
+	merged := mergeMaintainItems(olds, news)
+	want := []*mongodb.MgoMaintainItem{olds[0], olds[2], news[0], news[1]}
+	if len(merged) != len(want) {
+		t.Fatalf("merged items: got %v, want %v", len(merged), len(want))
+	}
+	for i, item := range want {
+		if merged[i] != item {
+			t.Errorf("merged item %v: got %v, want %v", i, merged[i].Key, item.Key)
+		}
+	}
+	if len(mergeMaintainItems(nil, news)) != len(news) {
+		t.Errorf("merge into empty items failed")
+	}
+}
+
+func TestReapplyMaintainStateWithConfig(t *testing.T) {
+	saveMaintainState(t)
+	reloadTestBlacklistConfig(t, "tokenA", "tokenB")
+
+	maintainVersion = 3
+	maintainItems = []*mongodb.MgoMaintainItem{
+		newTestMaintainItem("tokenid", "tokenB", false),
+		newTestMaintainItem("tokenid", "tokenC", true),
+	}
+	reapplyMaintainState()
+
+	tests := []struct {
+		tokenID string
+		want    bool
+	}{
+		{"tokenA", true},  // only in config
+		{"tokenB", false}, // removed by persisted item
+		{"tokenC", true},  // added by persisted item
+		{"tokenD", false},
+	}
+	check := func(stage string) {
+		for _, tt := range tests {
+			if got := params.IsTokenIDInBlackList(tt.tokenID); got != tt.want {
+				t.Errorf("%v: token %v in blacklist: got %v, want %v", stage, tt.tokenID, got, tt.want)
+			}
+		}
+	}
+	check("reapply")
+
+	// persisted items overwrite the reloaded local config again
+	reloadTestBlacklistConfig(t, "tokenA", "tokenB")
+	reapplyMaintainState()
+	check("reload")
+
+	state := GetMaintenanceState()
+	if state.Version != 3 || len(state.Items) != 2 || len(state.TokenIDBlackList) != 2 {
+		t.Errorf("maintenance state: got version %v items %v blacklist %v", state.Version, len(state.Items), state.TokenIDBlackList)
+	}
+}
+
+func TestLoadMaintainItemsFromServer(t *testing.T) {
+	saveMaintainState(t)
+	reloadTestBlacklistConfig(t)
+
+	var state *MaintenanceState
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if state == nil {
+			http.Error(w, "server unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		resp := map[string]interface{}{"jsonrpc": "2.0", "id": 1, "result": state}
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+	params.GetRouterConfig().Oracle = &params.RouterOracleConfig{ServerAPIAddress: server.URL}
+
+	maintainVersion, maintainItems = 0, nil
+	state = &MaintenanceState{
+		Version: 2,
+		Items:   []*mongodb.MgoMaintainItem{newTestMaintainItem("tokenid", "tokenA", true)},
+	}
+	if err := LoadMaintainState(false); err != nil {
+		t.Fatalf("load maintain state failed: %v", err)
+	}
+	if maintainVersion != 2 || len(maintainItems) != 1 || !params.IsTokenIDInBlackList("tokenA") {
+		t.Fatalf("sync version 2: got version %v items %v", maintainVersion, len(maintainItems))
+	}
+
+	// the same version is not changed
+	state.Items = []*mongodb.MgoMaintainItem{newTestMaintainItem("tokenid", "tokenB", true)}
+	if err := LoadMaintainState(false); err != nil {
+		t.Fatalf("load maintain state failed: %v", err)
+	}
+	if maintainVersion != 2 || maintainItems[0].Value != "tokenA" || params.IsTokenIDInBlackList("tokenB") {
+		t.Errorf("sync the same version: got version %v items %v", maintainVersion, maintainItems[0].Value)
+	}
+
+	// newer version is applied
+	state = &MaintenanceState{
+		Version: 3,
+		Items: []*mongodb.MgoMaintainItem{
+			newTestMaintainItem("tokenid", "tokenA", false),
+			newTestMaintainItem("tokenid", "tokenB", true),
+		},
+	}
+	if err := LoadMaintainState(false); err != nil {
+		t.Fatalf("load maintain state failed: %v", err)
+	}
+	if maintainVersion != 3 || len(maintainItems) != 2 ||
+		params.IsTokenIDInBlackList("tokenA") || !params.IsTokenIDInBlackList("tokenB") {
+		t.Errorf("sync version 3: got version %v items %v blacklist %v", maintainVersion, len(maintainItems), params.GetRouterConfig().TokenIDBlackList)
+	}
+
+	// failed sync keeps the current state
+	state = nil
+	if err := LoadMaintainState(false); err == nil {
+		t.Errorf("sync from unavailable server should fail")
+	}
+	if maintainVersion != 3 || !params.IsTokenIDInBlackList("tokenB") {
+		t.Errorf("failed sync changed state: got version %v", maintainVersion)
+	}
+}
+
+func TestCheckMaintainItem(t *testing.T) {
+	tests := []struct {
+		item    *mongodb.MgoMaintainItem
+		wantErr bool
+	}{
+		{&mongodb.MgoMaintainItem{Category: MaintainPause, Value: "1"}, false},
+		{&mongodb.MgoMaintainItem{Category: MaintainBlacklist, Type: "tokenid"}, false},
+		{&mongodb.MgoMaintainItem{Category: MaintainBlacklist, Type: "bigvalue"}, true},
+		{&mongodb.MgoMaintainItem{Category: MaintainWhitelist, Type: "bigvalue"}, false},
+		{&mongodb.MgoMaintainItem{Category: MaintainWhitelist, Type: "account"}, true},
+		{&mongodb.MgoMaintainItem{Category: "unknown"}, true},
+	}
+	for i, tt := range tests {
+		err := CheckMaintainItem(tt.item)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("test %v: got err %v, want err %v", i, err, tt.wantErr)
+		}
+	}
+	if items := NewMaintainItems(MaintainBlacklist, "TokenID", "", []string{"a", "", "b"}, true, "admin"); len(items) != 2 || items[0].Type != "tokenid" {
+		t.Errorf("new maintain items: got %v", items)
+	}
+}
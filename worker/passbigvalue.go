@@ -38,6 +38,9 @@ func StartPassBigValueJob() {
 func doPassBigValueJob() {
 	defer mongodb.MgoWaitGroup.Done()
 	for {
+		if !waitForLeadership("passbigval") {
+			return
+		}
 		res, err := findBigValRouterSwaps()
 		if err != nil {
 			logWorkerError("passbigval", "find big value swaps error", err)
@@ -49,6 +49,9 @@ func StartReplaceJob() {
 func startReplaceProducer() {
 	logWorker("replace", "start router swap replace job")
 	for {
+		if !waitForLeadership("replace") {
+			return
+		}
 		res, err := findRouterSwapResultToReplace()
 		if err != nil {
 			logWorkerError("replace", "find out router swap error", err)
@@ -107,6 +110,9 @@ func dispatchSwapResultToReplace(res *mongodb.MgoSwapResult) error {
 		checkAndRecycleSwapNonce(res)
 		return nil
 	}
+	if res.BatchSize > 0 && res.BatchIndex != 0 {
+		return nil // the batch swap tx is replaced by its first swap
+	}
 	if res.SwapTx != "" && getSepTimeInFind(waitTimeToReplace) < res.Timestamp {
 		return nil
 	}
@@ -121,7 +127,7 @@ func dispatchSwapResultToReplace(res *mongodb.MgoSwapResult) error {
 	if !ok {
 		return nil
 	}
-	nonce, err := nonceSetter.GetPoolNonce(res.MPC, "latest")
+	nonce, err := getLatestSwapNonce(nonceSetter, res)
 	if err != nil {
 		logWorkerTrace("replace: get nonce failed", "account", res.MPC, "fromChainID", res.FromChainID, "toChainID", res.ToChainID, "txid", res.TxID, "logIndex", res.LogIndex, "err", err)
 		return nil
@@ -165,15 +171,29 @@ func checkAndRecycleSwapNonce(res *mongodb.MgoSwapResult) {
 	if resBridge == nil {
 		return
 	}
-	nonceSetter, ok := resBridge.(tokens.NonceSetter)
-	if !ok {
+	if _, ok := resBridge.(tokens.NonceSetter); !ok {
 		return
 	}
 	if res.SwapNonce == 0 || res.MPC == "" {
 		return
 	}
 	logWorker("recycle swap nonce", "swap", res)
-	nonceSetter.RecycleSwapNonce(res.MPC, res.SwapNonce)
+	recycleSwapNonce(resBridge, res.MPC, res.KeyIndex, res.SwapNonce)
+}
+
+// recycleSwapNonce recycle swap nonce (of the account key if `keyIndex` is not nil)
+func recycleSwapNonce(resBridge tokens.IBridge, mpc string, keyIndex *uint64, nonce uint64) {
+	nonceSetter, ok := resBridge.(tokens.NonceSetter)
+	if !ok || nonce == 0 || mpc == "" {
+		return
+	}
+	if keyIndex != nil {
+		if keyNonceSetter, ok := nonceSetter.(tokens.KeyNonceSetter); ok {
+			keyNonceSetter.RecycleKeySwapNonce(mpc, *keyIndex, nonce)
+			return
+		}
+	}
+	nonceSetter.RecycleSwapNonce(mpc, nonce)
 }
 
 func startReplaceConsumer(chainID string) {
@@ -227,6 +247,9 @@ func ReplaceRouterSwap(res *mongodb.MgoSwapResult, gasPrice *big.Int, isManual b
 	if !router.IsNonceSupported(res.ToChainID) {
 		return tokens.ErrNonceNotSupport
 	}
+	if res.BatchSize > 0 {
+		return replaceBatchRouterSwap(res, gasPrice, isManual)
+	}
 	swap, err := verifyReplaceSwap(res, isManual)
 	if err != nil {
 		return err
@@ -244,24 +267,43 @@ func ReplaceRouterSwap(res *mongodb.MgoSwapResult, gasPrice *big.Int, isManual b
 		return tokens.ErrSenderMismatch
 	}
 
-	biFromChainID, biToChainID, biValue, err := getFromToChainIDAndValue(res.FromChainID, res.ToChainID, res.Value)
-	if err != nil {
-		return err
-	}
-
 	logWorker("replaceSwap", "process task", "swap", res)
 	_ = updateSwapTimestamp(res.FromChainID, res.TxID, res.LogIndex)
 
-	txid := res.TxID
 	nonce := res.SwapNonce
 	replaceNum := uint64(len(res.OldSwapTxs))
 	if replaceNum == 0 {
 		replaceNum++
 	}
+	args, err := getReplaceSwapArgs(res, swap, &tokens.AllExtras{
+		GasPrice:   gasPrice,
+		Sequence:   &nonce,
+		ReplaceNum: replaceNum,
+		KeyIndex:   res.KeyIndex,
+	})
+	if err != nil {
+		return err
+	}
+	txid := res.TxID
+	rawTx, err := resBridge.BuildRawTransaction(args)
+	if err != nil {
+		logWorkerError("replaceSwap", "build tx failed", err, "chainID", res.ToChainID, "txid", txid, "logIndex", res.LogIndex)
+		return err
+	}
+	go signAndSendReplaceTx(resBridge, rawTx, args, res)
+	return nil
+}
+
+// getReplaceSwapArgs get build tx args to replace swap result
+func getReplaceSwapArgs(res *mongodb.MgoSwapResult, swap *mongodb.MgoSwap, extra *tokens.AllExtras) (*tokens.BuildTxArgs, error) {
+	biFromChainID, biToChainID, biValue, err := getFromToChainIDAndValue(res.FromChainID, res.ToChainID, res.Value)
+	if err != nil {
+		return nil, err
+	}
 	args := &tokens.BuildTxArgs{
 		SwapArgs: tokens.SwapArgs{
 			Identifier:  params.GetIdentifier(),
-			SwapID:      txid,
+			SwapID:      res.TxID,
 			SwapType:    tokens.SwapType(res.SwapType),
 			Bind:        res.Bind,
 			LogIndex:    res.LogIndex,
@@ -272,26 +314,25 @@ func ReplaceRouterSwap(res *mongodb.MgoSwapResult, gasPrice *big.Int, isManual b
 		OriginFrom:  swap.From,
 		OriginTxTo:  swap.TxTo,
 		OriginValue: biValue,
-		Extra: &tokens.AllExtras{
-			GasPrice:   gasPrice,
-			Sequence:   &nonce,
-			ReplaceNum: replaceNum,
-		},
+		Extra:       extra,
 	}
 	args.SwapInfo, err = mongodb.ConvertFromSwapInfo(&swap.SwapInfo)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	rawTx, err := resBridge.BuildRawTransaction(args)
+	// keep the payout decision of the replaced tx
+	args.Extra.Payout, err = getPayoutFromSwapResult(res)
 	if err != nil {
-		logWorkerError("replaceSwap", "build tx failed", err, "chainID", res.ToChainID, "txid", txid, "logIndex", res.LogIndex)
-		return err
+		return nil, err
 	}
-	go signAndSendReplaceTx(resBridge, rawTx, args, res)
-	return nil
+	return args, nil
 }
 
 func signAndSendReplaceTx(resBridge tokens.IBridge, rawTx interface{}, args *tokens.BuildTxArgs, res *mongodb.MgoSwapResult) {
+	if err := checkLeadership(); err != nil {
+		logWorkerError("replaceSwap", "mpc sign tx failed", err, "fromChainID", res.FromChainID, "toChainID", res.ToChainID, "txid", res.TxID, "nonce", res.SwapNonce, "logIndex", res.LogIndex)
+		return
+	}
 	signedTx, txHash, err := resBridge.MPCSignTransaction(rawTx, args)
 	if err != nil {
 		logWorkerError("replaceSwap", "mpc sign tx failed", err, "fromChainID", res.FromChainID, "toChainID", res.ToChainID, "txid", res.TxID, "nonce", res.SwapNonce, "logIndex", res.LogIndex)
@@ -305,13 +346,15 @@ func signAndSendReplaceTx(resBridge tokens.IBridge, rawTx interface{}, args *tok
 	txid := res.TxID
 	logIndex := res.LogIndex
 
-	cacheKey := mongodb.GetRouterSwapKey(fromChainID, txid, logIndex)
-	disagreeRecords.Delete(cacheKey)
+	_ = mongodb.ResetRouterSwapDisagreeCount(fromChainID, txid, logIndex)
 
 	err = mongodb.UpdateRouterOldSwapTxs(fromChainID, txid, logIndex, txHash)
 	if err != nil {
 		return
 	}
+	if args.Extra.Delivery != "" && args.Extra.Delivery != res.Delivery {
+		_ = updateSwapDelivery(fromChainID, txid, logIndex, args.Extra.Delivery)
+	}
 
 	sentTxHash, err := sendSignedTransaction(resBridge, signedTx, args)
 	if err == nil && txHash != sentTxHash {
@@ -368,7 +411,7 @@ func checkIfSwapNonceHasPassed(bridge tokens.IBridge, res *mongodb.MgoSwapResult
 	if !ok {
 		return nil
 	}
-	nonce, err := nonceSetter.GetPoolNonce(res.MPC, "latest")
+	nonce, err := getLatestSwapNonce(nonceSetter, res)
 	if err != nil {
 		return fmt.Errorf("get router mpc nonce failed, %w", err)
 	}
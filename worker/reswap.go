@@ -35,6 +35,9 @@ func StartReswapJob() {
 func startReswapProducer() {
 	logWorker("reswap", "start router reswap job")
 	for {
+		if !waitForLeadership("reswap") {
+			return
+		}
 		res, err := findRouterSwapResultToreswap()
 		if err != nil {
 			logWorkerError("reswap", "find out router swap error", err)
@@ -207,6 +210,10 @@ func reswapRouterSwap(res *mongodb.MgoSwapResult, isManual bool) error {
 }
 
 func signAndSendReswapTx(resBridge tokens.IBridge, rawTx interface{}, args *tokens.BuildTxArgs, res *mongodb.MgoSwapResult) {
+	if err := checkLeadership(); err != nil {
+		logWorkerError("reswapSwap", "mpc sign tx failed", err, "fromChainID", res.FromChainID, "toChainID", res.ToChainID, "txid", res.TxID, "nonce", res.SwapNonce, "logIndex", res.LogIndex)
+		return
+	}
 	signedTx, txHash, err := resBridge.MPCSignTransaction(rawTx, args)
 	if err != nil {
 		logWorkerError("reswapSwap", "mpc sign tx failed", err, "fromChainID", res.FromChainID, "toChainID", res.ToChainID, "txid", res.TxID, "nonce", res.SwapNonce, "logIndex", res.LogIndex)
@@ -220,8 +227,7 @@ func signAndSendReswapTx(resBridge tokens.IBridge, rawTx interface{}, args *toke
 	txid := res.TxID
 	logIndex := res.LogIndex
 
-	cacheKey := mongodb.GetRouterSwapKey(fromChainID, txid, logIndex)
-	disagreeRecords.Delete(cacheKey)
+	_ = mongodb.ResetRouterSwapDisagreeCount(fromChainID, txid, logIndex)
 
 	// update database before sending transaction
 	addSwapHistory(fromChainID, txid, logIndex, txHash)
@@ -237,6 +243,8 @@ func signAndSendReswapTx(resBridge tokens.IBridge, rawTx interface{}, args *toke
 		SwapValue: args.SwapValue.String(),
 		MPC:       args.From,
 		TTL:       *args.Extra.TTL,
+		Payout:    args.Extra.Payout,
+		Delivery:  args.Extra.Delivery,
 	}
 
 	err = updateRouterSwapResult(fromChainID, txid, logIndex, matchTx)
@@ -1,9 +1,13 @@
 package worker
 
 import (
+	"math/big"
+
 	"github.com/anyswap/CrossChain-Router/v3/cmd/utils"
+	"github.com/anyswap/CrossChain-Router/v3/common"
 	"github.com/anyswap/CrossChain-Router/v3/log"
 	"github.com/anyswap/CrossChain-Router/v3/mongodb"
+	"github.com/anyswap/CrossChain-Router/v3/params"
 	"github.com/anyswap/CrossChain-Router/v3/router"
 	"github.com/anyswap/CrossChain-Router/v3/tokens"
 	"github.com/anyswap/CrossChain-Router/v3/tools/fifo"
@@ -25,6 +29,9 @@ func StartStableJob() {
 
 func startStableProducer() {
 	for {
+		if !waitForLeadership("stable") {
+			return
+		}
 		res, err := findRouterSwapResultsToStable()
 		if err != nil {
 			logWorkerError("stable", "find router swap results error", err)
@@ -155,8 +162,7 @@ func processRouterSwapStable(swap *mongodb.MgoSwapResult) (err error) {
 		return tokens.ErrNoBridgeForChainID
 	}
 	if swap.SwapHeight != 0 &&
-		swap.SwapHeight+resBridge.GetChainConfig().Confirmations >
-			router.GetCachedLatestBlockNumber(swap.ToChainID) {
+		!isPassedBaseConfirmations(resBridge, swap.ToChainID, swap.SwapHeight) {
 		return nil
 	}
 	txStatus := getSwapTxStatus(resBridge, swap)
@@ -181,8 +187,9 @@ func processRouterSwapStable(swap *mongodb.MgoSwapResult) (err error) {
 	}
 
 	if swap.SwapHeight != 0 {
-		if txStatus.Confirmations < resBridge.GetChainConfig().Confirmations {
-			return nil
+		stable, err := isSwapTxStable(resBridge, swap, txStatus)
+		if err != nil || !stable {
+			return err
 		}
 		if swap.SwapTx != oldSwapTx {
 			_ = updateSwapTx(swap.FromChainID, swap.TxID, swap.LogIndex, swap.SwapTx)
@@ -205,3 +212,26 @@ func processRouterSwapStable(swap *mongodb.MgoSwapResult) (err error) {
 	}
 	return updateRouterSwapResult(swap.FromChainID, swap.TxID, swap.LogIndex, matchTx)
 }
+
+// isPassedBaseConfirmations precheck with cached latest block number to reduce rpc calls,
+// only the confirmations mode of the base confirm rule can be prechecked.
+func isPassedBaseConfirmations(bridge tokens.IBridge, chainID string, height uint64) bool {
+	rule := tokens.GetBaseConfirmRule(bridge.GetChainConfig())
+	if rule.Mode != params.ConfirmModeConfirmations {
+		return true
+	}
+	return height+rule.Confirmations <= router.GetCachedLatestBlockNumber(chainID)
+}
+
+// isSwapTxStable check swap tx by the confirmation policy of dest chain
+func isSwapTxStable(resBridge tokens.IBridge, swap *mongodb.MgoSwapResult, txStatus *tokens.TxStatus) (bool, error) {
+	tokenID := swap.GetTokenID()
+	var value *big.Int
+	var decimals uint8
+	multichainToken := router.GetCachedMultichainToken(tokenID, swap.ToChainID)
+	if tokenCfg := resBridge.GetTokenConfig(multichainToken); tokenCfg != nil {
+		value, _ = common.GetBigIntFromStr(swap.SwapValue)
+		decimals = tokenCfg.Decimals
+	}
+	return tokens.IsTxStable(resBridge, txStatus, tokenID, value, decimals)
+}
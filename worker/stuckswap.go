@@ -0,0 +1,326 @@
+package worker
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anyswap/CrossChain-Router/v3/cmd/utils"
+	"github.com/anyswap/CrossChain-Router/v3/log"
+	"github.com/anyswap/CrossChain-Router/v3/mongodb"
+	"github.com/anyswap/CrossChain-Router/v3/params"
+	"github.com/anyswap/CrossChain-Router/v3/router"
+	"github.com/anyswap/CrossChain-Router/v3/tokens"
+)
+
+// stuck swap breach levels
+const (
+	StuckLevelWarning  = "warning"
+	StuckLevelCritical = "critical"
+)
+
+var (
+	stuckSwapStarter sync.Once
+
+	stuckSwapLock   sync.RWMutex
+	stuckSwapReport = &StuckSwapReport{}
+
+	maxStuckSwapsInCheck         = 5000 // per collection
+	stuckSwapsPageSize           = int64(100)
+	restIntervalInStuckSwapCheck = 60 * time.Second // rest interval if disabled
+
+	// statuses in swap register collection and swap result collection
+	stuckRegisterStatuses = []mongodb.SwapStatus{mongodb.TxNotStable, mongodb.TxNotSwapped}
+	stuckResultStatuses   = []mongodb.SwapStatus{mongodb.MatchTxEmpty, mongodb.MatchTxNotStable}
+)
+
+// StuckSwap swap staying in status longer than its SLA
+type StuckSwap struct {
+	Key           string   `json:"key"`
+	FromChainID   string   `json:"fromChainID"`
+	ToChainID     string   `json:"toChainID"`
+	TxID          string   `json:"txid"`
+	LogIndex      int      `json:"logIndex"`
+	TokenID       string   `json:"tokenID,omitempty"`
+	Value         string   `json:"value"`
+	Status        string   `json:"status"`
+	Age           int64    `json:"age"` // seconds in current status
+	SLA           int64    `json:"sla"` // seconds
+	Level         string   `json:"level"`
+	Causes        []string `json:"causes,omitempty"`
+	Memo          string   `json:"memo,omitempty"`
+	FirstDetected int64    `json:"firstDetected"`
+}
+
+// StuckSwapReport result of the latest stuck swap check
+type StuckSwapReport struct {
+	Enabled   bool         `json:"enabled"`
+	CheckTime int64        `json:"checkTime,omitempty"`
+	Swaps     []*StuckSwap `json:"swaps"`
+}
+
+// GetStuckSwapReport get result of the latest stuck swap check
+func GetStuckSwapReport() *StuckSwapReport {
+	stuckSwapLock.RLock()
+	defer stuckSwapLock.RUnlock()
+	report := *stuckSwapReport
+	report.Enabled = params.GetStuckSwapConfig() != nil
+	return &report
+}
+
+// StartStuckSwapJob start job to detect swaps staying in status longer than the SLA.
+// it does not change any swap, so it runs on all servers (including the standby ones).
+func StartStuckSwapJob() {
+	stuckSwapStarter.Do(func() {
+		logWorker("stuckswap", "start stuck swap detect job")
+		go func() {
+			for {
+				cfg := params.GetStuckSwapConfig()
+				if cfg == nil {
+					restInJob(restIntervalInStuckSwapCheck)
+					continue
+				}
+				checkStuckSwaps(cfg)
+				if utils.IsCleanuping() {
+					logWorker("stuckswap", "stop stuck swap detect job")
+					return
+				}
+				restInJob(time.Duration(cfg.GetCheckInterval()) * time.Second)
+			}
+		}()
+	})
+}
+
+func checkStuckSwaps(cfg *params.StuckSwapConfig) {
+	start := time.Now()
+	checkTime := start.Unix()
+	filter := &mongodb.SwapSearchFilter{
+		StartTime: (checkTime - cfg.GetMaxLookBack()) * 1000,
+		EndTime:   (checkTime - getMinStuckSwapSLA(cfg)) * 1000,
+		Ascending: true,
+		Limit:     stuckSwapsPageSize,
+	}
+	swaps := make([]*mongodb.MgoSwapResult, 0)
+	for _, statuses := range [][]mongodb.SwapStatus{stuckRegisterStatuses, stuckResultStatuses} {
+		res, err := findStuckSwapCandidates(filter, statuses)
+		if err != nil {
+			logWorkerError("stuckswap", "find stuck swap candidates failed", err, "statuses", statuses)
+			return
+		}
+		swaps = append(swaps, res...)
+	}
+
+	checked, stucks, resolved := updateStuckSwapReport(cfg, swaps, checkTime)
+	logWorker("stuckswap", "check stuck swaps finished", "candidates", checked, "stuck", len(stucks), "resolved", len(resolved), "timespent", time.Since(start).String())
+}
+
+// updateStuckSwapReport check the candidates and replace the report,
+// returns the count of checked candidates, the stuck swaps and the resolved stuck swaps of last report.
+func updateStuckSwapReport(cfg *params.StuckSwapConfig, swaps []*mongodb.MgoSwapResult, checkTime int64) (checkedCount int, stucks, resolved []*StuckSwap) {
+	stuckSwapLock.RLock()
+	olds := make(map[string]*StuckSwap, len(stuckSwapReport.Swaps))
+	for _, swap := range stuckSwapReport.Swaps {
+		olds[swap.Key] = swap
+	}
+	stuckSwapLock.RUnlock()
+
+	nonces := make(map[string]uint64) // key is chainID:mpc, cache of latest nonces in this check
+	checked := make(map[string]struct{}, len(swaps))
+	stuckKeys := make(map[string]struct{})
+	result := make([]*StuckSwap, 0)
+	for _, swap := range swaps {
+		// registered TxNotSwapped swap has a MatchTxEmpty swap result
+		if _, exist := checked[swap.Key]; exist {
+			continue
+		}
+		checked[swap.Key] = struct{}{}
+
+		stuck := checkStuckSwap(cfg, swap, checkTime, nonces)
+		if stuck == nil {
+			continue
+		}
+		ctx := []interface{}{"fromChainID", stuck.FromChainID, "toChainID", stuck.ToChainID, "txid", stuck.TxID, "logIndex", stuck.LogIndex, "status", stuck.Status, "age", stuck.Age, "sla", stuck.SLA, "causes", stuck.Causes}
+		old := olds[stuck.Key]
+		switch {
+		case old == nil:
+			stuck.FirstDetected = checkTime
+			logWorkerWarn("stuckswap", "detect stuck swap", ctx...)
+		case old.Level != stuck.Level || old.Status != stuck.Status:
+			stuck.FirstDetected = old.FirstDetected
+			if stuck.Level == StuckLevelCritical {
+				log.Error("[stuckswap] escalate stuck swap to critical", ctx...)
+			} else {
+				logWorkerWarn("stuckswap", "stuck swap changed", ctx...)
+			}
+		default:
+			stuck.FirstDetected = old.FirstDetected
+		}
+		stuckKeys[stuck.Key] = struct{}{}
+		result = append(result, stuck)
+	}
+	// a candidate which is checked but within SLA is also resolved
+	for key, old := range olds {
+		if _, exist := stuckKeys[key]; !exist {
+			resolved = append(resolved, old)
+			logWorker("stuckswap", "stuck swap is resolved", "fromChainID", old.FromChainID, "txid", old.TxID, "logIndex", old.LogIndex, "status", old.Status)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Age > result[j].Age
+	})
+
+	stuckSwapLock.Lock()
+	stuckSwapReport = &StuckSwapReport{CheckTime: checkTime, Swaps: result}
+	stuckSwapLock.Unlock()
+
+	return len(checked), result, resolved
+}
+
+func findStuckSwapCandidates(filter *mongodb.SwapSearchFilter, statuses []mongodb.SwapStatus) ([]*mongodb.MgoSwapResult, error) {
+	f := *filter
+	f.Statuses = statuses
+	result := make([]*mongodb.MgoSwapResult, 0)
+	for len(result) < maxStuckSwapsInCheck {
+		res, err := mongodb.SearchRouterSwaps(&f)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, res...)
+		if int64(len(res)) < f.Limit {
+			break
+		}
+		last := res[len(res)-1]
+		f.Cursor = &mongodb.SwapSearchCursor{InitTime: last.InitTime, Key: last.Key}
+	}
+	return result, nil
+}
+
+func getMinStuckSwapSLA(cfg *params.StuckSwapConfig) int64 {
+	var minSLA int64
+	statuses := make([]mongodb.SwapStatus, 0, len(stuckRegisterStatuses)+len(stuckResultStatuses))
+	statuses = append(statuses, stuckRegisterStatuses...)
+	statuses = append(statuses, stuckResultStatuses...)
+	// non-positive SLA disables the check
+	updateMinSLA := func(sla int64) {
+		if sla > 0 && (minSLA == 0 || sla < minSLA) {
+			minSLA = sla
+		}
+	}
+	for _, status := range statuses {
+		updateMinSLA(cfg.GetSLA(status.String(), "*", "*"))
+		for _, sla := range cfg.SLA[status.String()] {
+			updateMinSLA(sla)
+		}
+	}
+	return minSLA
+}
+
+func checkStuckSwap(cfg *params.StuckSwapConfig, swap *mongodb.MgoSwapResult, checkTime int64, nonces map[string]uint64) *StuckSwap {
+	status := swap.Status.String()
+	sla := cfg.GetSLA(status, swap.FromChainID, swap.ToChainID)
+	age := checkTime - getStatusTime(swap)
+	if sla <= 0 || age <= sla {
+		return nil
+	}
+	level := StuckLevelWarning
+	if age > sla*cfg.GetCriticalMultiple() {
+		level = StuckLevelCritical
+	}
+	return &StuckSwap{
+		Key:         swap.Key,
+		FromChainID: swap.FromChainID,
+		ToChainID:   swap.ToChainID,
+		TxID:        swap.TxID,
+		LogIndex:    swap.LogIndex,
+		TokenID:     swap.GetTokenID(),
+		Value:       swap.Value,
+		Status:      status,
+		Age:         age,
+		SLA:         sla,
+		Level:       level,
+		Causes:      getStuckSwapCauses(swap, checkTime, nonces),
+		Memo:        swap.Memo,
+	}
+}
+
+// getStatusTime get time of entering the current status,
+// swaps stored before status time is introduced fall back to the register time.
+func getStatusTime(swap *mongodb.MgoSwapResult) int64 {
+	if swap.StatusTime > 0 {
+		return swap.StatusTime
+	}
+	return swap.InitTime / 1000
+}
+
+//nolint:gocyclo // check all likely causes
+func getStuckSwapCauses(swap *mongodb.MgoSwapResult, checkTime int64, nonces map[string]uint64) (causes []string) {
+	for _, chainID := range []string{swap.FromChainID, swap.ToChainID} {
+		if router.IsChainIDPaused(chainID) {
+			causes = append(causes, fmt.Sprintf("chain %v is paused", chainID))
+		}
+		if router.GetBridgeByChainID(chainID) == nil {
+			causes = append(causes, fmt.Sprintf("chain %v has no bridge", chainID))
+		}
+	}
+
+	if swap.DisagreeCount > 0 {
+		causes = append(causes, fmt.Sprintf("mpc sign disagreed %v times", swap.DisagreeCount))
+	}
+
+	if strings.Contains(strings.ToLower(swap.Memo), "insufficient") {
+		causes = append(causes, "insufficient balance or liquidity: "+swap.Memo)
+	}
+
+	// the jobs only find swaps updated in their lifetime windows
+	var lifetime int64
+	switch swap.Status {
+	case mongodb.TxNotStable:
+		lifetime = maxVerifyLifetime
+	case mongodb.TxNotSwapped, mongodb.MatchTxEmpty:
+		lifetime = maxDoSwapLifetime
+	case mongodb.MatchTxNotStable:
+		lifetime = maxStableLifetime
+	}
+	if lifetime > 0 && swap.Timestamp+lifetime < checkTime {
+		causes = append(causes, fmt.Sprintf("out of job window, not updated in %v seconds", lifetime))
+	}
+
+	if swap.Status == mongodb.MatchTxNotStable && swap.SwapHeight == 0 && swap.MPC != "" {
+		if cause := getNonceGapCause(swap, nonces); cause != "" {
+			causes = append(causes, cause)
+		}
+	}
+	return causes
+}
+
+// getNonceGapCause check if swap tx is waiting for txs with lower nonces
+func getNonceGapCause(swap *mongodb.MgoSwapResult, nonces map[string]uint64) string {
+	bridge := router.GetBridgeByChainID(swap.ToChainID)
+	if bridge == nil {
+		return ""
+	}
+	nonceSetter, ok := bridge.(tokens.NonceSetter)
+	if !ok {
+		return ""
+	}
+	cacheKey := strings.ToLower(swap.ToChainID + ":" + swap.MPC)
+	if swap.KeyIndex != nil {
+		cacheKey += fmt.Sprintf(":%d", *swap.KeyIndex)
+	}
+	latestNonce, exist := nonces[cacheKey]
+	if !exist {
+		nonce, err := getLatestSwapNonce(nonceSetter, swap)
+		if err != nil {
+			return ""
+		}
+		latestNonce = nonce
+		nonces[cacheKey] = nonce
+	}
+	if swap.SwapNonce > latestNonce {
+		return fmt.Sprintf("nonce gap, swap nonce is %v but latest nonce of %v is %v", swap.SwapNonce, swap.MPC, latestNonce)
+	}
+	return ""
+}
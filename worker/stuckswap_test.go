@@ -0,0 +1,168 @@
+package worker
+
+import (
+	"testing"
+
+	"github.com/anyswap/CrossChain-Router/v3/mongodb"
+	"github.com/anyswap/CrossChain-Router/v3/params"
+)
+
+const stuckTestCheckTime = int64(1700000000)
+
+func newStuckTestSwap(key string, status mongodb.SwapStatus, age int64) *mongodb.MgoSwapResult {
+	return &mongodb.MgoSwapResult{
+		Key:         key,
+		FromChainID: "1",
+		ToChainID:   "56",
+		TxID:        key,
+		Status:      status,
+		StatusTime:  stuckTestCheckTime - age,
+		Timestamp:   stuckTestCheckTime,
+	}
+}
+
+func TestCheckStuckSwapLevel(t *testing.T) {
+	cfg := &params.StuckSwapConfig{
+		CriticalMultiple: 3,
+		SLA: map[string]map[string]int64{
+			"TxNotSwapped":     {"*-*": 100, "1-137": 0},
+			"MatchTxNotStable": {"1-56": 200},
+		},
+	}
+	tests := []struct {
+		status    mongodb.SwapStatus
+		toChainID string
+		age       int64
+		wantSLA   int64
+		wantLevel string // empty means not stuck
+	}{
+		{mongodb.TxNotSwapped, "56", 100, 100, ""},
+		{mongodb.TxNotSwapped, "56", 101, 100, StuckLevelWarning},
+		{mongodb.TxNotSwapped, "56", 300, 100, StuckLevelWarning},
+		{mongodb.TxNotSwapped, "56", 301, 100, StuckLevelCritical},
+		// zero SLA disables the check
+		{mongodb.TxNotSwapped, "137", 10000, 0, ""},
+		// the most specific chain pair is preferred
+		{mongodb.MatchTxNotStable, "56", 201, 200, StuckLevelWarning},
+		{mongodb.MatchTxNotStable, "56", 601, 200, StuckLevelCritical},
+		// default SLA of status
+		{mongodb.MatchTxNotStable, "137", 3601, 3600, StuckLevelWarning},
+		{mongodb.MatchTxEmpty, "56", 1800, 1800, ""},
+	}
+	for i, tt := range tests {
+		swap := newStuckTestSwap("key", tt.status, tt.age)
+		swap.ToChainID = tt.toChainID
+		stuck := checkStuckSwap(cfg, swap, stuckTestCheckTime, make(map[string]uint64))
+		if tt.wantLevel == "" {
+			if stuck != nil {
+				t.Errorf("test %v: got stuck swap with level %v, want not stuck", i, stuck.Level)
+			}
+			continue
+		}
+		if stuck == nil {
+			t.Errorf("test %v: got not stuck, want level %v", i, tt.wantLevel)
+			continue
+		}
+		if stuck.Level != tt.wantLevel || stuck.SLA != tt.wantSLA || stuck.Age != tt.age {
+			t.Errorf("test %v: got level %v sla %v age %v, want %v %v %v", i, stuck.Level, stuck.SLA, stuck.Age, tt.wantLevel, tt.wantSLA, tt.age)
+		}
+	}
+
+	// swaps without status time fall back to register time
+	swap := newStuckTestSwap("key", mongodb.TxNotSwapped, 0)
+	swap.StatusTime = 0
+	swap.InitTime = (stuckTestCheckTime - 150) * 1000
+	if stuck := checkStuckSwap(cfg, swap, stuckTestCheckTime, make(map[string]uint64)); stuck == nil || stuck.Age != 150 {
+		t.Errorf("stuck swap without status time: got %v", stuck)
+	}
+}
+
+func TestGetMinStuckSwapSLA(t *testing.T) {
+	tests := []struct {
+		sla  map[string]map[string]int64
+		want int64
+	}{
+		{nil, 1800},
+		{map[string]map[string]int64{"TxNotSwapped": {"1-56": 600}}, 600},
+		{map[string]map[string]int64{"MatchTxNotStable": {"*-*": 300}, "TxNotStable": {"1-*": 900}}, 300},
+		// disabled SLA is ignored
+		{map[string]map[string]int64{"TxNotSwapped": {"1-56": 0, "*-*": -1}}, 1800},
+		{map[string]map[string]int64{"TxNotStable": {"*-*": 0}, "TxNotSwapped": {"*-*": 0}, "MatchTxEmpty": {"*-*": 0}, "MatchTxNotStable": {"*-*": 0}}, 0},
+	}
+	for i, tt := range tests {
+		cfg := &params.StuckSwapConfig{SLA: tt.sla}
+		if got := getMinStuckSwapSLA(cfg); got != tt.want {
+			t.Errorf("test %v: got %v, want %v", i, got, tt.want)
+		}
+	}
+}
+
+func TestUpdateStuckSwapReport(t *testing.T) {
+	prevReport := stuckSwapReport
+	t.Cleanup(func() { stuckSwapReport = prevReport })
+	stuckSwapReport = &StuckSwapReport{}
+
+	cfg := &params.StuckSwapConfig{
+		CriticalMultiple: 3,
+		SLA:              map[string]map[string]int64{"TxNotSwapped": {"*-*": 100}},
+	}
+	swaps := []*mongodb.MgoSwapResult{
+		newStuckTestSwap("a", mongodb.TxNotSwapped, 150),
+		newStuckTestSwap("b", mongodb.TxNotSwapped, 200),
+		newStuckTestSwap("c", mongodb.TxNotSwapped, 120),
+		newStuckTestSwap("d", mongodb.TxNotSwapped, 50),
+		// registered swap and its swap result
+		newStuckTestSwap("a", mongodb.MatchTxEmpty, 150),
+	}
+	checked, stucks, resolved := updateStuckSwapReport(cfg, swaps, stuckTestCheckTime)
+	if checked != 4 || len(stucks) != 3 || len(resolved) != 0 {
+		t.Fatalf("first check: got checked %v stuck %v resolved %v", checked, len(stucks), len(resolved))
+	}
+	// sorted by age
+	if stucks[0].Key != "b" || stucks[1].Key != "a" || stucks[2].Key != "c" {
+		t.Errorf("stuck swaps are not sorted by age")
+	}
+
+	checkTime := stuckTestCheckTime + 100
+	swaps = []*mongodb.MgoSwapResult{
+		// escalated to critical
+		newStuckTestSwap("a", mongodb.TxNotSwapped, 350),
+		// still candidate, but within SLA after status changed
+		newStuckTestSwap("b", mongodb.MatchTxNotStable, 100),
+		// "c" is not candidate any more
+		newStuckTestSwap("d", mongodb.TxNotSwapped, 250),
+	}
+	for _, swap := range swaps {
+		swap.StatusTime += 100
+	}
+	checked, stucks, resolved = updateStuckSwapReport(cfg, swaps, checkTime)
+	if checked != 3 || len(stucks) != 2 {
+		t.Fatalf("second check: got checked %v stuck %v", checked, len(stucks))
+	}
+	resolvedKeys := make(map[string]bool)
+	for _, swap := range resolved {
+		resolvedKeys[swap.Key] = true
+	}
+	if len(resolved) != 2 || !resolvedKeys["b"] || !resolvedKeys["c"] {
+		t.Errorf("resolved stuck swaps: got %v, want b and c", resolvedKeys)
+	}
+
+	report := GetStuckSwapReport()
+	if report.CheckTime != checkTime || len(report.Swaps) != 2 {
+		t.Fatalf("report: got check time %v swaps %v", report.CheckTime, len(report.Swaps))
+	}
+	for _, stuck := range report.Swaps {
+		switch stuck.Key {
+		case "a":
+			if stuck.Level != StuckLevelCritical || stuck.FirstDetected != stuckTestCheckTime {
+				t.Errorf("escalated swap: got level %v first detected %v", stuck.Level, stuck.FirstDetected)
+			}
+		case "d":
+			if stuck.Level != StuckLevelWarning || stuck.FirstDetected != checkTime {
+				t.Errorf("new stuck swap: got level %v first detected %v", stuck.Level, stuck.FirstDetected)
+			}
+		default:
+			t.Errorf("unexpected stuck swap %v", stuck.Key)
+		}
+	}
+}
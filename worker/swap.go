@@ -32,7 +32,6 @@ var (
 	swapTaskQueues   = make(map[string]*fifo.Queue) // key is toChainID
 	swapTasksInQueue = mapset.NewSet()
 
-	disagreeRecords      = new(sync.Map)
 	maxDisagreeCount     = uint64(10)
 	disagreeWaitInterval = int64(300)
 
@@ -52,6 +51,9 @@ func StartSwapJob() {
 
 func startSwapProducer() {
 	for {
+		if !waitForLeadership("swap") {
+			return
+		}
 		res, err := findRouterSwapToSwap()
 		if err != nil {
 			logWorkerError("swap", "find out router swap error", err)
@@ -136,18 +138,12 @@ func processRouterSwap(swap *mongodb.MgoSwap) (err error) {
 		return nil
 	}
 
-	var disagreeCount uint64
-	cacheKey := mongodb.GetRouterSwapKey(fromChainID, txid, logIndex)
-	oldValue, exist := disagreeRecords.Load(cacheKey)
-	if exist {
-		disagreeCount = oldValue.(uint64)
-	}
-	if disagreeCount > maxDisagreeCount {
+	if swap.DisagreeCount > maxDisagreeCount {
 		if res.Timestamp+disagreeWaitInterval > now() {
 			logWorkerTrace("swap", "disagree too many times", "txid", txid, "logIndex", logIndex, "fromChainID", fromChainID, "toChainID", toChainID, "token", swap.GetToken(), "tokenID", swap.GetTokenID())
 			return nil
 		}
-		disagreeRecords.Store(cacheKey, 0) // recount from zero
+		_ = mongodb.ResetRouterSwapDisagreeCount(fromChainID, txid, logIndex) // recount from zero
 	}
 
 	logWorker("swap", "start process router swap", "fromChainID", fromChainID, "txid", txid, "logIndex", logIndex, "status", swap.Status, "value", res.Value)
@@ -194,9 +190,26 @@ func processRouterSwap(swap *mongodb.MgoSwap) (err error) {
 		return err
 	}
 
+	if isLiquidityDelayExpired(res) {
+		logWorker("swap", "liquidity delay expired, pay anyToken", "fromChainID", fromChainID, "txid", txid, "logIndex", logIndex, "tokenID", swap.GetTokenID(), "delaytime", res.DelayTime)
+		args.Extra.Payout = &tokens.PayoutInfo{Type: tokens.PayoutAnyToken}
+	}
+
 	return dispatchSwapTask(args)
 }
 
+// isLiquidityDelayExpired is swap delayed by insufficient liquidity longer than the max delay of its liquidity policy
+func isLiquidityDelayExpired(res *mongodb.MgoSwapResult) bool {
+	policy := params.GetLiquidityPolicy(res.GetTokenID())
+	if policy == nil || policy.Policy != params.LiquidityPolicyDelay || policy.MaxDelay <= 0 {
+		return false
+	}
+	if res.DelayTime == 0 || !strings.Contains(res.Memo, tokens.ErrInsufficientLiquidity.Error()) {
+		return false
+	}
+	return res.DelayTime+policy.MaxDelay < now()
+}
+
 func getFromToChainIDAndValue(fromChainIDStr, toChainIDStr, valueStr string) (fromChainID, toChainID, value *big.Int, err error) {
 	fromChainID, err = common.GetBigIntFromStr(fromChainIDStr)
 	if err != nil {
@@ -294,6 +307,8 @@ func startSwapConsumer(chainID string) {
 		log.Fatal("no task queue", "chainID", chainID)
 	}
 
+	batcher := newSwapBatcher(chainID)
+
 	i := 0
 	for {
 		if utils.IsCleanuping() {
@@ -306,6 +321,8 @@ func startSwapConsumer(chainID string) {
 		}
 		i++
 
+		batcher.flush(false)
+
 		front := taskQueue.Next()
 		if front == nil {
 			sleepSeconds(3)
@@ -318,23 +335,32 @@ func startSwapConsumer(chainID string) {
 			logWorkerWarn("doSwap", "ignore swap task as toChainID mismatch", "want", chainID, "args", args)
 			continue
 		}
-		logWorker("doSwap", "process router swap start", "args", args)
-		ctx := []interface{}{"fromChainID", args.FromChainID, "toChainID", args.ToChainID, "txid", args.SwapID, "logIndex", args.LogIndex}
-		err := doSwap(args)
-		switch {
-		case err == nil:
-			logWorker("doSwap", "process router swap success", ctx...)
-		case errors.Is(err, errAlreadySwapped),
-			errors.Is(err, tokens.ErrNoBridgeForChainID):
-			ctx = append(ctx, "err", err)
-			logWorkerTrace("doSwap", "process router swap failed", ctx...)
-		default:
-			logWorkerError("doSwap", "process router swap failed", err, ctx...)
+
+		if batcher.add(args) {
+			continue
 		}
 
-		cacheKey := mongodb.GetRouterSwapKey(args.FromChainID.String(), args.SwapID, args.LogIndex)
-		swapTasksInQueue.Remove(cacheKey)
+		processSwapTask(args)
+	}
+}
+
+func processSwapTask(args *tokens.BuildTxArgs) {
+	logWorker("doSwap", "process router swap start", "args", args)
+	ctx := []interface{}{"fromChainID", args.FromChainID, "toChainID", args.ToChainID, "txid", args.SwapID, "logIndex", args.LogIndex}
+	err := doSwap(args)
+	switch {
+	case err == nil:
+		logWorker("doSwap", "process router swap success", ctx...)
+	case errors.Is(err, errAlreadySwapped),
+		errors.Is(err, tokens.ErrNoBridgeForChainID):
+		ctx = append(ctx, "err", err)
+		logWorkerTrace("doSwap", "process router swap failed", ctx...)
+	default:
+		logWorkerError("doSwap", "process router swap failed", err, ctx...)
 	}
+
+	cacheKey := mongodb.GetRouterSwapKey(args.FromChainID.String(), args.SwapID, args.LogIndex)
+	swapTasksInQueue.Remove(cacheKey)
 }
 
 func checkAndUpdateProcessSwapTaskCache(key string) error {
@@ -396,6 +422,9 @@ func doSwap(args *tokens.BuildTxArgs) (err error) {
 	swapTxNonce := args.GetTxNonce() // assign after build tx
 	logWorker("doSwap", "build tx success", "fromChainID", fromChainID, "toChainID", toChainID, "txid", txid, "logIndex", logIndex, "swapNonce", swapTxNonce, "timespent", time.Since(start).String())
 
+	if err = checkLeadership(); err != nil {
+		return err
+	}
 	start = time.Now()
 	signedTx, txHash, err := resBridge.MPCSignTransaction(rawTx, args)
 	if err != nil {
@@ -407,7 +436,7 @@ func doSwap(args *tokens.BuildTxArgs) (err error) {
 	}
 	logWorker("doSwap", "sign tx success", "fromChainID", fromChainID, "toChainID", toChainID, "txid", txid, "logIndex", logIndex, "txHash", txHash, "swapNonce", swapTxNonce, "timespent", time.Since(start).String())
 
-	disagreeRecords.Delete(cacheKey)
+	_ = mongodb.ResetRouterSwapDisagreeCount(fromChainID, txid, logIndex)
 
 	// recheck reswap before update db
 	res, err := mongodb.FindRouterSwapResult(fromChainID, txid, logIndex)
@@ -426,6 +455,8 @@ func doSwap(args *tokens.BuildTxArgs) (err error) {
 		SwapNonce: swapTxNonce,
 		SwapValue: args.SwapValue.String(),
 		MPC:       args.From,
+		Payout:    args.Extra.Payout,
+		Delivery:  args.Extra.Delivery,
 	}
 	if args.Extra.TTL != nil {
 		matchTx.TTL = *args.Extra.TTL
@@ -512,6 +543,11 @@ func signAndSendTx(rawTx interface{}, args *tokens.BuildTxArgs) error {
 	swapTxNonce := args.GetTxNonce()
 	resBridge := router.GetBridgeByChainID(toChainID)
 
+	if err := checkLeadership(); err != nil {
+		// the nonce allocated in building tx will not be used
+		recycleSwapNonce(resBridge, args.From, args.Extra.KeyIndex, swapTxNonce)
+		return err
+	}
 	start := time.Now()
 	signedTx, txHash, err := resBridge.MPCSignTransaction(rawTx, args)
 	if err != nil {
@@ -523,12 +559,19 @@ func signAndSendTx(rawTx interface{}, args *tokens.BuildTxArgs) error {
 	}
 	logWorker("doSwap", "sign tx success", err, "fromChainID", fromChainID, "toChainID", toChainID, "txid", txid, "logIndex", logIndex, "swapNonce", swapTxNonce, "timespent", time.Since(start).String())
 
-	cacheKey := mongodb.GetRouterSwapKey(fromChainID, txid, logIndex)
-	disagreeRecords.Delete(cacheKey)
+	_ = mongodb.ResetRouterSwapDisagreeCount(fromChainID, txid, logIndex)
 
 	// update database before sending transaction
 	addSwapHistory(fromChainID, txid, logIndex, txHash)
-	_ = updateSwapTx(fromChainID, txid, logIndex, txHash)
+	if err = updateSwapTx(fromChainID, txid, logIndex, txHash); errors.Is(err, mongodb.ErrStaleFenceToken) {
+		return err
+	}
+	if args.Extra.Payout != nil {
+		_ = updateSwapPayout(fromChainID, txid, logIndex, args.Extra.Payout)
+	}
+	if args.Extra.Delivery != "" {
+		_ = updateSwapDelivery(fromChainID, txid, logIndex, args.Extra.Delivery)
+	}
 
 	start = time.Now()
 	sentTxHash, err := sendSignedTransaction(resBridge, signedTx, args)
@@ -580,13 +623,7 @@ func reverifySwap(args *tokens.BuildTxArgs) {
 	}
 	logWorker("verify", "reverify tx finished job", "fromChainID", fromChainID, "toChainID", toChainID, "txid", txid, "logIndex", logIndex, "timespent", time.Since(start).String())
 
-	var disagreeCount uint64
-	cacheKey := mongodb.GetRouterSwapKey(fromChainID, txid, logIndex)
-	oldValue, exist := disagreeRecords.Load(cacheKey)
-	if exist {
-		disagreeCount = oldValue.(uint64)
-	}
-	disagreeRecords.Store(cacheKey, disagreeCount+1)
+	_ = mongodb.IncreaseRouterSwapDisagreeCount(fromChainID, txid, logIndex)
 }
 
 // DeleteCachedSwap delete cached swap
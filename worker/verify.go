@@ -33,6 +33,9 @@ func StartVerifyJob() {
 
 func startVerifyProducer() {
 	for {
+		if !waitForLeadership("verify") {
+			return
+		}
 		septime := getSepTimeInFind(maxVerifyLifetime)
 		res, err := mongodb.FindRouterSwapsWithStatus(mongodb.TxNotStable, septime)
 		if err != nil {
@@ -61,8 +64,7 @@ func startVerifyProducer() {
 			if swap, err := mongodb.FindRouterSwap(swap.FromChainID, swap.TxID, swap.LogIndex); err == nil {
 				bridge := router.GetBridgeByChainID(swap.FromChainID)
 				if bridge != nil && swap.TxHeight > 0 &&
-					swap.TxHeight+bridge.GetChainConfig().Confirmations >
-						router.GetCachedLatestBlockNumber(swap.FromChainID) {
+					!isPassedBaseConfirmations(bridge, swap.FromChainID, swap.TxHeight) {
 					logWorkerTrace("verify", "ignore swap not stable", "key", swap.Key)
 					continue
 				}
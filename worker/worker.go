@@ -12,7 +12,15 @@ const interval = 10 * time.Millisecond
 func StartRouterSwapWork(isServer bool) {
 	logWorker("worker", "start router swap worker")
 
+	if err := LoadMaintainState(isServer); err != nil {
+		logWorkerWarn("worker", "load maintain state failed", "err", err)
+	}
+	StartMaintainSyncJob(isServer)
+
 	bridge.InitRouterBridges(isServer)
+	if isServer {
+		startConfigAuditRecorder()
+	}
 	bridge.StartReloadRouterConfigTask()
 
 	if !isServer {
@@ -22,6 +30,9 @@ func StartRouterSwapWork(isServer bool) {
 		return
 	}
 
+	StartLeaderElectionJob()
+	StartStuckSwapJob()
+
 	StartSwapJob()
 	time.Sleep(interval)
 